@@ -0,0 +1,221 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/git"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/task"
+	"github.com/dongho-jung/paw/internal/tui"
+)
+
+var (
+	backportPush bool
+	backportPR   bool
+)
+
+var backportCmd = &cobra.Command{
+	Use:   "backport <task> <branch>...",
+	Short: "Replay a finished task onto additional target branches",
+	Long: `Replay the commits of a task onto other branches (e.g. release/1.2).
+
+For each branch, a backport/<branch>/<task> branch is created from it and the
+task's commits are cherry-picked with -x. Tasks that still have their branch
+replay each commit; merged tasks replay their squash commit.
+
+Examples:
+  paw backport fix-login release/1.2
+  paw backport fix-login release/1.2 release/1.1 --pr`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runBackport,
+}
+
+func init() {
+	backportCmd.Flags().BoolVar(&backportPush, "push", false, "Push backport branches to the push remote")
+	backportCmd.Flags().BoolVar(&backportPR, "pr", false, "Push and open a PR against each branch")
+}
+
+func runBackport(_ *cobra.Command, args []string) error {
+	appCtx, err := buildAppFromCwd()
+	if err != nil {
+		return err
+	}
+	if appCtx, err = loadAppConfig(appCtx); err != nil {
+		return err
+	}
+	if !appCtx.IsGitRepo {
+		return errors.New("backport requires a git repository")
+	}
+
+	taskName := args[0]
+	_, cleanup := setupLoggerFromApp(appCtx, "backport", taskName)
+	defer cleanup()
+
+	logging.Debug("-> runBackport(task=%s, branches=%v)", taskName, args[1:])
+	defer logging.Debug("<- runBackport")
+
+	mgr := task.NewManager(appCtx.AgentsDir, appCtx.ProjectDir, appCtx.PawDir, appCtx.IsGitRepo, appCtx.Config)
+	gitClient := git.New()
+
+	commits, err := backportCommits(mgr, gitClient, appCtx.ProjectDir, taskName)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("\n  Backporting %s (%d commit(s))\n\n", taskName, len(commits))
+
+	failed := 0
+	for _, target := range args[1:] {
+		if !backportOnto(appCtx, mgr, gitClient, taskName, target, commits) {
+			failed++
+		}
+	}
+
+	fmt.Println()
+	if failed > 0 {
+		return fmt.Errorf("%d backport(s) failed", failed)
+	}
+	fmt.Println("  ✓ Backport complete")
+	return nil
+}
+
+// backportCommits returns the commits to replay for a task, oldest first.
+// Live tasks replay their branch commits; merged tasks replay the recorded squash commit.
+func backportCommits(mgr *task.Manager, gitClient git.Client, projectDir, taskName string) ([]string, error) {
+	if t, err := mgr.GetTask(taskName); err == nil && gitClient.BranchExists(projectDir, taskName) {
+		commits, err := gitClient.ListCommits(projectDir, mgr.TargetBranch(t), taskName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list commits of %s: %w", taskName, err)
+		}
+		if len(commits) == 0 {
+			return nil, fmt.Errorf("task %s has no commits to backport", taskName)
+		}
+		return commits, nil
+	}
+
+	mergedRef := constants.MergedTaskRefPrefix + taskName
+	if !gitClient.RefExists(projectDir, mergedRef) {
+		return nil, fmt.Errorf("task %s not found and has no merge record (%s)", taskName, mergedRef)
+	}
+	return []string{mergedRef}, nil
+}
+
+// errBackportConflict reports that the commits did not apply cleanly.
+var errBackportConflict = errors.New("cherry-pick conflict")
+
+// replayBackport creates branch from target in a temporary worktree and
+// cherry-picks the commits onto it. On a conflict the branch is deleted so the
+// backport can be retried; the worktree is removed first since git refuses to
+// delete a branch that is checked out.
+func replayBackport(gitClient git.Client, projectDir, worktreeDir, branch, target string, commits []string) error {
+	if err := gitClient.WorktreeAddFrom(projectDir, worktreeDir, branch, target); err != nil {
+		return err
+	}
+	removeWorktree := func() {
+		if err := gitClient.WorktreeRemove(projectDir, worktreeDir, true); err != nil {
+			logging.Warn("Failed to remove backport worktree: %v", err)
+		}
+	}
+
+	if err := gitClient.CherryPick(worktreeDir, commits); err != nil {
+		_ = gitClient.CherryPickAbort(worktreeDir)
+		removeWorktree()
+		if delErr := gitClient.BranchDelete(projectDir, branch, true); delErr != nil {
+			logging.Warn("Failed to delete backport branch %s: %v", branch, delErr)
+		}
+		return fmt.Errorf("%w: %w", errBackportConflict, err)
+	}
+	removeWorktree()
+	return nil
+}
+
+// backportOnto cherry-picks commits onto a new branch created from target.
+// Returns false if the backport failed; the target branch itself is never modified.
+func backportOnto(appCtx *app.App, mgr *task.Manager, gitClient git.Client, taskName, target string, commits []string) bool {
+	if !git.IsValidGitRef(target) {
+		fmt.Printf("  ✗ Invalid branch name: %s\n", target)
+		return false
+	}
+	if err := mgr.EnsureLocalBranch(target); err != nil {
+		fmt.Printf("  ✗ %v\n", err)
+		return false
+	}
+
+	branch := "backport/" + target + "/" + taskName
+	if gitClient.BranchExists(appCtx.ProjectDir, branch) {
+		fmt.Printf("  ✗ %s already exists\n", branch)
+		return false
+	}
+
+	worktreeDir := filepath.Join(appCtx.PawDir, constants.BackportDirName, strings.ReplaceAll(target, "/", "-")+"-"+taskName)
+	if err := os.MkdirAll(filepath.Dir(worktreeDir), 0755); err != nil { //nolint:gosec // G301: standard directory permissions
+		fmt.Printf("  ✗ Failed to create backport directory: %v\n", err)
+		return false
+	}
+
+	spinner := tui.NewSimpleSpinner(fmt.Sprintf("Cherry-picking onto %s", target))
+	spinner.Start()
+	timer := logging.StartTimer("backport " + target)
+
+	if err := replayBackport(gitClient, appCtx.ProjectDir, worktreeDir, branch, target, commits); err != nil {
+		if !errors.Is(err, errBackportConflict) {
+			timer.StopWithResult(false, err.Error())
+			spinner.Stop(false, err.Error())
+			return false
+		}
+		timer.StopWithResult(false, "conflict")
+		spinner.Stop(false, "conflict")
+		logging.Warn("Backport onto %s failed: %v", target, err)
+		fmt.Println("  ⚠️  Cherry-pick conflict; resolve manually:")
+		fmt.Printf("     git switch -c %s %s && git cherry-pick -x %s\n", branch, target, strings.Join(commits, " "))
+		return false
+	}
+	timer.StopWithResult(true, "branch="+branch)
+	spinner.Stop(true, branch)
+
+	if !backportPush && !backportPR {
+		return true
+	}
+
+	pushRemote := mgr.PushRemote()
+	pushSpinner := tui.NewSimpleSpinner(fmt.Sprintf("Pushing %s to %s", branch, pushRemote))
+	pushSpinner.Start()
+	if err := gitClient.Push(appCtx.ProjectDir, pushRemote, branch, true); err != nil {
+		pushSpinner.Stop(false, err.Error())
+		return false
+	}
+	pushSpinner.Stop(true, "")
+
+	if !backportPR {
+		return true
+	}
+
+	ghClient := mgr.GitHub()
+	if !ghClient.IsInstalled() {
+		fmt.Println("  ⚠️  gh CLI not found; cannot create PR")
+		return false
+	}
+	head := mgr.PRHead(branch)
+	if head == "" {
+		head = branch
+	}
+	title := fmt.Sprintf("[%s] %s", target, buildPRTitle(taskName))
+	body := fmt.Sprintf("Backport of `%s` onto `%s`.\n", taskName, target)
+
+	prSpinner := tui.NewSimpleSpinner("Creating pull request")
+	prSpinner.Start()
+	_, prURL, err := ghClient.CreatePR(appCtx.ProjectDir, title, body, target, head)
+	if err != nil {
+		prSpinner.Stop(false, err.Error())
+		return false
+	}
+	prSpinner.Stop(true, prURL)
+	return true
+}
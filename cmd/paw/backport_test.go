@@ -0,0 +1,98 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dongho-jung/paw/internal/git"
+)
+
+// gitIn runs git in dir and returns its trimmed output.
+func gitIn(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_SYSTEM=/dev/null")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// commitFile writes a file in dir and commits it.
+func commitFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitIn(t, dir, "add", name)
+	gitIn(t, dir, "commit", "-q", "-m", "change "+name)
+	return gitIn(t, dir, "rev-parse", "HEAD")
+}
+
+// setupBackportRepo creates a repository with a release branch and a task
+// commit made on main.
+func setupBackportRepo(t *testing.T, releaseContent string) (dir, commit string) {
+	t.Helper()
+	dir = t.TempDir()
+	gitIn(t, dir, "init", "-q", "-b", "main")
+	gitIn(t, dir, "config", "user.name", "Test User")
+	gitIn(t, dir, "config", "user.email", "test@example.com")
+	commitFile(t, dir, "app.txt", "v1\n")
+	gitIn(t, dir, "branch", "release")
+	commit = commitFile(t, dir, "app.txt", "v1\nfix\n")
+
+	if releaseContent != "" {
+		gitIn(t, dir, "switch", "-q", "release")
+		commitFile(t, dir, "app.txt", releaseContent)
+		gitIn(t, dir, "switch", "-q", "main")
+	}
+	return dir, commit
+}
+
+func TestReplayBackport(t *testing.T) {
+	dir, commit := setupBackportRepo(t, "")
+	worktreeDir := filepath.Join(t.TempDir(), "release-fix")
+	branch := "backport/release/fix"
+
+	if err := replayBackport(git.New(), dir, worktreeDir, branch, "release", []string{commit}); err != nil {
+		t.Fatalf("replayBackport() error = %v", err)
+	}
+	if got := gitIn(t, dir, "show", branch+":app.txt"); got != "v1\nfix" {
+		t.Errorf("%s app.txt = %q, want the task change", branch, got)
+	}
+	if _, err := os.Stat(worktreeDir); !os.IsNotExist(err) {
+		t.Errorf("worktree %s left behind", worktreeDir)
+	}
+}
+
+func TestReplayBackportConflict(t *testing.T) {
+	dir, commit := setupBackportRepo(t, "v2\n")
+	worktreeDir := filepath.Join(t.TempDir(), "release-fix")
+	branch := "backport/release/fix"
+	gitClient := git.New()
+
+	err := replayBackport(gitClient, dir, worktreeDir, branch, "release", []string{commit})
+	if !errors.Is(err, errBackportConflict) {
+		t.Fatalf("replayBackport() error = %v, want a conflict", err)
+	}
+	if gitClient.BranchExists(dir, branch) {
+		t.Errorf("%s left behind after the conflict", branch)
+	}
+	if _, err := os.Stat(worktreeDir); !os.IsNotExist(err) {
+		t.Errorf("worktree %s left behind", worktreeDir)
+	}
+
+	// A retry after resolving starts from a clean slate
+	gitIn(t, dir, "switch", "-q", "release")
+	commitFile(t, dir, "app.txt", "v1\n")
+	gitIn(t, dir, "switch", "-q", "main")
+	if err := replayBackport(gitClient, dir, worktreeDir, branch, "release", []string{commit}); err != nil {
+		t.Errorf("retry error = %v", err)
+	}
+}
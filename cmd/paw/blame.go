@@ -0,0 +1,177 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/git"
+	"github.com/dongho-jung/paw/internal/service"
+)
+
+var blameCmd = &cobra.Command{
+	Use:   "blame <file>:<line>",
+	Short: "Show the PAW task that introduced a line",
+	Long: `Map a line of code back to the PAW task that produced it.
+
+Runs git blame on the line, then reads the task provenance recorded on the
+commit: the Paw-Task/Paw-Model trailers and the refs/notes/paw note with the
+task content, summary and verification result. The matching task history
+entry is shown when it is still available.
+
+Examples:
+  paw blame internal/auth/login.go:42
+  paw blame README.md:10`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBlame,
+}
+
+// blameResult is the provenance of a blamed line.
+type blameResult struct {
+	Commit     string
+	Subject    string
+	Task       string
+	Model      string
+	Provenance *service.Provenance
+	History    *historyEntry
+}
+
+func runBlame(_ *cobra.Command, args []string) error {
+	file, line, err := parseBlameTarget(args[0])
+	if err != nil {
+		return err
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	gitClient := git.New()
+	if !gitClient.IsGitRepo(cwd) {
+		return errors.New("paw blame must be run inside a git repository")
+	}
+
+	commit, err := gitClient.BlameLine(cwd, file, line)
+	if err != nil {
+		return fmt.Errorf("git blame failed: %w", err)
+	}
+	if strings.Trim(commit, "0") == "" {
+		return fmt.Errorf("%s:%d is not committed yet", file, line)
+	}
+
+	result := &blameResult{Commit: commit}
+	if message, err := gitClient.GetCommitMessage(cwd, commit); err == nil {
+		result.Subject, _, _ = strings.Cut(message, "\n")
+		trailers := git.ParseTrailers(message)
+		result.Task = trailers[constants.TrailerTask]
+		result.Model = trailers[constants.TrailerModel]
+	}
+	if note, err := gitClient.GetNote(cwd, constants.ProvenanceNotesRef, commit); err == nil {
+		if provenance, err := service.ParseProvenanceNote(note); err == nil {
+			result.Provenance = provenance
+			if meta := provenance.Meta; meta != nil {
+				if result.Task == "" {
+					result.Task = meta.TaskName
+				}
+				if result.Model == "" && meta.TaskOptions != nil {
+					result.Model = string(meta.TaskOptions.Model)
+				}
+			}
+		}
+	}
+
+	if result.Task != "" {
+		if appCtx, err := buildAppFromCwd(); err == nil {
+			result.History = findTaskHistory(appCtx.GetHistoryDir(), result.Task)
+		}
+	}
+
+	printBlameResult(file, line, result)
+	if result.Task == "" {
+		return fmt.Errorf("no PAW provenance recorded for commit %s", shortenCommit(commit))
+	}
+	return nil
+}
+
+// parseBlameTarget splits "<file>:<line>" into its parts.
+func parseBlameTarget(target string) (string, int, error) {
+	idx := strings.LastIndex(target, ":")
+	if idx <= 0 || idx == len(target)-1 {
+		return "", 0, fmt.Errorf("expected <file>:<line>, got %q", target)
+	}
+	line, err := strconv.Atoi(target[idx+1:])
+	if err != nil || line < 1 {
+		return "", 0, fmt.Errorf("invalid line number in %q", target)
+	}
+	return target[:idx], line, nil
+}
+
+// findTaskHistory returns the newest history entry of a task, or nil if none exists.
+func findTaskHistory(historyDir, taskName string) *historyEntry {
+	entries, err := loadHistoryEntries(historyDir, historyOptions{task: taskName, withSummary: true})
+	if err != nil {
+		return nil
+	}
+	for i := range entries {
+		if entries[i].Task == taskName {
+			return &entries[i]
+		}
+	}
+	return nil
+}
+
+func printBlameResult(file string, line int, result *blameResult) {
+	fmt.Println()
+	fmt.Printf("  Line:     %s:%d\n", file, line)
+	fmt.Printf("  Commit:   %s %s\n", shortenCommit(result.Commit), result.Subject)
+	if result.Task == "" {
+		fmt.Println()
+		return
+	}
+	fmt.Printf("  Task:     %s\n", result.Task)
+	if result.Model != "" {
+		fmt.Printf("  Model:    %s\n", result.Model)
+	}
+
+	if p := result.Provenance; p != nil && p.Meta != nil && p.Meta.Verification != nil {
+		v := p.Meta.Verification
+		mark := "✓"
+		if !v.Success {
+			mark = "✗"
+		}
+		fmt.Printf("  Verified: %s %s (exit %d)\n", mark, v.Command, v.ExitCode)
+	}
+	if result.History != nil {
+		fmt.Printf("  History:  %s\n", result.History.Path)
+	}
+
+	summary := ""
+	if result.Provenance != nil {
+		summary = result.Provenance.Summary
+		if content := result.Provenance.TaskContent; content != "" {
+			fmt.Println()
+			fmt.Println("  Task content:")
+			printIndented(content)
+		}
+	}
+	if summary == "" && result.History != nil {
+		summary = result.History.Summary
+	}
+	if summary != "" {
+		fmt.Println()
+		fmt.Println("  Summary:")
+		printIndented(summary)
+	}
+	fmt.Println()
+}
+
+func printIndented(text string) {
+	for _, l := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		fmt.Printf("    %s\n", l)
+	}
+}
@@ -0,0 +1,31 @@
+package main
+
+import "testing"
+
+func TestParseBlameTarget(t *testing.T) {
+	tests := []struct {
+		target  string
+		file    string
+		line    int
+		wantErr bool
+	}{
+		{target: "main.go:42", file: "main.go", line: 42},
+		{target: "dir/a:b.go:7", file: "dir/a:b.go", line: 7},
+		{target: "main.go", wantErr: true},
+		{target: "main.go:", wantErr: true},
+		{target: ":3", wantErr: true},
+		{target: "main.go:0", wantErr: true},
+		{target: "main.go:x", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		file, line, err := parseBlameTarget(tt.target)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseBlameTarget(%q) error = %v, wantErr %v", tt.target, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && (file != tt.file || line != tt.line) {
+			t.Errorf("parseBlameTarget(%q) = %q, %d; want %q, %d", tt.target, file, line, tt.file, tt.line)
+		}
+	}
+}
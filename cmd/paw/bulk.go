@@ -0,0 +1,347 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/claude"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/task"
+	"github.com/dongho-jung/paw/internal/tmux"
+	"github.com/dongho-jung/paw/internal/tui"
+)
+
+var (
+	taskBulkStatus string
+	taskBulkYes    bool
+)
+
+var taskCmd = &cobra.Command{
+	Use:   "task <merge-push|merge|sync|resume|drop> [task...]",
+	Short: "Apply an action to several tasks at once",
+	Long: `Apply an action to several tasks of the current project, one at a time:
+
+  merge-push  merge each task to main, push and clean up
+  merge       merge each task to main (local only) and clean up
+  sync        rebase each task onto the latest main
+  resume      restart stopped agents with their previous session
+  drop        discard the changes of each task and clean up
+
+Select tasks by name, by --status, or both. Merges take the merge lock in
+the given order. Tasks that fail are left as they are and listed at the end.`,
+	Example: `  paw task merge fix-login add-search
+  paw task merge-push --status done
+  paw task resume --status warning
+  paw task drop old-spike --yes`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		action := tui.BulkAction(args[0])
+		opt, ok := tui.BulkOptionFor(action)
+		if !ok {
+			return fmt.Errorf("unknown action %q (use merge-push, merge, sync, resume or drop)", args[0])
+		}
+		names := args[1:]
+		if len(names) == 0 && taskBulkStatus == "" {
+			return errors.New("name the tasks or select them with --status")
+		}
+
+		application, err := getAppFromCwd()
+		if err != nil {
+			return err
+		}
+		targets, err := selectBulkTargets(service.NewTaskDiscoveryService().DiscoverAll(),
+			application.SessionName, names, service.DiscoveredStatus(taskBulkStatus))
+		if err != nil {
+			return err
+		}
+
+		if action == tui.BulkDrop && !taskBulkYes {
+			if !confirmPrompt(fmt.Sprintf("Drop %d task(s) and discard their changes? [y/N] ", len(targets))) {
+				fmt.Println("Cancelled.")
+				return nil
+			}
+		}
+
+		failed := 0
+		for _, target := range targets {
+			fmt.Printf("▸ %s: %s...\n", target.Task, opt.Name)
+			result := runBulkTask(action, target)
+			if !result.OK {
+				failed++
+				fmt.Printf("✗ %s: %s\n", target.Task, result.Message)
+				continue
+			}
+			msg := opt.Done
+			if result.Message != "" {
+				msg = result.Message
+			}
+			fmt.Printf("✓ %s: %s\n", target.Task, msg)
+		}
+
+		fmt.Printf("\n%d %s", len(targets)-failed, opt.Done)
+		if failed > 0 {
+			fmt.Printf(" · %d need attention\n", failed)
+			return fmt.Errorf("%d task(s) need attention", failed)
+		}
+		fmt.Println()
+		return nil
+	},
+}
+
+func init() {
+	taskCmd.Flags().StringVar(&taskBulkStatus, "status", "", "Select the tasks with this status (working, waiting, warning, review, done)")
+	taskCmd.Flags().BoolVarP(&taskBulkYes, "yes", "y", false, "Drop without asking for confirmation")
+}
+
+// selectBulkTargets picks the running tasks of a session by name and/or
+// status, keeping the order of the names (or the board order).
+func selectBulkTargets(tasks []*service.DiscoveredTask, session string, names []string, status service.DiscoveredStatus) ([]tui.BulkTarget, error) {
+	var running []*service.DiscoveredTask
+	for _, t := range tasks {
+		// Drafted tasks have no window to act on
+		if t.Session == session && t.BacklogID == "" {
+			running = append(running, t)
+		}
+	}
+
+	matches := func(t *service.DiscoveredTask) bool {
+		return status == "" || t.Status == status
+	}
+
+	var targets []tui.BulkTarget
+	if len(names) == 0 {
+		for _, t := range running {
+			if matches(t) {
+				targets = append(targets, tui.BulkTarget{Session: t.Session, WindowID: t.WindowID, Task: t.Name})
+			}
+		}
+		if len(targets) == 0 {
+			return nil, fmt.Errorf("no %s tasks in %s", status, session)
+		}
+		return targets, nil
+	}
+
+	seen := make(map[string]bool)
+	for _, name := range names {
+		var found *service.DiscoveredTask
+		for _, t := range running {
+			if t.Name == name {
+				found = t
+				break
+			}
+		}
+		if found == nil {
+			return nil, fmt.Errorf("task not found in %s: %s", session, name)
+		}
+		if !matches(found) {
+			return nil, fmt.Errorf("task %s is %s, not %s", name, found.Status, status)
+		}
+		if seen[found.WindowID] {
+			continue
+		}
+		seen[found.WindowID] = true
+		targets = append(targets, tui.BulkTarget{Session: found.Session, WindowID: found.WindowID, Task: found.Name})
+	}
+	return targets, nil
+}
+
+// runBulkTask applies a bulk action to one task by running the same internal
+// command as the single-task action, and reads the result from its exit status.
+func runBulkTask(action tui.BulkAction, target tui.BulkTarget) tui.BulkResult {
+	logging.Debug("-> runBulkTask(action=%s, session=%s, windowID=%s)", action, target.Session, target.WindowID)
+	defer logging.Debug("<- runBulkTask")
+
+	appCtx, err := getAppFromSession(target.Session)
+	if err != nil {
+		return tui.BulkResult{Message: err.Error()}
+	}
+
+	var args []string
+	switch action {
+	case tui.BulkMergePush, tui.BulkMerge, tui.BulkDrop:
+		args = []string{"end-task", "--user-initiated", "--action", string(action), target.Session, target.WindowID}
+	case tui.BulkSync:
+		if !appCtx.IsGitRepo {
+			return tui.BulkResult{Message: "not a git repository"}
+		}
+		args = []string{"sync-with-main", target.Session, target.WindowID}
+	case tui.BulkResume:
+		// Resuming respawns the agent pane, so leave running agents alone
+		if claude.New().IsClaudeRunning(tmux.New(target.Session), target.WindowID+".0") {
+			return tui.BulkResult{OK: true, Message: "already running"}
+		}
+		mgr := task.NewManager(appCtx.AgentsDir, appCtx.ProjectDir, appCtx.PawDir, appCtx.IsGitRepo, appCtx.Config)
+		t, err := mgr.FindTaskByWindowID(target.WindowID)
+		if err != nil {
+			return tui.BulkResult{Message: "task not found"}
+		}
+		args = []string{"resume-agent", target.Session, target.WindowID, t.AgentDir}
+	default:
+		return tui.BulkResult{Message: "unknown action: " + string(action)}
+	}
+
+	cmd := exec.Command(getPawBin(), append([]string{"internal"}, args...)...) //nolint:gosec // G204: pawBin is from getPawBin()
+	cmd.Dir = appCtx.ProjectDir
+	cmd.Env = append(os.Environ(),
+		"PAW_DIR="+appCtx.PawDir,
+		"PROJECT_DIR="+appCtx.ProjectDir,
+	)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		logging.Warn("runBulkTask: %s %s failed: %v", action, target.Task, err)
+	}
+	return bulkResultFromExit(string(output), err)
+}
+
+// bulkExitMessages explains the dedicated exit codes of the internal commands.
+var bulkExitMessages = map[int]string{
+	exitUnavailable:  "not available for this task",
+	exitMergeFailed:  "merge failed - manual resolution needed",
+	exitPushFailed:   "push failed",
+	exitPRFailed:     "PR not created",
+	exitSyncBlocked:  "uncommitted changes or an unfinished rebase or merge",
+	exitSyncConflict: "rebase conflict",
+}
+
+// bulkResultFromExit reads the result of an internal command from its exit
+// status: the commands exit non-zero whenever a task needs the user, with a
+// dedicated code when they have already explained why.
+func bulkResultFromExit(output string, err error) tui.BulkResult {
+	if err == nil {
+		return tui.BulkResult{OK: true}
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		if msg, ok := bulkExitMessages[exitErr.ExitCode()]; ok {
+			return tui.BulkResult{Message: msg}
+		}
+	}
+	return tui.BulkResult{Message: bulkErrorMessage(output, err)}
+}
+
+// bulkErrorMessage returns the last line of a failed command's output, or
+// the error itself.
+func bulkErrorMessage(output string, err error) string {
+	lines := bulkOutputLines(output)
+	if len(lines) > 0 {
+		return strings.TrimPrefix(lines[len(lines)-1], "Error: ")
+	}
+	return err.Error()
+}
+
+// bulkOutputLines splits command output into trimmed, non-empty lines,
+// dropping colors and spinner frames.
+func bulkOutputLines(output string) []string {
+	var lines []string
+	for _, line := range strings.FieldsFunc(ansi.Strip(output), func(r rune) bool {
+		return r == '\n' || r == '\r'
+	}) {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// parseBulkTargets parses "session:window-id" arguments.
+func parseBulkTargets(args []string) ([]tui.BulkTarget, error) {
+	var targets []tui.BulkTarget
+	for _, arg := range args {
+		session, windowID, ok := strings.Cut(arg, ":")
+		if !ok || session == "" || windowID == "" {
+			return nil, fmt.Errorf("invalid task %q (expected session:window-id)", arg)
+		}
+		targets = append(targets, tui.BulkTarget{Session: session, WindowID: windowID, Task: windowID})
+	}
+	return targets, nil
+}
+
+// resolveBulkTaskNames fills in the task names of the targets.
+func resolveBulkTaskNames(targets []tui.BulkTarget) {
+	managers := make(map[string]*task.Manager)
+	for i, target := range targets {
+		mgr, ok := managers[target.Session]
+		if !ok {
+			appCtx, err := getAppFromSession(target.Session)
+			if err != nil {
+				continue
+			}
+			mgr = task.NewManager(appCtx.AgentsDir, appCtx.ProjectDir, appCtx.PawDir, appCtx.IsGitRepo, appCtx.Config)
+			managers[target.Session] = mgr
+		}
+		if t, err := mgr.FindTaskByWindowID(target.WindowID); err == nil {
+			targets[i].Task = t.Name
+		}
+	}
+}
+
+var toggleBulkCmd = &cobra.Command{
+	Use:   "toggle-bulk [session] [session:window-id...]",
+	Short: "Show the bulk action popup for marked tasks",
+	Args:  cobra.MinimumNArgs(2),
+	RunE: func(_ *cobra.Command, args []string) error {
+		logging.Debug("-> toggleBulkCmd(session=%s, tasks=%v)", args[0], args[1:])
+		defer logging.Debug("<- toggleBulkCmd")
+
+		sessionName := args[0]
+		tm := tmux.New(sessionName)
+
+		appCtx, err := getAppFromSession(sessionName)
+		if err != nil {
+			logging.Debug("toggleBulkCmd: getAppFromSession failed: %v", err)
+			return err
+		}
+		if _, err := parseBulkTargets(args[1:]); err != nil {
+			return err
+		}
+
+		popupCmd := shellJoin(append([]string{getPawBin(), "internal", "bulk-tui", sessionName}, args[1:]...)...)
+		return tm.DisplayPopup(tmux.PopupOpts{
+			Width:     constants.PopupWidthBulk,
+			Height:    constants.PopupHeightBulk,
+			Title:     " Bulk Action ",
+			Close:     true,
+			Style:     "fg=terminal,bg=terminal",
+			Directory: appCtx.ProjectDir,
+			Env: map[string]string{
+				"PAW_DIR":     appCtx.PawDir,
+				"PROJECT_DIR": appCtx.ProjectDir,
+			},
+		}, popupCmd)
+	},
+}
+
+var bulkTUICmd = &cobra.Command{
+	Use:    "bulk-tui [session] [session:window-id...]",
+	Short:  "Run the bulk action TUI (called from popup)",
+	Args:   cobra.MinimumNArgs(2),
+	Hidden: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		sessionName := args[0]
+
+		appCtx, err := getAppFromSession(sessionName)
+		if err != nil {
+			return err
+		}
+		_, cleanup := setupLoggerFromApp(appCtx, "bulk-tui", "")
+		defer cleanup()
+
+		logging.Debug("-> bulkTUICmd(session=%s, tasks=%v)", sessionName, args[1:])
+		defer logging.Debug("<- bulkTUICmd")
+
+		targets, err := parseBulkTargets(args[1:])
+		if err != nil {
+			return err
+		}
+		resolveBulkTaskNames(targets)
+		return tui.RunBulk(targets, runBulkTask)
+	},
+}
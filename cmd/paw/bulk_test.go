@@ -0,0 +1,124 @@
+package main
+
+import (
+	"errors"
+	"os/exec"
+	"strconv"
+	"testing"
+
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/tui"
+)
+
+func TestBulkResultFromExit(t *testing.T) {
+	exitWith := func(code int) error {
+		return exec.Command("sh", "-c", "exit "+strconv.Itoa(code)).Run()
+	}
+
+	tests := []struct {
+		name   string
+		output string
+		err    error
+		want   tui.BulkResult
+	}{
+		{
+			name:   "merged",
+			output: "\n  Finishing task: a\n\r⠋ Merging\r  ✓ Merged\n\n  ✓ Done!\n",
+			want:   tui.BulkResult{OK: true},
+		},
+		{
+			name: "resumed without output",
+			want: tui.BulkResult{OK: true},
+		},
+		{
+			name:   "push failed after merge",
+			output: "  ⚠️  Failed to push main: rejected\n\n  ✓ Done!\n",
+			err:    exitWith(exitPushFailed),
+			want:   tui.BulkResult{Message: "push failed"},
+		},
+		{
+			name:   "merge conflict",
+			output: "  ✗ Merge failed - manual resolution needed\n",
+			err:    exitWith(exitMergeFailed),
+			want:   tui.BulkResult{Message: "merge failed - manual resolution needed"},
+		},
+		{
+			name:   "sync conflict",
+			output: "  ⚠️  Rebase conflict detected\n",
+			err:    exitWith(exitSyncConflict),
+			want:   tui.BulkResult{Message: "rebase conflict"},
+		},
+		{
+			name:   "command error",
+			output: "task not found\n",
+			err:    exitWith(1),
+			want:   tui.BulkResult{Message: "task not found"},
+		},
+		{
+			name: "failed to start",
+			err:  errors.New("exec: no such file"),
+			want: tui.BulkResult{Message: "exec: no such file"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := bulkResultFromExit(tt.output, tt.err); got != tt.want {
+				t.Errorf("bulkResultFromExit() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseBulkTargets(t *testing.T) {
+	targets, err := parseBulkTargets([]string{"proj:@3", "other:@10"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(targets) != 2 || targets[1] != (tui.BulkTarget{Session: "other", WindowID: "@10", Task: "@10"}) {
+		t.Errorf("parseBulkTargets() = %+v", targets)
+	}
+	for _, arg := range []string{"proj", ":@3", "proj:"} {
+		if _, err := parseBulkTargets([]string{arg}); err == nil {
+			t.Errorf("parseBulkTargets(%q) expected error", arg)
+		}
+	}
+}
+
+func TestSelectBulkTargets(t *testing.T) {
+	tasks := []*service.DiscoveredTask{
+		{Name: "draft", Session: "proj", Status: service.DiscoveredBacklog, BacklogID: "x"},
+		{Name: "a", Session: "proj", WindowID: "@1", Status: service.DiscoveredDone},
+		{Name: "b", Session: "proj", WindowID: "@2", Status: service.DiscoveredWorking},
+		{Name: "c", Session: "proj", WindowID: "@3", Status: service.DiscoveredDone},
+		{Name: "a", Session: "other", WindowID: "@1", Status: service.DiscoveredDone},
+	}
+
+	names := func(targets []tui.BulkTarget) []string {
+		var out []string
+		for _, target := range targets {
+			out = append(out, target.Task)
+		}
+		return out
+	}
+
+	got, err := selectBulkTargets(tasks, "proj", nil, service.DiscoveredDone)
+	if err != nil || len(got) != 2 || got[0].Task != "a" || got[1].Task != "c" {
+		t.Errorf("by status = %v, %v", names(got), err)
+	}
+
+	// Names keep their order; duplicates run once
+	got, err = selectBulkTargets(tasks, "proj", []string{"c", "b", "c"}, "")
+	if err != nil || len(got) != 2 || got[0].Task != "c" || got[1].Task != "b" {
+		t.Errorf("by name = %v, %v", names(got), err)
+	}
+
+	if _, err := selectBulkTargets(tasks, "proj", []string{"b"}, service.DiscoveredDone); err == nil {
+		t.Error("expected error for a task with another status")
+	}
+	if _, err := selectBulkTargets(tasks, "proj", []string{"draft"}, ""); err == nil {
+		t.Error("expected error for a backlog draft")
+	}
+	if _, err := selectBulkTargets(tasks, "proj", nil, service.DiscoveredWaiting); err == nil {
+		t.Error("expected error when no task matches")
+	}
+}
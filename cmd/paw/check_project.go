@@ -12,7 +12,9 @@ import (
 	"github.com/dongho-jung/paw/internal/constants"
 	"github.com/dongho-jung/paw/internal/embed"
 	"github.com/dongho-jung/paw/internal/git"
+	"github.com/dongho-jung/paw/internal/keymap"
 	"github.com/dongho-jung/paw/internal/task"
+	"github.com/dongho-jung/paw/internal/theme"
 	"github.com/dongho-jung/paw/internal/tmux"
 )
 
@@ -168,12 +170,104 @@ func projectChecks(appCtx *app.App) []checkResult {
 		}
 	}
 
+	results = append(results, keybindingsCheck(appCtx))
+	results = append(results, themeCheck(appCtx))
+	results = append(results, remoteChecks(appCtx)...)
 	results = append(results, worktreeChecks(appCtx)...)
 	results = append(results, sessionChecks(appCtx)...)
 
 	return results
 }
 
+func remoteChecks(appCtx *app.App) []checkResult {
+	if !appCtx.IsGitRepo || appCtx.Config == nil {
+		return nil
+	}
+
+	gitClient := git.New()
+	remotes, err := gitClient.ListRemotes(appCtx.ProjectDir)
+	if err != nil {
+		return []checkResult{
+			{
+				name:     "remotes",
+				ok:       false,
+				required: false,
+				message:  fmt.Sprintf("error: %v", err),
+			},
+		}
+	}
+	if len(remotes) == 0 {
+		return []checkResult{
+			{
+				name:     "remotes",
+				ok:       true,
+				required: false,
+				message:  "none (local repo)",
+			},
+		}
+	}
+
+	remoteResult := func(name, key, remote string) checkResult {
+		url, err := gitClient.GetRemoteURL(appCtx.ProjectDir, remote)
+		if err != nil {
+			return checkResult{
+				name:     name,
+				ok:       false,
+				required: false,
+				message:  fmt.Sprintf("remote %q not found (set %s; available: %s)", remote, key, stringsJoin(remotes)),
+			}
+		}
+		return checkResult{
+			name:     name,
+			ok:       true,
+			required: false,
+			message:  fmt.Sprintf("%s (%s)", remote, url),
+		}
+	}
+
+	fetchRemote := appCtx.Config.GetFetchRemote()
+	pushRemote := appCtx.Config.GetPushRemote()
+	results := []checkResult{
+		remoteResult("fetch remote", "fetch_remote", fetchRemote),
+		remoteResult("push remote", "push_remote", pushRemote),
+	}
+	if !results[0].ok || !results[1].ok {
+		return results
+	}
+
+	mgr := task.NewManager(appCtx.AgentsDir, appCtx.ProjectDir, appCtx.PawDir, appCtx.IsGitRepo, appCtx.Config)
+	prRepo := mgr.PRRepo()
+	switch {
+	case prRepo != "":
+		msg := prRepo
+		if head := mgr.PRHead("<branch>"); head != "" {
+			msg += " (head: " + head + ")"
+		}
+		results = append(results, checkResult{
+			name:     "PR target",
+			ok:       true,
+			required: false,
+			message:  msg,
+		})
+	case fetchRemote != pushRemote:
+		results = append(results, checkResult{
+			name:     "PR target",
+			ok:       false,
+			required: false,
+			message:  fmt.Sprintf("cannot derive a GitHub repository from %s (set pr_repo: owner/name)", fetchRemote),
+		})
+	default:
+		results = append(results, checkResult{
+			name:     "PR target",
+			ok:       true,
+			required: false,
+			message:  "gh default repository",
+		})
+	}
+
+	return results
+}
+
 func worktreeChecks(appCtx *app.App) []checkResult {
 	if !appCtx.IsGitRepo || appCtx.Config == nil {
 		return nil
@@ -287,6 +381,68 @@ func pathExists(path string) bool {
 	return err == nil
 }
 
+// keybindingsCheck validates the keybindings of the global and project config
+// and reports keys bound to more than one action.
+func keybindingsCheck(appCtx *app.App) checkResult {
+	km, errs := keymap.Load(appCtx.PawDir)
+	if len(errs) > 0 {
+		messages := make([]string, 0, len(errs))
+		for _, err := range errs {
+			messages = append(messages, err.Error())
+		}
+		return checkResult{
+			name:     "keybindings",
+			ok:       false,
+			required: false,
+			message:  stringsJoin(messages),
+		}
+	}
+	if conflicts := km.Conflicts(); len(conflicts) > 0 {
+		messages := make([]string, 0, len(conflicts))
+		for _, conflict := range conflicts {
+			messages = append(messages, conflict.String())
+		}
+		return checkResult{
+			name:     "keybindings",
+			ok:       false,
+			required: false,
+			message:  "conflicts: " + stringsJoin(messages),
+		}
+	}
+
+	remapped := 0
+	for _, def := range keymap.Definitions() {
+		if km.Remapped(def.Action) {
+			remapped++
+		}
+	}
+	return checkResult{
+		name:     "keybindings",
+		ok:       true,
+		required: false,
+		message:  boolMessage(remapped == 0, "defaults", fmt.Sprintf("%d remapped", remapped)),
+	}
+}
+
+// themeCheck validates the configured theme and its theme file.
+func themeCheck(appCtx *app.App) checkResult {
+	name := theme.ConfiguredName(appCtx.PawDir)
+	if _, err := theme.Lookup(name); err != nil {
+		return checkResult{
+			name:     "theme",
+			ok:       false,
+			required: false,
+			message:  err.Error(),
+		}
+	}
+	return checkResult{
+		name:     "theme",
+		ok:       true,
+		required: false,
+		message:  name,
+	}
+}
+
 func boolMessage(ok bool, okMessage, badMessage string) string {
 	if ok {
 		return okMessage
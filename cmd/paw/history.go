@@ -151,7 +151,7 @@ func loadHistoryEntries(historyDir string, opts historyOptions) ([]historyEntry,
 			continue
 		}
 
-		ts := parseHistoryTimestamp(path)
+		ts := service.HistoryTimestamp(path)
 		if ts.IsZero() {
 			ts = info.ModTime()
 		}
@@ -201,20 +201,6 @@ func loadHistoryEntries(historyDir string, opts historyOptions) ([]historyEntry,
 	return entries, nil
 }
 
-func parseHistoryTimestamp(path string) time.Time {
-	base := filepath.Base(strings.TrimSuffix(path, ".cancelled"))
-	parts := strings.SplitN(base, "_", 3)
-	if len(parts) < 3 {
-		return time.Time{}
-	}
-	tsStr := parts[0] + "_" + parts[1]
-	parsed, err := time.ParseInLocation("060102_150405", tsStr, time.Local)
-	if err != nil {
-		return time.Time{}
-	}
-	return parsed
-}
-
 func extractSummary(content string) string {
 	const summaryMarker = "\n---summary---\n"
 	idx := strings.Index(content, summaryMarker)
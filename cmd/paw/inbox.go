@@ -0,0 +1,251 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/tmux"
+	"github.com/dongho-jung/paw/internal/tui"
+)
+
+// inboxKeyDelay gives the agent's question UI time to redraw between keystrokes.
+const inboxKeyDelay = 150 * time.Millisecond
+
+func globalInbox() *service.Inbox {
+	return service.NewInbox(config.GlobalInboxDir())
+}
+
+// recordInboxQuestions adds the questions of an AskUserQuestion hook payload
+// on stdin to the inbox.
+func recordInboxQuestions(stdin io.Reader) {
+	sessionName := os.Getenv("SESSION_NAME")
+	windowID := os.Getenv("WINDOW_ID")
+	taskName := os.Getenv("TASK_NAME")
+	if sessionName == "" || windowID == "" || taskName == "" || config.GlobalInboxDir() == "" {
+		return
+	}
+
+	data, err := io.ReadAll(stdin)
+	if err != nil {
+		logging.Debug("recordInboxQuestions: failed to read hook payload: %v", err)
+		return
+	}
+	questions, err := service.ParseAskUserQuestionPayload(data)
+	if err != nil {
+		logging.Debug("recordInboxQuestions: %v", err)
+		return
+	}
+
+	if err := globalInbox().Put(service.InboxEntry{
+		Session:   sessionName,
+		WindowID:  windowID,
+		Task:      taskName,
+		Questions: questions,
+	}); err != nil {
+		logging.Warn("recordInboxQuestions: failed to write inbox entry: %v", err)
+	}
+}
+
+// clearInboxQuestions removes the inbox entry of the hook's task window.
+func clearInboxQuestions() {
+	sessionName := os.Getenv("SESSION_NAME")
+	windowID := os.Getenv("WINDOW_ID")
+	if sessionName == "" || windowID == "" || config.GlobalInboxDir() == "" {
+		return
+	}
+	if err := globalInbox().Remove(sessionName, windowID); err != nil {
+		logging.Debug("clearInboxQuestions: %v", err)
+	}
+}
+
+// hookStdin returns the hook payload reader, or an empty reader when stdin is
+// a terminal (hook run by hand).
+func hookStdin() io.Reader {
+	if info, err := os.Stdin.Stat(); err == nil && info.Mode()&os.ModeCharDevice == 0 {
+		return os.Stdin
+	}
+	return strings.NewReader("")
+}
+
+// liveInboxEntries lists the inbox, dropping entries whose window is gone or
+// no longer waiting (the agent resumed).
+func liveInboxEntries(inbox *service.Inbox) []service.InboxEntry {
+	entries, err := inbox.List()
+	if err != nil {
+		logging.Warn("Failed to read inbox: %v", err)
+		return nil
+	}
+
+	var live []service.InboxEntry
+	for _, e := range entries {
+		tm := tmux.New(e.Session)
+		name, err := getWindowName(tm, e.WindowID)
+		if err != nil || !isWaitingWindow(name) {
+			logging.Debug("liveInboxEntries: dropping %s (%s)", e.Task, e.Session)
+			_ = inbox.Remove(e.Session, e.WindowID)
+			continue
+		}
+		live = append(live, e)
+	}
+	return live
+}
+
+// inboxKeystroke is one step of answering a question UI.
+type inboxKeystroke struct {
+	keys    string
+	literal bool
+}
+
+// inboxKeystrokes returns the keystrokes that answer questions in the
+// agent's question UI. An option is chosen by its number; on multi-select
+// questions the number toggles the option and Enter submits the choices.
+// Typed answers pick the free-text row numbered after the options. Several
+// questions end on a review screen that is submitted with Enter.
+func inboxKeystrokes(questions []service.InboxQuestion, answers []service.InboxAnswer) []inboxKeystroke {
+	var strokes []inboxKeystroke
+	for i, q := range questions {
+		if i >= len(answers) {
+			break
+		}
+		a := answers[i]
+		if a.Text == "" && q.MultiSelect {
+			for _, idx := range a.Options {
+				if idx >= 0 && idx < len(q.Options) {
+					strokes = append(strokes, inboxKeystroke{keys: strconv.Itoa(idx + 1)})
+				}
+			}
+			strokes = append(strokes, inboxKeystroke{keys: "Enter"})
+			continue
+		}
+		if a.Text == "" && len(a.Options) == 1 {
+			strokes = append(strokes, inboxKeystroke{keys: strconv.Itoa(a.Options[0] + 1)})
+			continue
+		}
+
+		strokes = append(strokes,
+			inboxKeystroke{keys: strconv.Itoa(len(q.Options) + 1)},
+			inboxKeystroke{keys: a.Text, literal: true},
+			inboxKeystroke{keys: "Enter"},
+		)
+	}
+	if len(questions) > 1 {
+		strokes = append(strokes, inboxKeystroke{keys: "Enter"})
+	}
+	return strokes
+}
+
+// deliverInboxAnswers sends the answers to the agent pane of an entry.
+func deliverInboxAnswers(entry service.InboxEntry, answers []service.InboxAnswer) error {
+	tm := tmux.New(entry.Session)
+	paneID := entry.WindowID + ".0"
+	if !tm.HasPane(paneID) {
+		return fmt.Errorf("the window of %s is gone", entry.Task)
+	}
+
+	for i, stroke := range inboxKeystrokes(entry.Questions, answers) {
+		if i > 0 {
+			time.Sleep(inboxKeyDelay)
+		}
+		var err error
+		if stroke.literal {
+			err = tm.SendKeysLiteral(paneID, stroke.keys)
+		} else {
+			err = tm.SendKeys(paneID, stroke.keys)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to send answer: %w", err)
+		}
+	}
+	return nil
+}
+
+var toggleInboxCmd = &cobra.Command{
+	Use:   "toggle-inbox [session]",
+	Short: "Show the inbox of waiting agent questions",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		logging.Debug("-> toggleInboxCmd(session=%s)", args[0])
+		defer logging.Debug("<- toggleInboxCmd")
+
+		sessionName := args[0]
+		tm := tmux.New(sessionName)
+
+		appCtx, err := getAppFromSession(sessionName)
+		if err != nil {
+			logging.Debug("toggleInboxCmd: getAppFromSession failed: %v", err)
+			return err
+		}
+
+		inboxCmd := shellJoin(getPawBin(), "internal", "inbox-tui", sessionName)
+		return tm.DisplayPopup(tmux.PopupOpts{
+			Width:     constants.PopupWidthInbox,
+			Height:    constants.PopupHeightInbox,
+			Title:     " Inbox ",
+			Close:     true,
+			Style:     "fg=terminal,bg=terminal",
+			Directory: appCtx.ProjectDir,
+			Env: map[string]string{
+				"PAW_DIR":     appCtx.PawDir,
+				"PROJECT_DIR": appCtx.ProjectDir,
+			},
+		}, inboxCmd)
+	},
+}
+
+var inboxTUICmd = &cobra.Command{
+	Use:    "inbox-tui [session]",
+	Short:  "Run the inbox TUI (called from popup)",
+	Args:   cobra.ExactArgs(1),
+	Hidden: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		sessionName := args[0]
+
+		appCtx, err := getAppFromSession(sessionName)
+		if err != nil {
+			return err
+		}
+		_, cleanup := setupLoggerFromApp(appCtx, "inbox-tui", "")
+		defer cleanup()
+
+		logging.Debug("-> inboxTUICmd(session=%s)", sessionName)
+		defer logging.Debug("<- inboxTUICmd")
+
+		inbox := globalInbox()
+		load := func() []service.InboxEntry { return liveInboxEntries(inbox) }
+
+		// Keep the inbox open while other agents are still waiting
+		for {
+			entry, answers, err := tui.RunInbox(load)
+			if err != nil {
+				fmt.Printf("Failed to run inbox: %v\n", err)
+				return nil
+			}
+			if entry == nil {
+				return nil
+			}
+
+			logging.Debug("inboxTUICmd: answering %s (%s) with %d answers", entry.Task, entry.Session, len(answers))
+			if err := deliverInboxAnswers(*entry, answers); err != nil {
+				logging.Warn("inboxTUICmd: %v", err)
+				fmt.Printf("Failed to answer %s: %v\n", entry.Task, err)
+				waitForEnter()
+				return nil
+			}
+			_ = inbox.Remove(entry.Session, entry.WindowID)
+
+			if len(load()) == 0 {
+				return nil
+			}
+		}
+	},
+}
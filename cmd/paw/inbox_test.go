@@ -0,0 +1,78 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dongho-jung/paw/internal/service"
+)
+
+func TestInboxKeystrokes(t *testing.T) {
+	options := []service.InboxOption{{Label: "Postgres"}, {Label: "SQLite"}, {Label: "MySQL"}}
+
+	single := []service.InboxQuestion{{Question: "Which database?", Options: options}}
+	got := inboxKeystrokes(single, []service.InboxAnswer{{Options: []int{1}}})
+	if want := []inboxKeystroke{{keys: "2"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("option answer = %+v, want %+v", got, want)
+	}
+
+	got = inboxKeystrokes(single, []service.InboxAnswer{{Text: "DuckDB"}})
+	want := []inboxKeystroke{{keys: "4"}, {keys: "DuckDB", literal: true}, {keys: "Enter"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("typed answer = %+v, want %+v", got, want)
+	}
+
+	multi := []service.InboxQuestion{
+		{Question: "Which databases?", Options: options, MultiSelect: true},
+		{Question: "Migrate now?", Options: options[:2]},
+	}
+	got = inboxKeystrokes(multi, []service.InboxAnswer{{Options: []int{0, 2}}, {Options: []int{0}}})
+	want = []inboxKeystroke{
+		{keys: "1"}, {keys: "3"}, {keys: "Enter"},
+		{keys: "1"},
+		{keys: "Enter"}, // Submit the review screen
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("multiple questions = %+v, want %+v", got, want)
+	}
+}
+
+func TestInboxKeystrokesMultiSelect(t *testing.T) {
+	options := []service.InboxOption{{Label: "Lint"}, {Label: "Tests"}, {Label: "Docs"}}
+	questions := []service.InboxQuestion{{Question: "Which checks?", Options: options, MultiSelect: true}}
+
+	tests := []struct {
+		name   string
+		answer service.InboxAnswer
+		want   []inboxKeystroke
+	}{
+		{
+			name:   "several options",
+			answer: service.InboxAnswer{Options: []int{0, 2}},
+			want:   []inboxKeystroke{{keys: "1"}, {keys: "3"}, {keys: "Enter"}},
+		},
+		{
+			name:   "one option",
+			answer: service.InboxAnswer{Options: []int{1}},
+			want:   []inboxKeystroke{{keys: "2"}, {keys: "Enter"}},
+		},
+		{
+			name:   "out of range option",
+			answer: service.InboxAnswer{Options: []int{1, 5}},
+			want:   []inboxKeystroke{{keys: "2"}, {keys: "Enter"}},
+		},
+		{
+			name:   "typed answer",
+			answer: service.InboxAnswer{Text: "Benchmarks"},
+			want:   []inboxKeystroke{{keys: "4"}, {keys: "Benchmarks", literal: true}, {keys: "Enter"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := inboxKeystrokes(questions, []service.InboxAnswer{tt.answer})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("inboxKeystrokes() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
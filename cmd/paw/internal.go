@@ -29,6 +29,7 @@ func init() {
 	internalCmd.AddCommand(doneTaskCmd)
 	internalCmd.AddCommand(recoverTaskCmd)
 	internalCmd.AddCommand(resumeAgentCmd)
+	internalCmd.AddCommand(taskActionCmd)
 
 	// Sync commands
 	internalCmd.AddCommand(syncWithMainCmd)
@@ -52,9 +53,21 @@ func init() {
 	internalCmd.AddCommand(toggleProjectPickerCmd)
 	internalCmd.AddCommand(projectPickerCmd)
 	internalCmd.AddCommand(projectPickerWrapperCmd)
+	internalCmd.AddCommand(startProjectCmd)
 	internalCmd.AddCommand(loadingScreenCmd)
 	internalCmd.AddCommand(toggleCmdPaletteCmd)
 	internalCmd.AddCommand(cmdPaletteTUICmd)
+	internalCmd.AddCommand(paletteRunCmd)
+	internalCmd.AddCommand(toggleInboxCmd)
+	internalCmd.AddCommand(inboxTUICmd)
+	internalCmd.AddCommand(toggleOverviewCmd)
+	internalCmd.AddCommand(overviewTUICmd)
+	internalCmd.AddCommand(toggleTimelineCmd)
+	internalCmd.AddCommand(timelineTUICmd)
+	internalCmd.AddCommand(toggleStatsCmd)
+	internalCmd.AddCommand(statsTUICmd)
+	internalCmd.AddCommand(toggleBulkCmd)
+	internalCmd.AddCommand(bulkTUICmd)
 	internalCmd.AddCommand(restorePanesCmd)
 	internalCmd.AddCommand(showCurrentTaskCmd)
 	internalCmd.AddCommand(finishPickerTUICmd)
@@ -76,14 +89,24 @@ func init() {
 	internalCmd.AddCommand(askUserQuestionPreHookCmd)
 	internalCmd.AddCommand(askUserQuestionHookCmd)
 	internalCmd.AddCommand(watchWaitCmd)
-	internalCmd.AddCommand(watchPRCmd)
+	internalCmd.AddCommand(watchPRsCmd)
 	internalCmd.AddCommand(logPaneLayoutCmd)
 
 	// Add flags to end-task command
 	endTaskCmd.Flags().StringVar(&paneCaptureFile, "pane-capture-file", "", "Path to pre-captured pane content file")
 	endTaskCmd.Flags().BoolVar(&endTaskUserInitiated, "user-initiated", false, "Require explicit user action to finish")
-	endTaskCmd.Flags().StringVar(&endTaskAction, "action", "keep", "Finish action: keep, merge, pr, drop")
+	endTaskCmd.Flags().StringVar(&endTaskAction, "action", "keep", "Finish action: keep, merge, pr, push-upstream, drop")
 
 	// Add flags to end-task-ui command (receives action from finish-picker-tui)
-	endTaskUICmd.Flags().StringVar(&endTaskAction, "action", "keep", "Finish action: keep, merge, pr, drop")
+	endTaskUICmd.Flags().StringVar(&endTaskAction, "action", "keep", "Finish action: keep, merge, pr, push-upstream, drop")
+
+	// Add flags to task-action command (kanban card actions)
+	taskActionCmd.Flags().StringVar(&taskActionFrom, "from", "", "Session whose client shows popups (defaults to the task's session)")
+
+	// Add flags to diff-viewer command (task that receives review comments)
+	diffViewerCmd.Flags().StringVar(&diffViewerSession, "session", "", "Session of the task that receives review comments")
+	diffViewerCmd.Flags().StringVar(&diffViewerWindow, "window", "", "Window ID of the task that receives review comments")
+
+	// Add flags to git-viewer command (commit-message prompt location)
+	gitViewerCmd.Flags().StringVar(&gitViewerPawDir, "paw-dir", "", "PAW directory holding the commit-message prompt")
 }
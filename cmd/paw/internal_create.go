@@ -14,6 +14,7 @@ import (
 	"github.com/dongho-jung/paw/internal/app"
 	"github.com/dongho-jung/paw/internal/config"
 	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/git"
 	"github.com/dongho-jung/paw/internal/logging"
 	"github.com/dongho-jung/paw/internal/service"
 	"github.com/dongho-jung/paw/internal/task"
@@ -153,17 +154,30 @@ var filePickerCmd = &cobra.Command{
 			return fmt.Errorf("PAW_DIR and PROJECT_DIR must be set")
 		}
 
-		action, selectedPath, err := tui.RunFilePicker(projectDir)
+		recentFiles := service.NewRecentFiles(pawDir)
+		recent, _ := recentFiles.Recent()
+		action, refs, err := tui.RunFilePicker(projectDir, recent)
 		if err != nil {
 			return err
 		}
 
-		if action == tui.FilePickerSelect && selectedPath != "" {
-			// Write selected path to file for TUI to pick up
+		if action == tui.FilePickerSelect && len(refs) > 0 {
+			// Write selected references to file for TUI to pick up
+			selected := make([]string, 0, len(refs))
+			var paths []string
+			for _, ref := range refs {
+				selected = append(selected, ref.String())
+				if rel, err := filepath.Rel(projectDir, ref.Path); err == nil {
+					paths = append(paths, rel)
+				}
+			}
 			selectionPath := filepath.Join(pawDir, constants.YaziSelectionFile)
-			if err := os.WriteFile(selectionPath, []byte(selectedPath), 0644); err != nil { //nolint:gosec // G306: selection file needs to be readable
+			if err := os.WriteFile(selectionPath, []byte(strings.Join(selected, " ")), 0644); err != nil { //nolint:gosec // G306: selection file needs to be readable
 				return fmt.Errorf("failed to write selection: %w", err)
 			}
+			if err := recentFiles.Record(paths...); err != nil {
+				logging.Warn("Failed to record recent files: %v", err)
+			}
 		}
 
 		return nil
@@ -224,27 +238,7 @@ var newTaskCmd = &cobra.Command{
 				target := result.JumpTarget
 				logging.Debug("Cross-project jump requested: session=%s, window=%s", target.Session, target.WindowID)
 
-				// Ensure main window exists in target session (recovery)
-				// This is needed because we're bypassing PAW's normal attach flow
-				if err := ensureMainWindowInSession(target.Session); err != nil {
-					logging.Warn("Failed to ensure main window in target session: %v", err)
-				}
-
-				// Select the task window in target session
-				targetTm := tmux.New(target.Session)
-				if err := targetTm.SelectWindow(target.WindowID); err != nil {
-					logging.Warn("Failed to select target window: %v", err)
-				}
-
-				// Use detach-client -E to replace the current client with a new attachment
-				// to the target session. This works across different tmux sockets and
-				// prevents nesting (unlike syscall.Exec which would create nested tmux).
-				targetSocket := constants.TmuxSocketPrefix + target.Session
-				switchCmd := fmt.Sprintf("tmux -L %s attach-session -t %s", shellQuote(targetSocket), shellQuote(target.Session))
-				logging.Debug("Switching to session via detach-client -E: %s", switchCmd)
-
-				tm := tmux.New(sessionName)
-				if err := tm.Run("detach-client", "-E", switchCmd); err != nil {
+				if err := switchToSessionWindow(sessionName, target.Session, target.WindowID); err != nil {
 					logging.Error("Failed to switch session: %v", err)
 					fmt.Printf("Failed to switch to session %s: %v\n", target.Session, err)
 					continue
@@ -278,59 +272,18 @@ var newTaskCmd = &cobra.Command{
 
 			content := result.Content
 
-			// Save content to temp file for spawn-task to read
-			tmpFile, err := os.CreateTemp("", "paw-task-content-*.txt")
-			if err != nil {
-				fmt.Printf("Failed to create temp file: %v\n", err)
-				continue
-			}
-			if _, err := tmpFile.WriteString(content); err != nil {
-				_ = tmpFile.Close()
-				_ = os.Remove(tmpFile.Name())
-				fmt.Printf("Failed to write task content: %v\n", err)
-				continue
-			}
-			_ = tmpFile.Close()
-
-			// Save options to temp file
-			var optsTmpPath string
-			if result.Options != nil {
-				optsTmpFile, err := os.CreateTemp("", "paw-task-opts-*.json")
-				if err != nil {
-					_ = os.Remove(tmpFile.Name())
-					fmt.Printf("Failed to create options temp file: %v\n", err)
-					continue
-				}
-				optsData, err := json.Marshal(result.Options)
-				if err != nil {
-					_ = optsTmpFile.Close()
-					_ = os.Remove(optsTmpFile.Name())
-					_ = os.Remove(tmpFile.Name())
-					fmt.Printf("Failed to marshal task options: %v\n", err)
-					continue
-				}
-				if _, err := optsTmpFile.Write(optsData); err != nil {
-					_ = optsTmpFile.Close()
-					_ = os.Remove(optsTmpFile.Name())
-					_ = os.Remove(tmpFile.Name())
-					fmt.Printf("Failed to write task options: %v\n", err)
+			if result.SaveToBacklog {
+				if _, err := service.NewBacklog(appCtx.PawDir).Add(content, result.Options); err != nil {
+					logging.Warn("Failed to save backlog entry: %v", err)
+					fmt.Printf("Failed to save to backlog: %v\n", err)
 					continue
 				}
-				_ = optsTmpFile.Close()
-				optsTmpPath = optsTmpFile.Name()
+				fmt.Println("  ✓ Saved to backlog")
+				continue
 			}
 
 			// Spawn task creation in a separate window (non-blocking)
-			spawnArgs := []string{"internal", "spawn-task", sessionName, tmpFile.Name()}
-			if optsTmpPath != "" {
-				spawnArgs = append(spawnArgs, optsTmpPath)
-			}
-			spawnCmd := exec.Command(getPawBin(), spawnArgs...) //nolint:gosec // G204: pawBin is from getPawBin()
-			if err := spawnCmd.Start(); err != nil {
-				_ = os.Remove(tmpFile.Name())
-				if optsTmpPath != "" {
-					_ = os.Remove(optsTmpPath)
-				}
+			if err := startSpawnTask(sessionName, content, result.Options); err != nil {
 				logging.Warn("Failed to start spawn-task: %v", err)
 				fmt.Printf("Failed to start task: %v\n", err)
 				continue
@@ -341,7 +294,7 @@ var newTaskCmd = &cobra.Command{
 				logging.Warn("Failed to save input history: %v", err)
 			}
 
-			logging.Debug("Task spawned in background, content file: %s, opts file: %s", tmpFile.Name(), optsTmpPath)
+			logging.Debug("Task spawned in background")
 
 			// Refresh active tasks list
 			activeTasks = getActiveTaskNames(appCtx.AgentsDir)
@@ -366,6 +319,58 @@ func getActiveTaskNames(agentsDir string) []string {
 	return names
 }
 
+// startSpawnTask starts "paw internal spawn-task" in the background.
+// Content and options are handed over through temp files that spawn-task removes.
+func startSpawnTask(sessionName, content string, opts *config.TaskOptions) error {
+	// Save content to temp file for spawn-task to read
+	tmpFile, err := os.CreateTemp("", "paw-task-content-*.txt")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	if _, err := tmpFile.WriteString(content); err != nil {
+		_ = tmpFile.Close()
+		_ = os.Remove(tmpFile.Name())
+		return fmt.Errorf("failed to write task content: %w", err)
+	}
+	_ = tmpFile.Close()
+
+	spawnArgs := []string{"internal", "spawn-task", sessionName, tmpFile.Name()}
+
+	// Save options to temp file
+	var optsTmpPath string
+	if opts != nil {
+		optsData, err := json.Marshal(opts)
+		if err != nil {
+			_ = os.Remove(tmpFile.Name())
+			return fmt.Errorf("failed to marshal task options: %w", err)
+		}
+		optsTmpFile, err := os.CreateTemp("", "paw-task-opts-*.json")
+		if err != nil {
+			_ = os.Remove(tmpFile.Name())
+			return fmt.Errorf("failed to create options temp file: %w", err)
+		}
+		if _, err := optsTmpFile.Write(optsData); err != nil {
+			_ = optsTmpFile.Close()
+			_ = os.Remove(optsTmpFile.Name())
+			_ = os.Remove(tmpFile.Name())
+			return fmt.Errorf("failed to write task options: %w", err)
+		}
+		_ = optsTmpFile.Close()
+		optsTmpPath = optsTmpFile.Name()
+		spawnArgs = append(spawnArgs, optsTmpPath)
+	}
+
+	spawnCmd := exec.Command(getPawBin(), spawnArgs...) //nolint:gosec // G204: pawBin is from getPawBin()
+	if err := spawnCmd.Start(); err != nil {
+		_ = os.Remove(tmpFile.Name())
+		if optsTmpPath != "" {
+			_ = os.Remove(optsTmpPath)
+		}
+		return err
+	}
+	return nil
+}
+
 var spawnTaskCmd = &cobra.Command{
 	Use:   "spawn-task [session] [content-file] [options-file]",
 	Short: "Spawn a task in a separate window (shows progress)",
@@ -457,10 +462,26 @@ var spawnTaskCmd = &cobra.Command{
 			logging.Debug("Using custom branch name from options: %s", customBranchName)
 		}
 
-		newTask, err := mgr.CreateTask(content, customBranchName)
-		if err != nil {
-			logging.Error("Failed to create task: %v", err)
-			return fmt.Errorf("failed to create task: %w", err)
+		if taskOpts != nil && taskOpts.TargetBranch != "" && !git.IsValidGitRef(taskOpts.TargetBranch) {
+			_ = tm.DisplayMessage("⚠️ Invalid target branch: "+taskOpts.TargetBranch, constants.DisplayMsgImportant)
+			return fmt.Errorf("invalid target branch: %q", taskOpts.TargetBranch)
+		}
+
+		var newTask *task.Task
+		if taskOpts != nil && taskOpts.SourceRef != "" {
+			logging.Debug("Checking out existing source: %s", taskOpts.SourceRef)
+			newTask, err = mgr.CreateTaskFromSource(content, taskOpts.SourceRef, customBranchName)
+			if err != nil {
+				logging.Error("Failed to create task from %s: %v", taskOpts.SourceRef, err)
+				_ = tm.DisplayMessage("⚠️ Failed to check out "+taskOpts.SourceRef+": "+err.Error(), constants.DisplayMsgImportant)
+				return fmt.Errorf("failed to create task from %s: %w", taskOpts.SourceRef, err)
+			}
+		} else {
+			newTask, err = mgr.CreateTask(content, customBranchName)
+			if err != nil {
+				logging.Error("Failed to create task: %v", err)
+				return fmt.Errorf("failed to create task: %w", err)
+			}
 		}
 
 		logging.Log("Task created: %s", newTask.Name)
@@ -502,6 +523,29 @@ var spawnTaskCmd = &cobra.Command{
 	},
 }
 
+// switchToSessionWindow moves the client of fromSession to a window of
+// another PAW session. The main window of the target session is recovered
+// first, since this bypasses the normal attach flow.
+func switchToSessionWindow(fromSession, session, windowID string) error {
+	if err := ensureMainWindowInSession(session); err != nil {
+		logging.Warn("Failed to ensure main window in target session: %v", err)
+	}
+
+	// Select the task window in target session
+	targetTm := tmux.New(session)
+	if err := targetTm.SelectWindow(windowID); err != nil {
+		logging.Warn("Failed to select target window: %v", err)
+	}
+
+	// Use detach-client -E to replace the current client with a new attachment
+	// to the target session. This works across different tmux sockets and
+	// prevents nesting (unlike syscall.Exec which would create nested tmux).
+	targetSocket := constants.TmuxSocketPrefix + session
+	switchCmd := fmt.Sprintf("tmux -L %s attach-session -t %s", shellQuote(targetSocket), shellQuote(session))
+	logging.Debug("Switching to session via detach-client -E: %s", switchCmd)
+	return tmux.New(fromSession).Run("detach-client", "-E", switchCmd)
+}
+
 // ensureMainWindowInSession ensures the main window (⭐️main) exists in the given session.
 // If it doesn't exist, it creates one. This is used when jumping to another project
 // to ensure the target session has a properly functioning main window.
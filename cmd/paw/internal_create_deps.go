@@ -25,6 +25,13 @@ func waitForDependency(appCtx *app.App, tm tmux.Client, windowID string, t *task
 		return true
 	}
 
+	// The marker shows the task in the kanban's Blocked column while it waits
+	defer func() {
+		if err := t.ClearBlockedOn(); err != nil {
+			logging.Warn("Failed to clear dependency marker: %v", err)
+		}
+	}()
+
 	firstWait := true
 	for {
 		status, found, terminal := resolveDependencyStatus(appCtx, dep.TaskName)
@@ -51,6 +58,9 @@ func waitForDependency(appCtx *app.App, tm tmux.Client, windowID string, t *task
 		}
 
 		if firstWait {
+			if err := t.SaveBlockedOn(dep.TaskName); err != nil {
+				logging.Warn("Failed to save dependency marker: %v", err)
+			}
 			waitName := windowNameForStatus(t.Name, task.StatusWaiting)
 			_ = renameWindowWithStatus(tm, windowID, waitName, appCtx.PawDir, t.Name, "depends-on", task.StatusWaiting)
 			msg := fmt.Sprintf("⏳ Waiting for %s (%s)", dep.TaskName, dep.Condition)
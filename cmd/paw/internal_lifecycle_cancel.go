@@ -70,9 +70,9 @@ var cancelTaskCmd = &cobra.Command{
 		// Check if task was merged and needs to be reverted
 		if appCtx.IsGitRepo {
 			gitClient := git.New()
-			mainBranch := gitClient.GetMainBranch(appCtx.ProjectDir)
+			mainBranch := mgr.TargetBranch(targetTask)
 
-			// Check if branch was merged into main
+			// Check if branch was merged into the target branch
 			if gitClient.BranchMerged(appCtx.ProjectDir, targetTask.Name, mainBranch) {
 				revertNeeded = true
 				logging.Trace("cancelTaskCmd: task %s was merged, attempting revert", targetTask.Name)
@@ -126,11 +126,12 @@ var cancelTaskCmd = &cobra.Command{
 						pushSpinner := tui.NewSimpleSpinner("Pushing revert")
 						pushSpinner.Start()
 
-						if err := gitClient.Push(appCtx.ProjectDir, "origin", mainBranch, false); err != nil {
+						pushRemote := appCtx.Config.GetPushRemote()
+						if err := gitClient.Push(appCtx.ProjectDir, pushRemote, mainBranch, false); err != nil {
 							pushSpinner.Stop(false, "Push failed")
 							logging.Warn("Failed to push revert: %v", err)
 							fmt.Println("  ⚠️  Reverted locally but push failed")
-							fmt.Printf("     Run: git push origin %s\n", mainBranch)
+							fmt.Printf("     Run: git push %s %s\n", pushRemote, mainBranch)
 						} else {
 							pushSpinner.Stop(true, "Pushed")
 							logging.Log("Pushed revert for task %s", targetTask.Name)
@@ -161,6 +162,8 @@ var cancelTaskCmd = &cobra.Command{
 			}
 		}
 
+		recordTaskOutcome(appCtx, targetTask, service.OutcomeCancelled)
+
 		// Cleanup task
 		cleanupSpinner := tui.NewSimpleSpinner("Cleaning up")
 		cleanupSpinner.Start()
@@ -216,11 +219,8 @@ var cancelTaskUICmd = &cobra.Command{
 		// Get the paw binary path
 		pawBin := getPawBin()
 
-		// Get working directory from pane
-		panePath, err := tm.Display("#{pane_current_path}")
-		if err != nil || panePath == "" {
-			panePath = appCtx.ProjectDir
-		}
+		// Get working directory from the task window's pane
+		panePath := windowPanePath(tm, windowID, appCtx.ProjectDir)
 
 		// Build cancel-task command
 		// CRITICAL: Pass PAW_DIR as env var so cancel-task can find the correct project
@@ -233,6 +233,7 @@ var cancelTaskUICmd = &cobra.Command{
 
 		// Create a top pane spanning full window width
 		_, err = tm.SplitWindowPane(tmux.SplitOpts{
+			Target:     windowID,
 			Horizontal: false,
 			Size:       constants.TopPaneSize,
 			StartDir:   panePath,
@@ -1,9 +1,9 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -14,7 +14,6 @@ import (
 	"github.com/dongho-jung/paw/internal/app"
 	"github.com/dongho-jung/paw/internal/constants"
 	"github.com/dongho-jung/paw/internal/git"
-	"github.com/dongho-jung/paw/internal/github"
 	"github.com/dongho-jung/paw/internal/logging"
 	"github.com/dongho-jung/paw/internal/notify"
 	"github.com/dongho-jung/paw/internal/service"
@@ -31,6 +30,8 @@ var endTaskCmd = &cobra.Command{
 	Use:   "end-task [session] [window-id]",
 	Short: "Finish a task (commit, merge, cleanup)",
 	Args:  cobra.ExactArgs(2),
+	// Failures are shown as they happen; main prints the others once
+	SilenceErrors: true,
 	RunE: func(_ *cobra.Command, args []string) error {
 		logging.Debug("-> endTaskCmd(session=%s, windowID=%s)", args[0], args[1])
 		defer logging.Debug("<- endTaskCmd")
@@ -76,6 +77,9 @@ var endTaskCmd = &cobra.Command{
 		logging.Trace("Working directory: %s", workDir)
 		logging.Debug("Configuration: Action=%s", endTaskAction)
 
+		// Steps that failed without stopping the cleanup; reported in the exit status
+		var attention error
+
 		// Handle drop and done actions - skip git operations
 		skipGitOps := (endTaskAction == constants.ActionDrop || endTaskAction == constants.ActionDone)
 		switch endTaskAction {
@@ -101,7 +105,7 @@ var endTaskCmd = &cobra.Command{
 					if paneCaptureFile != "" {
 						_ = os.Remove(paneCaptureFile)
 					}
-					return nil
+					return reported(exitUnavailable, errors.New("PR creation is only available in worktree mode"))
 				}
 
 				fallbackBranch := targetTask.Name
@@ -111,36 +115,37 @@ var endTaskCmd = &cobra.Command{
 					if paneCaptureFile != "" {
 						_ = os.Remove(paneCaptureFile)
 					}
-					return nil
+					return reported(exitPRFailed, errors.New("failed to determine branch for PR creation"))
 				}
 
-				pushSpinner := tui.NewSimpleSpinner(fmt.Sprintf("Pushing %s to remote", branchName))
+				pushRemote := mgr.PushRemote()
+				pushSpinner := tui.NewSimpleSpinner(fmt.Sprintf("Pushing %s to %s", branchName, pushRemote))
 				pushSpinner.Start()
 
 				pushTimer := logging.StartTimer("git push")
-				if err := gitClient.Push(workDir, "origin", branchName, true); err != nil {
+				if err := gitClient.Push(workDir, pushRemote, branchName, true); err != nil {
 					pushTimer.StopWithResult(false, err.Error())
 					pushSpinner.Stop(false, err.Error())
 					fmt.Printf("  ⚠️  Failed to push branch: %v\n", err)
 					if paneCaptureFile != "" {
 						_ = os.Remove(paneCaptureFile)
 					}
-					return nil
+					return reported(exitPRFailed, fmt.Errorf("failed to push branch: %w", err))
 				}
 
 				pushTimer.StopWithResult(true, "branch="+branchName)
 				pushSpinner.Stop(true, branchName)
 
-				ghClient := github.New()
+				ghClient := mgr.GitHub()
 				if !ghClient.IsInstalled() {
 					fmt.Println("  ⚠️  gh CLI not found; cannot create PR")
 					if paneCaptureFile != "" {
 						_ = os.Remove(paneCaptureFile)
 					}
-					return nil
+					return reported(exitPRFailed, errors.New("gh CLI not found; cannot create PR"))
 				}
 
-				mainBranch := gitClient.GetMainBranch(appCtx.ProjectDir)
+				mainBranch := mgr.TargetBranch(targetTask)
 				prTitle := buildPRTitle(targetTask.Name)
 				commits, err := gitClient.GetBranchCommits(workDir, branchName, mainBranch, 20)
 				if err != nil {
@@ -152,7 +157,7 @@ var endTaskCmd = &cobra.Command{
 				prSpinner := tui.NewSimpleSpinner("Creating pull request")
 				prSpinner.Start()
 				prTimer := logging.StartTimer("gh pr create")
-				prNumber, prURL, err := ghClient.CreatePR(workDir, prTitle, prBody, mainBranch)
+				prNumber, prURL, err := ghClient.CreatePR(workDir, prTitle, prBody, mainBranch, mgr.PRHead(branchName))
 				if err != nil {
 					prTimer.StopWithResult(false, err.Error())
 					prSpinner.Stop(false, err.Error())
@@ -160,7 +165,7 @@ var endTaskCmd = &cobra.Command{
 					if paneCaptureFile != "" {
 						_ = os.Remove(paneCaptureFile)
 					}
-					return nil
+					return reported(exitPRFailed, fmt.Errorf("failed to create PR: %w", err))
 				}
 				prTimer.StopWithResult(true, fmt.Sprintf("pr=%d", prNumber))
 				prSpinner.Stop(true, fmt.Sprintf("#%d", prNumber))
@@ -175,7 +180,7 @@ var endTaskCmd = &cobra.Command{
 					logging.Warn("Failed to rename window for PR review: %v", err)
 				}
 
-				startPRWatch(appCtx, sessionName, windowID, targetTask.Name, prNumber)
+				startPRWatch(appCtx, sessionName, targetTask.Name, prNumber)
 				showPRPopup(tm, sessionName, prNumber, prURL)
 
 				if paneCaptureFile != "" {
@@ -183,14 +188,23 @@ var endTaskCmd = &cobra.Command{
 				}
 				return nil
 
+			case constants.ActionPushUpstream:
+				// Push to the existing branch/PR the task was checked out from
+				err := pushUpstream(appCtx, sessionName, windowID, targetTask, workDir, gitClient, tm)
+				if paneCaptureFile != "" {
+					_ = os.Remove(paneCaptureFile)
+				}
+				return err
+
 			case constants.ActionCreateMain:
 				// Create main branch and merge (for repos without main)
 				if !appCtx.IsWorktreeMode() {
 					logging.Warn("create-main requested in non-worktree mode; skipping")
 					fmt.Println()
 					fmt.Println("  ⚠️  Create main is only available in worktree mode")
+					attention = reported(exitUnavailable, errors.New("create main is only available in worktree mode"))
 				} else {
-					mainBranch := gitClient.GetMainBranch(appCtx.ProjectDir)
+					mainBranch := mgr.TargetBranch(targetTask)
 
 					// Create main branch with empty init commit
 					createSpinner := tui.NewSimpleSpinner(fmt.Sprintf("Creating %s branch", mainBranch))
@@ -203,7 +217,7 @@ var endTaskCmd = &cobra.Command{
 						if paneCaptureFile != "" {
 							_ = os.Remove(paneCaptureFile)
 						}
-						return nil
+						return reported(exitMergeFailed, fmt.Errorf("failed to create %s branch: %w", mainBranch, err))
 					}
 					createSpinner.Stop(true, mainBranch)
 					fmt.Printf("  ✓ Created %s branch with init commit\n", mainBranch)
@@ -211,7 +225,7 @@ var endTaskCmd = &cobra.Command{
 					// Now proceed with merge
 					mergeSuccess := runAutoMerge(appCtx, targetTask, windowID, workDir, gitClient, tm)
 					if !mergeSuccess {
-						return nil // Exit without cleanup - keep worktree and branch
+						return reported(exitMergeFailed, errors.New("merge failed")) // Exit without cleanup - keep worktree and branch
 					}
 				}
 
@@ -221,26 +235,30 @@ var endTaskCmd = &cobra.Command{
 					logging.Warn("merge requested in non-worktree mode; skipping merge")
 					fmt.Println()
 					fmt.Println("  ⚠️  Merge is only available in worktree mode")
+					attention = reported(exitUnavailable, errors.New("merge is only available in worktree mode"))
 				} else {
 					mergeSuccess := runAutoMerge(appCtx, targetTask, windowID, workDir, gitClient, tm)
 					if !mergeSuccess {
-						return nil // Exit without cleanup - keep worktree and branch
+						return reported(exitMergeFailed, errors.New("merge failed")) // Exit without cleanup - keep worktree and branch
 					}
 
 					// Push main to remote if "merge-push" action
 					if endTaskAction == constants.ActionMergePush {
-						mainBranch := gitClient.GetMainBranch(appCtx.ProjectDir)
-						pushSpinner := tui.NewSimpleSpinner(fmt.Sprintf("Pushing %s to remote", mainBranch))
+						mainBranch := mgr.TargetBranch(targetTask)
+						pushRemote := appCtx.Config.GetPushRemote()
+						pushSpinner := tui.NewSimpleSpinner(fmt.Sprintf("Pushing %s to %s", mainBranch, pushRemote))
 						pushSpinner.Start()
 
-						if err := gitClient.Push(appCtx.ProjectDir, "origin", mainBranch, false); err != nil {
+						if err := gitClient.Push(appCtx.ProjectDir, pushRemote, mainBranch, false); err != nil {
 							pushSpinner.Stop(false, err.Error())
 							logging.Warn("Failed to push main branch: %v", err)
 							fmt.Printf("  ⚠️  Failed to push %s: %v\n", mainBranch, err)
 							fmt.Println("  Note: Merge was successful, but push failed. You can push manually.")
+							attention = reported(exitPushFailed, fmt.Errorf("merged, but failed to push %s: %w", mainBranch, err))
 						} else {
 							pushSpinner.Stop(true, mainBranch)
-							fmt.Printf("  ✓ Pushed %s to remote\n", mainBranch)
+							fmt.Printf("  ✓ Pushed %s to %s\n", mainBranch, pushRemote)
+							pushProvenanceNotes(appCtx, pushRemote, gitClient)
 						}
 					}
 				}
@@ -290,6 +308,12 @@ var endTaskCmd = &cobra.Command{
 			logging.Trace("Failed to display message: %v", err)
 		}
 
+		outcome := endTaskAction
+		if outcome == "" {
+			outcome = constants.ActionKeep
+		}
+		recordTaskOutcome(appCtx, targetTask, outcome)
+
 		// Cleanup task (only reached if merge succeeded or not in auto-merge mode)
 		cleanupSpinner := tui.NewSimpleSpinner("Cleaning up")
 		cleanupSpinner.Start()
@@ -311,7 +335,7 @@ var endTaskCmd = &cobra.Command{
 		fmt.Println()
 		fmt.Println("  ✓ Done!")
 
-		return nil
+		return attention
 	},
 }
 
@@ -368,11 +392,8 @@ var endTaskUICmd = &cobra.Command{
 		// Get the paw binary path
 		pawBin := getPawBin()
 
-		// Get working directory from pane
-		panePath, err := tm.Display("#{pane_current_path}")
-		if err != nil || panePath == "" {
-			panePath = appCtx.ProjectDir
-		}
+		// Get working directory from the task window's pane
+		panePath := windowPanePath(tm, windowID, appCtx.ProjectDir)
 
 		// Build end-task command that runs in the pane
 		// Include pane-capture-file flag if we have pre-captured content
@@ -391,6 +412,7 @@ var endTaskUICmd = &cobra.Command{
 
 		// Create a top pane spanning full window width
 		_, err = tm.SplitWindowPane(tmux.SplitOpts{
+			Target:     windowID,
 			Horizontal: false, // vertical split (top/bottom)
 			Size:       constants.TopPaneSize,
 			StartDir:   panePath,
@@ -417,9 +439,13 @@ func runAutoMerge(appCtx *app.App, targetTask *task.Task, windowID, workDir stri
 	fmt.Println()
 	fmt.Println("  Auto-merge mode:")
 
-	// Get main branch name
-	mainBranch := gitClient.GetMainBranch(appCtx.ProjectDir)
-	logging.Debug("Main branch: %s", mainBranch)
+	// Get the branch to merge into
+	mgr := task.NewManager(appCtx.AgentsDir, appCtx.ProjectDir, appCtx.PawDir, appCtx.IsGitRepo, appCtx.Config)
+	mainBranch := mgr.TargetBranch(targetTask)
+	logging.Debug("Target branch: %s", mainBranch)
+	if err := mgr.EnsureLocalBranch(mainBranch); err != nil {
+		logging.Warn("Failed to prepare target branch: %v", err)
+	}
 
 	if appCtx.Config != nil && appCtx.Config.PreMergeHook != "" {
 		hookEnv := appCtx.GetEnvVars(targetTask.Name, workDir, windowID)
@@ -457,7 +483,14 @@ func runAutoMerge(appCtx *app.App, targetTask *task.Task, windowID, workDir stri
 		return handleMergeFailure(appCtx, targetTask, windowID, tm)
 	}
 	lockSpinner.Stop(true, "")
-	defer func() { _ = os.Remove(lockFile) }()
+	lockHeld := true
+	releaseLock := func() {
+		if lockHeld {
+			_ = os.Remove(lockFile)
+			lockHeld = false
+		}
+	}
+	defer releaseLock()
 
 	// Check for ongoing merge or conflicts in project dir
 	hasConflicts, conflictFiles, _ := gitClient.HasConflicts(appCtx.ProjectDir)
@@ -515,6 +548,9 @@ func runAutoMerge(appCtx *app.App, targetTask *task.Task, windowID, workDir stri
 		return handleMergeFailure(appCtx, targetTask, windowID, tm)
 	}
 
+	// Summarize provenance after releasing the lock so other merges don't wait for Claude
+	releaseLock()
+	summarizeProvenance(appCtx, targetTask, readPaneCapture(paneCaptureFile), gitClient)
 	return true
 }
 
@@ -550,23 +586,24 @@ func acquireMergeLock(lockFile, taskName string) bool {
 
 // performMerge executes the git merge operation.
 func performMerge(appCtx *app.App, targetTask *task.Task, windowID, workDir, mainBranch, currentBranch string, gitClient git.Client, mergeTimer *logging.Timer) bool {
-	// Check if remote origin exists
-	hasRemote := gitClient.HasRemote(appCtx.ProjectDir, "origin")
+	// Check if the fetch remote exists
+	fetchRemote := appCtx.Config.GetFetchRemote()
+	hasRemote := gitClient.HasRemote(appCtx.ProjectDir, fetchRemote)
 
-	// Fetch latest from origin (only if remote exists)
+	// Fetch latest from the fetch remote (only if remote exists)
 	if hasRemote {
-		fetchSpinner := tui.NewSimpleSpinner("Fetching from origin")
+		fetchSpinner := tui.NewSimpleSpinner("Fetching from " + fetchRemote)
 		fetchSpinner.Start()
-		logging.Debug("Fetching from origin...")
-		if err := gitClient.Fetch(appCtx.ProjectDir, "origin"); err != nil {
+		logging.Debug("Fetching from %s...", fetchRemote)
+		if err := gitClient.Fetch(appCtx.ProjectDir, fetchRemote); err != nil {
 			logging.Warn("Failed to fetch: %v", err)
 			fetchSpinner.Stop(false, err.Error())
 		} else {
 			fetchSpinner.Stop(true, "")
 		}
 	} else {
-		logging.Debug("No remote 'origin' found, skipping fetch")
-		fmt.Println("  ○ No remote origin (local repo)")
+		logging.Debug("No remote '%s' found, skipping fetch", fetchRemote)
+		fmt.Printf("  ○ No remote %s (local repo)\n", fetchRemote)
 	}
 
 	// Check if main branch exists before checkout
@@ -610,7 +647,7 @@ func performMerge(appCtx *app.App, targetTask *task.Task, windowID, workDir, mai
 	logging.Debug("Squash merging branch %s into %s...", targetTask.Name, mainBranch)
 
 	branchCommits, _ := gitClient.GetBranchCommits(appCtx.ProjectDir, targetTask.Name, mainBranch, 20)
-	mergeMsg := git.GenerateMergeCommitMessage(targetTask.Name, branchCommits)
+	mergeMsg := git.AppendTrailers(git.GenerateMergeCommitMessage(targetTask.Name, branchCommits), provenanceTrailers(appCtx, targetTask))
 	mergeConflictOccurred := false
 	mergeSuccess := true
 
@@ -626,6 +663,7 @@ func performMerge(appCtx *app.App, targetTask *task.Task, windowID, workDir, mai
 		if !mergeConflictOccurred {
 			mergeSpinner.Stop(true, "")
 		}
+		recordMergedTask(appCtx, targetTask, gitClient)
 		mergeTimer.StopWithResult(true, fmt.Sprintf("squash merged %s into %s (local only)", targetTask.Name, mainBranch))
 	}
 
@@ -668,6 +706,9 @@ func performMerge(appCtx *app.App, targetTask *task.Task, windowID, workDir, mai
 func handleMergeConflicts(appCtx *app.App, targetTask *task.Task, mainBranch, mergeMsg string, gitClient git.Client, mergeTimer *logging.Timer) bool {
 	hasConflicts, conflictFiles, _ := gitClient.HasConflicts(appCtx.ProjectDir)
 	if hasConflicts && len(conflictFiles) > 0 {
+		resolved := false
+		defer func() { recordMergeConflict(appCtx, targetTask, conflictFiles, resolved) }()
+
 		fmt.Println()
 		fmt.Printf("  ⚠️  Merge conflicts detected in %d file(s):\n", len(conflictFiles))
 		for _, f := range conflictFiles {
@@ -712,6 +753,7 @@ func handleMergeConflicts(appCtx *app.App, targetTask *task.Task, mainBranch, me
 			return false
 		}
 		logging.Log("Merge completed after conflict resolution")
+		resolved = true
 		return true
 	}
 
@@ -796,20 +838,48 @@ func buildPRBody(taskName string, commits []git.CommitInfo) string {
 	return strings.TrimSpace(sb.String())
 }
 
-func startPRWatch(appCtx *app.App, sessionName, windowID, taskName string, prNumber int) {
-	pawBin := getPawBin()
-
-	watchCmd := exec.Command(pawBin, "internal", "watch-pr", sessionName, windowID, taskName, strconv.Itoa(prNumber)) //nolint:gosec // G204: pawBin is from getPawBin()
-	watchCmd.Dir = appCtx.ProjectDir
-	watchCmd.Env = append(os.Environ(),
-		"PAW_DIR="+appCtx.PawDir,
-		"PROJECT_DIR="+appCtx.ProjectDir,
-	)
-	if err := watchCmd.Start(); err != nil {
-		logging.Warn("Failed to start PR watcher: %v", err)
-	} else {
-		logging.Debug("PR watcher started for windowID=%s pr=%d", windowID, prNumber)
+// pushUpstream pushes the task branch to the existing branch/PR it was checked out from.
+// The task is kept; if it belongs to a PR, the window moves to review and the PR is watched.
+// It returns an error when nothing was pushed.
+func pushUpstream(appCtx *app.App, sessionName, windowID string, targetTask *task.Task, workDir string, gitClient git.Client, tm tmux.Client) error {
+	upstream := targetTask.Upstream
+	if !upstream.CanPush() {
+		logging.Warn("push-upstream requested but task has no push destination")
+		fmt.Println("  ⚠️  This task was not checked out from a pushable branch or PR")
+		return reported(exitUnavailable, errors.New("task has no push destination"))
+	}
+
+	target := upstream.Branch
+	if upstream.PRNumber > 0 {
+		target = fmt.Sprintf("PR #%d (%s)", upstream.PRNumber, upstream.Branch)
+	}
+	pushSpinner := tui.NewSimpleSpinner("Pushing to " + target)
+	pushSpinner.Start()
+
+	pushTimer := logging.StartTimer("git push upstream")
+	if err := gitClient.Push(workDir, upstream.Remote, upstream.PushRefspec(), false); err != nil {
+		pushTimer.StopWithResult(false, err.Error())
+		pushSpinner.Stop(false, err.Error())
+		fmt.Printf("  ⚠️  Failed to push: %v\n", err)
+		if upstream.PRNumber > 0 && strings.Contains(upstream.Remote, "://") {
+			fmt.Println("    The PR comes from a fork; the author must allow edits from maintainers.")
+		}
+		return reported(exitPushFailed, fmt.Errorf("failed to push: %w", err))
+	}
+	pushTimer.StopWithResult(true, "remote="+upstream.Remote+" branch="+upstream.Branch)
+	pushSpinner.Stop(true, target)
+	fmt.Printf("  ✓ Pushed to %s\n", target)
+
+	if upstream.PRNumber == 0 {
+		return nil
+	}
+
+	reviewName := constants.EmojiReview + constants.TruncateForWindowName(targetTask.Name)
+	if err := renameWindowWithStatus(tm, windowID, reviewName, appCtx.PawDir, targetTask.Name, "end-task", task.StatusWaiting); err != nil {
+		logging.Warn("Failed to rename window for PR review: %v", err)
 	}
+	startPRWatch(appCtx, sessionName, targetTask.Name, upstream.PRNumber)
+	return nil
 }
 
 func showPRPopup(tm tmux.Client, sessionName string, prNumber int, prURL string) {
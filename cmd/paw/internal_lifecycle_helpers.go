@@ -8,13 +8,185 @@ import (
 	"os/exec"
 	"strings"
 	"syscall"
+	"time"
 
+	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/config"
 	"github.com/dongho-jung/paw/internal/constants"
 	"github.com/dongho-jung/paw/internal/git"
 	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/task"
 	"github.com/dongho-jung/paw/internal/tui"
 )
 
+// recordMergedTask records the squash commit of a merged task (HEAD of the project
+// directory right after the merge). refs/paw/merged/<task> keeps the task backportable
+// after its branch is deleted, and a provenance note is attached if git_notes is enabled.
+// It runs under the merge lock, so the note is written without the pane summary;
+// summarizeProvenance adds it once the lock is released.
+func recordMergedTask(appCtx *app.App, targetTask *task.Task, gitClient git.Client) {
+	head, err := gitClient.GetHeadCommit(appCtx.ProjectDir)
+	if err != nil {
+		logging.Warn("Failed to read merge commit: %v", err)
+		return
+	}
+	if err := gitClient.UpdateRef(appCtx.ProjectDir, constants.MergedTaskRefPrefix+targetTask.Name, head); err != nil {
+		logging.Warn("Failed to record merged task: %v", err)
+	} else {
+		logging.Debug("Recorded merged task %s at %s", targetTask.Name, head)
+	}
+
+	if appCtx.Config == nil || !appCtx.Config.GitNotes {
+		return
+	}
+
+	noteSpinner := tui.NewSimpleSpinner("Recording provenance")
+	noteSpinner.Start()
+	note := buildProvenance(appCtx, targetTask, head).FormatNote()
+	if err := gitClient.AddNote(appCtx.ProjectDir, constants.ProvenanceNotesRef, head, note); err != nil {
+		logging.Warn("Failed to add provenance note: %v", err)
+		noteSpinner.Stop(false, err.Error())
+		return
+	}
+	noteSpinner.Stop(true, "")
+}
+
+// pushProvenanceNotes pushes refs/notes/paw alongside main so provenance is shared.
+// Failures are only logged: notes are optional and the remote may reject them.
+func pushProvenanceNotes(appCtx *app.App, remote string, gitClient git.Client) {
+	if appCtx.Config == nil || !appCtx.Config.GitNotes {
+		return
+	}
+	if !gitClient.RefExists(appCtx.ProjectDir, constants.ProvenanceNotesRef) {
+		return
+	}
+	if err := gitClient.Push(appCtx.ProjectDir, remote, constants.ProvenanceNotesRef, false); err != nil {
+		logging.Warn("Failed to push provenance notes: %v", err)
+	}
+}
+
+// buildProvenance collects the task content, options and verification result for a
+// merge commit.
+func buildProvenance(appCtx *app.App, targetTask *task.Task, commit string) *service.Provenance {
+	opts, err := config.LoadTaskOptions(targetTask.AgentDir)
+	if err != nil {
+		logging.Trace("buildProvenance: failed to load task options: %v", err)
+		opts = nil
+	}
+
+	meta := &service.HistoryMetadata{
+		TaskName:    targetTask.Name,
+		SessionName: appCtx.SessionName,
+		ProjectDir:  appCtx.ProjectDir,
+		TaskOptions: opts,
+		Commit:      &service.CommitMetadata{Hash: commit, Branch: targetTask.Name},
+		FinishedAt:  time.Now().Format(time.RFC3339),
+	}
+	if verification, err := service.LoadVerificationMetadata(targetTask.GetVerifyMetaPath()); err == nil {
+		meta.Verification = verification
+	} else if !errors.Is(err, os.ErrNotExist) {
+		logging.Trace("buildProvenance: %v", err)
+	}
+
+	taskContent, err := targetTask.LoadContent()
+	if err != nil {
+		logging.Trace("buildProvenance: failed to load task content: %v", err)
+	}
+
+	return &service.Provenance{Meta: meta, TaskContent: taskContent}
+}
+
+// summarizeProvenance saves a merged task to history with a summary of the agent
+// pane, and adds the same summary to the task's provenance note so Claude is only
+// asked once. Generating the summary can take a while, so callers run it after
+// releasing the merge lock.
+func summarizeProvenance(appCtx *app.App, targetTask *task.Task, paneContent string, gitClient git.Client) {
+	if strings.TrimSpace(paneContent) == "" {
+		return
+	}
+
+	taskContent, err := targetTask.LoadContent()
+	if err != nil {
+		logging.Trace("summarizeProvenance: failed to load task content: %v", err)
+	}
+
+	spinner := tui.NewSimpleSpinner("Summarizing task")
+	spinner.Start()
+	summary, err := service.NewHistoryService(appCtx.GetHistoryDir()).SaveCompletedSummary(targetTask.Name, taskContent, paneContent)
+	if err != nil {
+		logging.Warn("Failed to save task history: %v", err)
+		spinner.Stop(false, err.Error())
+		return
+	}
+	if summary == "" || appCtx.Config == nil || !appCtx.Config.GitNotes {
+		spinner.Stop(true, "")
+		return
+	}
+
+	mergedRef := constants.MergedTaskRefPrefix + targetTask.Name
+	note, err := gitClient.GetNote(appCtx.ProjectDir, constants.ProvenanceNotesRef, mergedRef)
+	if err != nil {
+		logging.Trace("summarizeProvenance: no provenance note for %s: %v", mergedRef, err)
+		spinner.Stop(true, "")
+		return
+	}
+	provenance, err := service.ParseProvenanceNote(note)
+	if err != nil {
+		logging.Warn("Failed to parse provenance note: %v", err)
+		spinner.Stop(false, err.Error())
+		return
+	}
+	provenance.Summary = summary
+	if err := gitClient.AddNote(appCtx.ProjectDir, constants.ProvenanceNotesRef, mergedRef, provenance.FormatNote()); err != nil {
+		logging.Warn("Failed to add provenance summary: %v", err)
+		spinner.Stop(false, err.Error())
+		return
+	}
+	spinner.Stop(true, "")
+}
+
+// provenanceTrailers returns the configured provenance trailers for a task's merge commit.
+func provenanceTrailers(appCtx *app.App, targetTask *task.Task) []string {
+	model := config.DefaultModel
+	if opts, err := config.LoadTaskOptions(targetTask.AgentDir); err == nil && opts.Model != "" {
+		model = opts.Model
+	}
+	return service.ProvenanceTrailers(appCtx.Config, targetTask.Name, model)
+}
+
+// recordTaskOutcome records how a task was finished, for paw stats.
+// It must run before cleanup removes the task's options.
+func recordTaskOutcome(appCtx *app.App, targetTask *task.Task, outcome string) {
+	model := config.DefaultModel
+	if opts, err := config.LoadTaskOptions(targetTask.AgentDir); err == nil && opts.Model != "" {
+		model = opts.Model
+	}
+	if err := service.NewHistoryService(appCtx.GetHistoryDir()).RecordOutcome(targetTask.Name, outcome, model); err != nil {
+		logging.Warn("Failed to record task outcome: %v", err)
+	}
+}
+
+// recordMergeConflict records merge conflicts of a task, for paw stats.
+func recordMergeConflict(appCtx *app.App, targetTask *task.Task, files []string, resolved bool) {
+	if err := service.NewHistoryService(appCtx.GetHistoryDir()).RecordMergeConflict(targetTask.Name, files, resolved); err != nil {
+		logging.Warn("Failed to record merge conflict: %v", err)
+	}
+}
+
+// readPaneCapture returns the pre-captured agent pane content, or "" if unavailable.
+func readPaneCapture(path string) string {
+	if path == "" {
+		return ""
+	}
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is a temp file created by end-task-ui
+	if err != nil {
+		logging.Trace("readPaneCapture: %v", err)
+		return ""
+	}
+	return string(data)
+}
+
 // resolveConflictsWithClaude attempts to resolve merge conflicts using Claude.
 // It runs Claude with opus model for better conflict resolution.
 // Returns nil if conflicts were resolved, error otherwise.
@@ -76,7 +76,10 @@ var mergeTaskCmd = &cobra.Command{
 
 		gitClient := git.New()
 		workDir := mgr.GetWorkingDirectory(targetTask)
-		mainBranch := gitClient.GetMainBranch(appCtx.ProjectDir)
+		mainBranch := mgr.TargetBranch(targetTask)
+		if err := mgr.EnsureLocalBranch(mainBranch); err != nil {
+			logging.Warn("Failed to prepare target branch: %v", err)
+		}
 
 		// Check if already merged
 		if gitClient.BranchMerged(appCtx.ProjectDir, targetTask.Name, mainBranch) {
@@ -121,10 +124,11 @@ var mergeTaskCmd = &cobra.Command{
 		if !ok {
 			fmt.Println("  ⚠️  Skipping push: unable to determine branch")
 		} else {
-			pushSpinner := tui.NewSimpleSpinner(fmt.Sprintf("Pushing %s to remote", branchName))
+			pushRemote := appCtx.Config.GetPushRemote()
+			pushSpinner := tui.NewSimpleSpinner(fmt.Sprintf("Pushing %s to %s", branchName, pushRemote))
 			pushSpinner.Start()
 
-			if err := gitClient.Push(workDir, "origin", branchName, true); err != nil {
+			if err := gitClient.Push(workDir, pushRemote, branchName, true); err != nil {
 				pushSpinner.Stop(false, err.Error())
 				logging.Warn("Failed to push task branch: %v", err)
 			} else {
@@ -166,7 +170,14 @@ var mergeTaskCmd = &cobra.Command{
 			return nil
 		}
 		lockSpinner.Stop(true, "")
-		defer func() { _ = os.Remove(lockFile) }()
+		lockHeld := true
+		releaseLock := func() {
+			if lockHeld {
+				_ = os.Remove(lockFile)
+				lockHeld = false
+			}
+		}
+		defer releaseLock()
 
 		// Check for ongoing merge or conflicts
 		hasConflicts, conflictFiles, _ := gitClient.HasConflicts(appCtx.ProjectDir)
@@ -195,21 +206,26 @@ var mergeTaskCmd = &cobra.Command{
 		currentBranch, _ := gitClient.GetCurrentBranch(appCtx.ProjectDir)
 
 		mergeSuccess := true
+		merged := false     // The squash commit was recorded
+		pushedMain := false // mainBranch was pushed, so provenance notes can follow
 
-		// Check if remote origin exists
-		hasRemote := gitClient.HasRemote(appCtx.ProjectDir, "origin")
+		// Check if the fetch and push remotes exist
+		fetchRemote := appCtx.Config.GetFetchRemote()
+		pushRemote := appCtx.Config.GetPushRemote()
+		hasRemote := gitClient.HasRemote(appCtx.ProjectDir, fetchRemote)
+		hasPushRemote := gitClient.HasRemote(appCtx.ProjectDir, pushRemote)
 
-		// Fetch from origin (only if remote exists)
+		// Fetch from the fetch remote (only if remote exists)
 		if hasRemote {
-			fetchSpinner := tui.NewSimpleSpinner("Fetching from origin")
+			fetchSpinner := tui.NewSimpleSpinner("Fetching from " + fetchRemote)
 			fetchSpinner.Start()
-			if err := gitClient.Fetch(appCtx.ProjectDir, "origin"); err != nil {
+			if err := gitClient.Fetch(appCtx.ProjectDir, fetchRemote); err != nil {
 				fetchSpinner.Stop(false, err.Error())
 			} else {
 				fetchSpinner.Stop(true, "")
 			}
 		} else {
-			fmt.Println("  ○ No remote origin (local repo)")
+			fmt.Printf("  ○ No remote %s (local repo)\n", fetchRemote)
 		}
 
 		// Check if main branch exists before checkout
@@ -243,7 +259,7 @@ var mergeTaskCmd = &cobra.Command{
 			mergeSpinner := tui.NewSimpleSpinner("Merging " + targetTask.Name)
 			mergeSpinner.Start()
 			branchCommits, _ := gitClient.GetBranchCommits(appCtx.ProjectDir, targetTask.Name, mainBranch, 20)
-			mergeMsg := git.GenerateMergeCommitMessage(targetTask.Name, branchCommits)
+			mergeMsg := git.AppendTrailers(git.GenerateMergeCommitMessage(targetTask.Name, branchCommits), provenanceTrailers(appCtx, targetTask))
 			mergeConflictOccurred := false
 			if err := gitClient.MergeSquash(appCtx.ProjectDir, targetTask.Name, mergeMsg); err != nil {
 				mergeSpinner.Stop(false, "conflict")
@@ -319,15 +335,18 @@ var mergeTaskCmd = &cobra.Command{
 				if !mergeConflictOccurred {
 					mergeSpinner.Stop(true, "")
 				}
+				recordMergedTask(appCtx, targetTask, gitClient)
+				merged = true
 
-				if hasRemote {
-					pushMainSpinner := tui.NewSimpleSpinner("Pushing " + mainBranch)
+				if hasPushRemote {
+					pushMainSpinner := tui.NewSimpleSpinner(fmt.Sprintf("Pushing %s to %s", mainBranch, pushRemote))
 					pushMainSpinner.Start()
-					if err := gitClient.Push(appCtx.ProjectDir, "origin", mainBranch, false); err != nil {
+					if err := gitClient.Push(appCtx.ProjectDir, pushRemote, mainBranch, false); err != nil {
 						pushMainSpinner.Stop(false, err.Error())
 						mergeSuccess = false
 					} else {
 						pushMainSpinner.Stop(true, "")
+						pushedMain = true
 					}
 				}
 			}
@@ -345,6 +364,19 @@ var mergeTaskCmd = &cobra.Command{
 			}
 		}
 
+		// Summarize provenance after releasing the lock so other merges don't wait for Claude
+		releaseLock()
+		if merged {
+			paneContent, captureErr := tm.CapturePane(windowID+".0", constants.PaneCaptureLines)
+			if captureErr != nil {
+				logging.Trace("Failed to capture agent pane: %v", captureErr)
+			}
+			summarizeProvenance(appCtx, targetTask, paneContent, gitClient)
+			if pushedMain {
+				pushProvenanceNotes(appCtx, pushRemote, gitClient)
+			}
+		}
+
 		if mergeSuccess && appCtx.Config != nil && appCtx.Config.PostMergeHook != "" {
 			hookEnv := appCtx.GetEnvVars(targetTask.Name, workDir, windowID)
 			if _, err := service.RunHook(
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -9,10 +10,15 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea/v2"
 
+	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/config"
 	"github.com/dongho-jung/paw/internal/constants"
 	"github.com/dongho-jung/paw/internal/git"
+	"github.com/dongho-jung/paw/internal/keymap"
 	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/service"
 	"github.com/dongho-jung/paw/internal/task"
+	"github.com/dongho-jung/paw/internal/theme"
 	"github.com/dongho-jung/paw/internal/tmux"
 	"github.com/dongho-jung/paw/internal/tui"
 )
@@ -87,21 +93,22 @@ var cmdPaletteTUICmd = &cobra.Command{
 		logging.Debug("-> cmdPaletteTUICmd(session=%s)", sessionName)
 		defer logging.Debug("<- cmdPaletteTUICmd")
 
-		// Define available commands
-		commands := []tui.Command{
-			{
-				Name:        "Show Current Task",
-				Description: "Display current task content in a popup",
-				ID:          "show-current-task",
-			},
-			{
-				Name:        "Restore Panes",
-				Description: "Restore missing panes in current task window",
-				ID:          "restore-panes",
-			},
+		tm := tmux.New(sessionName)
+		windowID, windowName, err := getCurrentWindowInfo(tm)
+		if err != nil {
+			return fmt.Errorf("failed to get window info: %w", err)
+		}
+		ctx := newPaletteContext(appCtx, tm, windowID, windowName)
+
+		history := service.NewPaletteHistory(appCtx.PawDir)
+		recent, err := history.Recent()
+		if err != nil {
+			logging.Warn("cmdPaletteTUICmd: failed to load palette history: %v", err)
 		}
+		km, _ := keymap.Load(appCtx.PawDir)
+		commands := rankPaletteCommands(paletteEntries(appCtx.PawDir, ctx), recent, km)
 
-		logging.Debug("cmdPaletteTUICmd: running command palette")
+		logging.Debug("cmdPaletteTUICmd: running command palette (commands=%d, task=%s)", len(commands), ctx.TaskName)
 		action, selected, err := tui.RunCommandPalette(commands)
 		if err != nil {
 			logging.Debug("cmdPaletteTUICmd: RunCommandPalette failed: %v", err)
@@ -114,23 +121,47 @@ var cmdPaletteTUICmd = &cobra.Command{
 		}
 
 		logging.Debug("cmdPaletteTUICmd: selected command=%s", selected.ID)
-
-		pawBin := getPawBin()
-		switch selected.ID {
-		case "show-current-task":
-			logging.Debug("cmdPaletteTUICmd: executing show-current-task")
-			showTaskCmd := exec.Command(pawBin, "internal", "show-current-task", sessionName) //nolint:gosec // G204: pawBin is from getPawBin()
-			return showTaskCmd.Run()
-		case "restore-panes":
-			logging.Debug("cmdPaletteTUICmd: executing restore-panes")
-			restoreCmd := exec.Command(pawBin, "internal", "restore-panes", sessionName) //nolint:gosec // G204: pawBin is from getPawBin()
-			return restoreCmd.Run()
+		if err := history.Record(selected.ID); err != nil {
+			logging.Warn("cmdPaletteTUICmd: failed to record palette history: %v", err)
 		}
 
-		return nil
+		// The theme picker runs in the palette pane for its live preview
+		if selected.ID == "change-theme" {
+			return changeTheme(appCtx, tm)
+		}
+		return dispatchPaletteCommand(appCtx, tm, windowID, selected.ID)
 	},
 }
 
+// changeTheme runs the theme picker, previewing the highlighted theme on the
+// tmux chrome, and saves the picked theme to the project config.
+func changeTheme(appCtx *app.App, tm tmux.Client) error {
+	current := theme.ConfiguredName(appCtx.PawDir)
+	preview := func(t *theme.Theme) { applyTheme(tm, t) }
+
+	action, name, err := tui.RunThemePicker(theme.List(), current, preview)
+	if err != nil || action != tui.ThemePickerSelect {
+		return err
+	}
+
+	cfg, err := config.Load(appCtx.PawDir)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	cfg.Theme = name
+	if err := cfg.Save(appCtx.PawDir); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	logging.Info("Theme changed: %s -> %s", current, name)
+
+	applyProjectTheme(appCtx.PawDir, tm)
+	// Respawn the main window so the kanban and task input pick up the theme
+	if err := respawnMainWindow(appCtx, tm); err != nil {
+		logging.Debug("changeTheme: failed to respawn main window: %v", err)
+	}
+	return nil
+}
+
 var finishPickerTUICmd = &cobra.Command{
 	Use:    "finish-picker-tui [session] [window-id]",
 	Short:  "Run finish picker TUI (called from popup)",
@@ -157,13 +188,14 @@ var finishPickerTUICmd = &cobra.Command{
 		hasChanges := false
 		hasRemote := false
 		hasMainBranch := true // Assume main branch exists by default
+		pushTarget := ""      // Existing branch/PR the task was checked out from
 		if appCtx.IsGitRepo {
 			tm := tmux.New(sessionName)
 			mgr := task.NewManager(appCtx.AgentsDir, appCtx.ProjectDir, appCtx.PawDir, appCtx.IsGitRepo, appCtx.Config)
 			mgr.SetTmuxClient(tm)
 
 			gitClient := git.New()
-			hasRemote = gitClient.HasRemote(appCtx.ProjectDir, "origin")
+			hasRemote = gitClient.HasRemote(appCtx.ProjectDir, appCtx.Config.GetPushRemote())
 
 			// Try to find task by window ID first
 			targetTask, err := mgr.FindTaskByWindowID(windowID)
@@ -182,12 +214,20 @@ var finishPickerTUICmd = &cobra.Command{
 			}
 
 			if targetTask != nil {
-				mainBranch := gitClient.GetMainBranch(appCtx.ProjectDir)
+				if up := targetTask.Upstream; up.CanPush() {
+					pushTarget = up.Branch
+					if up.PRNumber > 0 {
+						pushTarget = fmt.Sprintf("PR #%d (%s)", up.PRNumber, up.Branch)
+					}
+				}
+
+				mainBranch := mgr.TargetBranch(targetTask)
 				workDir := mgr.GetWorkingDirectory(targetTask)
 				hasChanges = gitClient.HasChanges(workDir)
 
-				// Check if main branch exists
-				hasMainBranch = gitClient.BranchExists(appCtx.ProjectDir, mainBranch)
+				// Check if the target branch exists (a remote-only target is created on merge)
+				hasMainBranch = gitClient.BranchExists(appCtx.ProjectDir, mainBranch) ||
+					gitClient.RefExists(appCtx.ProjectDir, "refs/remotes/"+mgr.FetchRemote()+"/"+mainBranch)
 
 				// Only call GetBranchCommits if main branch exists
 				if hasMainBranch {
@@ -214,7 +254,7 @@ var finishPickerTUICmd = &cobra.Command{
 
 		// Run the finish picker
 		hasWork := hasCommits || hasChanges
-		action, err := tui.RunFinishPicker(appCtx.IsGitRepo, hasWork, hasRemote, hasMainBranch)
+		action, err := tui.RunFinishPicker(appCtx.IsGitRepo, hasWork, hasRemote, hasMainBranch, pushTarget)
 		if err != nil {
 			logging.Debug("finishPickerTUICmd: RunFinishPicker failed: %v", err)
 			return err
@@ -247,6 +287,8 @@ var finishPickerTUICmd = &cobra.Command{
 			endAction = constants.ActionDrop
 		case tui.FinishActionCreateMain:
 			endAction = constants.ActionCreateMain
+		case tui.FinishActionPushUpstream:
+			endAction = constants.ActionPushUpstream
 		default:
 			logging.Debug("finishPickerTUICmd: unknown action=%s", action)
 			return nil
@@ -10,9 +10,9 @@ import (
 
 	"github.com/dongho-jung/paw/internal/constants"
 	"github.com/dongho-jung/paw/internal/embed"
-	"github.com/dongho-jung/paw/internal/git"
 	"github.com/dongho-jung/paw/internal/logging"
 	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/task"
 	"github.com/dongho-jung/paw/internal/tmux"
 	"github.com/dongho-jung/paw/internal/tui"
 )
@@ -32,14 +32,13 @@ var (
 )
 
 // topPaneSizes maps pane types to their specific sizes.
-// Content-fitting panes (finish, palette) use absolute line counts to ensure
+// Content-fitting panes (finish) use absolute line counts to ensure
 // all content is visible without clipping.
-// Scrollable panes (log, git, help, etc.) use percentage for flexibility.
+// Scrollable panes (log, git, help, palette, etc.) use percentage for flexibility.
 // Note: project picker now uses popup instead of top pane for faster rendering.
 var topPaneSizes = map[string]string{
-	"finish":  "13", // 13 lines: title(1) + blank(1) + 4 options(8) + margin(1) + help(1) + buffer(1)
-	"palette": "13", // 13 lines: title(1) + blank(1) + input(3) + blank(1) + 2 commands(4) + margin(1) + help(1) + buffer(1)
-	// Scrollable/AltScreen panes (log, help, git, diff, history, template, prompt) use constants.TopPaneSize
+	"finish": "13", // 13 lines: title(1) + blank(1) + 4 options(8) + margin(1) + help(1) + buffer(1)
+	// Scrollable/AltScreen panes (log, help, git, diff, history, template, prompt, palette) use constants.TopPaneSize
 }
 
 // topPaneShortcuts maps pane types to their toggle shortcuts for user feedback
@@ -318,6 +317,9 @@ var taskViewerCmd = &cobra.Command{
 	},
 }
 
+// git-viewer flag locating the project's commit-message prompt
+var gitViewerPawDir string
+
 var gitViewerCmd = &cobra.Command{
 	Use:    "git-viewer [work-dir] [main-branch]",
 	Short:  "Run the git viewer",
@@ -326,7 +328,7 @@ var gitViewerCmd = &cobra.Command{
 	RunE: func(_ *cobra.Command, args []string) error {
 		workDir := args[0]
 		mainBranch := args[1]
-		return tui.RunGitViewer(workDir, mainBranch)
+		return tui.RunGitViewer(workDir, mainBranch, gitViewerPawDir)
 	},
 }
 
@@ -354,12 +356,28 @@ func runGitViewerTopPane(sessionName, viewerName, internalCmd string) error {
 	}
 	panePath = strings.TrimSpace(panePath)
 
-	// Get the main branch name dynamically
-	gitClient := git.New()
-	mainBranch := gitClient.GetMainBranch(panePath)
+	// Diff against the current task's target branch (project default outside task windows)
+	mgr := task.NewManager(appCtx.AgentsDir, appCtx.ProjectDir, appCtx.PawDir, appCtx.IsGitRepo, appCtx.Config)
+	mainBranch := mgr.DefaultTargetBranch()
+	var reviewArgs []string
+	if windowID, err := tm.Display("#{window_id}"); err == nil {
+		windowID = strings.TrimSpace(windowID)
+		if targetTask, err := mgr.FindTaskByWindowID(windowID); err == nil {
+			mainBranch = mgr.TargetBranch(targetTask)
+			// The diff viewer sends review comments to the task's agent
+			reviewArgs = []string{"--session", sessionName, "--window", windowID}
+		}
+	}
 
 	// Run viewer in top pane
-	viewerCmd := shellJoin(getPawBin(), "internal", internalCmd, panePath, mainBranch)
+	args := []string{getPawBin(), "internal", internalCmd, panePath, mainBranch}
+	switch internalCmd {
+	case "diff-viewer":
+		args = append(args, reviewArgs...)
+	case "git-viewer":
+		args = append(args, "--paw-dir", appCtx.PawDir)
+	}
+	viewerCmd := shellJoin(args...)
 
 	result, err := displayTopPane(tm, viewerName, viewerCmd, panePath)
 	if err != nil {
@@ -394,6 +412,12 @@ var toggleShowDiffCmd = &cobra.Command{
 	},
 }
 
+// diff-viewer flags identifying the task that receives review comments
+var (
+	diffViewerSession string
+	diffViewerWindow  string
+)
+
 var diffViewerCmd = &cobra.Command{
 	Use:    "diff-viewer [work-dir] [main-branch]",
 	Short:  "Run the diff viewer",
@@ -402,10 +426,36 @@ var diffViewerCmd = &cobra.Command{
 	RunE: func(_ *cobra.Command, args []string) error {
 		workDir := args[0]
 		mainBranch := args[1]
-		return tui.RunDiffViewer(workDir, mainBranch)
+		return tui.RunDiffViewer(workDir, mainBranch, resolveDiffReviewTarget(diffViewerSession, diffViewerWindow))
 	},
 }
 
+// resolveDiffReviewTarget returns the task of a window for diff viewer
+// reviews, or nil if the diff is not for a task.
+func resolveDiffReviewTarget(sessionName, windowID string) *tui.DiffReviewTarget {
+	if sessionName == "" || windowID == "" {
+		return nil
+	}
+	appCtx, err := getAppFromSession(sessionName)
+	if err != nil {
+		logging.Debug("diff-viewer: no app for session %s: %v", sessionName, err)
+		return nil
+	}
+	mgr := task.NewManager(appCtx.AgentsDir, appCtx.ProjectDir, appCtx.PawDir, appCtx.IsGitRepo, appCtx.Config)
+	targetTask, err := mgr.FindTaskByWindowID(windowID)
+	if err != nil {
+		logging.Debug("diff-viewer: no task for window %s: %v", windowID, err)
+		return nil
+	}
+	return &tui.DiffReviewTarget{
+		TaskName:   targetTask.Name,
+		AgentDir:   targetTask.AgentDir,
+		HistoryDir: appCtx.GetHistoryDir(),
+		Session:    sessionName,
+		WindowID:   windowID,
+	}
+}
+
 var toggleHistoryCmd = &cobra.Command{
 	Use:   "toggle-history [session]",
 	Short: "Toggle history picker top pane",
@@ -645,22 +695,10 @@ var projectPickerCmd = &cobra.Command{
 	RunE: func(_ *cobra.Command, args []string) error {
 		currentSession := args[0]
 
-		// Find all PAW sessions
-		sessions := findPawSessions()
-
-		// Filter out current session
-		var projects []tui.ProjectPickerItem
-		for _, s := range sessions {
-			if s.Name != currentSession {
-				projects = append(projects, tui.ProjectPickerItem{
-					Name:       s.Name,
-					SocketPath: s.SocketPath,
-				})
-			}
-		}
-
+		// Registered projects and running sessions, except the current one
+		projects := projectPickerItems(currentSession)
 		if len(projects) == 0 {
-			fmt.Println("No other PAW projects running.")
+			fmt.Println("No other PAW projects.")
 			return nil
 		}
 
@@ -714,22 +752,10 @@ var projectPickerWrapperCmd = &cobra.Command{
 		logging.Debug("-> projectPickerWrapperCmd(session=%s)", currentSession)
 		defer logging.Debug("<- projectPickerWrapperCmd")
 
-		// Find all PAW sessions
-		sessions := findPawSessions()
-
-		// Filter out current session
-		var projects []tui.ProjectPickerItem
-		for _, s := range sessions {
-			if s.Name != currentSession {
-				projects = append(projects, tui.ProjectPickerItem{
-					Name:       s.Name,
-					SocketPath: s.SocketPath,
-				})
-			}
-		}
-
+		// Registered projects and running sessions, except the current one
+		projects := projectPickerItems(currentSession)
 		if len(projects) == 0 {
-			fmt.Println("No other PAW projects running.")
+			fmt.Println("No other PAW projects.")
 			return nil
 		}
 
@@ -742,6 +768,19 @@ var projectPickerWrapperCmd = &cobra.Command{
 
 		// If user selected a project, perform the switch
 		if action == tui.ProjectPickerSelect && selected != nil {
+			if !selected.Running {
+				logging.Debug("projectPickerWrapperCmd: starting project %s (%s)", selected.Name, selected.Dir)
+				fmt.Printf("Starting %s...\n", selected.Name)
+				if err := startProjectSession(*selected); err != nil {
+					logging.Warn("projectPickerWrapperCmd: failed to start %s: %v", selected.Name, err)
+					fmt.Printf("Failed to start %s: %v\n", selected.Name, err)
+					waitForEnter()
+					return nil
+				}
+			} else if selected.Dir != "" {
+				touchProject(selected.Dir)
+			}
+
 			logging.Debug("projectPickerWrapperCmd: switching to session %s", selected.Name)
 
 			tm := tmux.New(currentSession)
@@ -6,8 +6,8 @@ import (
 
 	"github.com/spf13/cobra"
 
-	"github.com/dongho-jung/paw/internal/github"
 	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/task"
 	"github.com/dongho-jung/paw/internal/tui"
 )
 
@@ -47,7 +47,8 @@ var prPopupTUICmd = &cobra.Command{
 			return nil
 		}
 
-		ghClient := github.New()
+		mgr := task.NewManager(appCtx.AgentsDir, appCtx.ProjectDir, appCtx.PawDir, appCtx.IsGitRepo, appCtx.Config)
+		ghClient := mgr.GitHub()
 		if !ghClient.IsInstalled() {
 			logging.Warn("gh CLI not installed; cannot open PR")
 			return nil
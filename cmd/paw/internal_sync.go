@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os/exec"
 	"strings"
@@ -19,6 +20,8 @@ var syncWithMainCmd = &cobra.Command{
 	Use:   "sync-with-main [session] [window-id]",
 	Short: "Sync task branch with main (fetch and rebase)",
 	Args:  cobra.ExactArgs(2),
+	// Failures are shown as they happen; main prints the others once
+	SilenceErrors: true,
 	RunE: func(_ *cobra.Command, args []string) error {
 		logging.Debug("-> syncWithMainCmd(session=%s, windowID=%s)", args[0], args[1])
 		defer logging.Debug("<- syncWithMainCmd")
@@ -34,7 +37,7 @@ var syncWithMainCmd = &cobra.Command{
 		// This command only works in git mode
 		if !app.IsGitRepo {
 			fmt.Println("\n  ✗ Not a git repository - sync not available")
-			return nil
+			return reported(exitUnavailable, errors.New("not a git repository"))
 		}
 
 		// Find task by window ID
@@ -61,7 +64,7 @@ var syncWithMainCmd = &cobra.Command{
 		if gitClient.HasChanges(workDir) {
 			fmt.Println("  ⚠️  You have uncommitted changes")
 			fmt.Println("  Please commit or stash them before syncing")
-			return nil
+			return reported(exitSyncBlocked, errors.New("uncommitted changes"))
 		}
 
 		// Check for ongoing rebase or merge
@@ -70,7 +73,7 @@ var syncWithMainCmd = &cobra.Command{
 			fmt.Println("  Please complete or abort it first:")
 			fmt.Println("    git rebase --continue  # to continue")
 			fmt.Println("    git rebase --abort     # to abort")
-			return nil
+			return reported(exitSyncBlocked, errors.New("rebase in progress"))
 		}
 
 		if gitClient.HasOngoingMerge(workDir) {
@@ -78,12 +81,12 @@ var syncWithMainCmd = &cobra.Command{
 			fmt.Println("  Please complete or abort it first:")
 			fmt.Println("    git merge --continue  # to continue")
 			fmt.Println("    git merge --abort     # to abort")
-			return nil
+			return reported(exitSyncBlocked, errors.New("merge in progress"))
 		}
 
-		// Get main branch name
-		mainBranch := gitClient.GetMainBranch(app.ProjectDir)
-		logging.Debug("Main branch: %s", mainBranch)
+		// Get the branch to sync with
+		mainBranch := mgr.TargetBranch(targetTask)
+		logging.Debug("Target branch: %s", mainBranch)
 
 		// Get current branch
 		currentBranch, err := gitClient.GetCurrentBranch(workDir)
@@ -92,21 +95,22 @@ var syncWithMainCmd = &cobra.Command{
 		}
 		logging.Debug("Current branch: %s", currentBranch)
 
-		// Fetch from origin
-		fetchSpinner := tui.NewSimpleSpinner("Fetching from origin")
+		// Fetch from the fetch remote
+		fetchRemote := app.Config.GetFetchRemote()
+		fetchSpinner := tui.NewSimpleSpinner("Fetching from " + fetchRemote)
 		fetchSpinner.Start()
 
 		fetchTimer := logging.StartTimer("git fetch")
-		if err := gitClient.Fetch(workDir, "origin"); err != nil {
+		if err := gitClient.Fetch(workDir, fetchRemote); err != nil {
 			fetchTimer.StopWithResult(false, err.Error())
 			fetchSpinner.Stop(false, err.Error())
-			return fmt.Errorf("failed to fetch from origin: %w", err)
+			return fmt.Errorf("failed to fetch from %s: %w", fetchRemote, err)
 		}
 		fetchTimer.StopWithResult(true, "")
 		fetchSpinner.Stop(true, "")
 
 		// Check if there are new commits on main
-		remoteMain := "origin/" + mainBranch
+		remoteMain := fetchRemote + "/" + mainBranch
 		behindCount, err := getBehindCount(workDir, currentBranch, remoteMain)
 		if err != nil {
 			logging.Warn("Failed to check commit count: %v", err)
@@ -121,8 +125,8 @@ var syncWithMainCmd = &cobra.Command{
 
 		fmt.Printf("  ℹ️  %s new commit(s) on %s\n\n", behindCount, mainBranch)
 
-		// Rebase onto origin/main
-		rebaseSpinner := tui.NewSimpleSpinner(fmt.Sprintf("Rebasing %s onto origin/%s", currentBranch, mainBranch))
+		// Rebase onto <fetch remote>/main
+		rebaseSpinner := tui.NewSimpleSpinner(fmt.Sprintf("Rebasing %s onto %s", currentBranch, remoteMain))
 		rebaseSpinner.Start()
 
 		rebaseTimer := logging.StartTimer("git rebase")
@@ -143,9 +147,9 @@ var syncWithMainCmd = &cobra.Command{
 			fmt.Println("  Or abort the rebase:")
 			fmt.Println("    git rebase --abort")
 			fmt.Println()
-			return nil
+			return reported(exitSyncConflict, errors.New("rebase conflict"))
 		}
-		rebaseTimer.StopWithResult(true, "rebased onto origin/"+mainBranch)
+		rebaseTimer.StopWithResult(true, "rebased onto "+remoteMain)
 		rebaseSpinner.Stop(true, "")
 
 		logging.Log("Successfully synced %s with %s", targetTask.Name, mainBranch)
@@ -181,11 +185,8 @@ var syncWithMainUICmd = &cobra.Command{
 		// Get the paw binary path
 		pawBin := getPawBin()
 
-		// Get working directory from pane
-		panePath, err := tm.Display("#{pane_current_path}")
-		if err != nil || panePath == "" {
-			panePath = app.ProjectDir
-		}
+		// Get working directory from the task window's pane
+		panePath := windowPanePath(tm, windowID, app.ProjectDir)
 
 		// Build sync-with-main command that runs in the pane
 		syncCmdStr := shellJoin(pawBin, "internal", "sync-with-main", sessionName, windowID)
@@ -193,6 +194,7 @@ var syncWithMainUICmd = &cobra.Command{
 
 		// Create a top pane spanning full window width
 		_, err = tm.SplitWindowPane(tmux.SplitOpts{
+			Target:     windowID,
 			Horizontal: false, // vertical split (top/bottom)
 			Size:       constants.TopPaneSize,
 			StartDir:   panePath,
@@ -208,7 +210,7 @@ var syncWithMainUICmd = &cobra.Command{
 	},
 }
 
-// getBehindCount returns how many commits the current branch is behind origin/main
+// getBehindCount returns how many commits the current branch is behind the remote main
 func getBehindCount(workDir, currentBranch, remoteMain string) (string, error) {
 	// Security: Validate ref names to prevent command injection
 	if !git.IsValidGitRef(currentBranch) {
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/task"
+	"github.com/dongho-jung/paw/internal/tmux"
+	"github.com/dongho-jung/paw/internal/tui"
+)
+
+var taskActionFrom string
+
+var taskActionCmd = &cobra.Command{
+	Use:    "task-action [action] [session] [window-id|backlog-id]",
+	Short:  "Run a kanban card action (finish, cancel, sync, diff, start, discard) on a task",
+	Args:   cobra.ExactArgs(3),
+	Hidden: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		action := args[0]
+		sessionName := args[1]
+		windowID := args[2]
+
+		appCtx, err := getAppFromSession(sessionName)
+		if err != nil {
+			return err
+		}
+
+		// Setup logging
+		_, cleanup := setupLoggerFromApp(appCtx, "task-action", "")
+		defer cleanup()
+
+		logging.Debug("-> taskActionCmd(action=%s, session=%s, windowID=%s, from=%s)", action, sessionName, windowID, taskActionFrom)
+		defer logging.Debug("<- taskActionCmd")
+
+		// Popups are shown on the session the board runs in, which may differ
+		// from the task's session when acting on another project's task.
+		fromSession := taskActionFrom
+		if fromSession == "" {
+			fromSession = sessionName
+		}
+		popupTm := tmux.New(fromSession)
+		popupEnv := map[string]string{
+			"PAW_DIR":     appCtx.PawDir,
+			"PROJECT_DIR": appCtx.ProjectDir,
+		}
+		pawBin := getPawBin()
+
+		switch tui.KanbanAction(action) {
+		case tui.KanbanActionFinish:
+			finishCmd := shellJoin(pawBin, "internal", "finish-picker-tui", sessionName, windowID)
+			if err := popupTm.DisplayPopup(tmux.PopupOpts{
+				Width:     constants.PopupWidthFinish,
+				Height:    constants.PopupHeightFinish,
+				Title:     " Finish Task ",
+				Close:     true,
+				Style:     "fg=terminal,bg=terminal",
+				Directory: appCtx.ProjectDir,
+				Env:       popupEnv,
+			}, finishCmd); err != nil {
+				logging.Debug("taskActionCmd: displayPopup returned: %v", err)
+			}
+			return nil
+
+		case tui.KanbanActionCancel:
+			return runTaskActionCmd(pawBin, "cancel-task-ui", sessionName, windowID)
+
+		case tui.KanbanActionSync:
+			if !appCtx.IsGitRepo {
+				return errors.New("not a git repository")
+			}
+			return runTaskActionCmd(pawBin, "sync-with-main-ui", sessionName, windowID)
+
+		case tui.KanbanActionDiff:
+			if !appCtx.IsGitRepo {
+				return errors.New("not a git repository")
+			}
+			mgr := task.NewManager(appCtx.AgentsDir, appCtx.ProjectDir, appCtx.PawDir, appCtx.IsGitRepo, appCtx.Config)
+			targetTask, err := mgr.FindTaskByWindowID(windowID)
+			if err != nil {
+				return fmt.Errorf("task not found for window %s: %w", windowID, err)
+			}
+			workDir := mgr.GetWorkingDirectory(targetTask)
+			diffCmd := shellJoin(pawBin, "internal", "diff-viewer", workDir, mgr.TargetBranch(targetTask),
+				"--session", sessionName, "--window", windowID)
+			if err := popupTm.DisplayPopup(tmux.PopupOpts{
+				Width:     constants.PopupWidthDiff,
+				Height:    constants.PopupHeightDiff,
+				Title:     " Diff: " + targetTask.Name + " ",
+				Close:     true,
+				Style:     "fg=terminal,bg=terminal",
+				Directory: workDir,
+				Env:       popupEnv,
+			}, diffCmd); err != nil {
+				logging.Debug("taskActionCmd: displayPopup returned: %v", err)
+			}
+			return nil
+
+		case tui.KanbanActionStart:
+			// windowID is the backlog entry ID for drafted tasks
+			backlog := service.NewBacklog(appCtx.PawDir)
+			entry, err := backlog.Get(windowID)
+			if err != nil {
+				return err
+			}
+			if err := startSpawnTask(sessionName, entry.Content, entry.Options); err != nil {
+				return fmt.Errorf("failed to start task: %w", err)
+			}
+			if err := backlog.Remove(entry.ID); err != nil {
+				logging.Warn("Failed to remove started backlog entry: %v", err)
+			}
+			return nil
+
+		case tui.KanbanActionDiscard:
+			return service.NewBacklog(appCtx.PawDir).Remove(windowID)
+
+		case tui.KanbanActionJump, tui.KanbanActionReply:
+			// Handled directly by the kanban board
+			return fmt.Errorf("action %s is not run through task-action", action)
+		}
+
+		return fmt.Errorf("unknown task action: %s", action)
+	},
+}
+
+// runTaskActionCmd runs a task UI command (e.g. cancel-task-ui) for a window.
+func runTaskActionCmd(pawBin, name, sessionName, windowID string) error {
+	cmd := exec.Command(pawBin, "internal", name, sessionName, windowID) //nolint:gosec // G204: pawBin is from getPawBin()
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s failed: %w: %s", name, err, output)
+	}
+	return nil
+}
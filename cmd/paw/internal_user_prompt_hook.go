@@ -58,17 +58,20 @@ var userPromptSubmitHookCmd = &cobra.Command{
 	Use:   "user-prompt-submit-hook",
 	Short: "Handle Claude UserPromptSubmit hook to set working status",
 	RunE: func(_ *cobra.Command, _ []string) error {
+		clearInboxQuestions()
 		return updateWindowStatus("userPromptSubmitHookCmd", constants.EmojiWorking)
 	},
 }
 
 // askUserQuestionPreHookCmd handles the PreToolUse hook for AskUserQuestion.
 // This is triggered when Claude calls AskUserQuestion (before showing UI to user).
-// Sets status to WAITING immediately when Claude asks a question.
+// Sets status to WAITING immediately when Claude asks a question, and adds the
+// questions of the hook payload to the inbox.
 var askUserQuestionPreHookCmd = &cobra.Command{
 	Use:   "ask-user-question-pre-hook",
 	Short: "Handle Claude PreToolUse hook for AskUserQuestion to set waiting status",
 	RunE: func(_ *cobra.Command, _ []string) error {
+		recordInboxQuestions(hookStdin())
 		return updateWindowStatus("askUserQuestionPreHookCmd", constants.EmojiWaiting)
 	},
 }
@@ -80,6 +83,7 @@ var askUserQuestionHookCmd = &cobra.Command{
 	Use:   "ask-user-question-hook",
 	Short: "Handle Claude PostToolUse hook for AskUserQuestion to set working status",
 	RunE: func(_ *cobra.Command, _ []string) error {
+		clearInboxQuestions()
 		return updateWindowStatus("askUserQuestionHookCmd", constants.EmojiWorking)
 	},
 }
@@ -138,6 +138,17 @@ func readSessionEnv(tm tmux.Client, sessionName string) map[string]string {
 	return env
 }
 
+// windowPanePath returns the working directory of the active pane in a window.
+// Falls back to fallback when the window cannot be queried.
+func windowPanePath(tm tmux.Client, windowID, fallback string) string {
+	panePath, err := tm.RunWithOutput("display-message", "-p", "-t", windowID, "#{pane_current_path}")
+	panePath = strings.TrimSpace(panePath)
+	if err != nil || panePath == "" {
+		return fallback
+	}
+	return panePath
+}
+
 func loadSessionContext(sessionName string) sessionContext {
 	if sessionName == "" {
 		return sessionContext{}
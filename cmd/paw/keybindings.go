@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/dongho-jung/paw/internal/keymap"
+	"github.com/dongho-jung/paw/internal/logging"
 	"github.com/dongho-jung/paw/internal/tmux"
 )
 
@@ -14,6 +16,7 @@ type KeybindingsContext struct {
 	PawDir      string
 	ProjectDir  string
 	DisplayName string
+	Keymap      *keymap.Keymap // Effective keybindings (nil: defaults)
 }
 
 // shellPassthrough wraps a command to pass through the key in shell pane.
@@ -28,7 +31,7 @@ func shellPassthrough(key, pawCmd string) string {
 }
 
 // buildKeybindings creates tmux keybindings for PAW.
-// Keyboard shortcuts:
+// Default keyboard shortcuts (remappable with the keybindings config block):
 //   - Ctrl+N: New task
 //   - Ctrl+F: Toggle file picker pane focus (main window) / Finish task (task windows)
 //   - Ctrl+P: Command palette
@@ -48,8 +51,13 @@ func shellPassthrough(key, pawCmd string) string {
 //   - Alt+Shift+Tab: Cycle pane backward (in task windows) / Cycle options backward (in new task window)
 //
 // Note: Most Ctrl keybindings pass through to shell when in the shell pane (Ctrl+B popup),
-// except Ctrl+B (toggle shell), Ctrl+Q (quit), and Ctrl+F (finish task).
+// except toggle-shell (Ctrl+B), quit (Ctrl+Q), and finish-task (Ctrl+F).
 func buildKeybindings(ctx KeybindingsContext) []tmux.BindOpts {
+	km := ctx.Keymap
+	if km == nil {
+		km = keymap.Default()
+	}
+
 	buildPawRunShell := func(args ...string) string {
 		envParts := []string{
 			shellEnv("PAW_DIR", ctx.PawDir),
@@ -65,10 +73,10 @@ func buildKeybindings(ctx KeybindingsContext) []tmux.BindOpts {
 	cmdNewTask := buildPawRunShell("toggle-new", ctx.SessionName)
 	cmdDoneTask := buildPawRunShell("done-task", ctx.SessionName)
 
-	// Ctrl+F: In main window (⭐️main), toggle between file picker pane (0) and main pane (1)
+	// finish-task: In main window (⭐️main), toggle between file picker pane (0) and main pane (1)
 	// In other windows, run done-task
 	// #{==:#{pane_index},0} checks if current pane is file picker
-	cmdCtrlF := fmt.Sprintf(`if -F "#{==:#{window_name},⭐️main}" "if -F \"#{==:#{pane_index},0}\" \"select-pane -t :.1\" \"select-pane -t :.0\"" "%s"`, cmdDoneTask)
+	cmdFinish := fmt.Sprintf(`if -F "#{==:#{window_name},⭐️main}" "if -F \"#{==:#{pane_index},0}\" \"select-pane -t :.1\" \"select-pane -t :.0\"" "%s"`, cmdDoneTask)
 	cmdPrevWindow := buildPawRunShell("select-prev-window", ctx.SessionName)
 	cmdNextWindow := buildPawRunShell("select-next-window", ctx.SessionName)
 	// Disable mouse mode before detaching to prevent escape sequences from leaking to shell.
@@ -84,58 +92,121 @@ func buildKeybindings(ctx KeybindingsContext) []tmux.BindOpts {
 	cmdToggleBottom := buildPawRunShell("popup-shell", ctx.SessionName)
 	cmdToggleHelp := buildPawRunShell("toggle-help", ctx.SessionName)
 	cmdToggleCmdPalette := buildPawRunShell("toggle-cmd-palette", ctx.SessionName)
+	cmdToggleInbox := buildPawRunShell("toggle-inbox", ctx.SessionName)
+	cmdToggleOverview := buildPawRunShell("toggle-overview", ctx.SessionName)
 	cmdToggleProjectPicker := buildPawRunShell("toggle-project-picker", ctx.SessionName)
 	cmdTogglePromptPicker := buildPawRunShell("toggle-prompt-picker", ctx.SessionName)
 	cmdNewShellWindow := buildPawRunShell("new-shell-window", ctx.SessionName)
+	cmdToggleHistory := buildPawRunShell("toggle-history", ctx.SessionName)
+	cmdToggleTemplate := buildPawRunShell("toggle-template", ctx.SessionName)
 
-	// Alt+Tab: context-aware - pass through to TUI in new task window, cycle panes otherwise
+	// cycle-pane: context-aware - pass through to TUI in new task window, cycle panes otherwise
 	// #{m:pattern,string} checks if string matches pattern (⭐️* = starts with ⭐️)
 	// Use "Escape Tab" instead of "M-Tab" because send-keys M-Tab may not produce
 	// the correct escape sequence (\x1b\x09) that bubbletea expects for "alt+tab"
 	// -F flag is required so tmux evaluates the format as a boolean, not as a shell command
-	cmdAltTab := `if -F "#{m:⭐️*,#{window_name}}" "send-keys Escape Tab" "select-pane -t :.+"`
-	cmdAltShiftTab := `if -F "#{m:⭐️*,#{window_name}}" "send-keys Escape BTab" "select-pane -t :.-"`
-
-	// Ctrl+R: context-aware with shell passthrough
-	// Priority: shell pane > new task window > pass through
-	cmdCtrlRBase := fmt.Sprintf(`if -F "#{m:⭐️*,#{window_name}}" "%s" "send-keys C-r"`, buildPawRunShell("toggle-history", ctx.SessionName))
-	cmdCtrlR := shellPassthrough("C-r", cmdCtrlRBase)
-
-	// Ctrl+T: context-aware with shell passthrough
-	cmdCtrlTBase := fmt.Sprintf(`if -F "#{m:⭐️*,#{window_name}}" "%s" "send-keys C-t"`, buildPawRunShell("toggle-template", ctx.SessionName))
-	cmdCtrlT := shellPassthrough("C-t", cmdCtrlTBase)
+	cmdCyclePane := `if -F "#{m:⭐️*,#{window_name}}" "send-keys Escape Tab" "select-pane -t :.+"`
+	cmdCyclePaneBack := `if -F "#{m:⭐️*,#{window_name}}" "send-keys Escape BTab" "select-pane -t :.-"`
 
 	// Window reorder commands (swap current window with adjacent, then follow focus)
 	// Use run-shell to chain commands since \; doesn't work reliably in bind
 	cmdSwapWindowLeft := `run-shell 'tmux swap-window -t -1 && tmux select-window -t -1'`
 	cmdSwapWindowRight := `run-shell 'tmux swap-window -t +1 && tmux select-window -t +1'`
 
-	return []tmux.BindOpts{
+	// always runs a command regardless of the pane; passthrough sends the key
+	// to the shell pane instead of running the command there.
+	always := func(command string) func(string) string {
+		return func(string) string { return command }
+	}
+	passthrough := func(command string) func(string) string {
+		return func(key string) string { return shellPassthrough(key, command) }
+	}
+	// newTaskWindowOnly runs the command in the new task window and passes the
+	// key through elsewhere (history search and template picker).
+	// Priority: shell pane > new task window > pass through
+	newTaskWindowOnly := func(command string) func(string) string {
+		return func(key string) string {
+			base := fmt.Sprintf(`if -F "#{m:⭐️*,#{window_name}}" "%s" "send-keys %s"`, command, key)
+			return shellPassthrough(key, base)
+		}
+	}
+
+	actions := []struct {
+		action  keymap.Action
+		command func(key string) string
+	}{
 		// Navigation (Alt-based)
-		{Key: "M-Tab", Command: cmdAltTab, NoPrefix: true},
-		{Key: "M-BTab", Command: cmdAltShiftTab, NoPrefix: true},
-		{Key: "M-Left", Command: cmdPrevWindow, NoPrefix: true},
-		{Key: "M-Right", Command: cmdNextWindow, NoPrefix: true},
-		{Key: "M-Up", Command: cmdSwapWindowLeft, NoPrefix: true},
-		{Key: "M-Down", Command: cmdSwapWindowRight, NoPrefix: true},
+		{keymap.ActionCyclePane, always(cmdCyclePane)},
+		{keymap.ActionCyclePaneBack, always(cmdCyclePaneBack)},
+		{keymap.ActionPrevWindow, always(cmdPrevWindow)},
+		{keymap.ActionNextWindow, always(cmdNextWindow)},
+		{keymap.ActionSwapWindowLeft, always(cmdSwapWindowLeft)},
+		{keymap.ActionSwapWindowRight, always(cmdSwapWindowRight)},
 
 		// Task commands (Ctrl-based)
-		// These pass through to shell in shell pane, except Ctrl+F and Ctrl+Q
-		{Key: "C-n", Command: shellPassthrough("C-n", cmdNewTask), NoPrefix: true},
-		{Key: "C-f", Command: cmdCtrlF, NoPrefix: true}, // Main window: toggle file picker, others: finish task
-		{Key: "C-p", Command: shellPassthrough("C-p", cmdToggleCmdPalette), NoPrefix: true},
-		{Key: "C-q", Command: cmdQuit, NoPrefix: true}, // Always works (quit)
+		// These pass through to shell in shell pane, except finish-task and quit
+		{keymap.ActionNewTask, passthrough(cmdNewTask)},
+		{keymap.ActionFinishTask, always(cmdFinish)}, // Main window: toggle file picker, others: finish task
+		{keymap.ActionCommandPalette, passthrough(cmdToggleCmdPalette)},
+		{keymap.ActionInbox, passthrough(cmdToggleInbox)},
+		{keymap.ActionQuit, always(cmdQuit)}, // Always works (quit)
 
 		// Toggle commands (Ctrl-based)
-		// These pass through to shell in shell pane, except Ctrl+B
-		{Key: "C-o", Command: shellPassthrough("C-o", cmdToggleLogs), NoPrefix: true},
-		{Key: "C-g", Command: shellPassthrough("C-g", cmdToggleGitStatus), NoPrefix: true},
-		{Key: "C-b", Command: cmdToggleBottom, NoPrefix: true},                                 // Always works (toggle shell)
-		{Key: "C-_", Command: shellPassthrough("C-_", cmdToggleHelp), NoPrefix: true},          // Ctrl+/ sends C-_
-		{Key: "C-r", Command: cmdCtrlR, NoPrefix: true},                                        // History search in new task window
-		{Key: "C-t", Command: cmdCtrlT, NoPrefix: true},                                        // Template picker in new task window
-		{Key: "C-j", Command: shellPassthrough("C-j", cmdToggleProjectPicker), NoPrefix: true}, // Project picker
-		{Key: "C-y", Command: shellPassthrough("C-y", cmdTogglePromptPicker), NoPrefix: true},  // Prompt editor
-		{Key: "C-k", Command: shellPassthrough("C-k", cmdNewShellWindow), NoPrefix: true},      // New shell window
+		// These pass through to shell in shell pane, except toggle-shell
+		{keymap.ActionToggleLogs, passthrough(cmdToggleLogs)},
+		{keymap.ActionToggleGit, passthrough(cmdToggleGitStatus)},
+		{keymap.ActionToggleShell, always(cmdToggleBottom)}, // Always works (toggle shell)
+		{keymap.ActionToggleHelp, passthrough(cmdToggleHelp)},
+		{keymap.ActionHistorySearch, newTaskWindowOnly(cmdToggleHistory)},
+		{keymap.ActionTemplatePicker, newTaskWindowOnly(cmdToggleTemplate)},
+		{keymap.ActionProjectPicker, passthrough(cmdToggleProjectPicker)},
+		{keymap.ActionPromptPicker, passthrough(cmdTogglePromptPicker)},
+		{keymap.ActionOverview, passthrough(cmdToggleOverview)},
+		{keymap.ActionNewShellWindow, passthrough(cmdNewShellWindow)},
+	}
+
+	var bindings []tmux.BindOpts
+	for _, a := range actions {
+		for _, key := range km.Keys(a.action) {
+			tmuxKey := keymap.TmuxKey(key)
+			bindings = append(bindings, tmux.BindOpts{Key: tmuxKey, Command: a.command(tmuxKey), NoPrefix: true})
+		}
+	}
+	return bindings
+}
+
+// releasedKeybindings returns the tmux keys of default global bindings that are
+// no longer bound to any global action, so a config reload can unbind them.
+func releasedKeybindings(km *keymap.Keymap) []string {
+	bound := make(map[string]bool)
+	for _, def := range keymap.Definitions() {
+		if def.Scope != keymap.ScopeGlobal {
+			continue
+		}
+		for _, key := range km.Keys(def.Action) {
+			bound[key] = true
+		}
+	}
+
+	var released []string
+	for _, def := range keymap.Definitions() {
+		if def.Scope != keymap.ScopeGlobal {
+			continue
+		}
+		for _, key := range def.Keys {
+			if !bound[key] {
+				released = append(released, keymap.TmuxKey(key))
+			}
+		}
+	}
+	return released
+}
+
+// loadKeymap returns the effective keymap of a project, logging invalid bindings.
+func loadKeymap(pawDir string) *keymap.Keymap {
+	km, errs := keymap.Load(pawDir)
+	for _, err := range errs {
+		logging.Warn("keybindings: %v", err)
 	}
+	return km
 }
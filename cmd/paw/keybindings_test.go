@@ -0,0 +1,66 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/dongho-jung/paw/internal/keymap"
+)
+
+func bindingKeys(ctx KeybindingsContext) map[string]string {
+	keys := make(map[string]string)
+	for _, b := range buildKeybindings(ctx) {
+		keys[b.Key] = b.Command
+	}
+	return keys
+}
+
+func TestBuildKeybindingsDefaults(t *testing.T) {
+	keys := bindingKeys(KeybindingsContext{PawBin: "paw", SessionName: "demo"})
+	for _, key := range []string{"C-n", "C-g", "C-_", "M-Left", "M-BTab", "C-q"} {
+		if _, ok := keys[key]; !ok {
+			t.Errorf("default binding %s missing", key)
+		}
+	}
+	if !strings.Contains(keys["C-g"], "send-keys C-g") {
+		t.Errorf("C-g should pass through to the shell pane: %s", keys["C-g"])
+	}
+}
+
+func TestBuildKeybindingsRemapped(t *testing.T) {
+	km, errs := keymap.New(map[string]string{
+		"toggle-git":     "M-g",
+		"history-search": "none",
+	})
+	if len(errs) > 0 {
+		t.Fatalf("keymap errors: %v", errs)
+	}
+	keys := bindingKeys(KeybindingsContext{PawBin: "paw", SessionName: "demo", Keymap: km})
+
+	if _, ok := keys["C-g"]; ok {
+		t.Error("C-g should no longer be bound")
+	}
+	if _, ok := keys["C-r"]; ok {
+		t.Error("disabled history-search should not be bound")
+	}
+	if cmd := keys["M-g"]; !strings.Contains(cmd, "toggle-git-status") || !strings.Contains(cmd, "send-keys M-g") {
+		t.Errorf("M-g binding = %q", cmd)
+	}
+
+	released := releasedKeybindings(km)
+	if !slices.Contains(released, "C-g") || !slices.Contains(released, "C-r") || slices.Contains(released, "C-n") {
+		t.Errorf("released = %v", released)
+	}
+}
+
+func TestStatusRightHints(t *testing.T) {
+	if got := statusRightHints(keymap.Default()); got != " ⌥←→:windows ⌥↑↓:reorder ⌃K:shell ⌃/:help " {
+		t.Errorf("default hints = %q", got)
+	}
+
+	km, _ := keymap.New(map[string]string{"new-shell-window": "none", "toggle-help": "F1"})
+	if got := statusRightHints(km); got != " ⌥←→:windows ⌥↑↓:reorder F1:help " {
+		t.Errorf("remapped hints = %q", got)
+	}
+}
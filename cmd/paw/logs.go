@@ -2,33 +2,62 @@ package main
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/dongho-jung/paw/internal/app"
 	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/logging"
 )
 
+// logFollowInterval is how often `paw logs -f` polls the log file.
+const logFollowInterval = 500 * time.Millisecond
+
 var (
-	logsSince string
-	logsTask  string
+	logsSince    string
+	logsUntil    string
+	logsTask     string
+	logsScript   string
+	logsCaller   string
+	logsLevel    string
+	logsFollow   bool
+	logsJSON     bool
+	logsTimeline bool
 )
 
 var logsCmd = &cobra.Command{
 	Use:   "logs",
 	Short: "Show PAW logs with filters",
+	Long: `Show PAW logs (text or jsonl format) filtered by level, task, script,
+caller and time range.
+
+Use --timeline to pair timer start/stop entries into durations, which makes
+slow lifecycle steps stand out. Timer entries are logged at L1, so run PAW
+with PAW_DEBUG=1 to record them.`,
 	RunE: func(_ *cobra.Command, _ []string) error {
 		application, err := getAppFromCwd()
 		if err != nil {
 			return err
 		}
 
+		filter, err := logsFilterFromFlags()
+		if err != nil {
+			return err
+		}
+		if logsTimeline && logsFollow {
+			return fmt.Errorf("--timeline cannot be combined with --follow")
+		}
+
 		logPath := application.GetLogPath()
 		file, err := os.Open(logPath)
 		if err != nil {
@@ -36,46 +65,186 @@ var logsCmd = &cobra.Command{
 		}
 		defer func() { _ = file.Close() }()
 
-		var sinceTime time.Time
-		if logsSince != "" {
-			parsed, err := parseSince(logsSince)
-			if err != nil {
-				return err
-			}
-			sinceTime = parsed
-		}
+		printer := newLogPrinter(filter, logsJSON)
+		var entries []logging.Entry
 
 		scanner := bufio.NewScanner(file)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
 		for scanner.Scan() {
 			line := scanner.Text()
-			ts, task, ok := parseLogLine(line)
-
-			if !sinceTime.IsZero() {
-				if !ok || ts.Before(sinceTime) {
-					continue
+			if logsTimeline {
+				if e, ok := logging.ParseEntry(line); ok && filter.Match(e) {
+					entries = append(entries, e)
 				}
+				continue
 			}
-
-			if logsTask != "" {
-				if task == "" || task != logsTask {
-					continue
-				}
-			}
-
-			fmt.Println(line)
+			printer.print(line)
 		}
-
 		if err := scanner.Err(); err != nil {
 			return fmt.Errorf("failed to read log file: %w", err)
 		}
 
-		return nil
+		if logsTimeline {
+			return printTimeline(logging.BuildTimeline(entries), logsJSON)
+		}
+		if !logsFollow {
+			return nil
+		}
+
+		offset, err := file.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return err
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		return followLog(ctx, logPath, offset, printer.print)
 	},
 }
 
 func init() {
 	logsCmd.Flags().StringVar(&logsSince, "since", "", "Show logs since time (duration like 2h or timestamp)")
+	logsCmd.Flags().StringVar(&logsUntil, "until", "", "Show logs until time (duration like 30m or timestamp)")
 	logsCmd.Flags().StringVar(&logsTask, "task", "", "Filter logs by task name")
+	logsCmd.Flags().StringVar(&logsScript, "script", "", "Filter logs by script (e.g. new-task, end-task)")
+	logsCmd.Flags().StringVar(&logsCaller, "caller", "", "Filter logs by caller function (substring)")
+	logsCmd.Flags().StringVar(&logsLevel, "level", "", "Minimum level (0-5, L0-L5 or trace/debug/info/warn/error/fatal)")
+	logsCmd.Flags().BoolVarP(&logsFollow, "follow", "f", false, "Keep printing new log entries as they are written")
+	logsCmd.Flags().BoolVar(&logsJSON, "json", false, "Print entries (or timeline spans) as JSON lines")
+	logsCmd.Flags().BoolVar(&logsTimeline, "timeline", false, "Show timer durations instead of log lines")
+}
+
+// logsFilterFromFlags builds the log filter from the command flags.
+func logsFilterFromFlags() (logging.Filter, error) {
+	filter := logging.Filter{
+		Task:   logsTask,
+		Script: logsScript,
+		Caller: logsCaller,
+	}
+	var err error
+	if filter.Since, err = parseSince(logsSince); err != nil {
+		return filter, err
+	}
+	if filter.Until, err = parseUntil(logsUntil); err != nil {
+		return filter, err
+	}
+	if logsLevel != "" {
+		if filter.MinLevel, err = logging.ParseLevel(logsLevel); err != nil {
+			return filter, err
+		}
+	}
+	return filter, nil
+}
+
+// logPrinter prints log lines that pass a filter.
+type logPrinter struct {
+	filter   logging.Filter
+	json     bool
+	lastKept bool // Whether the last parsed entry was printed
+}
+
+func newLogPrinter(filter logging.Filter, jsonOutput bool) *logPrinter {
+	return &logPrinter{filter: filter, json: jsonOutput, lastKept: true}
+}
+
+// print prints the line if it passes the filter. Lines that are not log
+// entries (multi-line messages) follow the entry they belong to.
+func (p *logPrinter) print(line string) {
+	e, ok := logging.ParseEntry(line)
+	if !ok {
+		if p.lastKept && !p.json {
+			fmt.Println(line)
+		}
+		return
+	}
+	p.lastKept = p.filter.Match(e)
+	if !p.lastKept {
+		return
+	}
+	if !p.json {
+		fmt.Println(line)
+		return
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	fmt.Println(string(data))
+}
+
+// followLog prints lines appended to the log file until ctx is cancelled.
+// A shrinking file (rotation) is read again from the start.
+func followLog(ctx context.Context, path string, offset int64, emit func(string)) error {
+	ticker := time.NewTicker(logFollowInterval)
+	defer ticker.Stop()
+
+	var partial string
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			continue // The file may be mid-rotation
+		}
+		if info.Size() < offset {
+			offset = 0
+			partial = ""
+		}
+		if info.Size() == offset {
+			continue
+		}
+
+		data, err := readFrom(path, offset)
+		if err != nil {
+			return err
+		}
+		offset += int64(len(data))
+
+		lines := strings.Split(partial+string(data), "\n")
+		// The last element is an unfinished line (or empty after a newline)
+		partial = lines[len(lines)-1]
+		for _, line := range lines[:len(lines)-1] {
+			emit(line)
+		}
+	}
+}
+
+// readFrom reads a file from offset to its end.
+func readFrom(path string, offset int64) ([]byte, error) {
+	file, err := os.Open(path) //nolint:gosec // G304: path is the PAW log file
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return io.ReadAll(file)
+}
+
+// printTimeline prints timer spans as JSON lines or as a table with duration bars.
+func printTimeline(spans []logging.Span, jsonOutput bool) error {
+	if jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		for _, span := range spans {
+			if err := enc.Encode(span); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if len(spans) == 0 {
+		fmt.Println("No timer entries found (timers are logged with PAW_DEBUG=1)")
+		return nil
+	}
+
+	for _, line := range logging.FormatTimeline(spans) {
+		fmt.Println(line)
+	}
+	return nil
 }
 
 func getAppFromCwd() (*app.App, error) {
@@ -104,48 +273,3 @@ func getAppFromCwd() (*app.App, error) {
 
 	return nil, fmt.Errorf("could not find .paw directory from %s", cwd)
 }
-
-func parseLogLine(line string) (time.Time, string, bool) {
-	trimmed := strings.TrimSpace(line)
-	if strings.HasPrefix(trimmed, "{") {
-		var payload struct {
-			Timestamp string `json:"ts"`
-			Task      string `json:"task"`
-			Context   string `json:"context"`
-		}
-		if err := json.Unmarshal([]byte(trimmed), &payload); err == nil {
-			if payload.Task == "" && payload.Context != "" {
-				payload.Task = extractTaskFromContext(payload.Context)
-			}
-			if payload.Timestamp != "" {
-				if ts, err := time.Parse(time.RFC3339Nano, payload.Timestamp); err == nil {
-					return ts, payload.Task, true
-				}
-			}
-			return time.Time{}, payload.Task, false
-		}
-	}
-
-	parts := strings.SplitN(trimmed, "] [", 4)
-	if len(parts) < 3 {
-		return time.Time{}, "", false
-	}
-
-	tsStr := strings.TrimPrefix(parts[0], "[")
-	ts, err := time.ParseInLocation("06-01-02 15:04:05.0", tsStr, time.Local)
-	if err != nil {
-		return time.Time{}, "", false
-	}
-
-	context := strings.TrimSuffix(parts[2], "]")
-	task := extractTaskFromContext(context)
-	return ts, task, true
-}
-
-func extractTaskFromContext(context string) string {
-	parts := strings.SplitN(context, ":", 2)
-	if len(parts) == 2 {
-		return parts[1]
-	}
-	return ""
-}
@@ -2,6 +2,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"runtime/debug"
@@ -26,11 +27,41 @@ var (
 
 func main() {
 	if err := rootCmd.Execute(); err != nil {
+		// The command already told the user why; only report the exit code
+		var exitErr *exitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.code)
+		}
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 }
 
+// Exit codes of internal commands that have already printed why they failed.
+// The bulk runner reads them instead of the output.
+const (
+	exitUnavailable  = 2 // The action is not available for this task or workspace
+	exitMergeFailed  = 3 // The merge needs manual resolution; the task is kept
+	exitPushFailed   = 4 // A push failed; a merge before it still went through
+	exitPRFailed     = 5 // The pull request was not created
+	exitSyncBlocked  = 6 // Uncommitted changes or an unfinished rebase or merge
+	exitSyncConflict = 7 // The rebase stopped on conflicts
+)
+
+// exitError ends a command with a dedicated exit code without printing err.
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+func (e *exitError) Unwrap() error { return e.err }
+
+// reported wraps an error the command has already shown to the user.
+func reported(code int, err error) error {
+	return &exitError{code: code, err: err}
+}
+
 var rootCmd = &cobra.Command{
 	Use:   "paw",
 	Short: "PAW - Parallel AI Workers",
@@ -50,6 +81,8 @@ func init() {
 	tui.SetVersion(Version)
 
 	rootCmd.AddCommand(attachCmd)
+	rootCmd.AddCommand(backportCmd)
+	rootCmd.AddCommand(blameCmd)
 	rootCmd.AddCommand(checkCmd)
 	rootCmd.AddCommand(cleanCmd)
 	rootCmd.AddCommand(cleanAllCmd)
@@ -58,6 +91,10 @@ func init() {
 	rootCmd.AddCommand(locationCmd)
 	rootCmd.AddCommand(logsCmd)
 	rootCmd.AddCommand(historyCmd)
+	rootCmd.AddCommand(projectsCmd)
+	rootCmd.AddCommand(timelineCmd)
+	rootCmd.AddCommand(statsCmd)
+	rootCmd.AddCommand(taskCmd)
 	rootCmd.AddCommand(windowMapCmd)
 	rootCmd.AddCommand(versionCmd)
 
@@ -187,51 +224,49 @@ func runMain(_ *cobra.Command, _ []string) error {
 		return fmt.Errorf("failed to get current directory: %w", err)
 	}
 
-	// Detect git repo first - if in a git repo, use repo root as project dir
-	// This prevents issues with:
-	// 1. Session names containing colons (e.g., "project:src") conflicting with tmux target syntax
-	// 2. .paw directory being created in subdirectory instead of repo root
-	gitClient := git.New()
-	isGitRepo := gitClient.IsGitRepo(cwd)
-	projectDir := cwd
-	if isGitRepo {
-		if repoRoot, err := gitClient.GetRepoRoot(cwd); err == nil {
-			if repoRoot != cwd {
-				logging.Debug("Running from subdirectory: cwd=%s, repoRoot=%s", cwd, repoRoot)
-			}
-			projectDir = repoRoot
-		} else {
-			logging.Warn("Failed to get repo root, using cwd: %v", err)
-		}
+	application, closeLog, err := loadProjectApp(cwd, forceLocal)
+	if err != nil {
+		return err
 	}
+	defer closeLog()
+	registerProject(application, cwd)
 
-	workspaceMode := config.PawInProjectAuto
-	if forceLocal {
-		workspaceMode = config.PawInProjectLocal
-	}
+	// Create tmux client
+	tm := tmux.New(application.SessionName)
 
-	// Create app context with appropriate project directory
-	// Pass isGitRepo to ensure correct workspace location in auto mode
-	application, err := app.NewWithGitInfoWithWorkspace(projectDir, isGitRepo, workspaceMode)
-	if err != nil {
-		return fmt.Errorf("failed to create app: %w", err)
+	// Check if session already exists
+	if tm.HasSession(application.SessionName) {
+		logging.Debug("Attaching to existing session: %s", application.SessionName)
+		return attachToSession(application, tm)
 	}
 
-	// Set display name context for subdirectory runs
-	if isGitRepo {
-		application.SetSubdirectoryContext(cwd, projectDir)
+	// Start new session
+	logging.Log("=== New session start ===")
+	logging.Debug("Project: %s", application.ProjectDir)
+	logging.Debug("Session: %s", application.SessionName)
+	logging.Debug("Git repo: %v", application.IsGitRepo)
+	return startNewSession(application, tm)
+}
+
+// loadProjectApp initializes and configures the project of dir and sets up
+// its file logger. local forces a local .paw workspace. The returned func
+// closes the logger.
+func loadProjectApp(dir string, local bool) (*app.App, func(), error) {
+	application, err := resolveProjectApp(dir, local)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	// Set PAW home
 	pawHome, err := getPawHome()
 	if err != nil {
-		return fmt.Errorf("failed to get PAW home: %w", err)
+		return nil, nil, fmt.Errorf("failed to get PAW home: %w", err)
 	}
 	application.SetPawHome(pawHome)
 
 	// Initialize .paw directory
 	if err := application.Initialize(); err != nil {
-		return fmt.Errorf("failed to initialize: %w", err)
+		return nil, nil, fmt.Errorf("failed to initialize: %w", err)
 	}
 
 	// Bootstrap logger (stdout) until config loads
@@ -240,13 +275,13 @@ func runMain(_ *cobra.Command, _ []string) error {
 	// Ensure config exists (create defaults on first run)
 	if !application.HasConfig() {
 		if err := config.DefaultConfig().Save(application.PawDir); err != nil {
-			return fmt.Errorf("failed to write default config: %w", err)
+			return nil, nil, fmt.Errorf("failed to write default config: %w", err)
 		}
 	}
 
 	// Load configuration
 	if err := application.LoadConfig(); err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
 	}
 	if application.Config != nil {
 		_ = os.Setenv("PAW_LOG_FORMAT", application.Config.LogFormat)
@@ -257,25 +292,51 @@ func runMain(_ *cobra.Command, _ []string) error {
 	// Setup logging (file) with configured options
 	logger, err := logging.New(application.GetLogPath(), application.Debug)
 	if err != nil {
-		return fmt.Errorf("failed to setup logging: %w", err)
+		return nil, nil, fmt.Errorf("failed to setup logging: %w", err)
 	}
-	defer func() { _ = logger.Close() }()
 	logger.SetScript("paw")
 	logging.SetGlobal(logger)
 
-	// Create tmux client
-	tm := tmux.New(application.SessionName)
+	return application, func() { _ = logger.Close() }, nil
+}
 
-	// Check if session already exists
-	if tm.HasSession(application.SessionName) {
-		logging.Debug("Attaching to existing session: %s", application.SessionName)
-		return attachToSession(application, tm)
+// resolveProjectApp creates the app context of the project containing dir
+// (the repo root for git repositories) without touching its workspace.
+func resolveProjectApp(dir string, local bool) (*app.App, error) {
+	// Detect git repo first - if in a git repo, use repo root as project dir
+	// This prevents issues with:
+	// 1. Session names containing colons (e.g., "project:src") conflicting with tmux target syntax
+	// 2. .paw directory being created in subdirectory instead of repo root
+	gitClient := git.New()
+	isGitRepo := gitClient.IsGitRepo(dir)
+	projectDir := dir
+	if isGitRepo {
+		if repoRoot, err := gitClient.GetRepoRoot(dir); err == nil {
+			if repoRoot != dir {
+				logging.Debug("Running from subdirectory: cwd=%s, repoRoot=%s", dir, repoRoot)
+			}
+			projectDir = repoRoot
+		} else {
+			logging.Warn("Failed to get repo root, using cwd: %v", err)
+		}
 	}
 
-	// Start new session
-	logging.Log("=== New session start ===")
-	logging.Debug("Project: %s", application.ProjectDir)
-	logging.Debug("Session: %s", application.SessionName)
-	logging.Debug("Git repo: %v", application.IsGitRepo)
-	return startNewSession(application, tm)
+	workspaceMode := config.PawInProjectAuto
+	if local {
+		workspaceMode = config.PawInProjectLocal
+	}
+
+	// Create app context with appropriate project directory
+	// Pass isGitRepo to ensure correct workspace location in auto mode
+	application, err := app.NewWithGitInfoWithWorkspace(projectDir, isGitRepo, workspaceMode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create app: %w", err)
+	}
+
+	// Set display name context for subdirectory runs
+	if isGitRepo {
+		application.SetSubdirectoryContext(dir, projectDir)
+	}
+
+	return application, nil
 }
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/tmux"
+	"github.com/dongho-jung/paw/internal/tui"
+)
+
+var toggleOverviewCmd = &cobra.Command{
+	Use:   "toggle-overview [session]",
+	Short: "Open the overview window, or leave it",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		logging.Debug("-> toggleOverviewCmd(session=%s)", args[0])
+		defer logging.Debug("<- toggleOverviewCmd")
+
+		sessionName := args[0]
+		tm := tmux.New(sessionName)
+
+		windows, err := tm.ListWindows()
+		if err != nil {
+			return err
+		}
+		for _, w := range windows {
+			if w.Name != constants.OverviewWindow {
+				continue
+			}
+			if w.Active {
+				return tm.Run("last-window")
+			}
+			return tm.SelectWindow(w.ID)
+		}
+
+		appCtx, err := getAppFromSession(sessionName)
+		if err != nil {
+			logging.Debug("toggleOverviewCmd: getAppFromSession failed: %v", err)
+			return err
+		}
+
+		windowID, err := tm.NewWindow(tmux.WindowOpts{
+			Name:       constants.OverviewWindow,
+			StartDir:   appCtx.ProjectDir,
+			Command:    shellJoin(getPawBin(), "internal", "overview-tui", sessionName),
+			AfterIndex: -1,
+		})
+		if err != nil {
+			logging.Warn("toggleOverviewCmd: NewWindow failed: %v", err)
+			return err
+		}
+		logging.Debug("toggleOverviewCmd: created overview window %s", windowID)
+		return nil
+	},
+}
+
+var overviewTUICmd = &cobra.Command{
+	Use:    "overview-tui [session]",
+	Short:  "Run the overview TUI (called from the overview window)",
+	Args:   cobra.ExactArgs(1),
+	Hidden: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		sessionName := args[0]
+
+		appCtx, err := getAppFromSession(sessionName)
+		if err != nil {
+			return err
+		}
+		_, cleanup := setupLoggerFromApp(appCtx, "overview-tui", "")
+		defer cleanup()
+
+		logging.Debug("-> overviewTUICmd(session=%s)", sessionName)
+		defer logging.Debug("<- overviewTUICmd")
+
+		return tui.RunOverview(sessionName, func(task *service.DiscoveredTask) error {
+			logging.Debug("overviewTUICmd: jumping to %s (%s %s)", task.Name, task.Session, task.WindowID)
+			if task.Session == sessionName {
+				return tmux.New(sessionName).SelectWindow(task.WindowID)
+			}
+			return switchToSessionWindow(sessionName, task.Session, task.WindowID)
+		})
+	},
+}
@@ -0,0 +1,394 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/keymap"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/task"
+	"github.com/dongho-jung/paw/internal/tmux"
+	"github.com/dongho-jung/paw/internal/tui"
+)
+
+// userPaletteIDPrefix prefixes the IDs of palette_commands entries.
+const userPaletteIDPrefix = "user:"
+
+// paletteContext describes the window the command palette was opened in.
+type paletteContext struct {
+	SessionName string
+	WindowID    string
+	MainWindow  bool // ⭐️main window (task input and kanban)
+	TaskWindow  bool
+	TaskName    string // Empty outside task windows or if the task is not found
+	WorktreeDir string // Working directory of the task
+	IsGitRepo   bool
+}
+
+// newPaletteContext resolves the palette context of a window.
+func newPaletteContext(appCtx *app.App, tm tmux.Client, windowID, windowName string) paletteContext {
+	ctx := paletteContext{
+		SessionName: appCtx.SessionName,
+		WindowID:    windowID,
+		MainWindow:  strings.HasPrefix(windowName, constants.EmojiNew),
+		TaskWindow:  constants.IsTaskWindow(windowName),
+		IsGitRepo:   appCtx.IsGitRepo,
+	}
+	if !ctx.TaskWindow {
+		return ctx
+	}
+
+	mgr := task.NewManager(appCtx.AgentsDir, appCtx.ProjectDir, appCtx.PawDir, appCtx.IsGitRepo, appCtx.Config)
+	mgr.SetTmuxClient(tm)
+	t, err := mgr.FindTaskByWindowID(windowID)
+	if err != nil {
+		if name, ok := constants.ExtractTaskName(windowName); ok {
+			t, _ = mgr.FindTaskByTruncatedName(name)
+		}
+	}
+	if t != nil {
+		ctx.TaskName = t.Name
+		ctx.WorktreeDir = mgr.GetWorkingDirectory(t)
+	}
+	return ctx
+}
+
+// paletteEntry is a command palette command with the context it applies to.
+type paletteEntry struct {
+	id          string
+	name        string
+	description string
+	action      keymap.Action // Keybinding shown next to the name (optional)
+	when        func(paletteContext) bool
+	run         func(r *paletteRunner) error
+}
+
+// Availability predicates of palette entries.
+func anyWindow(paletteContext) bool      { return true }
+func mainWindow(c paletteContext) bool   { return c.MainWindow }
+func taskWindow(c paletteContext) bool   { return c.TaskWindow }
+func gitRepo(c paletteContext) bool      { return c.IsGitRepo }
+func gitTask(c paletteContext) bool      { return c.IsGitRepo && c.TaskWindow }
+func nonGitTask(c paletteContext) bool   { return !c.IsGitRepo && c.TaskWindow }
+func resolvedTask(c paletteContext) bool { return c.TaskName != "" }
+
+// builtinPaletteEntries returns the PAW actions offered by the palette.
+func builtinPaletteEntries() []paletteEntry {
+	return []paletteEntry{
+		{"new-task", "New Task", "Open the task input in the main window", keymap.ActionNewTask, anyWindow, internalRun("toggle-new")},
+		{"finish-task", "Finish Task", "Pick how to finish the current task", keymap.ActionFinishTask, taskWindow, internalRun("done-task")},
+		{"finish-merge-push", "Finish: Merge & Push", "Merge to main, push to remote, and clean up", "", gitTask, finishRun(constants.ActionMergePush)},
+		{"finish-merge", "Finish: Merge", "Merge branch to main (local only) and clean up", "", gitTask, finishRun(constants.ActionMerge)},
+		{"finish-pr", "Finish: PR", "Push branch and create a pull request", "", gitTask, finishRun(constants.ActionPR)},
+		{"finish-done", "Finish: Done", "Clean up the task", "", nonGitTask, finishRun(constants.ActionDone)},
+		{"finish-drop", "Finish: Drop", "Discard all changes and clean up (asks first)", "", taskWindow, runDropTask},
+		{"sync-with-main", "Sync With Main", "Rebase the task branch onto the latest main", "", gitTask, windowInternalRun("sync-with-main-ui")},
+		{"cancel-task", "Cancel Task", "Stop the agent and revert the task", "", taskWindow, windowInternalRun("cancel-task-ui")},
+		{"show-diff", "Show Diff", "Review the task's changes against main", "", gitTask, internalRun("toggle-show-diff")},
+		{"git-viewer", "Git Viewer", "Browse status, log and branches", keymap.ActionToggleGit, gitRepo, internalRun("toggle-git-status")},
+		{"log-viewer", "Log Viewer", "Show the PAW log", keymap.ActionToggleLogs, anyWindow, internalRun("toggle-log")},
+		{"help", "Help", "Show keyboard shortcuts and docs", keymap.ActionToggleHelp, anyWindow, internalRun("toggle-help")},
+		{"toggle-shell", "Toggle Shell", "Show or hide the shell pane", keymap.ActionToggleShell, anyWindow, internalRun("popup-shell")},
+		{"new-shell-window", "New Shell Window", "Open a shell window in the project", keymap.ActionNewShellWindow, anyWindow, internalRun("new-shell-window")},
+		{"show-current-task", "Show Current Task", "Display current task content in a popup", "", taskWindow, internalRun("show-current-task")},
+		{"restore-panes", "Restore Panes", "Restore missing panes in current task window", "", taskWindow, internalRun("restore-panes")},
+		{"recover-task", "Recover Task", "Repair a corrupted task (worktree, branch, window)", "", resolvedTask, runRecoverTask},
+		{"switch-project", "Switch Project", "Jump to another PAW project, starting it if stopped", keymap.ActionProjectPicker, anyWindow, internalRun("toggle-project-picker")},
+		{"overview", "Overview", "Watch the agent panes of running tasks side by side", keymap.ActionOverview, anyWindow, internalRun("toggle-overview")},
+		{"timeline", "Task Timeline", "See when tasks were working, waiting on you and done", "", anyWindow, internalRun("toggle-timeline")},
+		{"stats", "Statistics", "Tasks per day, completion rate, waiting time and models", "", anyWindow, internalRun("toggle-stats")},
+		{"inbox", "Inbox", "Answer the questions agents of all sessions are waiting on", keymap.ActionInbox, anyWindow, internalRun("toggle-inbox")},
+		{"templates", "Task Templates", "Insert a task template into the task input", keymap.ActionTemplatePicker, mainWindow, internalRun("toggle-template")},
+		{"history", "Task History", "Search previous task inputs", keymap.ActionHistorySearch, mainWindow, internalRun("toggle-history")},
+		{"prompts", "Edit Prompts", "Edit the agent prompts of this project", keymap.ActionPromptPicker, anyWindow, internalRun("toggle-prompt-picker")},
+		{"change-theme", "Change Theme", "Preview and pick a color theme for this project", "", anyWindow, nil}, // Runs inside the palette pane
+		{"check", "Check Project", "Run paw check and show the result", "", anyWindow, runCheckProject},
+		{"clean", "Clean Project", "Remove all worktrees, branches and the session (asks first)", "", anyWindow, runCleanProject},
+		{"quit", "Quit", "Detach from the session", keymap.ActionQuit, anyWindow, runQuit},
+	}
+}
+
+// userPaletteEntries returns the palette_commands of the global and project
+// configs (the project wins on equal names), sorted by name.
+func userPaletteEntries(pawDir string) []paletteEntry {
+	commands := make(map[string]string)
+	for _, dir := range []string{config.GlobalPawDir(), pawDir} {
+		if dir == "" {
+			continue
+		}
+		cfg, err := config.Load(dir)
+		if err != nil {
+			continue
+		}
+		for name, command := range cfg.PaletteCommands {
+			commands[name] = command
+		}
+	}
+
+	names := make([]string, 0, len(commands))
+	for name, command := range commands {
+		if strings.TrimSpace(name) != "" && strings.TrimSpace(command) != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	entries := make([]paletteEntry, 0, len(names))
+	for _, name := range names {
+		command := commands[name]
+		entries = append(entries, paletteEntry{
+			id:          userPaletteIDPrefix + name,
+			name:        name,
+			description: command,
+			when:        anyWindow,
+			run: func(r *paletteRunner) error {
+				return r.popup(name, r.taskDir(), expandPawCommand(command, r.pawBin), "")
+			},
+		})
+	}
+	return entries
+}
+
+// paletteEntries returns the built-in and user entries available in a context.
+func paletteEntries(pawDir string, ctx paletteContext) []paletteEntry {
+	var entries []paletteEntry
+	for _, e := range append(builtinPaletteEntries(), userPaletteEntries(pawDir)...) {
+		if e.when(ctx) {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// findPaletteEntry returns the entry with the given ID, if it is available.
+func findPaletteEntry(pawDir string, ctx paletteContext, id string) (paletteEntry, bool) {
+	for _, e := range paletteEntries(pawDir, ctx) {
+		if e.id == id {
+			return e, true
+		}
+	}
+	return paletteEntry{}, false
+}
+
+// rankPaletteCommands converts entries to palette commands, listing recently
+// used ones first (most recent first) and the rest in registry order.
+func rankPaletteCommands(entries []paletteEntry, recent []string, km *keymap.Keymap) []tui.Command {
+	rank := make(map[string]int, len(recent))
+	for i, id := range recent {
+		if _, seen := rank[id]; !seen {
+			rank[id] = i
+		}
+	}
+
+	commands := make([]tui.Command, 0, len(entries))
+	for _, e := range entries {
+		cmd := tui.Command{Name: e.name, Description: e.description, ID: e.id}
+		if e.action != "" && km != nil {
+			cmd.Shortcut = km.Label(e.action)
+		}
+		_, cmd.Recent = rank[e.id]
+		commands = append(commands, cmd)
+	}
+
+	sort.SliceStable(commands, func(i, j int) bool {
+		ri, iRecent := rank[commands[i].ID]
+		rj, jRecent := rank[commands[j].ID]
+		if iRecent != jRecent {
+			return iRecent
+		}
+		return iRecent && ri < rj
+	})
+	return commands
+}
+
+// expandPawCommand replaces a leading "paw" with the running PAW binary, so
+// palette commands use the same PAW as the session.
+func expandPawCommand(command, pawBin string) string {
+	trimmed := strings.TrimSpace(command)
+	if trimmed == "paw" || strings.HasPrefix(trimmed, "paw ") {
+		return shellQuote(pawBin) + strings.TrimPrefix(trimmed, "paw")
+	}
+	return command
+}
+
+// popupScript wraps a shell command so its output stays on screen until Enter
+// is pressed. A non-empty confirm prompt asks before running the command.
+func popupScript(command, confirm string) string {
+	var sb strings.Builder
+	if confirm != "" {
+		fmt.Fprintf(&sb, "printf '%%s [y/N] ' %s\nread answer\ncase \"$answer\" in y|Y) ;; *) exit 0 ;; esac\n", shellQuote(confirm))
+	}
+	// Subshell: an "exit" in the command still shows the output
+	sb.WriteString("(\n" + command + "\n)\n")
+	sb.WriteString("status=$?\necho\n")
+	sb.WriteString("[ \"$status\" -eq 0 ] || echo \"Exited with status $status\"\n")
+	sb.WriteString("printf 'Press Enter to close...'\nread _\n")
+	return sb.String()
+}
+
+// paletteRunner runs a palette entry outside of the palette pane.
+type paletteRunner struct {
+	appCtx *app.App
+	tm     tmux.Client
+	ctx    paletteContext
+	pawBin string
+}
+
+// internal runs a PAW internal command with the session (and extra args).
+func (r *paletteRunner) internal(name string, args ...string) error {
+	cmdArgs := append([]string{"internal", name, r.ctx.SessionName}, args...)
+	cmd := exec.Command(r.pawBin, cmdArgs...) //nolint:gosec // G204: pawBin is from getPawBin()
+	cmd.Env = append(os.Environ(),
+		"PAW_DIR="+r.appCtx.PawDir,
+		"PROJECT_DIR="+r.appCtx.ProjectDir,
+		"DISPLAY_NAME="+r.appCtx.DisplayName,
+	)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s failed: %w: %s", name, err, output)
+	}
+	return nil
+}
+
+// taskDir returns the task's working directory, or the project directory.
+func (r *paletteRunner) taskDir() string {
+	if r.ctx.WorktreeDir != "" {
+		return r.ctx.WorktreeDir
+	}
+	return r.appCtx.ProjectDir
+}
+
+// popup runs a shell command in a popup and keeps its output on screen.
+// $TASK_NAME, $WORKTREE_DIR, $PROJECT_DIR, $PAW_DIR and $SESSION are set.
+func (r *paletteRunner) popup(title, dir, command, confirm string) error {
+	err := r.tm.DisplayPopup(tmux.PopupOpts{
+		Width:     constants.PopupWidthPaletteOutput,
+		Height:    constants.PopupHeightPaletteOutput,
+		Title:     " " + title + " ",
+		Close:     true,
+		Style:     "fg=terminal,bg=terminal",
+		Directory: dir,
+		Env: map[string]string{
+			"PAW_DIR":      r.appCtx.PawDir,
+			"PROJECT_DIR":  r.appCtx.ProjectDir,
+			"SESSION":      r.ctx.SessionName,
+			"TASK_NAME":    r.ctx.TaskName,
+			"WORKTREE_DIR": r.ctx.WorktreeDir,
+		},
+	}, shellJoin("sh", "-c", popupScript(command, confirm)))
+	if err != nil {
+		// Popup exit codes are the command's; the output was shown in the popup
+		logging.Debug("paletteRunner.popup: displayPopup returned: %v", err)
+	}
+	return nil
+}
+
+// internalRun runs a session-wide internal command (e.g. toggle-log).
+func internalRun(name string) func(r *paletteRunner) error {
+	return func(r *paletteRunner) error { return r.internal(name) }
+}
+
+// windowInternalRun runs an internal command for the palette's window.
+func windowInternalRun(name string) func(r *paletteRunner) error {
+	return func(r *paletteRunner) error { return r.internal(name, r.ctx.WindowID) }
+}
+
+// finishRun finishes the task with an end-task action.
+func finishRun(action string) func(r *paletteRunner) error {
+	return func(r *paletteRunner) error {
+		return r.internal("end-task-ui", r.ctx.WindowID, "--action", action)
+	}
+}
+
+// runDropTask asks for confirmation before dropping the task, like the
+// finish picker does.
+func runDropTask(r *paletteRunner) error {
+	dropCmd := strings.Join([]string{
+		shellEnv("PAW_DIR", r.appCtx.PawDir),
+		shellEnv("PROJECT_DIR", r.appCtx.ProjectDir),
+		shellJoin(r.pawBin, "internal", "end-task-ui", r.ctx.SessionName, r.ctx.WindowID, "--action", constants.ActionDrop),
+	}, " ")
+	return r.tm.Run("confirm-before", "-p", "Drop task and discard all changes? (y/n)", "run-shell -b "+shellQuote(dropCmd))
+}
+
+func runRecoverTask(r *paletteRunner) error {
+	recoverCmd := shellJoin(r.pawBin, "internal", "recover-task", r.ctx.SessionName, r.ctx.TaskName)
+	return r.popup("Recover Task", r.appCtx.ProjectDir, recoverCmd, "")
+}
+
+func runCheckProject(r *paletteRunner) error {
+	return r.popup("Check", r.appCtx.ProjectDir, shellJoin(r.pawBin, "check"), "")
+}
+
+func runCleanProject(r *paletteRunner) error {
+	return r.popup("Clean", r.appCtx.ProjectDir, shellJoin(r.pawBin, "clean"),
+		"Remove all worktrees, branches and this session?")
+}
+
+func runQuit(r *paletteRunner) error {
+	// Disable mouse mode first, like the quit keybinding
+	return r.tm.Run("run-shell", "-b", "tmux set-option mouse off; tmux detach-client")
+}
+
+// dispatchPaletteCommand runs a palette command in the background with
+// palette-run, so it outlives the palette pane that is closing.
+func dispatchPaletteCommand(appCtx *app.App, tm tmux.Client, windowID, id string) error {
+	runCmd := strings.Join([]string{
+		shellEnv("PAW_DIR", appCtx.PawDir),
+		shellEnv("PROJECT_DIR", appCtx.ProjectDir),
+		shellEnv("DISPLAY_NAME", appCtx.DisplayName),
+		shellJoin(getPawBin(), "internal", "palette-run", appCtx.SessionName, windowID, id),
+	}, " ")
+	return tm.Run("run-shell", "-b", runCmd)
+}
+
+var paletteRunCmd = &cobra.Command{
+	Use:    "palette-run [session] [window-id] [command-id]",
+	Short:  "Run a command palette command",
+	Args:   cobra.ExactArgs(3),
+	Hidden: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		sessionName := args[0]
+		windowID := args[1]
+		id := args[2]
+
+		appCtx, err := getAppFromSession(sessionName)
+		if err != nil {
+			return err
+		}
+
+		// Setup logging
+		_, cleanup := setupLoggerFromApp(appCtx, "palette-run", "")
+		defer cleanup()
+
+		logging.Debug("-> paletteRunCmd(session=%s, windowID=%s, id=%s)", sessionName, windowID, id)
+		defer logging.Debug("<- paletteRunCmd")
+
+		tm := tmux.New(sessionName)
+		windowName, err := getWindowName(tm, windowID)
+		if err != nil {
+			return fmt.Errorf("failed to get window name: %w", err)
+		}
+		ctx := newPaletteContext(appCtx, tm, windowID, strings.TrimSpace(windowName))
+
+		entry, ok := findPaletteEntry(appCtx.PawDir, ctx, id)
+		if !ok || entry.run == nil {
+			_ = tm.DisplayMessage("Command not available here: "+id, constants.DisplayMsgStandard)
+			return nil
+		}
+
+		r := &paletteRunner{appCtx: appCtx, tm: tm, ctx: ctx, pawBin: getPawBin()}
+		if err := entry.run(r); err != nil {
+			logging.Warn("paletteRunCmd: %s failed: %v", id, err)
+			_ = tm.DisplayMessage(entry.name+" failed: "+err.Error(), constants.DisplayMsgStandard)
+		}
+		return nil
+	},
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/keymap"
+)
+
+func paletteIDs(pawDir string, ctx paletteContext) []string {
+	var ids []string
+	for _, e := range paletteEntries(pawDir, ctx) {
+		ids = append(ids, e.id)
+	}
+	return ids
+}
+
+func TestPaletteEntriesContext(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	pawDir := t.TempDir()
+
+	mainIDs := paletteIDs(pawDir, paletteContext{MainWindow: true, IsGitRepo: true})
+	for _, id := range []string{"new-task", "templates", "history", "git-viewer", "check", "clean"} {
+		if !slices.Contains(mainIDs, id) {
+			t.Errorf("main window palette is missing %s: %v", id, mainIDs)
+		}
+	}
+	for _, id := range []string{"finish-task", "sync-with-main", "cancel-task", "show-diff", "recover-task"} {
+		if slices.Contains(mainIDs, id) {
+			t.Errorf("main window palette should not offer %s", id)
+		}
+	}
+
+	gitTaskIDs := paletteIDs(pawDir, paletteContext{TaskWindow: true, TaskName: "fix-bug", IsGitRepo: true})
+	for _, id := range []string{"finish-merge", "finish-pr", "sync-with-main", "show-diff", "recover-task"} {
+		if !slices.Contains(gitTaskIDs, id) {
+			t.Errorf("git task palette is missing %s", id)
+		}
+	}
+	if slices.Contains(gitTaskIDs, "templates") || slices.Contains(gitTaskIDs, "finish-done") {
+		t.Errorf("git task palette = %v", gitTaskIDs)
+	}
+
+	plainTask := paletteIDs(pawDir, paletteContext{TaskWindow: true})
+	for _, id := range []string{"sync-with-main", "git-viewer", "finish-merge", "recover-task"} {
+		if slices.Contains(plainTask, id) {
+			t.Errorf("non-git task palette should not offer %s", id)
+		}
+	}
+	if !slices.Contains(plainTask, "finish-done") {
+		t.Error("non-git task palette should offer finish-done")
+	}
+}
+
+func TestUserPaletteEntries(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	pawDir := t.TempDir()
+
+	global := config.DefaultConfig()
+	global.PaletteCommands = map[string]string{"Run tests": "make test", "Lint": "make lint"}
+	if err := os.MkdirAll(config.GlobalPawDir(), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := global.Save(config.GlobalPawDir()); err != nil {
+		t.Fatal(err)
+	}
+	project := config.DefaultConfig()
+	project.PaletteCommands = map[string]string{"Run tests": "go test ./..."}
+	if err := project.Save(pawDir); err != nil {
+		t.Fatal(err)
+	}
+
+	entries := userPaletteEntries(pawDir)
+	if len(entries) != 2 {
+		t.Fatalf("userPaletteEntries() = %d entries, want 2", len(entries))
+	}
+	if entries[0].name != "Lint" || entries[1].id != userPaletteIDPrefix+"Run tests" || entries[1].description != "go test ./..." {
+		t.Errorf("entries = %+v", entries)
+	}
+	if !slices.Contains(paletteIDs(pawDir, paletteContext{}), userPaletteIDPrefix+"Lint") {
+		t.Error("user commands should be offered in every window")
+	}
+}
+
+func TestRankPaletteCommands(t *testing.T) {
+	entries := []paletteEntry{
+		{id: "a", name: "A", action: keymap.ActionToggleLogs},
+		{id: "b", name: "B"},
+		{id: "c", name: "C"},
+		{id: "d", name: "D"},
+	}
+	commands := rankPaletteCommands(entries, []string{"c", "gone", "a"}, keymap.Default())
+
+	var order []string
+	for _, c := range commands {
+		order = append(order, c.ID)
+	}
+	if want := []string{"c", "a", "b", "d"}; !slices.Equal(order, want) {
+		t.Errorf("order = %v, want %v", order, want)
+	}
+	if !commands[0].Recent || commands[2].Recent {
+		t.Errorf("recent flags = %+v", commands)
+	}
+	if commands[1].Shortcut != "⌃O" {
+		t.Errorf("shortcut = %q, want ⌃O", commands[1].Shortcut)
+	}
+}
+
+func TestExpandPawCommand(t *testing.T) {
+	tests := map[string]string{
+		"paw logs --task $TASK_NAME": "'/opt/paw' logs --task $TASK_NAME",
+		"paw":                        "'/opt/paw'",
+		"pawn run":                   "pawn run",
+		"make test":                  "make test",
+	}
+	for in, want := range tests {
+		if got := expandPawCommand(in, "/opt/paw"); got != want {
+			t.Errorf("expandPawCommand(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestPopupScript(t *testing.T) {
+	script := popupScript("make test", "")
+	if !strings.Contains(script, "(\nmake test\n)") || !strings.Contains(script, "Press Enter to close") {
+		t.Errorf("popupScript() = %q", script)
+	}
+	if strings.Contains(script, "[y/N]") {
+		t.Error("popupScript() without confirm should not prompt")
+	}
+	if confirm := popupScript("paw clean", "Really?"); !strings.HasPrefix(confirm, "printf '%s [y/N] ' 'Really?'") {
+		t.Errorf("popupScript() with confirm = %q", confirm)
+	}
+}
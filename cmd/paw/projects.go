@@ -0,0 +1,341 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/tmux"
+	"github.com/dongho-jung/paw/internal/tui"
+)
+
+var projectsLocal bool
+
+var projectsCmd = &cobra.Command{
+	Use:   "projects",
+	Short: "List and manage registered PAW projects",
+	Long: `List and manage the projects PAW knows about.
+
+Projects are registered automatically when 'paw' starts in them, and are
+listed in the project picker (Ctrl+J) even while their sessions are stopped.
+Pinned projects are listed first, then the most recently used.`,
+	Args: cobra.NoArgs,
+	RunE: runProjectsList,
+}
+
+var projectsLsCmd = &cobra.Command{
+	Use:   "ls",
+	Short: "List registered projects",
+	Args:  cobra.NoArgs,
+	RunE:  runProjectsList,
+}
+
+var projectsAddCmd = &cobra.Command{
+	Use:   "add [dir]",
+	Short: "Register a project (default: current directory)",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		dir := "."
+		if len(args) > 0 {
+			dir = args[0]
+		}
+		absDir, err := filepath.Abs(dir)
+		if err != nil {
+			return err
+		}
+		if info, err := os.Stat(absDir); err != nil || !info.IsDir() {
+			return fmt.Errorf("not a directory: %s", dir)
+		}
+
+		application, err := resolveProjectApp(absDir, projectsLocal)
+		if err != nil {
+			return err
+		}
+		if err := projectRegistry().Add(registeredProject(application, absDir), false); err != nil {
+			return fmt.Errorf("failed to register project: %w", err)
+		}
+		fmt.Printf("Registered %s (%s)\n", application.SessionName, absDir)
+		return nil
+	},
+}
+
+var projectsRmCmd = &cobra.Command{
+	Use:   "rm <project>",
+	Short: "Unregister a project by name or directory",
+	Long: `Unregister a project by name or directory.
+
+The project's workspace and tasks are left untouched.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		removed, err := projectRegistry().Remove(args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Unregistered %s (%s)\n", removed.Name, removed.Dir)
+		return nil
+	},
+}
+
+var projectsPinCmd = &cobra.Command{
+	Use:   "pin <project>",
+	Short: "Pin a project to the top of the list",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		return setProjectPinned(args[0], true)
+	},
+}
+
+var projectsUnpinCmd = &cobra.Command{
+	Use:   "unpin <project>",
+	Short: "Unpin a project",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		return setProjectPinned(args[0], false)
+	},
+}
+
+func init() {
+	projectsAddCmd.Flags().BoolVar(&projectsLocal, "local", false, "Use a local .paw workspace for git repositories")
+	projectsCmd.AddCommand(projectsLsCmd)
+	projectsCmd.AddCommand(projectsAddCmd)
+	projectsCmd.AddCommand(projectsRmCmd)
+	projectsCmd.AddCommand(projectsPinCmd)
+	projectsCmd.AddCommand(projectsUnpinCmd)
+}
+
+func projectRegistry() *service.ProjectRegistry {
+	return service.NewProjectRegistry(config.GlobalPawDir())
+}
+
+func registeredProject(appCtx *app.App, dir string) service.RegisteredProject {
+	return service.RegisteredProject{
+		Name:   appCtx.SessionName,
+		Dir:    dir,
+		PawDir: appCtx.PawDir,
+		Git:    appCtx.IsGitRepo,
+	}
+}
+
+// registerProject records a started project in the registry. dir is the
+// directory paw was started from, so that starting it again reproduces the
+// session name of subdirectory runs.
+func registerProject(appCtx *app.App, dir string) {
+	if config.GlobalPawDir() == "" {
+		return
+	}
+	if err := projectRegistry().Add(registeredProject(appCtx, dir), true); err != nil {
+		logging.Warn("Failed to register project: %v", err)
+	}
+}
+
+func setProjectPinned(ref string, pinned bool) error {
+	p, err := projectRegistry().SetPinned(ref, pinned)
+	if err != nil {
+		return err
+	}
+	if pinned {
+		fmt.Printf("Pinned %s\n", p.Name)
+	} else {
+		fmt.Printf("Unpinned %s\n", p.Name)
+	}
+	return nil
+}
+
+func runProjectsList(_ *cobra.Command, _ []string) error {
+	projects, err := projectRegistry().List()
+	if err != nil {
+		return fmt.Errorf("failed to read project registry: %w", err)
+	}
+	if len(projects) == 0 {
+		fmt.Println("No projects registered. Run 'paw' in a project or 'paw projects add <dir>'.")
+		return nil
+	}
+
+	running := make(map[string]bool)
+	for _, s := range findPawSessions() {
+		running[s.Name] = true
+	}
+	printProjectList(projects, running, time.Now())
+	return nil
+}
+
+func printProjectList(projects []service.RegisteredProject, running map[string]bool, now time.Time) {
+	nameWidth := 0
+	for _, p := range projects {
+		nameWidth = max(nameWidth, len(p.Name))
+	}
+	for _, p := range projects {
+		pin := " "
+		if p.Pinned {
+			pin = "★"
+		}
+		state := "stopped"
+		if running[p.Name] {
+			state = "running"
+		}
+		fmt.Printf("%s %-*s  %-7s  %3d tasks  %-10s  %s\n",
+			pin, nameWidth, p.Name, state, p.TaskCount(), formatLastUsed(p.LastUsed, now), p.Dir)
+	}
+}
+
+// formatLastUsed renders how long ago a project was used.
+func formatLastUsed(t, now time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	d := now.Sub(t)
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
+
+// projectPickerItems lists the projects to offer in the project picker of
+// currentSession: registered projects and running sessions.
+func projectPickerItems(currentSession string) []tui.ProjectPickerItem {
+	projects, err := projectRegistry().List()
+	if err != nil {
+		logging.Warn("Failed to read project registry: %v", err)
+	}
+	return mergeProjectPickerItems(projects, findPawSessions(), currentSession)
+}
+
+// mergeProjectPickerItems marks the registered projects that are running and
+// appends running sessions that are not registered, excluding currentSession.
+func mergeProjectPickerItems(projects []service.RegisteredProject, sessions []pawSession, currentSession string) []tui.ProjectPickerItem {
+	running := make(map[string]pawSession, len(sessions))
+	for _, s := range sessions {
+		running[s.Name] = s
+	}
+
+	var items []tui.ProjectPickerItem
+	seen := map[string]bool{currentSession: true}
+	for _, p := range projects {
+		if seen[p.Name] {
+			continue
+		}
+		seen[p.Name] = true
+		s, ok := running[p.Name]
+		items = append(items, tui.ProjectPickerItem{
+			Name:       p.Name,
+			SocketPath: s.SocketPath,
+			Dir:        p.Dir,
+			Running:    ok,
+			Pinned:     p.Pinned,
+			Tasks:      p.TaskCount(),
+		})
+	}
+
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].Name < sessions[j].Name })
+	for _, s := range sessions {
+		if seen[s.Name] {
+			continue
+		}
+		seen[s.Name] = true
+		items = append(items, tui.ProjectPickerItem{
+			Name:       s.Name,
+			SocketPath: s.SocketPath,
+			Running:    true,
+		})
+	}
+	return items
+}
+
+// startProjectSession starts the session of a stopped project in the
+// background. It runs in a separate process with the environment of the
+// current session stripped, so the new tmux server does not inherit it.
+func startProjectSession(item tui.ProjectPickerItem) error {
+	if item.Dir == "" {
+		return fmt.Errorf("project directory unknown: %s", item.Name)
+	}
+
+	cmd := exec.Command(getPawBin(), "internal", "start-project", item.Dir) //nolint:gosec // G204: paw binary with a registered project directory
+	cmd.Dir = item.Dir
+	cmd.Env = startProjectEnv(os.Environ())
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// startProjectEnv removes the variables that tie a process to the current
+// tmux session and project.
+func startProjectEnv(env []string) []string {
+	var cleaned []string
+	for _, kv := range env {
+		key, _, _ := strings.Cut(kv, "=")
+		switch key {
+		case "TMUX", "TMUX_PANE", "PAW_DIR", "PROJECT_DIR", "DISPLAY_NAME", "SESSION_NAME", "WINDOW_ID", "TASK_NAME":
+			continue
+		}
+		cleaned = append(cleaned, kv)
+	}
+	return cleaned
+}
+
+// touchProject marks a registered project as used now.
+func touchProject(ref string) {
+	registry := projectRegistry()
+	if p, ok := registry.Find(ref); ok {
+		if err := registry.Add(p, true); err != nil {
+			logging.Warn("Failed to update project registry: %v", err)
+		}
+	}
+}
+
+// waitForEnter keeps a popup open until the user has read its output.
+func waitForEnter() {
+	fmt.Println("Press Enter to close...")
+	_, _ = bufio.NewReader(os.Stdin).ReadString('\n')
+}
+
+var startProjectCmd = &cobra.Command{
+	Use:    "start-project [dir]",
+	Short:  "Start the session of a project in the background",
+	Args:   cobra.ExactArgs(1),
+	Hidden: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		dir, err := filepath.Abs(args[0])
+		if err != nil {
+			return err
+		}
+		local := false
+		if p, ok := projectRegistry().Find(dir); ok {
+			local = p.Local()
+		}
+
+		application, closeLog, err := loadProjectApp(dir, local)
+		if err != nil {
+			return err
+		}
+		defer closeLog()
+		registerProject(application, dir)
+
+		tm := tmux.New(application.SessionName)
+		if tm.HasSession(application.SessionName) {
+			return nil
+		}
+
+		logging.Log("=== New session start (background) ===")
+		logging.Debug("Project: %s", application.ProjectDir)
+		logging.Debug("Session: %s", application.SessionName)
+		return createSession(application, tm)
+	},
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/service"
+)
+
+func TestMergeProjectPickerItems(t *testing.T) {
+	projects := []service.RegisteredProject{
+		{Name: "web", Dir: "/src/web", Pinned: true},
+		{Name: "current", Dir: "/src/current"},
+		{Name: "api", Dir: "/src/api"},
+	}
+	sessions := []pawSession{
+		{Name: "zeta", SocketPath: "/tmp/paw-zeta"},
+		{Name: "api", SocketPath: "/tmp/paw-api"},
+		{Name: "current", SocketPath: "/tmp/paw-current"},
+		{Name: "beta", SocketPath: "/tmp/paw-beta"},
+	}
+
+	items := mergeProjectPickerItems(projects, sessions, "current")
+
+	var names []string
+	for _, item := range items {
+		names = append(names, item.Name)
+	}
+	if want := []string{"web", "api", "beta", "zeta"}; !slices.Equal(names, want) {
+		t.Fatalf("items = %v, want %v", names, want)
+	}
+	if items[0].Running || !items[0].Pinned || items[0].Dir != "/src/web" {
+		t.Errorf("web = %+v, want stopped and pinned", items[0])
+	}
+	if !items[1].Running || items[1].SocketPath != "/tmp/paw-api" {
+		t.Errorf("api = %+v, want running", items[1])
+	}
+	if !items[2].Running || items[2].Dir != "" {
+		t.Errorf("beta = %+v, want running and unregistered", items[2])
+	}
+}
+
+func TestFormatLastUsed(t *testing.T) {
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	tests := map[time.Time]string{
+		{}:                         "never",
+		now.Add(-30 * time.Second): "just now",
+		now.Add(-5 * time.Minute):  "5m ago",
+		now.Add(-3 * time.Hour):    "3h ago",
+		now.Add(-50 * time.Hour):   "2d ago",
+	}
+	for in, want := range tests {
+		if got := formatLastUsed(in, now); got != want {
+			t.Errorf("formatLastUsed(%v) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestStartProjectEnv(t *testing.T) {
+	env := startProjectEnv([]string{"HOME=/home/me", "TMUX=/tmp/tmux", "PAW_DIR=/src/app/.paw", "PATH=/bin"})
+	if len(env) != 2 || env[0] != "HOME=/home/me" || env[1] != "PATH=/bin" {
+		t.Errorf("startProjectEnv() = %v", env)
+	}
+}
@@ -18,6 +18,20 @@ import (
 
 // startNewSession creates a new tmux session
 func startNewSession(appCtx *app.App, tm tmux.Client) error {
+	if err := createSession(appCtx, tm); err != nil {
+		return err
+	}
+
+	// Attach to session
+	if err := tm.AttachSession(appCtx.SessionName); err != nil {
+		return fmt.Errorf("failed to attach to newly created session %q: %w (workspace: %s)", appCtx.SessionName, err, appCtx.PawDir)
+	}
+	return nil
+}
+
+// createSession creates and sets up a detached tmux session for the project:
+// config, reopened tasks, file picker and the task input.
+func createSession(appCtx *app.App, tm tmux.Client) error {
 	logging.Debug("Starting new tmux session...")
 
 	// Create/update bin symlink for hook execution
@@ -114,6 +128,9 @@ func startNewSession(appCtx *app.App, tm tmux.Client) error {
 		}
 	}
 
+	// Resume watching PRs that were open before the session stopped
+	resumePRWatcher(appCtx, appCtx.SessionName)
+
 	// Wait for shell to be ready before sending keys
 	paneTarget := appCtx.SessionName + ":" + constants.NewWindowName + ".0"
 	if err := tm.WaitForPane(paneTarget, constants.PaneWaitTimeout, 1); err != nil {
@@ -134,11 +151,6 @@ func startNewSession(appCtx *app.App, tm tmux.Client) error {
 	newTaskCmd := buildNewTaskCommand(appCtx, pawBin, appCtx.SessionName)
 	_ = tm.SendKeysLiteral(appCtx.SessionName+":"+constants.NewWindowName, newTaskCmd)
 	_ = tm.SendKeys(appCtx.SessionName+":"+constants.NewWindowName, "Enter")
-
-	// Attach to session
-	if err := tm.AttachSession(appCtx.SessionName); err != nil {
-		return fmt.Errorf("failed to attach to newly created session %q: %w (workspace: %s)", appCtx.SessionName, err, appCtx.PawDir)
-	}
 	return nil
 }
 
@@ -312,18 +324,19 @@ func attachToSession(appCtx *app.App, tm tmux.Client) error {
 		}
 	}
 
+	// Make sure the PR watcher is running (it may have died with a previous tmux server)
+	resumePRWatcher(appCtx, appCtx.SessionName)
+
 	logging.Debug("Attaching to session: %s", appCtx.SessionName)
 
 	// Re-apply tmux config to ensure terminal title is set
 	reapplyTmuxConfig(appCtx, tm)
 
-	// Detect terminal theme and apply theme-aware tmux colors.
+	// Apply the project's theme to tmux.
 	// This ensures status bar, window tabs, and pane borders match the terminal's
 	// dark/light mode when re-attaching from a different terminal.
-	themePreset := ThemeAuto
-	resolved := resolveThemePreset(themePreset)
-	applyTmuxTheme(tm, resolved)
-	logging.Debug("Applied theme on reattach: preset=%s resolved=%s", themePreset, resolved)
+	applied := applyProjectTheme(appCtx.PawDir, tm)
+	logging.Debug("Applied theme on reattach: %s", applied.Name)
 
 	// Always respawn main window on reattach to ensure fresh theme detection.
 	// When user re-attaches from a terminal with different light/dark mode,
@@ -0,0 +1,257 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/tmux"
+	"github.com/dongho-jung/paw/internal/tui"
+)
+
+var (
+	statsSince   string
+	statsProject string
+	statsJSON    bool
+	statsCSV     bool
+	statsTUI     bool
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show task statistics of all projects",
+	Long: `Show how tasks went, computed from the task history of every registered
+project: tasks per day, completion vs drop/cancel rate, median time to done,
+time waiting on you, merge conflicts and a comparison of models.
+
+--json prints the statistics with every task; --csv prints one row per task.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		if statsJSON && statsCSV {
+			return errors.New("--json cannot be combined with --csv")
+		}
+		projects, err := statsProjects()
+		if err != nil {
+			return err
+		}
+
+		if statsTUI {
+			if cmd.Flags().Changed("since") {
+				return errors.New("--since cannot be combined with --tui (press r to change the period)")
+			}
+			return runStatsTUI(projects, statsProject)
+		}
+
+		since, err := parseSince(statsSince)
+		if err != nil {
+			return err
+		}
+		stats, err := computeStats(projects, statsProject, since, time.Now())
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case statsJSON:
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(stats)
+		case statsCSV:
+			return writeStatsCSV(stats.Tasks)
+		}
+		for _, line := range tui.FormatStats(stats) {
+			fmt.Println(line)
+		}
+		return nil
+	},
+}
+
+func init() {
+	statsCmd.Flags().StringVar(&statsSince, "since", "30d", "Count tasks started since (duration like 7d or timestamp; empty for all)")
+	statsCmd.Flags().StringVar(&statsProject, "project", "", "Only count one project (session name or directory)")
+	statsCmd.Flags().BoolVar(&statsJSON, "json", false, "Print the statistics and tasks as JSON")
+	statsCmd.Flags().BoolVar(&statsCSV, "csv", false, "Print one CSV row per task")
+	statsCmd.Flags().BoolVar(&statsTUI, "tui", false, "Open the statistics dashboard")
+}
+
+// statsProjects returns the registered projects, plus the project of the
+// current directory if it is not registered.
+func statsProjects() ([]service.RegisteredProject, error) {
+	var projects []service.RegisteredProject
+	if config.GlobalPawDir() != "" {
+		registered, err := projectRegistry().List()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read project registry: %w", err)
+		}
+		projects = registered
+	}
+
+	if application, err := getAppFromCwd(); err == nil {
+		known := false
+		for _, p := range projects {
+			known = known || p.PawDir == application.PawDir
+		}
+		if !known {
+			projects = append(projects, service.RegisteredProject{
+				Name:   application.SessionName,
+				Dir:    application.ProjectDir,
+				PawDir: application.PawDir,
+			})
+		}
+	}
+	return projects, nil
+}
+
+// selectStatsProjects returns the projects matching ref (all when empty).
+func selectStatsProjects(projects []service.RegisteredProject, ref string) ([]service.RegisteredProject, error) {
+	if ref == "" {
+		return projects, nil
+	}
+	dir := ref
+	if abs, err := filepath.Abs(ref); err == nil {
+		dir = abs
+	}
+	for _, p := range projects {
+		if p.Name == ref || p.Dir == dir {
+			return []service.RegisteredProject{p}, nil
+		}
+	}
+	return nil, fmt.Errorf("project not found: %s (see paw projects)", ref)
+}
+
+// computeStats computes the statistics of the tasks started since the given
+// time in the selected projects.
+func computeStats(projects []service.RegisteredProject, ref string, since, now time.Time) (service.Stats, error) {
+	selected, err := selectStatsProjects(projects, ref)
+	if err != nil {
+		return service.Stats{}, err
+	}
+
+	var tasks []service.StatsTask
+	for _, p := range selected {
+		history := service.NewHistoryService(filepath.Join(p.PawDir, constants.HistoryDirName))
+		projectTasks, err := history.LoadStatsTasks(p.Name, since, now)
+		if err != nil {
+			logging.Warn("computeStats: failed to read history of %s: %v", p.Name, err)
+			continue
+		}
+		tasks = append(tasks, projectTasks...)
+	}
+	return service.ComputeStats(tasks, since, now), nil
+}
+
+// writeStatsCSV prints one row per task.
+func writeStatsCSV(tasks []service.StatsTask) error {
+	w := csv.NewWriter(os.Stdout)
+	_ = w.Write([]string{"project", "task", "model", "outcome", "started", "done", "finished",
+		"working_seconds", "waiting_seconds", "time_to_done_seconds", "merge_conflicts", "conflicts_resolved"})
+
+	formatTime := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	}
+	seconds := func(d time.Duration) string { return strconv.FormatInt(int64(d.Seconds()), 10) }
+	for _, t := range tasks {
+		_ = w.Write([]string{
+			t.Project, t.Task, t.Model, t.Outcome,
+			formatTime(t.Started), formatTime(t.Done), formatTime(t.Finished),
+			seconds(t.Working), seconds(t.Waiting), seconds(t.TimeToDone()),
+			strconv.Itoa(t.Conflicts), strconv.Itoa(t.Resolved),
+		})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// runStatsTUI opens the statistics dashboard, starting on a project ("" for all).
+func runStatsTUI(projects []service.RegisteredProject, project string) error {
+	if _, err := selectStatsProjects(projects, project); err != nil {
+		return err
+	}
+	names := make([]string, 0, len(projects))
+	for _, p := range projects {
+		names = append(names, p.Name)
+		if p.Dir == project {
+			project = p.Name
+		}
+	}
+	return tui.RunStats(names, project, func(ref string, since, now time.Time) (service.Stats, error) {
+		return computeStats(projects, ref, since, now)
+	})
+}
+
+var toggleStatsCmd = &cobra.Command{
+	Use:   "toggle-stats [session]",
+	Short: "Show the task statistics popup",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		logging.Debug("-> toggleStatsCmd(session=%s)", args[0])
+		defer logging.Debug("<- toggleStatsCmd")
+
+		sessionName := args[0]
+		tm := tmux.New(sessionName)
+
+		appCtx, err := getAppFromSession(sessionName)
+		if err != nil {
+			logging.Debug("toggleStatsCmd: getAppFromSession failed: %v", err)
+			return err
+		}
+
+		popupCmd := shellJoin(getPawBin(), "internal", "stats-tui", sessionName)
+		return tm.DisplayPopup(tmux.PopupOpts{
+			Width:     constants.PopupWidthStats,
+			Height:    constants.PopupHeightStats,
+			Title:     " Statistics ",
+			Close:     true,
+			Style:     "fg=terminal,bg=terminal",
+			Directory: appCtx.ProjectDir,
+			Env: map[string]string{
+				"PAW_DIR":     appCtx.PawDir,
+				"PROJECT_DIR": appCtx.ProjectDir,
+			},
+		}, popupCmd)
+	},
+}
+
+var statsTUICmd = &cobra.Command{
+	Use:    "stats-tui [session]",
+	Short:  "Run the statistics TUI (called from popup)",
+	Args:   cobra.ExactArgs(1),
+	Hidden: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		sessionName := args[0]
+
+		appCtx, err := getAppFromSession(sessionName)
+		if err != nil {
+			return err
+		}
+		_, cleanup := setupLoggerFromApp(appCtx, "stats-tui", "")
+		defer cleanup()
+
+		logging.Debug("-> statsTUICmd(session=%s)", sessionName)
+		defer logging.Debug("<- statsTUICmd")
+
+		projects, err := statsProjects()
+		if err != nil {
+			return err
+		}
+		// Start on this project, if it is known
+		project := sessionName
+		if _, err := selectStatsProjects(projects, project); err != nil {
+			project = ""
+		}
+		return runStatsTUI(projects, project)
+	},
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/task"
+)
+
+func TestComputeStatsProjects(t *testing.T) {
+	projects := []service.RegisteredProject{
+		{Name: "api", Dir: "/src/api", PawDir: t.TempDir()},
+		{Name: "web", Dir: "/src/web", PawDir: t.TempDir()},
+	}
+	for _, p := range projects {
+		history := service.NewHistoryService(filepath.Join(p.PawDir, constants.HistoryDirName))
+		if err := history.RecordStatusTransition(p.Name+"-task", task.StatusPending, task.StatusWorking, "test", "", true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	apiHistory := service.NewHistoryService(filepath.Join(projects[0].PawDir, constants.HistoryDirName))
+	if err := apiHistory.RecordOutcome("api-task", constants.ActionMerge, config.ModelOpus); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now().Add(time.Second)
+	all, err := computeStats(projects, "", now.Add(-time.Hour), now)
+	if err != nil || all.TaskCount != 2 || all.Completed != 1 || all.Open != 1 {
+		t.Fatalf("all projects = %+v, %v", all, err)
+	}
+
+	for _, ref := range []string{"web", "/src/web"} {
+		web, err := computeStats(projects, ref, now.Add(-time.Hour), now)
+		if err != nil || web.TaskCount != 1 || web.Tasks[0].Project != "web" {
+			t.Errorf("computeStats(%q) = %+v, %v", ref, web, err)
+		}
+	}
+
+	if _, err := computeStats(projects, "unknown", time.Time{}, now); err == nil {
+		t.Error("unknown project should fail")
+	}
+}
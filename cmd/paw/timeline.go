@@ -0,0 +1,149 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/tmux"
+	"github.com/dongho-jung/paw/internal/tui"
+)
+
+// timelineDefaultWidth is used when stdout is not a terminal.
+const timelineDefaultWidth = 120
+
+var (
+	timelineWeek  bool
+	timelineSince string
+	timelineTask  string
+)
+
+var timelineCmd = &cobra.Command{
+	Use:   "timeline",
+	Short: "Show when tasks were working, waiting on you and done",
+	Long: `Show one bar per task across the last day (or week), drawn from the
+recorded status changes: █ working, ▒ waiting on you, ▔ done. Each task lists
+the time its agent worked and the time it sat waiting on you.`,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		application, err := getAppFromCwd()
+		if err != nil {
+			return err
+		}
+
+		end := time.Now()
+		start := end.Add(-tui.TimelineDay)
+		if timelineWeek {
+			start = end.Add(-tui.TimelineWeek)
+		}
+		if timelineSince != "" {
+			if start, err = parseSince(timelineSince); err != nil {
+				return err
+			}
+			if !start.Before(end) {
+				return errors.New("--since must be in the past")
+			}
+		}
+
+		timelines, err := service.NewHistoryService(application.GetHistoryDir()).LoadTimelines(start, end)
+		if err != nil {
+			return err
+		}
+		timelines = filterTimelines(timelines, timelineTask)
+		if len(timelines) == 0 {
+			fmt.Println("No task status changes in this period")
+			return nil
+		}
+
+		width, _, colored := getTerminalSize()
+		if !colored {
+			width = timelineDefaultWidth
+		}
+		for _, line := range tui.FormatTimeline(timelines, start, end, width, colored) {
+			fmt.Println(line)
+		}
+		return nil
+	},
+}
+
+func init() {
+	timelineCmd.Flags().BoolVar(&timelineWeek, "week", false, "Show the last 7 days instead of the last 24 hours")
+	timelineCmd.Flags().StringVar(&timelineSince, "since", "", "Start of the timeline (duration or timestamp)")
+	timelineCmd.Flags().StringVar(&timelineTask, "task", "", "Filter tasks by name (substring)")
+}
+
+// filterTimelines keeps the timelines whose task name contains query.
+func filterTimelines(timelines []service.TaskTimeline, query string) []service.TaskTimeline {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return timelines
+	}
+	var filtered []service.TaskTimeline
+	for _, tl := range timelines {
+		if strings.Contains(strings.ToLower(tl.Task), query) {
+			filtered = append(filtered, tl)
+		}
+	}
+	return filtered
+}
+
+var toggleTimelineCmd = &cobra.Command{
+	Use:   "toggle-timeline [session]",
+	Short: "Show the task timeline popup",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		logging.Debug("-> toggleTimelineCmd(session=%s)", args[0])
+		defer logging.Debug("<- toggleTimelineCmd")
+
+		sessionName := args[0]
+		tm := tmux.New(sessionName)
+
+		appCtx, err := getAppFromSession(sessionName)
+		if err != nil {
+			logging.Debug("toggleTimelineCmd: getAppFromSession failed: %v", err)
+			return err
+		}
+
+		timelineCmd := shellJoin(getPawBin(), "internal", "timeline-tui", sessionName)
+		return tm.DisplayPopup(tmux.PopupOpts{
+			Width:     constants.PopupWidthTimeline,
+			Height:    constants.PopupHeightTimeline,
+			Title:     " Timeline ",
+			Close:     true,
+			Style:     "fg=terminal,bg=terminal",
+			Directory: appCtx.ProjectDir,
+			Env: map[string]string{
+				"PAW_DIR":     appCtx.PawDir,
+				"PROJECT_DIR": appCtx.ProjectDir,
+			},
+		}, timelineCmd)
+	},
+}
+
+var timelineTUICmd = &cobra.Command{
+	Use:    "timeline-tui [session]",
+	Short:  "Run the timeline TUI (called from popup)",
+	Args:   cobra.ExactArgs(1),
+	Hidden: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		sessionName := args[0]
+
+		appCtx, err := getAppFromSession(sessionName)
+		if err != nil {
+			return err
+		}
+		_, cleanup := setupLoggerFromApp(appCtx, "timeline-tui", "")
+		defer cleanup()
+
+		logging.Debug("-> timelineTUICmd(session=%s)", sessionName)
+		defer logging.Debug("<- timelineTUICmd")
+
+		history := service.NewHistoryService(appCtx.GetHistoryDir())
+		return tui.RunTimeline(history.LoadTimelines)
+	},
+}
@@ -3,30 +3,23 @@ package main
 import (
 	"fmt"
 	"time"
+
+	"github.com/dongho-jung/paw/internal/logging"
 )
 
 func parseSince(value string) (time.Time, error) {
-	if value == "" {
-		return time.Time{}, nil
-	}
-
-	if dur, err := time.ParseDuration(value); err == nil {
-		return time.Now().Add(-dur), nil
-	}
+	return parseTimeFlag("--since", value)
+}
 
-	layouts := []string{
-		time.RFC3339Nano,
-		time.RFC3339,
-		"2006-01-02 15:04:05",
-		"06-01-02 15:04:05.0",
-		"2006-01-02",
-	}
+func parseUntil(value string) (time.Time, error) {
+	return parseTimeFlag("--until", value)
+}
 
-	for _, layout := range layouts {
-		if parsed, err := time.ParseInLocation(layout, value, time.Local); err == nil {
-			return parsed, nil
-		}
+// parseTimeFlag parses a time flag given as a duration ago (2h) or a timestamp.
+func parseTimeFlag(flag, value string) (time.Time, error) {
+	parsed, err := logging.ParseTimeBound(value, time.Now())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s value: %s", flag, value)
 	}
-
-	return time.Time{}, fmt.Errorf("invalid --since value: %s", value)
+	return parsed, nil
 }
@@ -2,8 +2,10 @@ package main
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/dongho-jung/paw/internal/app"
+	"github.com/dongho-jung/paw/internal/keymap"
 	"github.com/dongho-jung/paw/internal/logging"
 	"github.com/dongho-jung/paw/internal/tmux"
 )
@@ -13,19 +15,70 @@ import (
 func reapplyTmuxConfig(appCtx *app.App, tm tmux.Client) {
 	applyAttachHooks(tm)
 
-	// Re-apply keybindings (in case session name changed or for consistency)
+	// Re-apply keybindings (in case session name changed or keybindings were remapped)
+	applyKeybindings(appCtx, tm)
+}
+
+// applyKeybindings binds the effective keymap and unbinds default keys that were
+// remapped away or disabled.
+func applyKeybindings(appCtx *app.App, tm tmux.Client) {
+	km := loadKeymap(appCtx.PawDir)
+	for _, key := range releasedKeybindings(km) {
+		_ = tm.Run("unbind-key", "-n", key)
+	}
+
 	bindings := buildKeybindings(KeybindingsContext{
 		PawBin:      getPawBin(),
 		SessionName: appCtx.SessionName,
 		PawDir:      appCtx.PawDir,
 		ProjectDir:  appCtx.ProjectDir,
 		DisplayName: appCtx.GetDisplayName(),
+		Keymap:      km,
 	})
 	for _, b := range bindings {
 		if err := tm.Bind(b); err != nil {
 			logging.Debug("Failed to bind %s: %v", b.Key, err)
 		}
 	}
+	_ = tm.SetOption("status-right", statusRightHints(km), true)
+}
+
+// statusRightHints renders the status bar key hints from the effective keymap.
+func statusRightHints(km *keymap.Keymap) string {
+	hints := []struct {
+		keys  []keymap.Action
+		label string
+	}{
+		{[]keymap.Action{keymap.ActionPrevWindow, keymap.ActionNextWindow}, "windows"},
+		{[]keymap.Action{keymap.ActionSwapWindowLeft, keymap.ActionSwapWindowRight}, "reorder"},
+		{[]keymap.Action{keymap.ActionNewShellWindow}, "shell"},
+		{[]keymap.Action{keymap.ActionToggleHelp}, "help"},
+	}
+
+	var sb strings.Builder
+	for _, hint := range hints {
+		var labels []string
+		for _, action := range hint.keys {
+			if label := km.Label(action); label != "" {
+				labels = append(labels, label)
+			}
+		}
+		if len(labels) > 0 {
+			sb.WriteString(" " + compactLabels(labels) + ":" + hint.label)
+		}
+	}
+	return sb.String() + " "
+}
+
+// compactLabels joins key labels, sharing a common modifier prefix ("⌥←", "⌥→" -> "⌥←→").
+func compactLabels(labels []string) string {
+	if len(labels) == 2 {
+		a, b := []rune(labels[0]), []rune(labels[1])
+		if len(a) == 2 && len(b) == 2 && a[0] == b[0] {
+			return string(a) + string(b[1])
+		}
+	}
+	return strings.Join(labels, "/")
 }
 
 func applyAttachHooks(tm tmux.Client) {
@@ -44,9 +97,8 @@ func applyAttachHooks(tm tmux.Client) {
 
 // setupTmuxConfig configures tmux keybindings and options
 func setupTmuxConfig(appCtx *app.App, tm tmux.Client) {
-	// Detect terminal theme and apply theme-aware colors (auto only)
-	resolved := resolveThemePreset(ThemeAuto)
-	applyTmuxTheme(tm, resolved)
+	// Apply the project's theme (auto-detecting dark/light unless the theme fixes it)
+	applyProjectTheme(appCtx.PawDir, tm)
 
 	// Change prefix to an unused key (M-F12) so C-b is available for toggle-bottom
 	// Note: "None" is not a valid tmux key, so we use an obscure key instead
@@ -65,7 +117,6 @@ func setupTmuxConfig(appCtx *app.App, tm tmux.Client) {
 	_ = tm.SetOption("status-position", "bottom", true)
 	_ = tm.SetOption("status-left", " "+appCtx.GetDisplayName()+" ", true)
 	_ = tm.SetOption("status-left-length", "30", true)
-	_ = tm.SetOption("status-right", " ⌥←→:windows ⌥↑↓:reorder ^K:shell ^/:help ", true)
 	_ = tm.SetOption("status-right-length", "100", true)
 
 	// Window status separator (no separator between windows)
@@ -118,17 +169,6 @@ func setupTmuxConfig(appCtx *app.App, tm tmux.Client) {
 	// (tmux.conf is only loaded when server starts, not on reconnect)
 	_ = tm.Run("unbind-key", "-T", "root", "C-b")
 
-	// Setup keybindings (English + Korean layouts)
-	bindings := buildKeybindings(KeybindingsContext{
-		PawBin:      getPawBin(),
-		SessionName: appCtx.SessionName,
-		PawDir:      appCtx.PawDir,
-		ProjectDir:  appCtx.ProjectDir,
-		DisplayName: appCtx.GetDisplayName(),
-	})
-	for _, b := range bindings {
-		if err := tm.Bind(b); err != nil {
-			logging.Debug("Failed to bind %s: %v", b.Key, err)
-		}
-	}
+	// Setup keybindings (and the status bar hints rendered from them)
+	applyKeybindings(appCtx, tm)
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/charmbracelet/lipgloss/v2"
 	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/theme"
 	"github.com/dongho-jung/paw/internal/tmux"
 )
 
@@ -74,9 +75,61 @@ func getThemeColors(preset ThemePreset) tmuxThemeColors {
 	}
 }
 
-// applyTmuxTheme applies the specified theme preset to tmux.
-func applyTmuxTheme(tm tmux.Client, preset ThemePreset) {
+// themeTmuxColors returns the tmux colors of a theme: the built-in preset of
+// its mode with the theme's [tmux] colors applied on top.
+func themeTmuxColors(t *theme.Theme, preset ThemePreset) tmuxThemeColors {
 	colors := getThemeColors(preset)
+	fields := map[string]*string{
+		"status_fg":          &colors.statusFg,
+		"status_bg":          &colors.statusBg,
+		"window_fg":          &colors.windowFg,
+		"window_bg":          &colors.windowBg,
+		"window_current_fg":  &colors.windowCurrentFg,
+		"window_current_bg":  &colors.windowCurrentBg,
+		"pane_border":        &colors.paneBorderFg,
+		"pane_active_border": &colors.paneActiveBorderFg,
+		"popup_border":       &colors.popupBorderFg,
+	}
+	for key, value := range t.Tmux {
+		if field, ok := fields[key]; ok {
+			*field = theme.TmuxColor(value)
+		}
+	}
+	return colors
+}
+
+// themePreset resolves the built-in preset of a theme, auto-detecting the
+// terminal background only if the theme does not fix its mode.
+func themePreset(t *theme.Theme) ThemePreset {
+	switch t.Mode {
+	case theme.ModeDark:
+		return ThemeDark
+	case theme.ModeLight:
+		return ThemeLight
+	}
+	return resolveThemePreset(ThemeAuto)
+}
+
+// applyProjectTheme applies the configured theme of a project to tmux and
+// returns it. An invalid theme is logged and replaced by the auto theme.
+func applyProjectTheme(pawDir string, tm tmux.Client) *theme.Theme {
+	t, err := theme.Load(pawDir)
+	if err != nil {
+		logging.Warn("theme: %v", err)
+	}
+	applyTheme(tm, t)
+	return t
+}
+
+// applyTheme applies a theme to tmux.
+func applyTheme(tm tmux.Client, t *theme.Theme) {
+	preset := themePreset(t)
+	applyTmuxTheme(tm, themeTmuxColors(t, preset))
+	logging.Debug("Applied tmux theme: %s (%s)", t.Name, preset)
+}
+
+// applyTmuxTheme applies tmux theme colors.
+func applyTmuxTheme(tm tmux.Client, colors tmuxThemeColors) {
 
 	// Status bar style
 	statusStyle := "fg=" + colors.statusFg + ",bg=" + colors.statusBg
@@ -94,8 +147,6 @@ func applyTmuxTheme(tm tmux.Client, preset ThemePreset) {
 
 	// Popup styling
 	_ = tm.SetOption("popup-border-style", "fg="+colors.popupBorderFg, true)
-
-	logging.Debug("Applied tmux theme: %s", preset)
 }
 
 // detectTerminalTheme detects whether the terminal is in dark or light mode.
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/dongho-jung/paw/internal/theme"
+)
+
+func TestThemeTmuxColors(t *testing.T) {
+	if got := themeTmuxColors(theme.Auto(), ThemeDark); got != getThemeColors(ThemeDark) {
+		t.Errorf("auto theme should keep the built-in colors, got %+v", got)
+	}
+
+	th, err := theme.Parse("test", []byte("mode = \"light\"\n[tmux]\nstatus_bg = \"39\"\npane_border = \"#ff0000\"\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if preset := themePreset(th); preset != ThemeLight {
+		t.Fatalf("themePreset() = %s, want light", preset)
+	}
+	colors := themeTmuxColors(th, ThemeLight)
+	if colors.statusBg != "colour39" || colors.paneBorderFg != "#ff0000" {
+		t.Errorf("colors = %+v", colors)
+	}
+	if colors.statusFg != getThemeColors(ThemeLight).statusFg {
+		t.Error("colors left out should come from the light preset")
+	}
+}
@@ -2,112 +2,328 @@ package main
 
 import (
 	"fmt"
-	"strconv"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/dongho-jung/paw/internal/app"
 	"github.com/dongho-jung/paw/internal/constants"
 	"github.com/dongho-jung/paw/internal/github"
 	"github.com/dongho-jung/paw/internal/logging"
 	"github.com/dongho-jung/paw/internal/notify"
+	"github.com/dongho-jung/paw/internal/service"
 	"github.com/dongho-jung/paw/internal/task"
 	"github.com/dongho-jung/paw/internal/tmux"
 )
 
-var watchPRCmd = &cobra.Command{
-	Use:   "watch-pr [session] [window-id] [task-name] [pr-number]",
-	Short: "Watch PR status and clean up when merged",
-	Args:  cobra.ExactArgs(4),
+var watchPRsCmd = &cobra.Command{
+	Use:   "watch-prs [session]",
+	Short: "Watch all tracked PRs of a session and clean up merged tasks",
+	Args:  cobra.ExactArgs(1),
 	RunE: func(_ *cobra.Command, args []string) error {
 		sessionName := args[0]
-		windowID := args[1]
-		taskName := args[2]
-		prNumberStr := args[3]
-
-		prNumber, err := strconv.Atoi(prNumberStr)
-		if err != nil {
-			return fmt.Errorf("invalid PR number: %s", prNumberStr)
-		}
 
 		appCtx, err := getAppFromSession(sessionName)
 		if err != nil {
 			return err
 		}
 
-		_, cleanup := setupLoggerFromApp(appCtx, "watch-pr", taskName)
+		_, cleanup := setupLoggerFromApp(appCtx, "watch-prs", "")
 		defer cleanup()
 
-		logging.Debug("-> watchPRCmd(session=%s, windowID=%s, task=%s, pr=%d)", sessionName, windowID, taskName, prNumber)
-		defer logging.Debug("<- watchPRCmd")
+		logging.Debug("-> watchPRsCmd(session=%s)", sessionName)
+		defer logging.Debug("<- watchPRsCmd")
 
-		ghClient := github.New()
+		mgr := task.NewManager(appCtx.AgentsDir, appCtx.ProjectDir, appCtx.PawDir, appCtx.IsGitRepo, appCtx.Config)
+		ghClient := mgr.GitHub()
 		if !ghClient.IsInstalled() {
 			logging.Warn("gh CLI not installed; PR watcher exiting")
 			return nil
 		}
 
-		tm := tmux.New(sessionName)
-		mgr := task.NewManager(appCtx.AgentsDir, appCtx.ProjectDir, appCtx.PawDir, appCtx.IsGitRepo, appCtx.Config)
+		w := &prWatcher{
+			appCtx:      appCtx,
+			sessionName: sessionName,
+			tm:          tmux.New(sessionName),
+			gh:          ghClient,
+			mgr:         mgr,
+			list:        service.NewPRWatchList(appCtx.PawDir),
+		}
+		w.run()
+		return nil
+	},
+}
 
-		ticker := time.NewTicker(constants.PRWatchInterval)
-		defer ticker.Stop()
+// prWatcher polls every tracked PR of a session with batched requests.
+// Only one watcher runs per workspace; it exits when the watch list is empty.
+type prWatcher struct {
+	appCtx      *app.App
+	sessionName string
+	tm          tmux.Client
+	gh          github.Client
+	mgr         *task.Manager
+	list        *service.PRWatchList
+	failures    int
+}
+
+func (w *prWatcher) lockFile() string {
+	return filepath.Join(w.appCtx.PawDir, constants.PRWatchLockFileName)
+}
+
+func (w *prWatcher) run() {
+	if !acquireWatcherLock(w.lockFile(), w.sessionName) {
+		logging.Debug("PR watcher already running for session %s", w.sessionName)
+		return
+	}
 
-		for {
-			windowName, err := getWindowName(tm, windowID)
-			if err != nil {
-				logging.Debug("Window %s no longer exists, stopping PR watcher", windowID)
-				return nil
+	for {
+		if !w.tm.HasSession(w.sessionName) {
+			logging.Debug("Session %s no longer exists, stopping PR watcher", w.sessionName)
+			_ = os.Remove(w.lockFile())
+			return
+		}
+
+		state, err := w.list.Load()
+		if err != nil {
+			logging.Warn("Failed to load PR watch list: %v", err)
+		}
+		if err == nil && len(state.Entries) == 0 {
+			_ = os.Remove(w.lockFile())
+			// Re-check after releasing the lock: a PR may have been added after the
+			// load, while ensurePRWatcher still saw this watcher as running.
+			if state, err := w.list.Load(); err == nil && len(state.Entries) > 0 &&
+				acquireWatcherLock(w.lockFile(), w.sessionName) {
+				continue
 			}
+			logging.Debug("PR watch list is empty, stopping PR watcher")
+			return
+		}
+
+		delay := constants.PRWatchInterval
+		if state != nil {
+			delay = w.poll(state)
+		}
+		time.Sleep(delay)
+	}
+}
 
-			if extractedName, isTask := constants.ExtractTaskName(windowName); isTask {
-				if !constants.MatchesWindowToken(extractedName, taskName) {
-					logging.Debug("Window %s now belongs to different task (%s vs %s), stopping PR watcher",
-						windowID, extractedName, taskName)
-					return nil
-				}
+// poll checks all watched PRs once and returns the delay until the next poll.
+func (w *prWatcher) poll(state *service.PRWatchState) time.Duration {
+	numbers := make([]int, 0, len(state.Entries))
+	etag := state.ETag
+	for _, e := range state.Entries {
+		numbers = append(numbers, e.PRNumber)
+		if e.LastState == "" {
+			// Never observed this PR; a 304 would tell us nothing about it
+			etag = ""
+		}
+	}
+
+	timer := logging.StartTimer("PR status poll")
+	result, err := w.gh.ListPRStatuses(w.appCtx.ProjectDir, numbers, etag)
+	if err != nil {
+		timer.StopWithResult(false, err.Error())
+		return w.backoff(err)
+	}
+	w.failures = 0
+
+	statuses := result.Statuses
+	if result.NotModified {
+		timer.StopWithResult(true, "not modified")
+		statuses = make(map[int]*github.PRStatus, len(state.Entries))
+		for _, e := range state.Entries {
+			statuses[e.PRNumber] = &github.PRStatus{Number: e.PRNumber, State: e.LastState, Merged: e.LastState == "merged"}
+		}
+	} else {
+		timer.StopWithResult(true, fmt.Sprintf("prs=%d", len(statuses)))
+	}
+
+	done := make(map[string]bool)
+	observed := make(map[string]string)
+	for _, e := range state.Entries {
+		status, ok := statuses[e.PRNumber]
+		if !ok {
+			logging.Debug("No status for PR #%d (task=%s)", e.PRNumber, e.TaskName)
+			continue
+		}
+		if w.handleStatus(e, status) {
+			done[e.TaskName] = true
+		} else {
+			observed[e.TaskName] = status.State
+		}
+	}
+
+	if err := w.list.Update(func(s *service.PRWatchState) {
+		entries := s.Entries[:0]
+		for _, e := range s.Entries {
+			if done[e.TaskName] {
+				continue
 			}
+			if st, ok := observed[e.TaskName]; ok {
+				e.LastState = st
+			}
+			entries = append(entries, e)
+		}
+		s.Entries = entries
+		if s.ETag == state.ETag {
+			// Keep a cleared ETag if a PR was added meanwhile
+			s.ETag = result.ETag
+		}
+	}); err != nil {
+		logging.Warn("Failed to save PR watch list: %v", err)
+	}
 
-			status, err := ghClient.GetPRStatus(appCtx.ProjectDir, prNumber)
-			if err != nil {
-				logging.Warn("Failed to check PR status: %v", err)
-			} else if status.Merged {
-				logging.Info("PR merged: task=%s pr=%d", taskName, prNumber)
-				notify.PlaySound(notify.SoundTaskCompleted)
-				_ = notify.Send("PR merged", fmt.Sprintf("✅ %s merged and cleaned up", taskName))
-
-				t, err := mgr.GetTask(taskName)
-				if err != nil {
-					logging.Warn("Failed to load task for cleanup: %v", err)
-					return nil
-				}
-
-				if err := mgr.CleanupTask(t); err != nil {
-					logging.Warn("Failed to clean up task: %v", err)
-				}
-
-				if err := tm.KillWindow(windowID); err != nil {
-					logging.Warn("Failed to kill window: %v", err)
-				}
-				return nil
-			} else if status.State == "closed" {
-				logging.Info("PR closed without merge: task=%s pr=%d", taskName, prNumber)
-				warnName := constants.EmojiWarning + constants.TruncateForWindowName(taskName)
-				if err := renameWindowWithStatus(tm, windowID, warnName, appCtx.PawDir, taskName, "watch-pr", task.StatusWaiting); err != nil {
-					logging.Warn("Failed to rename window for PR warning: %v", err)
-				}
-				return nil
-			} else if status.State == "open" && !strings.HasPrefix(windowName, constants.EmojiWorking) {
-				if !strings.HasPrefix(windowName, constants.EmojiReview) && !strings.HasPrefix(windowName, constants.EmojiWarning) {
-					reviewName := constants.EmojiReview + constants.TruncateForWindowName(taskName)
-					if err := renameWindowWithStatus(tm, windowID, reviewName, appCtx.PawDir, taskName, "watch-pr", task.StatusWaiting); err != nil {
-						logging.Warn("Failed to rename window for PR review: %v", err)
-					}
-				}
+	return constants.PRWatchInterval
+}
+
+// backoff returns the delay after a failed poll, honoring rate limit resets.
+func (w *prWatcher) backoff(err error) time.Duration {
+	w.failures++
+	delay := constants.PRWatchInterval << min(w.failures, 4)
+	if rlErr, ok := github.AsRateLimitError(err); ok {
+		logging.Warn("GitHub rate limit hit, backing off: %v", rlErr)
+		if !rlErr.ResetAt.IsZero() {
+			delay = max(time.Until(rlErr.ResetAt), constants.PRWatchInterval)
+		}
+	} else {
+		logging.Warn("Failed to check PR statuses (attempt %d): %v", w.failures, err)
+	}
+	return min(delay, constants.PRWatchMaxBackoff)
+}
+
+// handleStatus applies a PR status to its task window.
+// Returns true if the PR no longer needs watching.
+func (w *prWatcher) handleStatus(entry service.PRWatchEntry, status *github.PRStatus) bool {
+	t, err := w.mgr.GetTask(entry.TaskName)
+	if err != nil {
+		logging.Debug("Task %s no longer exists, dropping PR #%d from watch list", entry.TaskName, entry.PRNumber)
+		return true
+	}
+
+	windowID, windowName := w.taskWindow(t)
+
+	switch {
+	case status.Merged:
+		logging.Info("PR merged: task=%s pr=%d", entry.TaskName, entry.PRNumber)
+		notify.PlaySound(notify.SoundTaskCompleted)
+		_ = notify.Send("PR merged", fmt.Sprintf("✅ %s merged and cleaned up", entry.TaskName))
+
+		if err := w.mgr.CleanupTask(t); err != nil {
+			logging.Warn("Failed to clean up task: %v", err)
+		}
+		if windowID != "" {
+			if err := w.tm.KillWindow(windowID); err != nil {
+				logging.Warn("Failed to kill window: %v", err)
 			}
+		}
+		return true
 
-			<-ticker.C
+	case status.State == "closed":
+		logging.Info("PR closed without merge: task=%s pr=%d", entry.TaskName, entry.PRNumber)
+		if windowID != "" {
+			warnName := constants.EmojiWarning + constants.TruncateForWindowName(entry.TaskName)
+			if err := renameWindowWithStatus(w.tm, windowID, warnName, w.appCtx.PawDir, entry.TaskName, "watch-prs", task.StatusWaiting); err != nil {
+				logging.Warn("Failed to rename window for PR warning: %v", err)
+			}
 		}
-	},
+		return true
+
+	case status.State == "open" && windowID != "":
+		if !strings.HasPrefix(windowName, constants.EmojiWorking) &&
+			!strings.HasPrefix(windowName, constants.EmojiReview) &&
+			!strings.HasPrefix(windowName, constants.EmojiWarning) {
+			reviewName := constants.EmojiReview + constants.TruncateForWindowName(entry.TaskName)
+			if err := renameWindowWithStatus(w.tm, windowID, reviewName, w.appCtx.PawDir, entry.TaskName, "watch-prs", task.StatusWaiting); err != nil {
+				logging.Warn("Failed to rename window for PR review: %v", err)
+			}
+		}
+	}
+	return false
+}
+
+// taskWindow returns the window ID and name of a task, or empty strings if
+// the task has no live window (e.g. while tmux is restarting).
+func (w *prWatcher) taskWindow(t *task.Task) (string, string) {
+	if t.WindowID == "" {
+		return "", ""
+	}
+	windowName, err := getWindowName(w.tm, t.WindowID)
+	if err != nil {
+		return "", ""
+	}
+	if extractedName, isTask := constants.ExtractTaskName(windowName); isTask {
+		if !constants.MatchesWindowToken(extractedName, t.Name) {
+			logging.Debug("Window %s now belongs to different task (%s vs %s)", t.WindowID, extractedName, t.Name)
+			return "", ""
+		}
+	}
+	return t.WindowID, windowName
+}
+
+// acquireWatcherLock takes the single-instance watcher lock, replacing stale locks.
+func acquireWatcherLock(lockFile, sessionName string) bool {
+	for attempt := 0; attempt < 2; attempt++ {
+		f, err := os.OpenFile(lockFile, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644) //nolint:gosec // G302: lock file just needs to exist
+		if err == nil {
+			_, writeErr := fmt.Fprintf(f, "%s\n%d", sessionName, os.Getpid())
+			closeErr := f.Close()
+			if writeErr != nil || closeErr != nil {
+				_ = os.Remove(lockFile)
+				logging.Warn("Failed to write PR watcher lock: write=%v, close=%v", writeErr, closeErr)
+				return false
+			}
+			return true
+		}
+		if !isStaleLock(lockFile) {
+			return false
+		}
+		logging.Debug("Removing stale PR watcher lock")
+		_ = os.Remove(lockFile)
+	}
+	return false
+}
+
+// startPRWatch adds a PR to the session watch list and makes sure the watcher runs.
+func startPRWatch(appCtx *app.App, sessionName, taskName string, prNumber int) {
+	if err := service.NewPRWatchList(appCtx.PawDir).Add(taskName, prNumber); err != nil {
+		logging.Warn("Failed to add PR to watch list: %v", err)
+		return
+	}
+	logging.Debug("PR #%d added to watch list for task=%s", prNumber, taskName)
+	ensurePRWatcher(appCtx, sessionName)
+}
+
+// resumePRWatcher restarts the session PR watcher if any PRs are still being watched.
+func resumePRWatcher(appCtx *app.App, sessionName string) {
+	state, err := service.NewPRWatchList(appCtx.PawDir).Load()
+	if err != nil || len(state.Entries) == 0 {
+		return
+	}
+	logging.Debug("Resuming PR watcher for %d PRs", len(state.Entries))
+	ensurePRWatcher(appCtx, sessionName)
+}
+
+// ensurePRWatcher starts the session PR watcher unless one is already running.
+func ensurePRWatcher(appCtx *app.App, sessionName string) {
+	lockFile := filepath.Join(appCtx.PawDir, constants.PRWatchLockFileName)
+	if _, err := os.Stat(lockFile); err == nil && !isStaleLock(lockFile) {
+		logging.Debug("PR watcher already running")
+		return
+	}
+
+	watchCmd := exec.Command(getPawBin(), "internal", "watch-prs", sessionName) //nolint:gosec // G204: pawBin is from getPawBin()
+	watchCmd.Dir = appCtx.ProjectDir
+	watchCmd.Env = append(os.Environ(),
+		"PAW_DIR="+appCtx.PawDir,
+		"PROJECT_DIR="+appCtx.ProjectDir,
+	)
+	if err := watchCmd.Start(); err != nil {
+		logging.Warn("Failed to start PR watcher: %v", err)
+	} else {
+		logging.Debug("PR watcher started for session=%s", sessionName)
+	}
 }
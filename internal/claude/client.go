@@ -33,6 +33,10 @@ type Client interface {
 	// GenerateSummary generates a brief summary of the task work from pane content.
 	GenerateSummary(paneContent string) (string, error)
 
+	// GenerateCommitMessage writes a commit message for a staged diff,
+	// following the conventions in guidelines (e.g. the commit-message prompt).
+	GenerateCommitMessage(diff, guidelines string) (string, error)
+
 	// WaitForReady waits for Claude to be ready in a tmux pane.
 	WaitForReady(tm tmux.Client, target string) error
 
@@ -123,6 +127,46 @@ Write a concise summary only. Do NOT include any header like "Summary:" or "**Su
 	return summary, nil
 }
 
+// CommitMessageTimeout is the timeout for commit message generation.
+const CommitMessageTimeout = 30 * time.Second
+
+// GenerateCommitMessage generates a commit message for the staged diff.
+func (c *claudeClient) GenerateCommitMessage(diff, guidelines string) (string, error) {
+	// Truncate the diff if too long (keep the beginning, which names the files)
+	if len(diff) > constants.SummaryMaxLen {
+		diff = diff[:constants.SummaryMaxLen]
+	}
+
+	prompt := fmt.Sprintf(`Write a git commit message for the staged changes below.
+
+- First line: a concise summary (max 72 chars)
+- Optionally a blank line followed by a short body explaining why
+- Follow the commit conventions in the guidelines when they apply
+
+Guidelines:
+%s
+
+Staged diff:
+%s
+
+Output the commit message only, without code fences or any commentary.`, guidelines, diff)
+
+	logging.Trace("GenerateCommitMessage: starting with diff length=%d", len(diff))
+
+	msg, err := c.runClaude(prompt, CommitMessageTimeout)
+	if err != nil {
+		logging.Debug("GenerateCommitMessage: failed: %v", err)
+		return "", err
+	}
+	msg = strings.TrimSpace(strings.Trim(strings.TrimSpace(msg), "`"))
+	if msg == "" {
+		return "", errors.New("empty commit message")
+	}
+
+	logging.Debug("GenerateCommitMessage: success, length=%d", len(msg))
+	return msg, nil
+}
+
 // modelAttempt defines a model escalation attempt configuration.
 type modelAttempt struct {
 	model    string
@@ -34,6 +34,16 @@ func GlobalWorkspacesDir() string {
 	return filepath.Join(home, constants.GlobalDataDir, constants.GlobalWorkspacesDir)
 }
 
+// GlobalInboxDir returns the directory of questions waiting for an answer
+// across all sessions ($HOME/.local/share/paw/inbox).
+func GlobalInboxDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, constants.GlobalDataDir, constants.GlobalInboxDir)
+}
+
 // ProjectWorkspaceID generates a unique workspace ID for a project directory.
 // Uses a hash of the absolute path to ensure uniqueness while keeping names reasonable.
 func ProjectWorkspaceID(projectDir string) string {
@@ -185,6 +195,75 @@ type Config struct {
 	LogFormat       string `yaml:"log_format"`
 	LogMaxSizeMB    int    `yaml:"log_max_size_mb"`
 	LogMaxBackups   int    `yaml:"log_max_backups"`
+	FetchRemote     string `yaml:"fetch_remote"`    // Remote to fetch/sync main from
+	PushRemote      string `yaml:"push_remote"`     // Remote to push task branches and main to
+	PRRepo          string `yaml:"pr_repo"`         // "owner/name" PRs are opened against (empty: derived from fetch_remote)
+	TargetBranch    string `yaml:"target_branch"`   // Branch tasks merge into (empty: detected main branch)
+	CommitTrailers  string `yaml:"commit_trailers"` // Comma-separated provenance trailers: task, model, co-author (or "none")
+	CoAuthor        string `yaml:"co_author"`       // Co-authored-by value (empty: constants.DefaultCoAuthor)
+	GitNotes        bool   `yaml:"git_notes"`       // Attach a provenance note (refs/notes/paw) to merge commits
+	KanbanColumns   string `yaml:"kanban_columns"`  // Comma-separated kanban columns; "+" merges statuses into one column
+	Theme           string `yaml:"theme"`           // Color theme name (empty: auto; project config overrides the global one)
+
+	// Keybindings remaps PAW actions (action name -> comma-separated keys, or "none").
+	Keybindings map[string]string `yaml:"keybindings"`
+
+	// PaletteCommands adds command palette entries (name -> shell command).
+	PaletteCommands map[string]string `yaml:"palette_commands"`
+}
+
+// Provenance trailer names accepted in commit_trailers.
+const (
+	TrailerTask     = "task"
+	TrailerModel    = "model"
+	TrailerCoAuthor = "co-author"
+	TrailersNone    = "none"
+)
+
+// DefaultCommitTrailers is the default commit_trailers value.
+const DefaultCommitTrailers = TrailerTask + "," + TrailerModel
+
+// HasCommitTrailer reports whether the named provenance trailer is enabled.
+func (c *Config) HasCommitTrailer(name string) bool {
+	if c == nil {
+		return false
+	}
+	for _, trailer := range strings.Split(c.CommitTrailers, ",") {
+		if strings.TrimSpace(trailer) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// GetCoAuthor returns the Co-authored-by trailer value.
+func (c *Config) GetCoAuthor() string {
+	if c == nil || c.CoAuthor == "" {
+		return constants.DefaultCoAuthor
+	}
+	return c.CoAuthor
+}
+
+// GetFetchRemote returns the remote main is fetched and synced from.
+func (c *Config) GetFetchRemote() string {
+	if c == nil || c.FetchRemote == "" {
+		return constants.DefaultRemote
+	}
+	return c.FetchRemote
+}
+
+// GetPushRemote returns the remote branches are pushed to.
+func (c *Config) GetPushRemote() string {
+	if c == nil || c.PushRemote == "" {
+		return constants.DefaultRemote
+	}
+	return c.PushRemote
+}
+
+// IsValidRepoName reports whether repo has the "owner/name" form.
+func IsValidRepoName(repo string) bool {
+	owner, name, ok := strings.Cut(repo, "/")
+	return ok && owner != "" && name != "" && !strings.ContainsAny(name, "/ ") && !strings.Contains(owner, " ")
 }
 
 // Normalize validates configuration values, applying safe defaults when needed.
@@ -211,15 +290,53 @@ func (c *Config) Normalize() []string {
 		c.LogMaxBackups = 3
 	}
 
+	c.FetchRemote = strings.TrimSpace(c.FetchRemote)
+	if c.FetchRemote == "" {
+		c.FetchRemote = constants.DefaultRemote
+	}
+	c.PushRemote = strings.TrimSpace(c.PushRemote)
+	if c.PushRemote == "" {
+		c.PushRemote = constants.DefaultRemote
+	}
+	c.TargetBranch = strings.TrimSpace(c.TargetBranch)
+	c.PRRepo = strings.TrimSpace(c.PRRepo)
+	if c.PRRepo != "" && !IsValidRepoName(c.PRRepo) {
+		warnings = append(warnings, fmt.Sprintf("invalid pr_repo %q (expected owner/name); ignoring", c.PRRepo))
+		c.PRRepo = ""
+	}
+
+	c.CoAuthor = strings.TrimSpace(c.CoAuthor)
+	c.KanbanColumns = strings.TrimSpace(c.KanbanColumns)
+	c.Theme = strings.TrimSpace(c.Theme)
+	c.CommitTrailers = strings.TrimSpace(c.CommitTrailers)
+	if c.CommitTrailers != "" && c.CommitTrailers != TrailersNone {
+		var trailers []string
+		for _, trailer := range strings.Split(c.CommitTrailers, ",") {
+			trailer = strings.TrimSpace(trailer)
+			switch trailer {
+			case TrailerTask, TrailerModel, TrailerCoAuthor:
+				trailers = append(trailers, trailer)
+			case "":
+			default:
+				warnings = append(warnings, fmt.Sprintf("unknown commit trailer %q; ignoring", trailer))
+			}
+		}
+		c.CommitTrailers = strings.Join(trailers, ",")
+	}
+
 	return warnings
 }
 
 // DefaultConfig returns the default configuration.
 func DefaultConfig() *Config {
 	return &Config{
-		LogFormat:     constants.LogFormatText,
-		LogMaxSizeMB:  10,
-		LogMaxBackups: 3,
+		LogFormat:      constants.LogFormatText,
+		LogMaxSizeMB:   10,
+		LogMaxBackups:  3,
+		FetchRemote:    constants.DefaultRemote,
+		PushRemote:     constants.DefaultRemote,
+		CommitTrailers: DefaultCommitTrailers,
+		GitNotes:       true,
 	}
 }
 
@@ -229,6 +346,18 @@ func (c *Config) Clone() *Config {
 		return nil
 	}
 	clone := *c
+	if c.Keybindings != nil {
+		clone.Keybindings = make(map[string]string, len(c.Keybindings))
+		for action, keys := range c.Keybindings {
+			clone.Keybindings[action] = keys
+		}
+	}
+	if c.PaletteCommands != nil {
+		clone.PaletteCommands = make(map[string]string, len(c.PaletteCommands))
+		for name, command := range c.PaletteCommands {
+			clone.PaletteCommands[name] = command
+		}
+	}
 	return &clone
 }
 
@@ -272,13 +401,78 @@ log_format: %s
 log_max_size_mb: %d
 log_max_backups: %d
 
+# Remotes: fetch_remote is synced/merged from, push_remote receives pushes.
+# For fork workflows use fetch_remote: upstream and push_remote: origin.
+fetch_remote: %s
+push_remote: %s
+
+# PR target repository (owner/name). Defaults to the fetch_remote repository.
+# pr_repo: owner/name
+
+# Branch tasks start from, merge into and open PRs against (default: detected main branch).
+# Can be overridden per task in the task input options.
+# target_branch: release/1.0
+
+# Provenance of merge commits: trailers (task, model, co-author, or none)
+# and a git note under refs/notes/paw with the task content and results.
+commit_trailers: %s
+git_notes: %t
+# co_author: Claude <noreply@anthropic.com>
+
+# Kanban columns (global config only). Statuses: backlog, blocked, working,
+# waiting, warning, review, done. Use "+" to show several statuses in one column.
+# kanban_columns: backlog, blocked, working, waiting+warning, review, done
+
+# Color theme: auto, dark, light, a preset (solarized-dark, solarized-light,
+# high-contrast, colorblind) or a ~/.config/paw/themes/<name>.toml file.
+# Set it in a project config to tell sessions of different projects apart.
+# theme: solarized-dark
+
+# Keybindings: remap or disable PAW actions (see "paw check" for validation).
+# Keys use tmux (C-g, M-Left) or bubbletea (ctrl+g, alt+left) notation;
+# several keys are comma-separated and "none" disables an action.
+# keybindings:
+#   finish-task: C-x
+#   toggle-git: none
+#   kanban-sync: S
+
+# Command palette (⌃P) entries: name -> shell command. $TASK_NAME and
+# $WORKTREE_DIR are set for the current task; a leading "paw" runs this PAW.
+# Output is shown in a popup. Names cannot contain ":".
+# palette_commands:
+#   Run tests: make test
+#   Task status: paw logs --task $TASK_NAME --level warn
+
 # Hooks (optional) (supports multi-line command with ': |')
 # pre_worktree_hook: echo "pre worktree"
 # pre_task_hook: echo "pre task"
 # post_task_hook: echo "post task"
 # pre_merge_hook: echo "pre merge"
 # post_merge_hook: echo "post merge"
-`, c.LogFormat, c.LogMaxSizeMB, c.LogMaxBackups)
+`, c.LogFormat, c.LogMaxSizeMB, c.LogMaxBackups, c.GetFetchRemote(), c.GetPushRemote(), c.commitTrailersValue(), c.GitNotes)
+
+	if c.PRRepo != "" {
+		content += fmt.Sprintf("pr_repo: %s\n", c.PRRepo)
+	}
+	if c.TargetBranch != "" {
+		content += fmt.Sprintf("target_branch: %s\n", c.TargetBranch)
+	}
+	if c.CoAuthor != "" {
+		content += fmt.Sprintf("co_author: %s\n", c.CoAuthor)
+	}
+	if c.KanbanColumns != "" {
+		content += fmt.Sprintf("kanban_columns: %s\n", c.KanbanColumns)
+	}
+	if c.Theme != "" {
+		content += fmt.Sprintf("theme: %s\n", c.Theme)
+	}
+
+	if len(c.Keybindings) > 0 {
+		content += formatStringMap("keybindings", c.Keybindings)
+	}
+	if len(c.PaletteCommands) > 0 {
+		content += formatStringMap("palette_commands", c.PaletteCommands)
+	}
 
 	// Add hooks if set
 	if c.PreWorktreeHook != "" {
@@ -305,6 +499,14 @@ log_max_backups: %d
 	return nil
 }
 
+// commitTrailersValue returns commit_trailers as saved; an empty list is written as "none".
+func (c *Config) commitTrailersValue() string {
+	if c.CommitTrailers == "" {
+		return TrailersNone
+	}
+	return c.CommitTrailers
+}
+
 // Exists checks if a configuration file exists in the given paw directory.
 func Exists(pawDir string) bool {
 	configPath := filepath.Join(pawDir, constants.ConfigFileName)
@@ -3,6 +3,7 @@ package config
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -35,6 +36,15 @@ func parseConfig(content string) *Config {
 		key := strings.TrimSpace(parts[0])
 		value := strings.TrimSpace(parts[1])
 
+		if key == "keybindings" && value == "" {
+			cfg.Keybindings = parseStringMap(lines, &i)
+			continue
+		}
+		if key == "palette_commands" && value == "" {
+			cfg.PaletteCommands = parseStringMap(lines, &i)
+			continue
+		}
+
 		// Skip unsupported nested blocks to avoid mis-parsing indented content.
 		if value == "" && hasIndentedBlock(lines, i) {
 			skipIndentedBlock(lines, &i)
@@ -98,6 +108,26 @@ func parseConfig(content string) *Config {
 			if parsed, err := strconv.Atoi(value); err == nil {
 				cfg.LogMaxBackups = parsed
 			}
+		case "fetch_remote":
+			cfg.FetchRemote = value
+		case "push_remote":
+			cfg.PushRemote = value
+		case "pr_repo":
+			cfg.PRRepo = value
+		case "target_branch":
+			cfg.TargetBranch = value
+		case "commit_trailers":
+			cfg.CommitTrailers = value
+		case "co_author":
+			cfg.CoAuthor = value
+		case "kanban_columns":
+			cfg.KanbanColumns = value
+		case "theme":
+			cfg.Theme = value
+		case "git_notes":
+			if parsed, err := strconv.ParseBool(value); err == nil {
+				cfg.GitNotes = parsed
+			}
 		}
 	}
 
@@ -159,6 +189,62 @@ func skipIndentedBlock(lines []string, i *int) {
 	}
 }
 
+// parseStringMap parses the indented "name: value" lines of a block map such
+// as keybindings or palette_commands. Values may be quoted.
+func parseStringMap(lines []string, i *int) map[string]string {
+	entries := make(map[string]string)
+	baseIndent := getIndentLevel(lines, *i)
+	*i++ // Move past the parent line
+
+	for *i < len(lines) {
+		line := lines[*i]
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			*i++
+			continue
+		}
+		if countLeadingSpaces(line) <= baseIndent {
+			break
+		}
+		*i++
+		name, value, ok := strings.Cut(trimmed, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		// Allow quoting values that YAML would otherwise misread (e.g. "#")
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		}
+		entries[strings.TrimSpace(name)] = value
+	}
+	return entries
+}
+
+// formatStringMap formats a block map (e.g. keybindings) for saving.
+func formatStringMap(key string, entries map[string]string) string {
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	sb.WriteString(key + ":\n")
+	for _, name := range names {
+		fmt.Fprintf(&sb, "  %s: %s\n", name, quoteValue(entries[name]))
+	}
+	return sb.String()
+}
+
+// quoteValue quotes block map values that would not survive parsing unquoted.
+func quoteValue(value string) string {
+	if strings.ContainsAny(value, "#\"'") || strings.TrimSpace(value) != value {
+		return strconv.Quote(value)
+	}
+	return value
+}
+
 // formatHook formats a hook command for saving.
 // Multi-line values use YAML-like '|' syntax.
 func formatHook(key, hook string) string {
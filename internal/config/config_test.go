@@ -125,6 +125,184 @@ func TestConfigNormalize_InvalidLogFormat(t *testing.T) {
 	}
 }
 
+func TestConfigNormalize_Remotes(t *testing.T) {
+	cfg := &Config{
+		LogFormat:   "text",
+		FetchRemote: " upstream ",
+		PRRepo:      "not-a-repo",
+	}
+
+	warnings := cfg.Normalize()
+
+	if cfg.FetchRemote != "upstream" {
+		t.Errorf("FetchRemote = %q, want %q", cfg.FetchRemote, "upstream")
+	}
+	if cfg.PushRemote != "origin" {
+		t.Errorf("PushRemote = %q, want %q", cfg.PushRemote, "origin")
+	}
+	if cfg.PRRepo != "" {
+		t.Errorf("PRRepo = %q, want empty", cfg.PRRepo)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("warnings len = %d, want 1", len(warnings))
+	}
+}
+
+func TestConfigRemotes_NilSafe(t *testing.T) {
+	var cfg *Config
+	if got := cfg.GetFetchRemote(); got != "origin" {
+		t.Errorf("GetFetchRemote() = %q, want %q", got, "origin")
+	}
+	if got := cfg.GetPushRemote(); got != "origin" {
+		t.Errorf("GetPushRemote() = %q, want %q", got, "origin")
+	}
+}
+
+func TestRoundTrip_Remotes(t *testing.T) {
+	tempDir := t.TempDir()
+
+	cfg := DefaultConfig()
+	cfg.FetchRemote = "upstream"
+	cfg.PushRemote = "fork"
+	cfg.PRRepo = "owner/repo"
+	if err := cfg.Save(tempDir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(tempDir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.FetchRemote != "upstream" || loaded.PushRemote != "fork" || loaded.PRRepo != "owner/repo" {
+		t.Errorf("roundtrip failed: fetch=%q push=%q pr_repo=%q", loaded.FetchRemote, loaded.PushRemote, loaded.PRRepo)
+	}
+}
+
+func TestRoundTrip_TargetBranch(t *testing.T) {
+	tempDir := t.TempDir()
+
+	cfg := DefaultConfig()
+	cfg.TargetBranch = "release/1.0"
+	if err := cfg.Save(tempDir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(tempDir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.TargetBranch != "release/1.0" {
+		t.Errorf("TargetBranch = %q, want %q", loaded.TargetBranch, "release/1.0")
+	}
+}
+
+func TestConfigNormalize_CommitTrailers(t *testing.T) {
+	cfg := &Config{LogFormat: "text", CommitTrailers: " task, bogus ,co-author "}
+
+	warnings := cfg.Normalize()
+
+	if cfg.CommitTrailers != "task,co-author" {
+		t.Errorf("CommitTrailers = %q, want %q", cfg.CommitTrailers, "task,co-author")
+	}
+	if !cfg.HasCommitTrailer(TrailerCoAuthor) || cfg.HasCommitTrailer(TrailerModel) {
+		t.Errorf("HasCommitTrailer mismatch for %q", cfg.CommitTrailers)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("warnings len = %d, want 1", len(warnings))
+	}
+}
+
+func TestRoundTrip_Provenance(t *testing.T) {
+	tempDir := t.TempDir()
+
+	cfg := DefaultConfig()
+	cfg.CommitTrailers = ""
+	cfg.GitNotes = false
+	cfg.CoAuthor = "Bot <bot@example.com>"
+	if err := cfg.Save(tempDir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(tempDir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.CommitTrailers != TrailersNone || loaded.GitNotes || loaded.CoAuthor != "Bot <bot@example.com>" {
+		t.Errorf("roundtrip failed: trailers=%q notes=%v co_author=%q", loaded.CommitTrailers, loaded.GitNotes, loaded.CoAuthor)
+	}
+	if loaded.HasCommitTrailer(TrailerTask) {
+		t.Error("HasCommitTrailer(task) = true with trailers disabled")
+	}
+}
+
+func TestRoundTrip_KanbanColumns(t *testing.T) {
+	tempDir := t.TempDir()
+
+	cfg := DefaultConfig()
+	cfg.KanbanColumns = "working, waiting+warning, done"
+	if err := cfg.Save(tempDir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(tempDir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.KanbanColumns != cfg.KanbanColumns {
+		t.Errorf("KanbanColumns = %q, want %q", loaded.KanbanColumns, cfg.KanbanColumns)
+	}
+}
+
+func TestRoundTrip_Theme(t *testing.T) {
+	tempDir := t.TempDir()
+
+	cfg := DefaultConfig()
+	cfg.Theme = "solarized-dark"
+	if err := cfg.Save(tempDir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(tempDir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.Theme != cfg.Theme {
+		t.Errorf("Theme = %q, want %q", loaded.Theme, cfg.Theme)
+	}
+}
+
+func TestRoundTrip_Keybindings(t *testing.T) {
+	tempDir := t.TempDir()
+
+	cfg := DefaultConfig()
+	cfg.Keybindings = map[string]string{
+		"finish-task": "C-x, M-f",
+		"toggle-git":  "none",
+		"search":      "#",
+	}
+	cfg.PostTaskHook = "echo done"
+	if err := cfg.Save(tempDir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(tempDir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(loaded.Keybindings) != len(cfg.Keybindings) {
+		t.Fatalf("Keybindings = %v, want %v", loaded.Keybindings, cfg.Keybindings)
+	}
+	for action, keys := range cfg.Keybindings {
+		if loaded.Keybindings[action] != keys {
+			t.Errorf("Keybindings[%s] = %q, want %q", action, loaded.Keybindings[action], keys)
+		}
+	}
+	// The block must not swallow the settings that follow it
+	if loaded.PostTaskHook != "echo done" {
+		t.Errorf("PostTaskHook = %q, want %q", loaded.PostTaskHook, "echo done")
+	}
+}
+
 func TestLoad_NoConfigFile(t *testing.T) {
 	tempDir := t.TempDir()
 
@@ -445,3 +623,40 @@ func TestEnsureConfigInDir_CreatesDirectoryIfMissing(t *testing.T) {
 		t.Fatal("Config file should exist after ensureConfigInDir")
 	}
 }
+
+func TestRoundTrip_PaletteCommands(t *testing.T) {
+	tempDir := t.TempDir()
+
+	cfg := DefaultConfig()
+	cfg.PaletteCommands = map[string]string{
+		"Run tests":   "make test",
+		"Task status": `paw logs --task "$TASK_NAME"`,
+		"Open PR":     "gh pr view --web # opens a browser",
+	}
+	cfg.Keybindings = map[string]string{"toggle-git": "none"}
+	if err := cfg.Save(tempDir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(tempDir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(loaded.PaletteCommands) != len(cfg.PaletteCommands) {
+		t.Fatalf("PaletteCommands = %v, want %v", loaded.PaletteCommands, cfg.PaletteCommands)
+	}
+	for name, command := range cfg.PaletteCommands {
+		if loaded.PaletteCommands[name] != command {
+			t.Errorf("PaletteCommands[%s] = %q, want %q", name, loaded.PaletteCommands[name], command)
+		}
+	}
+	if loaded.Keybindings["toggle-git"] != "none" {
+		t.Errorf("Keybindings = %v", loaded.Keybindings)
+	}
+
+	clone := loaded.Clone()
+	clone.PaletteCommands["Run tests"] = "changed"
+	if loaded.PaletteCommands["Run tests"] != "make test" {
+		t.Error("Clone() should deep-copy PaletteCommands")
+	}
+}
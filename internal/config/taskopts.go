@@ -62,6 +62,13 @@ type TaskOptions struct {
 
 	// BranchName specifies a custom branch name (default: auto-generated from task content)
 	BranchName string `json:"branch_name,omitempty"`
+
+	// SourceRef checks out an existing branch or PR instead of creating a new branch
+	// (local branch, remote/branch, #N, refs/pull/N/head or a PR URL)
+	SourceRef string `json:"source_ref,omitempty"`
+
+	// TargetBranch overrides the project's target branch for merges, PRs and sync
+	TargetBranch string `json:"target_branch,omitempty"`
 }
 
 // DefaultTaskOptions returns the default task options.
@@ -132,6 +139,14 @@ func (o *TaskOptions) Merge(other *TaskOptions) {
 	if other.BranchName != "" {
 		o.BranchName = other.BranchName
 	}
+
+	if other.SourceRef != "" {
+		o.SourceRef = other.SourceRef
+	}
+
+	if other.TargetBranch != "" {
+		o.TargetBranch = other.TargetBranch
+	}
 }
 
 // Clone creates a deep copy of the task options.
@@ -140,6 +155,8 @@ func (o *TaskOptions) Clone() *TaskOptions {
 		Model:           o.Model,
 		PreWorktreeHook: o.PreWorktreeHook,
 		BranchName:      o.BranchName,
+		SourceRef:       o.SourceRef,
+		TargetBranch:    o.TargetBranch,
 	}
 
 	if o.DependsOn != nil {
@@ -150,6 +150,23 @@ func TestTaskOptionsMerge(t *testing.T) {
 	}
 }
 
+func TestTaskOptionsMergeTargetBranch(t *testing.T) {
+	base := DefaultTaskOptions()
+	base.Merge(&TaskOptions{TargetBranch: "release/1.0"})
+	if base.TargetBranch != "release/1.0" {
+		t.Errorf("Expected target branch 'release/1.0' after merge, got '%s'", base.TargetBranch)
+	}
+
+	base.Merge(&TaskOptions{})
+	if base.TargetBranch != "release/1.0" {
+		t.Errorf("Expected empty target branch to keep 'release/1.0', got '%s'", base.TargetBranch)
+	}
+
+	if clone := base.Clone(); clone.TargetBranch != "release/1.0" {
+		t.Errorf("Clone target branch mismatch: %s", clone.TargetBranch)
+	}
+}
+
 func TestTaskOptionsMergeNil(t *testing.T) {
 	base := DefaultTaskOptions()
 	originalModel := base.Model
@@ -158,7 +158,14 @@ const (
 
 // PR watch interval
 const (
-	PRWatchInterval = 1 * time.Minute
+	PRWatchInterval   = 1 * time.Minute
+	PRWatchMaxBackoff = 15 * time.Minute // Upper bound for error/rate-limit backoff
+)
+
+// Lock settings for read-modify-write of shared files (PR watch list, backlog, projects)
+const (
+	FileLockMaxRetries    = 500                   // Maximum retries to acquire a file lock (5 seconds)
+	FileLockRetryInterval = 10 * time.Millisecond // Interval between file lock retries
 )
 
 // Hook execution timeout
@@ -177,17 +184,19 @@ const (
 const (
 	DefaultMainBranch = "main"
 	DefaultWorkMode   = "worktree"
+	DefaultRemote     = "origin"
 )
 
 // End-task action names
 const (
-	ActionDone       = "done"
-	ActionDrop       = "drop"
-	ActionKeep       = "keep"
-	ActionMerge      = "merge"
-	ActionMergePush  = "merge-push"
-	ActionPR         = "pr"
-	ActionCreateMain = "create-main" // Create main branch and merge
+	ActionDone         = "done"
+	ActionDrop         = "drop"
+	ActionKeep         = "keep"
+	ActionMerge        = "merge"
+	ActionMergePush    = "merge-push"
+	ActionPR           = "pr"
+	ActionCreateMain   = "create-main"   // Create main branch and merge
+	ActionPushUpstream = "push-upstream" // Push to the existing branch/PR the task was checked out from
 )
 
 // Log format constants
@@ -201,6 +210,10 @@ const (
 	GlobalConfigDir     = ".config/paw"       // Global config directory ($HOME/.config/paw)
 	GlobalDataDir       = ".local/share/paw"  // Base directory for global PAW data
 	GlobalWorkspacesDir = "workspaces"        // Subdirectory for project workspaces
+	GlobalThemesDir     = "themes"            // Subdirectory of GlobalConfigDir for theme files
+	ProjectRegistryFile = "projects.json"     // Project registry in GlobalConfigDir
+	ProjectRegistryLock = "projects.lock"     // Guards read-modify-write of the project registry
+	GlobalInboxDir      = "inbox"             // Subdirectory of GlobalDataDir for waiting questions
 )
 
 // Directory and file names
@@ -217,6 +230,7 @@ const (
 	TabLockDirName        = ".tab-lock"
 	WindowIDFileName      = "window_id"
 	PRFileName            = ".pr"
+	UpstreamFileName      = ".upstream"
 	GitRepoMarker         = ".is-git-repo"
 	GlobalPromptLink      = ".global-prompt"
 	ClaudeLink            = ".claude"
@@ -230,16 +244,24 @@ const (
 	ProjectSwitchFileName = ".project-switch"      // Temp file for project picker to signal switch target
 	ProjectPathFileName   = ".project-path"        // Stores project path for global workspaces
 	TaskNameSelectionFile = ".task-name-selection" // Temp file for Alt+Enter task name input
+	PRWatchFileName       = "pr-watch.json"        // Persisted list of PRs watched by the session watcher
+	PRWatchLockFileName   = "pr-watch.lock"        // Single-instance lock for the session PR watcher
+	PRWatchListLockName   = "pr-watch-list.lock"   // Guards read-modify-write of the PR watch list
+	KanbanFilterFileName  = "kanban-filter.json"   // Persisted kanban filter (in the global config dir)
+	BacklogFileName       = "backlog.json"         // Drafted tasks that have not been started
+	BacklogLockName       = "backlog.lock"         // Guards read-modify-write of the backlog
 
 	// Task agent directory file names
 	OriginLinkName          = "origin"           // Symlink to project root
 	WorktreeDirName         = "worktree"         // Git worktree directory
 	StatusFileName          = ".status"          // Task status file (working/waiting/done)
+	BlockedFileName         = ".blocked"         // Name of the dependency a waiting task is blocked on
 	SessionStartedFile      = ".session-started" // Session marker file
 	AgentSystemPromptFile   = ".system-prompt"   // Agent's system prompt file (in agent dir)
 	AgentUserPromptFile     = ".user-prompt"     // Agent's user prompt file (in agent dir)
 	VerifyLogFile           = ".verify.log"      // Verify log file
 	VerifyJSONFile          = ".verify.json"     // Verify JSON result file
+	ReviewFileName          = ".review.json"     // Diff viewer review comments (drafts and submitted)
 	StartAgentScriptName    = "start-agent"      // Agent start script
 )
 
@@ -257,6 +279,7 @@ const (
 const (
 	TmuxSocketPrefix = "paw-"
 	NewWindowName    = EmojiNew + "main"
+	OverviewWindow   = "🔭overview"
 )
 
 // Pane capture settings
@@ -288,6 +311,31 @@ const (
 	MergeStashMessage = "paw-merge-temp"
 )
 
+// Backport settings
+const (
+	MergedTaskRefPrefix = "refs/paw/merged/" // Ref to the squash commit of a merged task
+	BackportDirName     = "backports"        // Temporary worktrees for backports (under PAW dir)
+)
+
+// Review settings
+const (
+	ReviewSnapshotRefPrefix = "refs/paw/review/" // Ref to the commit a task was last reviewed at
+)
+
+// Kanban card settings
+const (
+	CardDetailsTTL = 10 * time.Second // How long kanban card details (diff, commits, PR, review) are reused
+)
+
+// Provenance settings
+const (
+	ProvenanceNotesRef = "refs/notes/paw"                 // git notes ref holding task provenance of merged commits
+	TrailerTask        = "Paw-Task"                       // Commit trailer naming the task
+	TrailerModel       = "Paw-Model"                      // Commit trailer naming the agent model
+	TrailerCoAuthor    = "Co-authored-by"                 // Commit trailer crediting the agent
+	DefaultCoAuthor    = "Claude <noreply@anthropic.com>" // Co-authored-by value when co_author is unset
+)
+
 // commitTypeMapping defines the mapping from task name patterns to commit types.
 type commitTypeMapping struct {
 	prefix     string
@@ -444,6 +492,10 @@ const (
 	PopupWidthPR  = PopupWidthFull
 	PopupHeightPR = PopupHeightFull
 
+	// Large size for the diff viewer popup (opened from the kanban board).
+	PopupWidthDiff  = "90%"
+	PopupHeightDiff = "90%"
+
 	// Compact size for the finish picker popup.
 	PopupWidthFinish  = "80%"
 	PopupHeightFinish = "15"
@@ -455,6 +507,26 @@ const (
 	// Compact size for the task name input popup.
 	PopupWidthTaskName  = "60%"
 	PopupHeightTaskName = "10"
+
+	// Size for the output popup of command palette commands.
+	PopupWidthPaletteOutput  = "80%"
+	PopupHeightPaletteOutput = "60%"
+
+	// Size for the inbox of waiting agent questions.
+	PopupWidthInbox  = "80%"
+	PopupHeightInbox = "60%"
+
+	// Size for the task timeline popup.
+	PopupWidthTimeline  = "90%"
+	PopupHeightTimeline = "70%"
+
+	// Size for the task statistics popup.
+	PopupWidthStats  = "90%"
+	PopupHeightStats = "80%"
+
+	// Size for the bulk action popup.
+	PopupWidthBulk  = "70%"
+	PopupHeightBulk = "60%"
 )
 
 // Pane sizes for split panes
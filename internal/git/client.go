@@ -8,6 +8,8 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -29,9 +31,12 @@ type Client interface {
 	GetRepoRoot(dir string) (string, error)
 	GetMainBranch(dir string) string
 	HasRemote(dir, remote string) bool
+	ListRemotes(dir string) ([]string, error)
+	GetRemoteURL(dir, remote string) (string, error)
 
 	// Worktree
 	WorktreeAdd(projectDir, worktreeDir, branch string, createBranch bool) error
+	WorktreeAddFrom(projectDir, worktreeDir, branch, startPoint string) error // Creates branch at startPoint
 	WorktreeRemove(projectDir, worktreeDir string, force bool) error
 	WorktreePrune(projectDir string) error
 	WorktreeList(projectDir string) ([]Worktree, error)
@@ -44,12 +49,16 @@ type Client interface {
 	BranchCreateOrphan(dir, branch string) error // Create orphan branch (no parent)
 	GetCurrentBranch(dir string) (string, error)
 	GetHeadCommit(dir string) (string, error)
+	GetUpstream(dir, branch string) (string, error) // Returns e.g. "origin/feature" for the branch's upstream
+	SetUpstream(dir, branch, upstream string) error // Sets the branch's upstream (e.g. "origin/feature")
+	RefExists(dir, ref string) bool                 // Checks whether any ref/commit-ish resolves
 
 	// Changes
 	HasChanges(dir string) bool
 	HasStagedChanges(dir string) bool
 	HasUntrackedFiles(dir string) bool
 	GetUntrackedFiles(dir string) ([]string, error)
+	ListFiles(dir string) ([]string, error) // Tracked and untracked files, without ignored ones
 	StashCreate(dir string) (string, error)
 	StashApply(dir, stashHash string) error
 	StashPush(dir, message string) error
@@ -62,11 +71,16 @@ type Client interface {
 	Add(dir, path string) error
 	AddAll(dir string) error
 	Commit(dir, message string) error
+	CommitAmend(dir, message string) error // Empty message keeps HEAD's message
 	GetDiffStat(dir string) (string, error)
+	DiffNumstat(dir, base string) (DiffStat, error)           // Worktree changes since the merge base of base and HEAD
+	ApplyPatch(dir, patch string, cached, reverse bool) error // Applies a patch to the index (cached) or worktree
+	DiscardPath(dir, path string, untracked bool) error       // Drops worktree changes; removes untracked files
 
 	// Remote
 	Push(dir, remote, branch string, setUpstream bool) error
 	Fetch(dir, remote string) error
+	FetchRef(dir, remote, ref string) (string, error) // Fetches a single ref and returns its commit
 	Pull(dir string) error
 
 	// Merge
@@ -79,11 +93,17 @@ type Client interface {
 	CheckoutTheirs(dir, path string) error
 	FindMergeCommit(dir, branch, into string) (string, error)
 	RevertCommit(dir, commitHash, message string) error
+	CherryPick(dir string, commits []string) error
+	CherryPickAbort(dir string) error
+	UpdateRef(dir, ref, commit string) error
+	DeleteRef(dir, ref string) error
 
 	// Rebase
 	Rebase(dir, onto string) error
 	RebaseAbort(dir string) error
 	HasOngoingRebase(dir string) bool
+	RewordCommit(dir, commit, message string) error // Rewrites a commit message and replays its descendants
+	SquashCommit(dir, commit string) error          // Folds a commit into its parent, joining the messages
 
 	// Status
 	Status(dir string) (string, error)
@@ -91,6 +111,15 @@ type Client interface {
 
 	// Log
 	GetBranchCommits(dir, branch, baseBranch string, maxCount int) ([]CommitInfo, error)
+	ListCommits(dir, base, head string) ([]string, error) // Non-merge commits in base..head, oldest first
+	AheadBehind(dir, base, head string) (int, int, error) // Commits in base..head and in head..base
+	GetCommitMessage(dir, commit string) (string, error)
+	BlameLine(dir, file string, line int) (string, error)           // Commit that last changed a line
+	ChangedBlobs(dir, base, head string) (map[string]string, error) // Path → new blob hash of files changed in base...head
+
+	// Notes
+	AddNote(dir, notesRef, commit, message string) error // Overwrites any existing note
+	GetNote(dir, notesRef, commit string) (string, error)
 
 	// Index
 	UpdateIndexAssumeUnchanged(dir, path string) error
@@ -105,6 +134,13 @@ type CommitInfo struct {
 	Subject string
 }
 
+// DiffStat is the size of a diff.
+type DiffStat struct {
+	Files   int
+	Added   int // Added lines
+	Removed int // Removed lines
+}
+
 // Worktree represents a git worktree.
 type Worktree struct {
 	Path   string
@@ -195,10 +231,18 @@ func (c *gitClient) run(dir string, args ...string) error {
 }
 
 func (c *gitClient) runOutput(dir string, args ...string) (string, error) {
+	return c.runOutputEnv(dir, nil, args...)
+}
+
+// runOutputEnv is runOutput with extra environment variables for git.
+func (c *gitClient) runOutputEnv(dir string, env []string, args ...string) (string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
 	defer cancel()
 
 	cmd := c.cmd(ctx, dir, args...)
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
 
 	// Reuse buffers from pool to reduce allocations
 	stdout := bufferPool.Get().(*bytes.Buffer)
@@ -217,6 +261,26 @@ func (c *gitClient) runOutput(dir string, args ...string) (string, error) {
 	return strings.TrimSpace(stdout.String()), nil
 }
 
+// runInput runs a git command with input written to its stdin.
+func (c *gitClient) runInput(dir, input string, args ...string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	cmd := c.cmd(ctx, dir, args...)
+	cmd.Stdin = strings.NewReader(input)
+
+	stderr := bufferPool.Get().(*bytes.Buffer)
+	stderr.Reset()
+	defer bufferPool.Put(stderr)
+
+	cmd.Stderr = stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%w: %s", err, stderr.String())
+	}
+	return nil
+}
+
 // Repository
 
 func (c *gitClient) IsGitRepo(dir string) bool {
@@ -265,8 +329,33 @@ func (c *gitClient) HasRemote(dir, remote string) bool {
 	return false
 }
 
+// ListRemotes returns the names of all configured remotes.
+func (c *gitClient) ListRemotes(dir string) ([]string, error) {
+	output, err := c.runOutput(dir, "remote")
+	if err != nil {
+		return nil, err
+	}
+	var remotes []string
+	for _, line := range strings.Split(output, "\n") {
+		if name := strings.TrimSpace(line); name != "" {
+			remotes = append(remotes, name)
+		}
+	}
+	return remotes, nil
+}
+
+// GetRemoteURL returns the fetch URL of a remote.
+func (c *gitClient) GetRemoteURL(dir, remote string) (string, error) {
+	return c.runOutput(dir, "remote", "get-url", remote)
+}
+
 // Worktree
 
+// WorktreeAddFrom creates a worktree on a new branch starting at startPoint.
+func (c *gitClient) WorktreeAddFrom(projectDir, worktreeDir, branch, startPoint string) error {
+	return c.run(projectDir, "worktree", "add", "-b", branch, worktreeDir, startPoint)
+}
+
 func (c *gitClient) WorktreeAdd(projectDir, worktreeDir, branch string, createBranch bool) error {
 	args := []string{"worktree", "add"}
 	if createBranch {
@@ -388,6 +477,18 @@ func (c *gitClient) GetHeadCommit(dir string) (string, error) {
 	return c.runOutput(dir, "rev-parse", "HEAD")
 }
 
+func (c *gitClient) GetUpstream(dir, branch string) (string, error) {
+	return c.runOutput(dir, "rev-parse", "--abbrev-ref", branch+"@{upstream}")
+}
+
+func (c *gitClient) SetUpstream(dir, branch, upstream string) error {
+	return c.run(dir, "branch", "--set-upstream-to="+upstream, branch)
+}
+
+func (c *gitClient) RefExists(dir, ref string) bool {
+	return c.run(dir, "rev-parse", "--verify", "--quiet", ref+"^{commit}") == nil
+}
+
 // Changes
 
 func (c *gitClient) HasChanges(dir string) bool {
@@ -427,6 +528,31 @@ func (c *gitClient) GetUntrackedFiles(dir string) ([]string, error) {
 	return strings.Split(output, "\n"), nil
 }
 
+// ListFiles returns the tracked and untracked files under dir, relative to
+// dir and sorted, leaving out files ignored by .gitignore.
+func (c *gitClient) ListFiles(dir string) ([]string, error) {
+	output, err := c.runOutput(dir, "ls-files", "-z", "--cached", "--others", "--exclude-standard")
+	if err != nil {
+		return nil, err
+	}
+	if output == "" {
+		return nil, nil
+	}
+
+	// NUL-separated so paths are not quoted; files with unmerged changes
+	// are listed once per stage
+	var files []string
+	seen := make(map[string]bool)
+	for _, file := range strings.Split(output, "\x00") {
+		if file != "" && !seen[file] {
+			seen[file] = true
+			files = append(files, file)
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
 func (c *gitClient) StashCreate(dir string) (string, error) {
 	return c.runOutput(dir, "stash", "create")
 }
@@ -534,10 +660,42 @@ func (c *gitClient) Commit(dir, message string) error {
 	return c.run(dir, "commit", "-m", message)
 }
 
+// CommitAmend amends HEAD with the staged changes.
+// An empty message keeps the existing commit message.
+func (c *gitClient) CommitAmend(dir, message string) error {
+	if message == "" {
+		return c.run(dir, "commit", "--amend", "--no-edit")
+	}
+	return c.run(dir, "commit", "--amend", "-m", message)
+}
+
 func (c *gitClient) GetDiffStat(dir string) (string, error) {
 	return c.runOutput(dir, "diff", "--cached", "--stat")
 }
 
+// ApplyPatch applies a unified diff patch read from stdin.
+// With cached the patch is applied to the index only; reverse undoes it.
+func (c *gitClient) ApplyPatch(dir, patch string, cached, reverse bool) error {
+	args := []string{"apply", "--whitespace=nowarn"}
+	if cached {
+		args = append(args, "--cached")
+	}
+	if reverse {
+		args = append(args, "-R")
+	}
+	args = append(args, "-")
+	return c.runInput(dir, patch, args...)
+}
+
+// DiscardPath drops unstaged worktree changes to path.
+// Untracked paths are removed from disk instead.
+func (c *gitClient) DiscardPath(dir, path string, untracked bool) error {
+	if untracked {
+		return c.run(dir, "clean", "-f", "--", path)
+	}
+	return c.run(dir, "checkout", "--", path)
+}
+
 // Remote
 
 func (c *gitClient) Push(dir, remote, branch string, setUpstream bool) error {
@@ -553,6 +711,13 @@ func (c *gitClient) Fetch(dir, remote string) error {
 	return c.run(dir, "fetch", remote)
 }
 
+func (c *gitClient) FetchRef(dir, remote, ref string) (string, error) {
+	if err := c.run(dir, "fetch", remote, ref); err != nil {
+		return "", err
+	}
+	return c.runOutput(dir, "rev-parse", "FETCH_HEAD")
+}
+
 func (c *gitClient) Pull(dir string) error {
 	return c.run(dir, "pull")
 }
@@ -669,6 +834,27 @@ func (c *gitClient) RevertCommit(dir, commitHash, message string) error {
 	return c.run(dir, args...)
 }
 
+// CherryPick applies the given commits in order, recording their origin (-x).
+func (c *gitClient) CherryPick(dir string, commits []string) error {
+	args := append([]string{"cherry-pick", "-x"}, commits...)
+	return c.run(dir, args...)
+}
+
+// CherryPickAbort aborts an in-progress cherry-pick.
+func (c *gitClient) CherryPickAbort(dir string) error {
+	return c.run(dir, "cherry-pick", "--abort")
+}
+
+// UpdateRef points ref at commit, creating it if needed.
+func (c *gitClient) UpdateRef(dir, ref, commit string) error {
+	return c.run(dir, "update-ref", ref, commit)
+}
+
+// DeleteRef deletes ref.
+func (c *gitClient) DeleteRef(dir, ref string) error {
+	return c.run(dir, "update-ref", "-d", ref)
+}
+
 // Rebase
 
 // Rebase rebases the current branch onto the given target.
@@ -681,6 +867,91 @@ func (c *gitClient) RebaseAbort(dir string) error {
 	return c.run(dir, "rebase", "--abort")
 }
 
+// RewordCommit replaces the message of commit, which must be an ancestor of HEAD.
+// The commit is recreated with the same tree, parents and author, and its
+// descendants are replayed on top; trees are unchanged, so the replay cannot conflict.
+func (c *gitClient) RewordCommit(dir, commit, message string) error {
+	if !isValidGitRef(commit) {
+		return fmt.Errorf("invalid commit: %q", commit)
+	}
+	if strings.TrimSpace(message) == "" {
+		return fmt.Errorf("empty commit message")
+	}
+	args := []string{commit + "^{tree}"}
+	parents, err := c.runOutput(dir, "rev-list", "--parents", "-n1", commit)
+	if err != nil {
+		return err
+	}
+	for _, parent := range strings.Fields(parents)[1:] {
+		args = append(args, "-p", parent)
+	}
+	rewritten, err := c.commitTree(dir, commit, append(args, "-m", message)...)
+	if err != nil {
+		return err
+	}
+	return c.replayOnto(dir, rewritten, commit)
+}
+
+// SquashCommit folds commit into its parent, keeping the commit's tree, the
+// parent's author and both messages. Descendants of commit are replayed on top.
+func (c *gitClient) SquashCommit(dir, commit string) error {
+	if !isValidGitRef(commit) {
+		return fmt.Errorf("invalid commit: %q", commit)
+	}
+	parents, err := c.runOutput(dir, "rev-list", "--parents", "-n1", commit+"^")
+	if err != nil {
+		return fmt.Errorf("commit has no parent to squash into: %w", err)
+	}
+	parentMsg, err := c.GetCommitMessage(dir, commit+"^")
+	if err != nil {
+		return err
+	}
+	msg, err := c.GetCommitMessage(dir, commit)
+	if err != nil {
+		return err
+	}
+	args := []string{commit + "^{tree}"}
+	for _, parent := range strings.Fields(parents)[1:] {
+		args = append(args, "-p", parent)
+	}
+	squashed, err := c.commitTree(dir, commit+"^", append(args, "-m", parentMsg+"\n\n"+msg)...)
+	if err != nil {
+		return err
+	}
+	return c.replayOnto(dir, squashed, commit)
+}
+
+// commitTree runs commit-tree with args, keeping the author and author date
+// of authorCommit. The committer is the current user.
+func (c *gitClient) commitTree(dir, authorCommit string, args ...string) (string, error) {
+	author, err := c.runOutput(dir, "log", "-1", "--date=raw", "--format=%an%x00%ae%x00%ad", authorCommit)
+	if err != nil {
+		return "", err
+	}
+	fields := strings.Split(author, "\x00")
+	if len(fields) != 3 {
+		return "", fmt.Errorf("failed to read the author of %s", authorCommit)
+	}
+	env := []string{
+		"GIT_AUTHOR_NAME=" + fields[0],
+		"GIT_AUTHOR_EMAIL=" + fields[1],
+		"GIT_AUTHOR_DATE=" + fields[2],
+	}
+	return c.runOutputEnv(dir, env, append([]string{"commit-tree"}, args...)...)
+}
+
+// replayOnto moves the current branch so that the commits after upstream are
+// replayed on newBase. Local changes are stashed and restored around the rebase.
+func (c *gitClient) replayOnto(dir, newBase, upstream string) error {
+	if err := c.run(dir, "rebase", "--autostash", "--onto", newBase, upstream); err != nil {
+		if c.HasOngoingRebase(dir) {
+			_ = c.RebaseAbort(dir)
+		}
+		return err
+	}
+	return nil
+}
+
 // HasOngoingRebase checks if there's an ongoing rebase operation.
 // This is indicated by the presence of rebase-merge or rebase-apply directory.
 func (c *gitClient) HasOngoingRebase(dir string) bool {
@@ -719,6 +990,157 @@ func (c *gitClient) Checkout(dir, target string) error {
 	return c.run(dir, "checkout", target)
 }
 
+// ListCommits returns the hashes of non-merge commits in base..head, oldest first.
+func (c *gitClient) ListCommits(dir, base, head string) ([]string, error) {
+	if !isValidGitRef(base) {
+		return nil, fmt.Errorf("invalid base ref: %q", base)
+	}
+	if !isValidGitRef(head) {
+		return nil, fmt.Errorf("invalid head ref: %q", head)
+	}
+
+	output, err := c.runOutput(dir, "rev-list", "--reverse", "--no-merges", base+".."+head)
+	if err != nil {
+		return nil, err
+	}
+	if output == "" {
+		return nil, nil
+	}
+	return strings.Split(output, "\n"), nil
+}
+
+// AheadBehind counts the commits on head that are not on base (ahead) and the
+// commits on base that are not on head (behind).
+func (c *gitClient) AheadBehind(dir, base, head string) (int, int, error) {
+	if !isValidGitRef(base) {
+		return 0, 0, fmt.Errorf("invalid base ref: %q", base)
+	}
+	if !isValidGitRef(head) {
+		return 0, 0, fmt.Errorf("invalid head ref: %q", head)
+	}
+
+	output, err := c.runOutput(dir, "rev-list", "--left-right", "--count", base+"..."+head)
+	if err != nil {
+		return 0, 0, err
+	}
+	var behind, ahead int
+	if _, err := fmt.Sscanf(output, "%d %d", &behind, &ahead); err != nil {
+		return 0, 0, fmt.Errorf("unexpected rev-list output %q: %w", output, err)
+	}
+	return ahead, behind, nil
+}
+
+// DiffNumstat returns the size of the worktree changes (committed or not) since
+// HEAD diverged from base. Binary files count as changed files without lines.
+func (c *gitClient) DiffNumstat(dir, base string) (DiffStat, error) {
+	if !isValidGitRef(base) {
+		return DiffStat{}, fmt.Errorf("invalid base ref: %q", base)
+	}
+
+	output, err := c.runOutput(dir, "diff", "--numstat", "--merge-base", base)
+	if err != nil {
+		return DiffStat{}, err
+	}
+
+	// Lines are "<added>\t<removed>\t<path>", with "-" counts for binary files
+	var stat DiffStat
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) < 3 {
+			continue
+		}
+		stat.Files++
+		if n, err := strconv.Atoi(fields[0]); err == nil {
+			stat.Added += n
+		}
+		if n, err := strconv.Atoi(fields[1]); err == nil {
+			stat.Removed += n
+		}
+	}
+	return stat, nil
+}
+
+// ChangedBlobs returns the files changed on head since it diverged from base
+// (base...head), mapped to their blob hash on head. Deleted files map to the
+// all-zero hash; renamed files are keyed by their new path.
+func (c *gitClient) ChangedBlobs(dir, base, head string) (map[string]string, error) {
+	if !isValidGitRef(base) {
+		return nil, fmt.Errorf("invalid base ref: %q", base)
+	}
+	if !isValidGitRef(head) {
+		return nil, fmt.Errorf("invalid head ref: %q", head)
+	}
+
+	output, err := c.runOutput(dir, "diff", "--raw", "--no-abbrev", "-z", base+"..."+head)
+	if err != nil {
+		return nil, err
+	}
+
+	// Records are ":<modes> <old> <new> <status>\0<path>\0", with a second path for renames and copies
+	blobs := make(map[string]string)
+	fields := strings.Split(strings.TrimSuffix(output, "\x00"), "\x00")
+	for i := 0; i < len(fields); i++ {
+		meta := strings.Fields(fields[i])
+		if len(meta) < 5 || !strings.HasPrefix(meta[0], ":") {
+			continue
+		}
+		pathIdx := i + 1
+		if status := meta[4]; strings.HasPrefix(status, "R") || strings.HasPrefix(status, "C") {
+			pathIdx = i + 2
+		}
+		if pathIdx >= len(fields) {
+			break
+		}
+		blobs[fields[pathIdx]] = meta[3]
+		i = pathIdx
+	}
+	return blobs, nil
+}
+
+// GetCommitMessage returns the full message (subject, body and trailers) of a commit.
+func (c *gitClient) GetCommitMessage(dir, commit string) (string, error) {
+	if !isValidGitRef(commit) {
+		return "", fmt.Errorf("invalid commit: %q", commit)
+	}
+	return c.runOutput(dir, "log", "-1", "--format=%B", commit)
+}
+
+// BlameLine returns the hash of the commit that last changed line (1-based) of file.
+func (c *gitClient) BlameLine(dir, file string, line int) (string, error) {
+	if line < 1 {
+		return "", fmt.Errorf("invalid line: %d", line)
+	}
+	lineRange := fmt.Sprintf("%d,%d", line, line)
+	output, err := c.runOutput(dir, "blame", "--porcelain", "-L", lineRange, "--", file)
+	if err != nil {
+		return "", err
+	}
+	// Porcelain output starts with "<hash> <orig-line> <final-line> <count>"
+	hash, _, _ := strings.Cut(output, " ")
+	if hash == "" {
+		return "", fmt.Errorf("no blame output for %s:%d", file, line)
+	}
+	return hash, nil
+}
+
+// Notes
+
+// AddNote attaches message to commit under notesRef, replacing any existing note.
+func (c *gitClient) AddNote(dir, notesRef, commit, message string) error {
+	if !isValidGitRef(commit) {
+		return fmt.Errorf("invalid commit: %q", commit)
+	}
+	return c.run(dir, "notes", "--ref="+notesRef, "add", "-f", "-m", message, commit)
+}
+
+// GetNote returns the note attached to commit under notesRef.
+func (c *gitClient) GetNote(dir, notesRef, commit string) (string, error) {
+	if !isValidGitRef(commit) {
+		return "", fmt.Errorf("invalid commit: %q", commit)
+	}
+	return c.runOutput(dir, "notes", "--ref="+notesRef, "show", commit)
+}
+
 // GetBranchCommits returns commit information for commits unique to a branch.
 // It returns commits that are in 'branch' but not in 'baseBranch'.
 func (c *gitClient) GetBranchCommits(dir, branch, baseBranch string, maxCount int) ([]CommitInfo, error) {
@@ -795,6 +1217,44 @@ func GenerateMergeCommitMessage(taskName string, commits []CommitInfo) string {
 	return msg.String()
 }
 
+// AppendTrailers appends git trailers ("Key: value" lines) to a commit message,
+// separated from the body by a blank line.
+func AppendTrailers(message string, trailers []string) string {
+	if len(trailers) == 0 {
+		return message
+	}
+	var msg strings.Builder
+	msg.WriteString(strings.TrimRight(message, "\n"))
+	msg.WriteString("\n\n")
+	for _, trailer := range trailers {
+		msg.WriteString(trailer)
+		msg.WriteString("\n")
+	}
+	return msg.String()
+}
+
+// ParseTrailers returns the trailers in the last paragraph of a commit message,
+// keyed by trailer name. The first occurrence of a key wins.
+func ParseTrailers(message string) map[string]string {
+	trailers := make(map[string]string)
+	message = strings.TrimSpace(message)
+	idx := strings.LastIndex(message, "\n\n")
+	if idx == -1 {
+		return trailers // Subject only
+	}
+
+	for _, line := range strings.Split(message[idx+2:], "\n") {
+		key, value, ok := strings.Cut(line, ": ")
+		if !ok || key == "" || strings.ContainsAny(key, " \t") {
+			continue
+		}
+		if _, exists := trailers[key]; !exists {
+			trailers[key] = strings.TrimSpace(value)
+		}
+	}
+	return trailers
+}
+
 // CopyUntrackedFiles copies untracked files from source to destination.
 func CopyUntrackedFiles(files []string, srcDir, dstDir string) error {
 	for _, file := range files {
@@ -4,6 +4,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -1117,3 +1118,354 @@ func TestIsValidGitRef(t *testing.T) {
 		})
 	}
 }
+
+func TestAppendAndParseTrailers(t *testing.T) {
+	msg := AppendTrailers("feat: add login\n\nChanges:\n- add form\n", []string{"Paw-Task: add-login", "Paw-Model: opus"})
+
+	want := "feat: add login\n\nChanges:\n- add form\n\nPaw-Task: add-login\nPaw-Model: opus\n"
+	if msg != want {
+		t.Errorf("AppendTrailers() = %q, want %q", msg, want)
+	}
+
+	trailers := ParseTrailers(msg)
+	if trailers["Paw-Task"] != "add-login" || trailers["Paw-Model"] != "opus" {
+		t.Errorf("ParseTrailers() = %v", trailers)
+	}
+	if _, ok := trailers["Changes"]; ok {
+		t.Error("ParseTrailers() should only read the last paragraph")
+	}
+
+	if got := ParseTrailers("feat: subject only"); len(got) != 0 {
+		t.Errorf("ParseTrailers(subject only) = %v, want empty", got)
+	}
+	if got := AppendTrailers("msg", nil); got != "msg" {
+		t.Errorf("AppendTrailers(nil) = %q, want %q", got, "msg")
+	}
+}
+
+func TestNotesAndBlame(t *testing.T) {
+	client := New()
+	gitDir := setupGitRepo(t)
+
+	createCommit(t, gitDir, "README.md", "line one\nline two\n", "feat: readme\n\nPaw-Task: readme")
+	head, err := client.GetHeadCommit(gitDir)
+	if err != nil {
+		t.Fatalf("GetHeadCommit() error = %v", err)
+	}
+
+	commit, err := client.BlameLine(gitDir, "README.md", 2)
+	if err != nil {
+		t.Fatalf("BlameLine() error = %v", err)
+	}
+	if commit != head {
+		t.Errorf("BlameLine() = %q, want %q", commit, head)
+	}
+
+	message, err := client.GetCommitMessage(gitDir, commit)
+	if err != nil {
+		t.Fatalf("GetCommitMessage() error = %v", err)
+	}
+	if ParseTrailers(message)["Paw-Task"] != "readme" {
+		t.Errorf("GetCommitMessage() = %q, missing trailer", message)
+	}
+
+	if _, err := client.GetNote(gitDir, "refs/notes/paw", head); err == nil {
+		t.Error("GetNote() expected error before a note is added")
+	}
+	if err := client.AddNote(gitDir, "refs/notes/paw", head, "first"); err != nil {
+		t.Fatalf("AddNote() error = %v", err)
+	}
+	if err := client.AddNote(gitDir, "refs/notes/paw", head, "second"); err != nil {
+		t.Fatalf("AddNote() overwrite error = %v", err)
+	}
+	note, err := client.GetNote(gitDir, "refs/notes/paw", head)
+	if err != nil {
+		t.Fatalf("GetNote() error = %v", err)
+	}
+	if note != "second" {
+		t.Errorf("GetNote() = %q, want %q", note, "second")
+	}
+}
+
+func TestChangedBlobs(t *testing.T) {
+	client := New()
+	gitDir := setupGitRepo(t)
+
+	createCommit(t, gitDir, "keep.txt", "keep\n", "Initial commit")
+	createCommit(t, gitDir, "old name.txt", "same content for rename\n", "Add file to rename")
+	if err := runGitCmd(gitDir, "branch", "base").Run(); err != nil {
+		t.Fatalf("Failed to create base branch: %v", err)
+	}
+
+	createCommit(t, gitDir, "keep.txt", "changed\n", "Change file")
+	if output, err := runGitCmd(gitDir, "mv", "old name.txt", "new name.txt").CombinedOutput(); err != nil {
+		t.Fatalf("Failed to rename: %v\n%s", err, output)
+	}
+	if output, err := runGitCmd(gitDir, "commit", "-m", "Rename").CombinedOutput(); err != nil {
+		t.Fatalf("Failed to commit rename: %v\n%s", err, output)
+	}
+
+	blobs, err := client.ChangedBlobs(gitDir, "base", "HEAD")
+	if err != nil {
+		t.Fatalf("ChangedBlobs() error = %v", err)
+	}
+	want, err := runGitCmd(gitDir, "rev-parse", "HEAD:keep.txt").Output()
+	if err != nil {
+		t.Fatalf("rev-parse error = %v", err)
+	}
+	if len(blobs) != 2 || blobs["keep.txt"] != strings.TrimSpace(string(want)) || blobs["new name.txt"] == "" {
+		t.Errorf("ChangedBlobs() = %v", blobs)
+	}
+
+	if _, err := client.ChangedBlobs(gitDir, "-bad", "HEAD"); err == nil {
+		t.Error("ChangedBlobs() expected error for an invalid ref")
+	}
+}
+
+func TestApplyPatchAndDiscardPath(t *testing.T) {
+	client := New()
+	gitDir := setupGitRepo(t)
+
+	createCommit(t, gitDir, "file.txt", "one\ntwo\n", "Initial commit")
+	if err := os.WriteFile(filepath.Join(gitDir, "file.txt"), []byte("one\ntwo\nthree\n"), 0644); err != nil {
+		t.Fatalf("Failed to modify file: %v", err)
+	}
+	patch, err := runGitCmd(gitDir, "diff").Output()
+	if err != nil {
+		t.Fatalf("git diff error = %v", err)
+	}
+
+	// Stage, then unstage the patch
+	if err := client.ApplyPatch(gitDir, string(patch), true, false); err != nil {
+		t.Fatalf("ApplyPatch(cached) error = %v", err)
+	}
+	if staged, _ := client.IsFileStaged(gitDir, "file.txt"); !staged {
+		t.Error("file.txt should be staged after applying the patch to the index")
+	}
+	if err := client.ApplyPatch(gitDir, string(patch), true, true); err != nil {
+		t.Fatalf("ApplyPatch(cached, reverse) error = %v", err)
+	}
+	if staged, _ := client.IsFileStaged(gitDir, "file.txt"); staged {
+		t.Error("file.txt should not be staged after reversing the patch")
+	}
+
+	if err := client.DiscardPath(gitDir, "file.txt", false); err != nil {
+		t.Fatalf("DiscardPath() error = %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(gitDir, "file.txt")); string(data) != "one\ntwo\n" {
+		t.Errorf("file.txt = %q after discard", data)
+	}
+
+	if err := os.WriteFile(filepath.Join(gitDir, "new.txt"), []byte("new\n"), 0644); err != nil {
+		t.Fatalf("Failed to write untracked file: %v", err)
+	}
+	if err := client.DiscardPath(gitDir, "new.txt", true); err != nil {
+		t.Fatalf("DiscardPath(untracked) error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(gitDir, "new.txt")); !os.IsNotExist(err) {
+		t.Error("untracked file should be removed")
+	}
+}
+
+func TestCommitAmend(t *testing.T) {
+	client := New()
+	gitDir := setupGitRepo(t)
+
+	createCommit(t, gitDir, "file.txt", "one\n", "Initial commit")
+	createCommit(t, gitDir, "file.txt", "two\n", "Second commit")
+
+	if err := os.WriteFile(filepath.Join(gitDir, "extra.txt"), []byte("extra\n"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if err := client.Add(gitDir, "extra.txt"); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if err := client.CommitAmend(gitDir, ""); err != nil {
+		t.Fatalf("CommitAmend() error = %v", err)
+	}
+	if msg, _ := client.GetCommitMessage(gitDir, "HEAD"); msg != "Second commit" {
+		t.Errorf("message = %q, want it kept", msg)
+	}
+	if !client.IsFileTracked(gitDir, "extra.txt") {
+		t.Error("extra.txt should be part of the amended commit")
+	}
+
+	if err := client.CommitAmend(gitDir, "Reworded"); err != nil {
+		t.Fatalf("CommitAmend(message) error = %v", err)
+	}
+	if msg, _ := client.GetCommitMessage(gitDir, "HEAD"); msg != "Reworded" {
+		t.Errorf("message = %q, want %q", msg, "Reworded")
+	}
+}
+
+func TestRewordAndSquashCommit(t *testing.T) {
+	client := New()
+	gitDir := setupGitRepo(t)
+
+	createCommit(t, gitDir, "a.txt", "a\n", "Initial commit")
+	createCommit(t, gitDir, "b.txt", "b\n", "Add b")
+	createCommit(t, gitDir, "c.txt", "c\n", "Add c")
+	createCommit(t, gitDir, "d.txt", "d\n", "Add d")
+
+	// Uncommitted changes survive the rewrite
+	if err := os.WriteFile(filepath.Join(gitDir, "a.txt"), []byte("dirty\n"), 0644); err != nil {
+		t.Fatalf("Failed to modify file: %v", err)
+	}
+
+	if err := client.RewordCommit(gitDir, "HEAD~1", "Add c (reworded)"); err != nil {
+		t.Fatalf("RewordCommit() error = %v", err)
+	}
+	if msg, _ := client.GetCommitMessage(gitDir, "HEAD~1"); msg != "Add c (reworded)" {
+		t.Errorf("HEAD~1 message = %q", msg)
+	}
+	if msg, _ := client.GetCommitMessage(gitDir, "HEAD"); msg != "Add d" {
+		t.Errorf("HEAD message = %q, want descendants kept", msg)
+	}
+
+	if err := client.SquashCommit(gitDir, "HEAD~1"); err != nil {
+		t.Fatalf("SquashCommit() error = %v", err)
+	}
+	count, err := runGitCmd(gitDir, "rev-list", "--count", "HEAD").Output()
+	if err != nil || strings.TrimSpace(string(count)) != "3" {
+		t.Fatalf("commit count = %q, %v; want 3", count, err)
+	}
+	if msg, _ := client.GetCommitMessage(gitDir, "HEAD~1"); msg != "Add b\n\nAdd c (reworded)" {
+		t.Errorf("squashed message = %q", msg)
+	}
+	for _, name := range []string{"b.txt", "c.txt", "d.txt"} {
+		if !client.IsFileTracked(gitDir, name) {
+			t.Errorf("%s should still be tracked", name)
+		}
+	}
+	if data, _ := os.ReadFile(filepath.Join(gitDir, "a.txt")); string(data) != "dirty\n" {
+		t.Errorf("a.txt = %q, want local changes restored", data)
+	}
+
+	if err := client.RewordCommit(gitDir, "HEAD", " "); err == nil {
+		t.Error("RewordCommit() expected error for an empty message")
+	}
+}
+
+func TestRewordAndSquashCommitKeepAuthor(t *testing.T) {
+	client := New()
+	gitDir := setupGitRepo(t)
+
+	commitAs := func(name, email, date, file, message string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(gitDir, file), []byte(file+"\n"), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+		if err := runGitCmd(gitDir, "add", file).Run(); err != nil {
+			t.Fatalf("Failed to add file: %v", err)
+		}
+		cmd := runGitCmd(gitDir, "commit", "-m", message, "--author", name+" <"+email+">", "--date", date)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("Failed to commit: %v\nOutput: %s", err, output)
+		}
+	}
+	author := func(rev string) string {
+		t.Helper()
+		out, err := runGitCmd(gitDir, "log", "-1", "--format=%an <%ae> %at %ai", rev).Output()
+		if err != nil {
+			t.Fatalf("Failed to read author: %v", err)
+		}
+		return strings.TrimSpace(string(out))
+	}
+
+	createCommit(t, gitDir, "a.txt", "a\n", "Initial commit")
+	commitAs("Ada", "ada@example.com", "2020-01-02T03:04:05+0900", "b.txt", "Add b")
+	commitAs("Bob", "bob@example.com", "2021-06-07T08:09:10-0500", "c.txt", "Add c")
+	commitAs("Cy", "cy@example.com", "2022-11-12T13:14:15+0000", "d.txt", "Add d")
+	wantB, wantC, wantD := author("HEAD~2"), author("HEAD~1"), author("HEAD")
+
+	if err := client.RewordCommit(gitDir, "HEAD~1", "Add c (reworded)"); err != nil {
+		t.Fatalf("RewordCommit() error = %v", err)
+	}
+	if got := author("HEAD~1"); got != wantC {
+		t.Errorf("reworded author = %q, want %q", got, wantC)
+	}
+	if got := author("HEAD"); got != wantD {
+		t.Errorf("replayed author = %q, want %q", got, wantD)
+	}
+
+	if err := client.SquashCommit(gitDir, "HEAD~1"); err != nil {
+		t.Fatalf("SquashCommit() error = %v", err)
+	}
+	if got := author("HEAD~1"); got != wantB {
+		t.Errorf("squashed author = %q, want the squashed-into author %q", got, wantB)
+	}
+	if got := author("HEAD"); got != wantD {
+		t.Errorf("replayed author = %q, want %q", got, wantD)
+	}
+}
+
+func TestAheadBehindAndDiffNumstat(t *testing.T) {
+	client := New()
+	gitDir := setupGitRepo(t)
+
+	createCommit(t, gitDir, "keep.txt", "one\ntwo\n", "Initial commit")
+	if err := runGitCmd(gitDir, "branch", "base").Run(); err != nil {
+		t.Fatalf("Failed to create base branch: %v", err)
+	}
+	createCommit(t, gitDir, "keep.txt", "one\nthree\nfour\n", "Change file")
+	createCommit(t, gitDir, "new.txt", "new\n", "Add file")
+
+	// Move base ahead by one commit
+	if err := runGitCmd(gitDir, "checkout", "-q", "base").Run(); err != nil {
+		t.Fatalf("Failed to checkout base: %v", err)
+	}
+	createCommit(t, gitDir, "other.txt", "other\n", "Base moved")
+	if err := runGitCmd(gitDir, "checkout", "-q", "-").Run(); err != nil {
+		t.Fatalf("Failed to checkout back: %v", err)
+	}
+
+	ahead, behind, err := client.AheadBehind(gitDir, "base", "HEAD")
+	if err != nil || ahead != 2 || behind != 1 {
+		t.Errorf("AheadBehind() = %d, %d, %v; want 2, 1", ahead, behind, err)
+	}
+
+	// Uncommitted changes count too
+	if err := os.WriteFile(filepath.Join(gitDir, "new.txt"), []byte("new\nmore\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	stat, err := client.DiffNumstat(gitDir, "base")
+	if err != nil {
+		t.Fatalf("DiffNumstat() error = %v", err)
+	}
+	if want := (DiffStat{Files: 2, Added: 4, Removed: 1}); stat != want {
+		t.Errorf("DiffNumstat() = %+v, want %+v", stat, want)
+	}
+
+	if _, _, err := client.AheadBehind(gitDir, "-bad", "HEAD"); err == nil {
+		t.Error("AheadBehind() expected error for an invalid ref")
+	}
+}
+
+func TestListFiles(t *testing.T) {
+	client := New()
+	gitDir := setupGitRepo(t)
+
+	createCommit(t, gitDir, ".gitignore", "build/\n", "Ignore build")
+	if err := os.Mkdir(filepath.Join(gitDir, "cmd"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	createCommit(t, gitDir, "cmd/main.go", "package main\n", "Add main")
+	for _, name := range []string{"notes.md", "build/out.bin"} {
+		path := filepath.Join(gitDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("x\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, err := client.ListFiles(gitDir)
+	if err != nil {
+		t.Fatalf("ListFiles() error = %v", err)
+	}
+	want := ".gitignore,cmd/main.go,notes.md"
+	if got := strings.Join(files, ","); got != want {
+		t.Errorf("ListFiles() = %s, want %s", got, want)
+	}
+}
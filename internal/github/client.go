@@ -2,11 +2,16 @@
 package github
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"os"
 	"os/exec"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -26,7 +31,8 @@ type Client interface {
 	IsInstalled() bool
 
 	// CreatePR creates a pull request and returns the PR number.
-	CreatePR(dir, title, body, base string) (int, string, error)
+	// head may be "owner:branch" for PRs from a fork; empty lets gh infer it.
+	CreatePR(dir, title, body, base, head string) (int, string, error)
 
 	// GetPRStatus gets the status of a pull request.
 	GetPRStatus(dir string, prNumber int) (*PRStatus, error)
@@ -36,6 +42,13 @@ type Client interface {
 
 	// ViewPRWeb opens the pull request in a web browser.
 	ViewPRWeb(dir string, prNumber int) error
+
+	// GetPRHead gets the head branch and repository of a pull request.
+	GetPRHead(dir string, prNumber int) (*PRHead, error)
+
+	// ListPRStatuses gets the status of several pull requests in as few API calls as possible.
+	// A non-empty etag is sent as If-None-Match; an unchanged repository yields NotModified.
+	ListPRStatuses(dir string, prNumbers []int, etag string) (*PRStatusList, error)
 }
 
 // PRStatus represents the status of a pull request.
@@ -46,9 +59,55 @@ type PRStatus struct {
 	URL    string `json:"url"`
 }
 
+// PRHead describes the head branch of a pull request.
+type PRHead struct {
+	Number            int
+	HeadRefName       string // Branch name in the head repository
+	HeadRepo          string // "owner/name" of the head repository
+	IsCrossRepository bool   // True if the PR comes from a fork
+	URL               string
+}
+
+// CloneURL returns the HTTPS clone URL of the head repository.
+func (h *PRHead) CloneURL() string {
+	if h.HeadRepo == "" {
+		return ""
+	}
+	return "https://github.com/" + h.HeadRepo + ".git"
+}
+
+// PRStatusList is the result of a batched PR status query.
+type PRStatusList struct {
+	Statuses    map[int]*PRStatus // Keyed by PR number; only requested PRs are included
+	ETag        string            // ETag of the pull request list response
+	NotModified bool              // True if nothing changed since the given ETag
+}
+
+// RateLimitError is returned when the GitHub API rate limit has been exhausted.
+type RateLimitError struct {
+	ResetAt time.Time // When the limit resets (zero if unknown)
+}
+
+func (e *RateLimitError) Error() string {
+	if e.ResetAt.IsZero() {
+		return "GitHub API rate limit exceeded"
+	}
+	return "GitHub API rate limit exceeded until " + e.ResetAt.Format(time.RFC3339)
+}
+
+// AsRateLimitError returns the RateLimitError wrapped by err, if any.
+func AsRateLimitError(err error) (*RateLimitError, bool) {
+	var rlErr *RateLimitError
+	if errors.As(err, &rlErr) {
+		return rlErr, true
+	}
+	return nil, false
+}
+
 // ghClient implements the Client interface.
 type ghClient struct {
 	timeout time.Duration
+	repo    string // "owner/name" passed as GH_REPO; empty lets gh resolve it from dir
 }
 
 // Compile-time check that ghClient implements Client interface.
@@ -61,11 +120,49 @@ func New() Client {
 	}
 }
 
+// NewForRepo creates a GitHub CLI client that targets the given "owner/name" repository
+// instead of the one gh resolves from the git remotes. An empty repo behaves like New.
+func NewForRepo(repo string) Client {
+	return &ghClient{
+		timeout: 30 * time.Second,
+		repo:    repo,
+	}
+}
+
+// ParseRepoURL extracts "owner/name" from a GitHub remote URL
+// (https://github.com/owner/name.git, git@github.com:owner/name.git, ssh://git@github.com/owner/name).
+// Returns an empty string if the URL is not recognized.
+func ParseRepoURL(remoteURL string) string {
+	u := strings.TrimSpace(remoteURL)
+	u = strings.TrimSuffix(strings.TrimSuffix(u, "/"), ".git")
+	if i := strings.Index(u, "://"); i >= 0 {
+		u = u[i+3:]
+		if j := strings.Index(u, "/"); j >= 0 {
+			u = u[j+1:]
+		} else {
+			return ""
+		}
+	} else if i := strings.Index(u, ":"); i >= 0 {
+		u = u[i+1:]
+	} else {
+		return ""
+	}
+
+	parts := strings.Split(u, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return ""
+	}
+	return parts[0] + "/" + parts[1]
+}
+
 func (c *ghClient) cmd(ctx context.Context, dir string, args ...string) *exec.Cmd {
 	cmd := exec.CommandContext(ctx, "gh", args...)
 	if dir != "" {
 		cmd.Dir = dir
 	}
+	if c.repo != "" {
+		cmd.Env = append(os.Environ(), "GH_REPO="+c.repo)
+	}
 	return cmd
 }
 
@@ -111,6 +208,30 @@ func (c *ghClient) runOutput(dir string, args ...string) (string, error) {
 	return strings.TrimSpace(stdout.String()), nil
 }
 
+// runRaw runs a gh command and returns stdout even if the command fails.
+// gh api exits non-zero for 304 and 4xx responses, but still prints them with -i.
+func (c *ghClient) runRaw(dir string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	cmd := c.cmd(ctx, dir, args...)
+
+	stdout := bufferPool.Get().(*bytes.Buffer)
+	stderr := bufferPool.Get().(*bytes.Buffer)
+	stdout.Reset()
+	stderr.Reset()
+	defer bufferPool.Put(stdout)
+	defer bufferPool.Put(stderr)
+
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	if err := cmd.Run(); err != nil {
+		return stdout.String(), fmt.Errorf("%w: %s", err, stderr.String())
+	}
+	return stdout.String(), nil
+}
+
 // IsInstalled checks if gh CLI is available.
 func (c *ghClient) IsInstalled() bool {
 	_, err := exec.LookPath("gh")
@@ -118,11 +239,14 @@ func (c *ghClient) IsInstalled() bool {
 }
 
 // CreatePR creates a pull request and returns the PR number.
-func (c *ghClient) CreatePR(dir, title, body, base string) (int, string, error) {
+func (c *ghClient) CreatePR(dir, title, body, base, head string) (int, string, error) {
 	args := []string{"pr", "create", "--title", title, "--body", body}
 	if base != "" {
 		args = append(args, "--base", base)
 	}
+	if head != "" {
+		args = append(args, "--head", head)
+	}
 
 	output, err := c.runOutput(dir, args...)
 	if err != nil {
@@ -169,7 +293,258 @@ func (c *ghClient) IsPRMerged(dir string, prNumber int) (bool, error) {
 	return status.Merged, nil
 }
 
+// GetPRHead gets the head branch and repository of a pull request.
+func (c *ghClient) GetPRHead(dir string, prNumber int) (*PRHead, error) {
+	output, err := c.runOutput(dir, "pr", "view", strconv.Itoa(prNumber),
+		"--json", "number,headRefName,headRepository,headRepositoryOwner,isCrossRepository,url")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PR head: %w", err)
+	}
+
+	var resp struct {
+		Number         int    `json:"number"`
+		HeadRefName    string `json:"headRefName"`
+		HeadRepository struct {
+			Name string `json:"name"`
+		} `json:"headRepository"`
+		HeadRepositoryOwner struct {
+			Login string `json:"login"`
+		} `json:"headRepositoryOwner"`
+		IsCrossRepository bool   `json:"isCrossRepository"`
+		URL               string `json:"url"`
+	}
+	if err := json.Unmarshal([]byte(output), &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse PR head: %w", err)
+	}
+
+	head := &PRHead{
+		Number:            resp.Number,
+		HeadRefName:       resp.HeadRefName,
+		IsCrossRepository: resp.IsCrossRepository,
+		URL:               resp.URL,
+	}
+	if resp.HeadRepositoryOwner.Login != "" && resp.HeadRepository.Name != "" {
+		head.HeadRepo = resp.HeadRepositoryOwner.Login + "/" + resp.HeadRepository.Name
+	}
+	return head, nil
+}
+
 // ViewPRWeb opens the pull request in a web browser.
 func (c *ghClient) ViewPRWeb(dir string, prNumber int) error {
 	return c.run(dir, "pr", "view", strconv.Itoa(prNumber), "--web")
 }
+
+// prListPageSize is the number of recently updated PRs fetched by ListPRStatuses.
+const prListPageSize = 100
+
+// ListPRStatuses gets the status of several pull requests.
+// It first fetches the most recently updated PRs with a conditional request,
+// then resolves any PRs not on that page with a single GraphQL query.
+func (c *ghClient) ListPRStatuses(dir string, prNumbers []int, etag string) (*PRStatusList, error) {
+	result := &PRStatusList{Statuses: make(map[int]*PRStatus, len(prNumbers))}
+	if len(prNumbers) == 0 {
+		return result, nil
+	}
+
+	endpoint := fmt.Sprintf("repos/{owner}/{repo}/pulls?state=all&sort=updated&direction=desc&per_page=%d", prListPageSize)
+	args := []string{"api", "-i", endpoint}
+	if etag != "" {
+		args = append(args, "-H", "If-None-Match: "+etag)
+	}
+
+	raw, runErr := c.runRaw(dir, args...)
+	resp, err := parseAPIResponse(raw)
+	if err != nil {
+		if runErr != nil {
+			return nil, fmt.Errorf("failed to list PRs: %w", runErr)
+		}
+		return nil, fmt.Errorf("failed to parse PR list response: %w", err)
+	}
+
+	if rlErr := rateLimitFromResponse(resp); rlErr != nil {
+		return nil, rlErr
+	}
+
+	result.ETag = resp.Header.Get("ETag")
+	if resp.StatusCode == http.StatusNotModified {
+		result.NotModified = true
+		if result.ETag == "" {
+			result.ETag = etag
+		}
+		return result, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to list PRs: HTTP %d: %s", resp.StatusCode, strings.TrimSpace(resp.Body))
+	}
+
+	var pulls []struct {
+		Number   int     `json:"number"`
+		State    string  `json:"state"`
+		MergedAt *string `json:"merged_at"`
+		HTMLURL  string  `json:"html_url"`
+	}
+	if err := json.Unmarshal([]byte(resp.Body), &pulls); err != nil {
+		return nil, fmt.Errorf("failed to parse PR list: %w", err)
+	}
+
+	wanted := make(map[int]bool, len(prNumbers))
+	for _, n := range prNumbers {
+		wanted[n] = true
+	}
+	for _, p := range pulls {
+		if !wanted[p.Number] {
+			continue
+		}
+		merged := p.MergedAt != nil && *p.MergedAt != ""
+		state := strings.ToLower(p.State)
+		if merged {
+			state = "merged"
+		}
+		result.Statuses[p.Number] = &PRStatus{Number: p.Number, State: state, Merged: merged, URL: p.HTMLURL}
+	}
+
+	var missing []int
+	for _, n := range prNumbers {
+		if _, ok := result.Statuses[n]; !ok {
+			missing = append(missing, n)
+		}
+	}
+	if len(missing) == 0 {
+		return result, nil
+	}
+
+	statuses, err := c.queryPRStatuses(dir, missing)
+	if err != nil {
+		return nil, err
+	}
+	for n, status := range statuses {
+		result.Statuses[n] = status
+	}
+	return result, nil
+}
+
+// queryPRStatuses fetches the status of the given PRs with a single GraphQL query.
+func (c *ghClient) queryPRStatuses(dir string, prNumbers []int) (map[int]*PRStatus, error) {
+	query := buildPRStatusQuery(prNumbers)
+	output, err := c.runOutput(dir, "api", "graphql",
+		"-F", "owner={owner}", "-F", "name={repo}", "-f", "query="+query)
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "rate limit") {
+			return nil, &RateLimitError{}
+		}
+		return nil, fmt.Errorf("failed to query PR statuses: %w", err)
+	}
+
+	var resp struct {
+		Data struct {
+			Repository map[string]*struct {
+				Number int    `json:"number"`
+				State  string `json:"state"`
+				Merged bool   `json:"merged"`
+				URL    string `json:"url"`
+			} `json:"repository"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(output), &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse PR statuses: %w", err)
+	}
+
+	statuses := make(map[int]*PRStatus, len(prNumbers))
+	for _, pr := range resp.Data.Repository {
+		if pr == nil {
+			continue
+		}
+		statuses[pr.Number] = &PRStatus{
+			Number: pr.Number,
+			State:  strings.ToLower(pr.State),
+			Merged: pr.Merged,
+			URL:    pr.URL,
+		}
+	}
+	return statuses, nil
+}
+
+// buildPRStatusQuery builds a GraphQL query that fetches several PRs using aliases.
+func buildPRStatusQuery(prNumbers []int) string {
+	sorted := append([]int(nil), prNumbers...)
+	sort.Ints(sorted)
+
+	var sb strings.Builder
+	sb.WriteString("query($owner: String!, $name: String!) { repository(owner: $owner, name: $name) {")
+	for _, n := range sorted {
+		fmt.Fprintf(&sb, " pr%d: pullRequest(number: %d) { number state merged url }", n, n)
+	}
+	sb.WriteString(" } }")
+	return sb.String()
+}
+
+// apiResponse is an HTTP response printed by `gh api -i`.
+type apiResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       string
+}
+
+// parseAPIResponse parses the status line, headers and body printed by `gh api -i`.
+func parseAPIResponse(raw string) (*apiResponse, error) {
+	reader := bufio.NewReader(strings.NewReader(raw))
+
+	statusLine, err := reader.ReadString('\n')
+	if err != nil && statusLine == "" {
+		return nil, errors.New("empty response")
+	}
+	fields := strings.Fields(statusLine)
+	if len(fields) < 2 || !strings.HasPrefix(fields[0], "HTTP/") {
+		return nil, fmt.Errorf("unexpected status line: %q", strings.TrimSpace(statusLine))
+	}
+	code, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid status code: %q", fields[1])
+	}
+
+	resp := &apiResponse{StatusCode: code, Header: http.Header{}}
+	for {
+		line, err := reader.ReadString('\n')
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+		if key, value, ok := strings.Cut(line, ":"); ok {
+			resp.Header.Add(strings.TrimSpace(key), strings.TrimSpace(value))
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	var body bytes.Buffer
+	_, _ = body.ReadFrom(reader)
+	resp.Body = body.String()
+	return resp, nil
+}
+
+// rateLimitFromResponse returns a RateLimitError if the response indicates an exhausted rate limit.
+func rateLimitFromResponse(resp *apiResponse) *RateLimitError {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return nil
+	}
+
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+		if secs, err := strconv.Atoi(retryAfter); err == nil {
+			return &RateLimitError{ResetAt: time.Now().Add(time.Duration(secs) * time.Second)}
+		}
+	}
+
+	if resp.Header.Get("X-Ratelimit-Remaining") == "0" {
+		rlErr := &RateLimitError{}
+		if reset, err := strconv.ParseInt(resp.Header.Get("X-Ratelimit-Reset"), 10, 64); err == nil {
+			rlErr.ResetAt = time.Unix(reset, 0)
+		}
+		return rlErr
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return &RateLimitError{}
+	}
+	return nil
+}
@@ -2,6 +2,8 @@ package github
 
 import (
 	"encoding/json"
+	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -60,3 +62,96 @@ func TestIsInstalled(t *testing.T) {
 	// Just test that it doesn't panic - the result depends on the environment
 	_ = client.IsInstalled()
 }
+
+func TestParseAPIResponse(t *testing.T) {
+	raw := "HTTP/2.0 200 OK\r\nEtag: W/\"abc\"\r\nX-Ratelimit-Remaining: 4999\r\n\r\n[{\"number\": 1}]"
+
+	resp, err := parseAPIResponse(raw)
+	if err != nil {
+		t.Fatalf("parseAPIResponse() error = %v", err)
+	}
+	if resp.StatusCode != 200 {
+		t.Errorf("StatusCode = %d, want 200", resp.StatusCode)
+	}
+	if got := resp.Header.Get("ETag"); got != `W/"abc"` {
+		t.Errorf("ETag = %q, want %q", got, `W/"abc"`)
+	}
+	if resp.Body != `[{"number": 1}]` {
+		t.Errorf("Body = %q", resp.Body)
+	}
+}
+
+func TestParseAPIResponseNotModified(t *testing.T) {
+	resp, err := parseAPIResponse("HTTP/2.0 304 Not Modified\r\nEtag: W/\"abc\"\r\n\r\n")
+	if err != nil {
+		t.Fatalf("parseAPIResponse() error = %v", err)
+	}
+	if resp.StatusCode != 304 {
+		t.Errorf("StatusCode = %d, want 304", resp.StatusCode)
+	}
+	if resp.Body != "" {
+		t.Errorf("Body = %q, want empty", resp.Body)
+	}
+}
+
+func TestParseAPIResponseInvalid(t *testing.T) {
+	if _, err := parseAPIResponse(""); err == nil {
+		t.Error("expected error for empty response")
+	}
+	if _, err := parseAPIResponse("not a response"); err == nil {
+		t.Error("expected error for missing status line")
+	}
+}
+
+func TestRateLimitFromResponse(t *testing.T) {
+	resp, err := parseAPIResponse("HTTP/2.0 403 Forbidden\r\nX-Ratelimit-Remaining: 0\r\nX-Ratelimit-Reset: 1700000000\r\n\r\n{}")
+	if err != nil {
+		t.Fatalf("parseAPIResponse() error = %v", err)
+	}
+	rlErr := rateLimitFromResponse(resp)
+	if rlErr == nil {
+		t.Fatal("expected rate limit error")
+	}
+	if rlErr.ResetAt.Unix() != 1700000000 {
+		t.Errorf("ResetAt = %v, want unix 1700000000", rlErr.ResetAt)
+	}
+	if _, ok := AsRateLimitError(fmt.Errorf("wrapped: %w", rlErr)); !ok {
+		t.Error("AsRateLimitError() should unwrap rate limit error")
+	}
+
+	resp, _ = parseAPIResponse("HTTP/2.0 403 Forbidden\r\nX-Ratelimit-Remaining: 10\r\n\r\n{}")
+	if rateLimitFromResponse(resp) != nil {
+		t.Error("403 with remaining quota should not be a rate limit error")
+	}
+}
+
+func TestBuildPRStatusQuery(t *testing.T) {
+	query := buildPRStatusQuery([]int{42, 7})
+	if !strings.Contains(query, "pr7: pullRequest(number: 7)") {
+		t.Errorf("query missing pr7 alias: %s", query)
+	}
+	if strings.Index(query, "pr7:") > strings.Index(query, "pr42:") {
+		t.Errorf("query aliases should be sorted: %s", query)
+	}
+}
+
+func TestParseRepoURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://github.com/owner/repo.git", "owner/repo"},
+		{"https://github.com/owner/repo", "owner/repo"},
+		{"git@github.com:owner/repo.git", "owner/repo"},
+		{"ssh://git@github.com/owner/repo.git", "owner/repo"},
+		{"/local/path/repo", ""},
+		{"https://github.com/owner", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := ParseRepoURL(tt.url); got != tt.want {
+			t.Errorf("ParseRepoURL(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+// Package keymap defines the remappable PAW key bindings.
+package keymap
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// helpLabelWidth is the key column width of rendered help lines.
+const helpLabelWidth = 12
+
+// helpPlaceholder matches a "{{keys:section}}" line in HELP.md.
+var helpPlaceholder = regexp.MustCompile(`(?m)^\{\{keys:([a-z-]+)\}\}$`)
+
+// tipPlaceholder matches an "{action}" reference in a tip.
+var tipPlaceholder = regexp.MustCompile(`\{([a-z-]+)\}`)
+
+// RenderHelp replaces "{{keys:section}}" lines with the effective keys of the
+// section's actions. Disabled actions are left out.
+func (k *Keymap) RenderHelp(content string) string {
+	return helpPlaceholder.ReplaceAllStringFunc(content, func(line string) string {
+		section := helpPlaceholder.FindStringSubmatch(line)[1]
+		var lines []string
+		for _, def := range definitions {
+			if def.Section != section {
+				continue
+			}
+			label := k.Label(def.Action)
+			if label == "" {
+				continue
+			}
+			pad := max(1, helpLabelWidth-utf8.RuneCountInString(label))
+			lines = append(lines, "  "+label+strings.Repeat(" ", pad)+def.Description)
+		}
+		if len(lines) == 0 {
+			return "  (all disabled)"
+		}
+		return strings.Join(lines, "\n")
+	})
+}
+
+// RenderTip replaces "{action}" references with the action's key labels.
+// It returns false if a referenced action is disabled or unknown.
+func (k *Keymap) RenderTip(tip string) (string, bool) {
+	ok := true
+	rendered := tipPlaceholder.ReplaceAllStringFunc(tip, func(ref string) string {
+		label := k.Label(Action(ref[1 : len(ref)-1]))
+		if label == "" {
+			ok = false
+		}
+		return label
+	})
+	return rendered, ok
+}
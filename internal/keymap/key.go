@@ -0,0 +1,248 @@
+// Package keymap defines the remappable PAW key bindings.
+package keymap
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// namedKeys maps accepted key names (lowercase) to their canonical name.
+// Canonical names follow bubbletea key notation.
+var namedKeys = map[string]string{
+	"left":      "left",
+	"right":     "right",
+	"up":        "up",
+	"down":      "down",
+	"tab":       "tab",
+	"btab":      "shift+tab",
+	"enter":     "enter",
+	"return":    "enter",
+	"esc":       "esc",
+	"escape":    "esc",
+	"space":     "space",
+	"pgup":      "pgup",
+	"pageup":    "pgup",
+	"ppage":     "pgup",
+	"pgdown":    "pgdown",
+	"pagedown":  "pgdown",
+	"npage":     "pgdown",
+	"home":      "home",
+	"end":       "end",
+	"backspace": "backspace",
+	"bspace":    "backspace",
+	"delete":    "delete",
+	"dc":        "delete",
+	"insert":    "insert",
+	"ic":        "insert",
+}
+
+// tmuxNames maps canonical key names to tmux key names.
+var tmuxNames = map[string]string{
+	"left":      "Left",
+	"right":     "Right",
+	"up":        "Up",
+	"down":      "Down",
+	"tab":       "Tab",
+	"enter":     "Enter",
+	"esc":       "Escape",
+	"space":     "Space",
+	"pgup":      "PPage",
+	"pgdown":    "NPage",
+	"home":      "Home",
+	"end":       "End",
+	"backspace": "BSpace",
+	"delete":    "DC",
+	"insert":    "IC",
+}
+
+// labelNames maps canonical key names to the labels shown in help and hints.
+var labelNames = map[string]string{
+	"left":      "←",
+	"right":     "→",
+	"up":        "↑",
+	"down":      "↓",
+	"tab":       "Tab",
+	"enter":     "Enter",
+	"esc":       "Esc",
+	"space":     "Space",
+	"pgup":      "PgUp",
+	"pgdown":    "PgDn",
+	"home":      "Home",
+	"end":       "End",
+	"backspace": "⌫",
+	"delete":    "Del",
+	"insert":    "Ins",
+}
+
+// ParseKey parses a key in bubbletea notation ("ctrl+g", "alt+left", "G")
+// or tmux notation ("C-g", "M-Left", "BTab") into its canonical form.
+func ParseKey(value string) (string, error) {
+	if value == " " {
+		return "space", nil
+	}
+	s := strings.TrimSpace(value)
+	if s == "" {
+		return "", fmt.Errorf("empty key")
+	}
+
+	var ctrl, alt, shift bool
+	base := s
+	if idx := strings.LastIndex(s[:len(s)-1], "+"); idx > 0 {
+		// bubbletea notation: modifiers joined with "+" (the key itself may be "+")
+		base = s[idx+1:]
+		for _, mod := range strings.Split(s[:idx], "+") {
+			switch strings.ToLower(mod) {
+			case "ctrl", "control":
+				ctrl = true
+			case "alt", "meta", "opt", "option":
+				alt = true
+			case "shift":
+				shift = true
+			default:
+				return "", fmt.Errorf("invalid key %q: unknown modifier %q", value, mod)
+			}
+		}
+	} else {
+		// tmux notation: C-, M- and S- prefixes
+		for len(base) > 2 && base[1] == '-' {
+			switch base[0] {
+			case 'C', 'c':
+				ctrl = true
+			case 'M', 'm':
+				alt = true
+			case 'S', 's':
+				shift = true
+			default:
+				return "", fmt.Errorf("invalid key %q: unknown modifier %q", value, base[:2])
+			}
+			base = base[2:]
+		}
+	}
+
+	name, err := parseBase(base)
+	if err != nil {
+		return "", fmt.Errorf("invalid key %q: %w", value, err)
+	}
+	if name == "shift+tab" {
+		shift, name = true, "tab"
+	}
+	if name == "_" && ctrl {
+		// Terminals send Ctrl+/ as Ctrl+_
+		name = "/"
+	}
+	if utf8.RuneCountInString(name) == 1 {
+		if ctrl {
+			// Ctrl+letter is case-insensitive
+			name = strings.ToLower(name)
+		} else if shift && strings.ToUpper(name) != strings.ToLower(name) {
+			// Shift+letter is the uppercase letter
+			shift, name = false, strings.ToUpper(name)
+		}
+	}
+
+	var sb strings.Builder
+	if ctrl {
+		sb.WriteString("ctrl+")
+	}
+	if alt {
+		sb.WriteString("alt+")
+	}
+	if shift {
+		sb.WriteString("shift+")
+	}
+	sb.WriteString(name)
+	return sb.String(), nil
+}
+
+// parseBase parses a key without modifiers.
+func parseBase(base string) (string, error) {
+	if utf8.RuneCountInString(base) == 1 {
+		return base, nil
+	}
+	lower := strings.ToLower(base)
+	if name, ok := namedKeys[lower]; ok {
+		return name, nil
+	}
+	if isFunctionKey(lower) {
+		return lower, nil
+	}
+	return "", fmt.Errorf("unknown key name %q", base)
+}
+
+// isFunctionKey reports whether name is f1..f12.
+func isFunctionKey(name string) bool {
+	if len(name) < 2 || name[0] != 'f' || name[1] == '0' {
+		return false
+	}
+	n, err := strconv.Atoi(name[1:])
+	return err == nil && n >= 1 && n <= 12
+}
+
+// splitKey splits a canonical key into its modifiers and base name.
+func splitKey(key string) (ctrl, alt, shift bool, name string) {
+	name = key
+	for {
+		switch {
+		case strings.HasPrefix(name, "ctrl+") && len(name) > len("ctrl+"):
+			ctrl, name = true, name[len("ctrl+"):]
+		case strings.HasPrefix(name, "alt+") && len(name) > len("alt+"):
+			alt, name = true, name[len("alt+"):]
+		case strings.HasPrefix(name, "shift+") && len(name) > len("shift+"):
+			shift, name = true, name[len("shift+"):]
+		default:
+			return ctrl, alt, shift, name
+		}
+	}
+}
+
+// TmuxKey converts a canonical key to tmux key notation.
+func TmuxKey(key string) string {
+	ctrl, alt, shift, name := splitKey(key)
+	if shift && name == "tab" {
+		shift, name = false, "BTab"
+	} else if tmuxName, ok := tmuxNames[name]; ok {
+		name = tmuxName
+	} else if isFunctionKey(name) {
+		name = strings.ToUpper(name)
+	} else if ctrl && name == "/" {
+		name = "_"
+	}
+
+	var sb strings.Builder
+	if ctrl {
+		sb.WriteString("C-")
+	}
+	if alt {
+		sb.WriteString("M-")
+	}
+	if shift {
+		sb.WriteString("S-")
+	}
+	sb.WriteString(name)
+	return sb.String()
+}
+
+// KeyLabel returns the label of a canonical key, e.g. "⌃G", "⌥←" or "G".
+func KeyLabel(key string) string {
+	ctrl, alt, shift, name := splitKey(key)
+	if label, ok := labelNames[name]; ok {
+		name = label
+	} else if ctrl && utf8.RuneCountInString(name) == 1 || len(name) > 1 {
+		name = strings.ToUpper(name)
+	}
+
+	var sb strings.Builder
+	if ctrl {
+		sb.WriteString("⌃")
+	}
+	if alt {
+		sb.WriteString("⌥")
+	}
+	if shift {
+		sb.WriteString("⇧")
+	}
+	sb.WriteString(name)
+	return sb.String()
+}
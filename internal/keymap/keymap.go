@@ -0,0 +1,398 @@
+// Package keymap defines the remappable PAW key bindings.
+package keymap
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dongho-jung/paw/internal/config"
+)
+
+// Action names a remappable PAW action. Action names are the keys of the
+// keybindings config block.
+type Action string
+
+// Scope is where the keys of an action are handled.
+type Scope string
+
+// Action scopes. Keys only conflict within overlapping scopes.
+const (
+	ScopeGlobal Scope = "global" // tmux root bindings, active in every pane
+	ScopeNav    Scope = "nav"    // Cursor movement shared by the kanban and the viewers
+	ScopeKanban Scope = "kanban" // Kanban card actions
+	ScopeViewer Scope = "viewer" // Help, log, git, diff and task viewers
+)
+
+// Global actions (tmux bindings).
+const (
+	ActionCyclePane       Action = "cycle-pane"
+	ActionCyclePaneBack   Action = "cycle-pane-back"
+	ActionPrevWindow      Action = "prev-window"
+	ActionNextWindow      Action = "next-window"
+	ActionSwapWindowLeft  Action = "swap-window-left"
+	ActionSwapWindowRight Action = "swap-window-right"
+	ActionProjectPicker   Action = "project-picker"
+	ActionNewTask         Action = "new-task"
+	ActionNewShellWindow  Action = "new-shell-window"
+	ActionHistorySearch   Action = "history-search"
+	ActionTemplatePicker  Action = "template-picker"
+	ActionFinishTask      Action = "finish-task"
+	ActionCommandPalette  Action = "command-palette"
+	ActionInbox           Action = "inbox"
+	ActionQuit            Action = "quit"
+	ActionToggleLogs      Action = "toggle-logs"
+	ActionToggleGit       Action = "toggle-git"
+	ActionToggleShell     Action = "toggle-shell"
+	ActionToggleHelp      Action = "toggle-help"
+	ActionPromptPicker    Action = "prompt-picker"
+	ActionOverview        Action = "overview"
+)
+
+// Kanban actions.
+const (
+	ActionKanbanJump   Action = "kanban-jump"
+	ActionKanbanFinish Action = "kanban-finish"
+	ActionKanbanReply  Action = "kanban-reply"
+	ActionKanbanSync   Action = "kanban-sync"
+	ActionKanbanDiff   Action = "kanban-diff"
+	ActionKanbanCancel Action = "kanban-cancel"
+	ActionKanbanMark   Action = "kanban-mark"
+	ActionKanbanBulk   Action = "kanban-bulk"
+	ActionKanbanFilter Action = "kanban-filter"
+	ActionKanbanSort   Action = "kanban-sort"
+	ActionKanbanGroup  Action = "kanban-group"
+)
+
+// Navigation and viewer actions.
+const (
+	ActionUp           Action = "up"
+	ActionDown         Action = "down"
+	ActionLeft         Action = "left"
+	ActionRight        Action = "right"
+	ActionPageUp       Action = "page-up"
+	ActionPageDown     Action = "page-down"
+	ActionHalfPageUp   Action = "half-page-up"
+	ActionHalfPageDown Action = "half-page-down"
+	ActionTop          Action = "top"
+	ActionBottom       Action = "bottom"
+	ActionSearch       Action = "search"
+	ActionNextMatch    Action = "next-match"
+	ActionPrevMatch    Action = "prev-match"
+	ActionWrap         Action = "wrap"
+	ActionClose        Action = "close"
+)
+
+// Help sections, in the order they appear in HELP.md.
+const (
+	SectionNavigation    = "navigation"
+	SectionTaskCommands  = "task-commands"
+	SectionKanbanActions = "kanban-actions"
+	SectionKanbanFilter  = "kanban-filter"
+	SectionTogglePanels  = "toggle-panels"
+	SectionViewers       = "viewers"
+)
+
+// Definition describes an action and its default keys.
+type Definition struct {
+	Action      Action
+	Scope       Scope
+	Keys        []string // Default keys in canonical (bubbletea) notation
+	Section     string   // Help section the action is listed in
+	Description string
+}
+
+// definitions lists every remappable action in help order.
+var definitions = []Definition{
+	{ActionCyclePane, ScopeGlobal, []string{"alt+tab"}, SectionNavigation, "Cycle panes / Cycle options (in new task window)"},
+	{ActionCyclePaneBack, ScopeGlobal, []string{"alt+shift+tab"}, SectionNavigation, "Cycle panes backward"},
+	{ActionPrevWindow, ScopeGlobal, []string{"alt+left"}, SectionNavigation, "Move to previous window"},
+	{ActionNextWindow, ScopeGlobal, []string{"alt+right"}, SectionNavigation, "Move to next window"},
+	{ActionSwapWindowLeft, ScopeGlobal, []string{"alt+up"}, SectionNavigation, "Swap window left (reorder)"},
+	{ActionSwapWindowRight, ScopeGlobal, []string{"alt+down"}, SectionNavigation, "Swap window right (reorder)"},
+	{ActionProjectPicker, ScopeGlobal, []string{"ctrl+j"}, SectionNavigation, "Switch project (jump to other PAW sessions)"},
+
+	{ActionNewTask, ScopeGlobal, []string{"ctrl+n"}, SectionTaskCommands, "New task"},
+	{ActionNewShellWindow, ScopeGlobal, []string{"ctrl+k"}, SectionTaskCommands, "New shell window"},
+	{ActionHistorySearch, ScopeGlobal, []string{"ctrl+r"}, SectionTaskCommands, "Search task history (in new task window)"},
+	{ActionTemplatePicker, ScopeGlobal, []string{"ctrl+t"}, SectionTaskCommands, "Template picker (in new task window)"},
+	{ActionFinishTask, ScopeGlobal, []string{"ctrl+f"}, SectionTaskCommands, "Finish task (action picker: merge/merge+push/PR/drop or done)"},
+	{ActionCommandPalette, ScopeGlobal, []string{"ctrl+p"}, SectionTaskCommands, "Command palette (fuzzy search commands)"},
+	{ActionInbox, ScopeGlobal, []string{"alt+i"}, SectionTaskCommands, "Inbox (answer waiting agent questions of all sessions)"},
+	{ActionQuit, ScopeGlobal, []string{"ctrl+q"}, SectionTaskCommands, "Quit paw"},
+
+	{ActionKanbanJump, ScopeKanban, []string{"enter", "space"}, SectionKanbanActions, "Jump to task window"},
+	{ActionKanbanFinish, ScopeKanban, []string{"f"}, SectionKanbanActions, "Finish task (action picker)"},
+	{ActionKanbanReply, ScopeKanban, []string{"r"}, SectionKanbanActions, "Quick reply (send text to the agent)"},
+	{ActionKanbanSync, ScopeKanban, []string{"s"}, SectionKanbanActions, "Sync with main"},
+	{ActionKanbanDiff, ScopeKanban, []string{"d"}, SectionKanbanActions, "Show diff (±N on a card: changed files not yet viewed)"},
+	{ActionKanbanCancel, ScopeKanban, []string{"x"}, SectionKanbanActions, "Cancel task (asks for confirmation)"},
+	{ActionKanbanMark, ScopeKanban, []string{"m"}, SectionKanbanActions, "Mark task for a bulk action"},
+	{ActionKanbanBulk, ScopeKanban, []string{"b"}, SectionKanbanActions, "Bulk action on marked tasks (merge, sync, resume, drop)"},
+
+	{ActionKanbanFilter, ScopeKanban, []string{"/"}, SectionKanbanFilter, `Filter bar (e.g. "login project:api status:waiting age:<2h")`},
+	{ActionKanbanSort, ScopeKanban, []string{"o"}, SectionKanbanFilter, "Cycle sort: age, activity, tokens"},
+	{ActionKanbanGroup, ScopeKanban, []string{"g"}, SectionKanbanFilter, "Toggle project swimlanes"},
+
+	{ActionToggleLogs, ScopeGlobal, []string{"ctrl+o"}, SectionTogglePanels, "Toggle logs (show log viewer)"},
+	{ActionToggleGit, ScopeGlobal, []string{"ctrl+g"}, SectionTogglePanels, "Toggle git viewer"},
+	{ActionToggleShell, ScopeGlobal, []string{"ctrl+b"}, SectionTogglePanels, "Toggle bottom (shell pane)"},
+	{ActionToggleHelp, ScopeGlobal, []string{"ctrl+/"}, SectionTogglePanels, "Toggle help"},
+	{ActionPromptPicker, ScopeGlobal, []string{"ctrl+y"}, SectionTogglePanels, "Edit prompts (open prompt picker)"},
+	{ActionOverview, ScopeGlobal, []string{"alt+o"}, SectionTogglePanels, "Toggle overview (live tiles of agent panes)"},
+
+	{ActionUp, ScopeNav, []string{"up", "k"}, SectionViewers, "Move up / scroll up"},
+	{ActionDown, ScopeNav, []string{"down", "j"}, SectionViewers, "Move down / scroll down"},
+	{ActionLeft, ScopeNav, []string{"left", "h"}, SectionViewers, "Previous kanban column / scroll left"},
+	{ActionRight, ScopeNav, []string{"right", "l"}, SectionViewers, "Next kanban column / scroll right"},
+	{ActionPageUp, ScopeViewer, []string{"pgup"}, SectionViewers, "Scroll a page up"},
+	{ActionPageDown, ScopeViewer, []string{"pgdown"}, SectionViewers, "Scroll a page down"},
+	{ActionHalfPageUp, ScopeViewer, []string{"ctrl+u"}, SectionViewers, "Scroll half a page up"},
+	{ActionHalfPageDown, ScopeViewer, []string{"ctrl+d"}, SectionViewers, "Scroll half a page down"},
+	{ActionTop, ScopeViewer, []string{"g", "home"}, SectionViewers, "Go to top"},
+	{ActionBottom, ScopeViewer, []string{"G", "end"}, SectionViewers, "Go to bottom"},
+	{ActionSearch, ScopeViewer, []string{"/"}, SectionViewers, "Search (log and git viewers)"},
+	{ActionNextMatch, ScopeViewer, []string{"n"}, SectionViewers, "Next search match"},
+	{ActionPrevMatch, ScopeViewer, []string{"N"}, SectionViewers, "Previous search match"},
+	{ActionWrap, ScopeViewer, []string{"w"}, SectionViewers, "Toggle word wrap (log and git viewers)"},
+	{ActionClose, ScopeViewer, []string{"q"}, SectionViewers, "Close viewer"},
+}
+
+// Definitions returns every remappable action in help order.
+func Definitions() []Definition {
+	return definitions
+}
+
+// Lookup returns the definition of an action.
+func Lookup(action Action) (Definition, bool) {
+	for _, def := range definitions {
+		if def.Action == action {
+			return def, true
+		}
+	}
+	return Definition{}, false
+}
+
+// scopesOverlap reports whether keys of the two scopes are handled in the same place.
+// Global keys are tmux root bindings and shadow every TUI key.
+func scopesOverlap(a, b Scope) bool {
+	if a == b || a == ScopeGlobal || b == ScopeGlobal {
+		return true
+	}
+	return a == ScopeNav || b == ScopeNav
+}
+
+// DisabledValue disables an action in the keybindings config block.
+const DisabledValue = "none"
+
+// Keymap holds the effective keys of every action.
+type Keymap struct {
+	keys map[Action][]string
+}
+
+// Load returns the keymap configured in the global config, overridden per
+// action by the project config in pawDir (pawDir may be empty).
+func Load(pawDir string) (*Keymap, []error) {
+	bindings := make(map[string]string)
+	for _, dir := range []string{config.GlobalPawDir(), pawDir} {
+		if dir == "" {
+			continue
+		}
+		cfg, err := config.Load(dir)
+		if err != nil {
+			continue
+		}
+		for name, value := range cfg.Keybindings {
+			bindings[name] = value
+		}
+	}
+	return New(bindings)
+}
+
+// Default returns the keymap with default keys only.
+func Default() *Keymap {
+	k := &Keymap{keys: make(map[Action][]string, len(definitions))}
+	for _, def := range definitions {
+		k.keys[def.Action] = def.Keys
+	}
+	return k
+}
+
+// New returns the default keymap with bindings applied. Binding values are
+// comma-separated keys in bubbletea ("ctrl+g") or tmux ("C-g") notation,
+// or "none" to disable the action. Invalid bindings are skipped and reported.
+func New(bindings map[string]string) (*Keymap, []error) {
+	k := Default()
+	var errs []error
+
+	names := make([]string, 0, len(bindings))
+	for name := range bindings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		action := Action(strings.TrimSpace(name))
+		if _, ok := Lookup(action); !ok {
+			errs = append(errs, fmt.Errorf("unknown action %q", name))
+			continue
+		}
+		keys, err := parseKeys(bindings[name])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", action, err))
+			continue
+		}
+		k.keys[action] = keys
+	}
+	return k, errs
+}
+
+// parseKeys parses a comma-separated binding value.
+func parseKeys(value string) ([]string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, fmt.Errorf("no keys (use %q to disable the action)", DisabledValue)
+	}
+	if strings.EqualFold(value, DisabledValue) {
+		return nil, nil
+	}
+
+	var keys []string
+	seen := make(map[string]bool)
+	for _, part := range splitKeyList(value) {
+		key, err := ParseKey(part)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+// splitKeyList splits a binding value on commas, skipping empty parts.
+func splitKeyList(value string) []string {
+	var parts []string
+	for _, part := range strings.Split(value, ",") {
+		if strings.TrimSpace(part) != "" {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+// Keys returns the effective keys of an action (nil if disabled).
+func (k *Keymap) Keys(action Action) []string {
+	return k.keys[action]
+}
+
+// Key returns the first effective key of an action, or "" if it is disabled.
+func (k *Keymap) Key(action Action) string {
+	if keys := k.keys[action]; len(keys) > 0 {
+		return keys[0]
+	}
+	return ""
+}
+
+// Label returns the labels of the effective keys of an action joined with "/",
+// e.g. "⌃G" or "Enter/Space". Disabled actions have an empty label.
+func (k *Keymap) Label(action Action) string {
+	keys := k.keys[action]
+	labels := make([]string, 0, len(keys))
+	for _, key := range keys {
+		labels = append(labels, KeyLabel(key))
+	}
+	return strings.Join(labels, "/")
+}
+
+// Remapped reports whether an action's keys differ from its defaults.
+func (k *Keymap) Remapped(action Action) bool {
+	def, ok := Lookup(action)
+	if !ok {
+		return false
+	}
+	return strings.Join(def.Keys, ",") != strings.Join(k.keys[action], ",")
+}
+
+// Translate maps a pressed key to the first default key of the action it is
+// bound to within the given scopes, so key handlers can keep matching default
+// keys. Default keys taken away from their action translate to "", and keys
+// unrelated to the scopes are returned unchanged.
+func (k *Keymap) Translate(key string, scopes ...Scope) string {
+	canonical, err := ParseKey(key)
+	if err != nil {
+		return key
+	}
+
+	released := false
+	for _, scope := range scopes {
+		for _, def := range definitions {
+			if def.Scope != scope {
+				continue
+			}
+			for _, bound := range k.keys[def.Action] {
+				if bound == canonical {
+					return def.Keys[0]
+				}
+			}
+			if !released {
+				for _, dflt := range def.Keys {
+					released = released || dflt == canonical
+				}
+			}
+		}
+	}
+	if released {
+		return ""
+	}
+	return key
+}
+
+// Conflict is a key bound to several actions in overlapping scopes.
+type Conflict struct {
+	Key     string
+	Actions []Action
+}
+
+// String formats the conflict for display, e.g. "⌃G: toggle-git, new-task".
+func (c Conflict) String() string {
+	names := make([]string, 0, len(c.Actions))
+	for _, action := range c.Actions {
+		names = append(names, string(action))
+	}
+	return KeyLabel(c.Key) + ": " + strings.Join(names, ", ")
+}
+
+// Conflicts returns keys bound to more than one action in overlapping scopes.
+func (k *Keymap) Conflicts() []Conflict {
+	byKey := make(map[string][]Definition)
+	var order []string
+	for _, def := range definitions {
+		for _, key := range k.keys[def.Action] {
+			if _, ok := byKey[key]; !ok {
+				order = append(order, key)
+			}
+			byKey[key] = append(byKey[key], def)
+		}
+	}
+
+	var conflicts []Conflict
+	for _, key := range order {
+		defs := byKey[key]
+		var actions []Action
+		for i, a := range defs {
+			for j, b := range defs {
+				if i != j && scopesOverlap(a.Scope, b.Scope) {
+					actions = append(actions, a.Action)
+					break
+				}
+			}
+		}
+		if len(actions) > 0 {
+			conflicts = append(conflicts, Conflict{Key: key, Actions: actions})
+		}
+	}
+	return conflicts
+}
@@ -0,0 +1,174 @@
+package keymap
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseKey(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"C-g", "ctrl+g"},
+		{"ctrl+G", "ctrl+g"},
+		{"M-Left", "alt+left"},
+		{"alt+left", "alt+left"},
+		{"M-BTab", "alt+shift+tab"},
+		{"BTab", "shift+tab"},
+		{"C-_", "ctrl+/"},
+		{"ctrl+_", "ctrl+/"},
+		{"shift+g", "G"},
+		{"G", "G"},
+		{"g", "g"},
+		{" ", "space"},
+		{"Space", "space"},
+		{"PPage", "pgup"},
+		{"F5", "f5"},
+		{"C-M-x", "ctrl+alt+x"},
+		{"+", "+"},
+	}
+	for _, tt := range tests {
+		got, err := ParseKey(tt.input)
+		if err != nil {
+			t.Errorf("ParseKey(%q) error = %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseKey(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+
+	for _, input := range []string{"", "hyper+x", "X-y", "ctrl+nope", "F13"} {
+		if _, err := ParseKey(input); err == nil {
+			t.Errorf("ParseKey(%q) should fail", input)
+		}
+	}
+}
+
+func TestTmuxKeyAndLabel(t *testing.T) {
+	tests := []struct {
+		key   string
+		tmux  string
+		label string
+	}{
+		{"ctrl+g", "C-g", "⌃G"},
+		{"ctrl+/", "C-_", "⌃/"},
+		{"alt+left", "M-Left", "⌥←"},
+		{"alt+shift+tab", "M-BTab", "⌥⇧Tab"},
+		{"pgdown", "NPage", "PgDn"},
+		{"G", "G", "G"},
+		{"f5", "F5", "F5"},
+	}
+	for _, tt := range tests {
+		if got := TmuxKey(tt.key); got != tt.tmux {
+			t.Errorf("TmuxKey(%q) = %q, want %q", tt.key, got, tt.tmux)
+		}
+		if got := KeyLabel(tt.key); got != tt.label {
+			t.Errorf("KeyLabel(%q) = %q, want %q", tt.key, got, tt.label)
+		}
+	}
+}
+
+func TestDefaultsHaveNoConflicts(t *testing.T) {
+	if conflicts := Default().Conflicts(); len(conflicts) > 0 {
+		t.Errorf("default keymap has conflicts: %v", conflicts)
+	}
+	for _, def := range Definitions() {
+		for _, key := range def.Keys {
+			if canonical, err := ParseKey(key); err != nil || canonical != key {
+				t.Errorf("%s default key %q is not canonical (%q, %v)", def.Action, key, canonical, err)
+			}
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	k, errs := New(map[string]string{
+		"finish-task": "C-x, M-f",
+		"toggle-git":  "none",
+		"bogus":       "C-z",
+		"new-task":    "ctrl+nope",
+	})
+	if len(errs) != 2 {
+		t.Fatalf("errors = %v, want unknown action and invalid key", errs)
+	}
+	if got := k.Keys(ActionFinishTask); strings.Join(got, ",") != "ctrl+x,alt+f" {
+		t.Errorf("finish-task keys = %v", got)
+	}
+	if k.Key(ActionToggleGit) != "" || k.Label(ActionToggleGit) != "" {
+		t.Error("toggle-git should be disabled")
+	}
+	if k.Key(ActionNewTask) != "ctrl+n" {
+		t.Error("an invalid binding should keep the default keys")
+	}
+	if !k.Remapped(ActionFinishTask) || k.Remapped(ActionNewTask) {
+		t.Error("Remapped() should only report changed actions")
+	}
+}
+
+func TestConflicts(t *testing.T) {
+	// Global keys shadow TUI keys; kanban and viewer keys never meet
+	k, _ := New(map[string]string{
+		"new-task":    "C-g",
+		"kanban-sync": "q",
+		"up":          "x",
+	})
+	conflicts := k.Conflicts()
+	if len(conflicts) != 2 {
+		t.Fatalf("conflicts = %v, want two", conflicts)
+	}
+	if got := conflicts[0].String(); got != "⌃G: new-task, toggle-git" {
+		t.Errorf("conflict string = %q", got)
+	}
+	if got := conflicts[1].String(); got != "x: kanban-cancel, up" {
+		t.Errorf("conflict string = %q", got)
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	k, _ := New(map[string]string{
+		"down":       "J",
+		"toggle-git": "C-x",
+		"wrap":       "j",
+	})
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"J", "down"}, // Remapped key resolves to the default key
+		{"down", ""},  // Default key taken away
+		{"j", "w"},    // Key moved to another action
+		{"up", "up"},  // Untouched action
+		{"ctrl+x", "ctrl+g"},
+		{"ctrl+g", ""},
+		{"z", "z"}, // Not bound anywhere
+		{"space", "space"},
+	}
+	for _, tt := range tests {
+		if got := k.Translate(tt.key, ScopeNav, ScopeViewer, ScopeGlobal); got != tt.want {
+			t.Errorf("Translate(%q) = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+	if got := Default().Translate("space", ScopeKanban); got != "enter" {
+		t.Errorf("Translate(space) = %q, want enter", got)
+	}
+}
+
+func TestRenderHelpAndTip(t *testing.T) {
+	k, _ := New(map[string]string{"toggle-git": "C-x", "toggle-shell": "none"})
+	help := k.RenderHelp("### Toggle Panels\n{{keys:toggle-panels}}\n")
+	if !strings.Contains(help, "  ⌃X          Toggle git viewer\n") {
+		t.Errorf("help should list the remapped key:\n%s", help)
+	}
+	if strings.Contains(help, "Toggle bottom") {
+		t.Errorf("help should skip disabled actions:\n%s", help)
+	}
+
+	if tip, ok := k.RenderTip("Press {toggle-git} to toggle git viewer"); !ok || tip != "Press ⌃X to toggle git viewer" {
+		t.Errorf("RenderTip() = %q, %v", tip, ok)
+	}
+	if _, ok := k.RenderTip("Press {toggle-shell} to toggle bottom shell"); ok {
+		t.Error("tips of disabled actions should be skipped")
+	}
+}
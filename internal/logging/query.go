@@ -0,0 +1,335 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LevelUnknown marks entries whose level could not be parsed.
+const LevelUnknown Level = -1
+
+// textTimestampLayout is the timestamp layout of the text log format.
+const textTimestampLayout = "06-01-02 15:04:05.0"
+
+// Entry is a parsed log line in either the text or the JSONL format.
+type Entry struct {
+	Time    time.Time
+	Level   Level
+	Script  string
+	Task    string
+	Caller  string
+	Message string
+	Raw     string
+}
+
+// ParseEntry parses a log line written by the file logger.
+// It returns false for lines that are not log entries (e.g. continuation lines).
+func ParseEntry(line string) (Entry, bool) {
+	trimmed := strings.TrimSpace(line)
+	if strings.HasPrefix(trimmed, "{") {
+		var payload logEntry
+		if err := json.Unmarshal([]byte(trimmed), &payload); err != nil {
+			return Entry{}, false
+		}
+		e := Entry{
+			Level:   parseLevelTag(payload.Level),
+			Script:  payload.Script,
+			Task:    payload.Task,
+			Caller:  payload.Caller,
+			Message: payload.Message,
+			Raw:     line,
+		}
+		if e.Script == "" && e.Task == "" && payload.Context != "" {
+			e.Script, e.Task = splitContext(payload.Context)
+		}
+		if ts, err := time.Parse(time.RFC3339Nano, payload.Timestamp); err == nil {
+			e.Time = ts
+		}
+		return e, true
+	}
+
+	// Format: [timestamp] [level] [context] [caller] message
+	parts := strings.SplitN(trimmed, "] [", 4)
+	if len(parts) < 4 || !strings.HasPrefix(parts[0], "[") {
+		return Entry{}, false
+	}
+	ts, err := time.ParseInLocation(textTimestampLayout, parts[0][1:], time.Local)
+	if err != nil {
+		return Entry{}, false
+	}
+	caller, message, _ := strings.Cut(parts[3], "] ")
+	script, task := splitContext(parts[2])
+	return Entry{
+		Time:    ts,
+		Level:   parseLevelTag(parts[1]),
+		Script:  script,
+		Task:    task,
+		Caller:  strings.TrimSuffix(caller, "]"),
+		Message: message,
+		Raw:     line,
+	}, true
+}
+
+// MarshalJSON encodes the entry in the JSONL log format, whatever format it was read from.
+func (e Entry) MarshalJSON() ([]byte, error) {
+	entry := logEntry{
+		Level:   e.Level.String(),
+		Script:  e.Script,
+		Task:    e.Task,
+		Caller:  e.Caller,
+		Message: e.Message,
+	}
+	if e.Level != LevelUnknown {
+		entry.LevelName = e.Level.Name()
+	}
+	if !e.Time.IsZero() {
+		entry.Timestamp = e.Time.Format(time.RFC3339Nano)
+	}
+	return json.Marshal(entry)
+}
+
+// splitContext splits a "script:task" context.
+func splitContext(context string) (script, task string) {
+	script, task, _ = strings.Cut(context, ":")
+	return script, task
+}
+
+// parseLevelTag parses an "L0".."L5" level tag.
+func parseLevelTag(tag string) Level {
+	if len(tag) == 2 && tag[0] == 'L' && tag[1] >= '0' && tag[1] <= '5' {
+		return Level(tag[1] - '0')
+	}
+	return LevelUnknown
+}
+
+// ParseLevel parses a level given as a number (2), a tag (L2) or a name (info).
+func ParseLevel(value string) (Level, error) {
+	v := strings.TrimSpace(value)
+	if n, err := strconv.Atoi(v); err == nil && n >= 0 && n <= 5 {
+		return Level(n), nil
+	}
+	if level := parseLevelTag(strings.ToUpper(v)); level != LevelUnknown {
+		return level, nil
+	}
+	for l := LevelTrace; l <= LevelFatal; l++ {
+		if strings.EqualFold(v, l.Name()) {
+			return l, nil
+		}
+	}
+	return LevelUnknown, fmt.Errorf("invalid level: %q (use 0-5, L0-L5 or a name like info)", value)
+}
+
+// ParseTimeBound parses a time bound given as a duration ago (2h, 7d) or a timestamp.
+func ParseTimeBound(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if dur, err := time.ParseDuration(value); err == nil {
+		return now.Add(-dur), nil
+	}
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return now.AddDate(0, 0, -n), nil
+		}
+	}
+	layouts := []string{
+		time.RFC3339Nano,
+		time.RFC3339,
+		"2006-01-02 15:04:05",
+		textTimestampLayout,
+		"2006-01-02",
+	}
+	for _, layout := range layouts {
+		if parsed, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time: %q (use a duration like 2h or a timestamp)", value)
+}
+
+// Filter selects log entries. Zero fields match everything.
+type Filter struct {
+	MinLevel Level  // Minimum level (entries with unknown level always pass)
+	Task     string // Exact task name
+	Script   string // Exact script name (the SetScript context)
+	Caller   string // Case-insensitive substring of the caller
+	Since    time.Time
+	Until    time.Time
+}
+
+// IsZero reports whether the filter matches every entry.
+func (f Filter) IsZero() bool {
+	return f == Filter{}
+}
+
+// Match reports whether the entry passes the filter.
+func (f Filter) Match(e Entry) bool {
+	if f.MinLevel > 0 && e.Level != LevelUnknown && e.Level < f.MinLevel {
+		return false
+	}
+	if f.Task != "" && e.Task != f.Task {
+		return false
+	}
+	if f.Script != "" && e.Script != f.Script {
+		return false
+	}
+	if f.Caller != "" && !strings.Contains(strings.ToLower(e.Caller), strings.ToLower(f.Caller)) {
+		return false
+	}
+	if !f.Since.IsZero() && (e.Time.IsZero() || e.Time.Before(f.Since)) {
+		return false
+	}
+	if !f.Until.IsZero() && (e.Time.IsZero() || e.Time.After(f.Until)) {
+		return false
+	}
+	return true
+}
+
+// ParseFilterQuery parses a query of space-separated key:value terms
+// (level, task, script, caller, since, until) into a filter.
+func ParseFilterQuery(query string, now time.Time) (Filter, error) {
+	var f Filter
+	for _, term := range strings.Fields(query) {
+		key, value, ok := strings.Cut(term, ":")
+		if !ok || value == "" {
+			return Filter{}, fmt.Errorf("invalid filter term %q (use key:value)", term)
+		}
+		var err error
+		switch strings.ToLower(key) {
+		case "level":
+			f.MinLevel, err = ParseLevel(value)
+		case "task":
+			f.Task = value
+		case "script":
+			f.Script = value
+		case "caller":
+			f.Caller = value
+		case "since":
+			f.Since, err = ParseTimeBound(value, now)
+		case "until":
+			f.Until, err = ParseTimeBound(value, now)
+		default:
+			err = fmt.Errorf("unknown filter key %q (use level, task, script, caller, since or until)", key)
+		}
+		if err != nil {
+			return Filter{}, err
+		}
+	}
+	return f, nil
+}
+
+// Span is a timed operation reconstructed from StartTimer and Stop entries.
+type Span struct {
+	Operation string        `json:"operation"`
+	Script    string        `json:"script,omitempty"`
+	Task      string        `json:"task,omitempty"`
+	Start     time.Time     `json:"start"`
+	Duration  time.Duration `json:"duration_ns"`
+	Success   bool          `json:"success"`
+	Running   bool          `json:"running,omitempty"` // Started but no stop entry yet
+	Detail    string        `json:"detail,omitempty"`
+}
+
+var (
+	timerStartPattern = regexp.MustCompile(`^(.+) started$`)
+	timerStopPattern  = regexp.MustCompile(`^(.+) (completed|failed) in (\S+?)(?:: (.*))?$`)
+)
+
+// BuildTimeline pairs timer start and stop entries into spans ordered by start time.
+// Stops without a recorded start still yield a span (start = stop - duration);
+// starts without a stop are returned as running spans.
+func BuildTimeline(entries []Entry) []Span {
+	type key struct{ op, script, task string }
+	open := make(map[key][]int) // Indices into spans of running timers
+	var spans []Span
+
+	for _, e := range entries {
+		// Timers log starts and completions at L1 and failures at L3
+		if e.Level != LevelDebug && e.Level != LevelWarn {
+			continue
+		}
+		if m := timerStopPattern.FindStringSubmatch(e.Message); m != nil {
+			dur, err := time.ParseDuration(m[3])
+			if err != nil {
+				continue
+			}
+			k := key{m[1], e.Script, e.Task}
+			span := Span{
+				Operation: m[1],
+				Script:    e.Script,
+				Task:      e.Task,
+				Start:     e.Time.Add(-dur),
+				Duration:  dur,
+				Success:   m[2] == "completed",
+				Detail:    m[4],
+			}
+			if idx := open[k]; len(idx) > 0 {
+				// Close the most recent matching start
+				i := idx[len(idx)-1]
+				open[k] = idx[:len(idx)-1]
+				span.Start = spans[i].Start
+				spans[i] = span
+				continue
+			}
+			spans = append(spans, span)
+			continue
+		}
+		if m := timerStartPattern.FindStringSubmatch(e.Message); m != nil && e.Level == LevelDebug {
+			k := key{m[1], e.Script, e.Task}
+			open[k] = append(open[k], len(spans))
+			spans = append(spans, Span{
+				Operation: m[1],
+				Script:    e.Script,
+				Task:      e.Task,
+				Start:     e.Time,
+				Running:   true,
+			})
+		}
+	}
+
+	sort.SliceStable(spans, func(i, j int) bool {
+		return spans[i].Start.Before(spans[j].Start)
+	})
+	return spans
+}
+
+// FormatTimeline renders one line per span: start, duration, status, context,
+// operation and a bar proportional to the longest duration.
+func FormatTimeline(spans []Span) []string {
+	const barWidth = 20
+	var longest time.Duration
+	for _, span := range spans {
+		longest = max(longest, span.Duration)
+	}
+	lines := make([]string, 0, len(spans))
+	for _, span := range spans {
+		status, duration := "ok", span.Duration.Round(time.Millisecond).String()
+		switch {
+		case span.Running:
+			status, duration = "running", "-"
+		case !span.Success:
+			status = "failed"
+		}
+
+		scope := span.Script
+		if span.Task != "" {
+			scope += ":" + span.Task
+		}
+		bar := ""
+		if longest > 0 && !span.Running {
+			bar = strings.Repeat("█", max(1, int(int64(barWidth)*int64(span.Duration)/int64(longest))))
+		}
+		operation := span.Operation
+		if span.Detail != "" {
+			operation += " (" + span.Detail + ")"
+		}
+		lines = append(lines, fmt.Sprintf("%s %10s %-7s %-28s %-40s %s",
+			span.Start.Local().Format("01-02 15:04:05.0"), duration, status, scope, operation, bar))
+	}
+	return lines
+}
@@ -0,0 +1,119 @@
+package logging
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParseEntry(t *testing.T) {
+	text := "[25-01-02 15:04:05.6] [L3] [end-task:fix-login] [Manager.Merge] merge failed: conflict"
+	e, ok := ParseEntry(text)
+	if !ok {
+		t.Fatal("ParseEntry() should parse a text line")
+	}
+	if e.Level != LevelWarn || e.Script != "end-task" || e.Task != "fix-login" || e.Caller != "Manager.Merge" || e.Message != "merge failed: conflict" {
+		t.Errorf("text entry = %+v", e)
+	}
+	if e.Time.Minute() != 4 || e.Time.Second() != 5 {
+		t.Errorf("text timestamp = %v", e.Time)
+	}
+
+	jsonl := `{"ts":"2025-01-02T15:04:05.6Z","level":"L1","script":"new-task","task":"add-api","caller":"run","msg":"setup started"}`
+	e, ok = ParseEntry(jsonl)
+	if !ok || e.Level != LevelDebug || e.Script != "new-task" || e.Task != "add-api" || e.Message != "setup started" || e.Time.IsZero() {
+		t.Errorf("jsonl entry = %+v, ok=%v", e, ok)
+	}
+
+	if _, ok := ParseEntry("  continuation of a multi-line message"); ok {
+		t.Error("ParseEntry() should reject lines that are not log entries")
+	}
+}
+
+func TestEntryMarshalJSON(t *testing.T) {
+	e, _ := ParseEntry("[25-01-02 15:04:05.6] [L2] [watch] [loop] hello")
+	data, err := json.Marshal(e)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	back, ok := ParseEntry(string(data))
+	if !ok || back.Level != LevelInfo || back.Script != "watch" || back.Message != "hello" || !back.Time.Equal(e.Time) {
+		t.Errorf("round trip = %+v from %s", back, data)
+	}
+}
+
+func TestParseFilterQuery(t *testing.T) {
+	now := time.Date(2025, 1, 2, 12, 0, 0, 0, time.Local)
+	f, err := ParseFilterQuery("level:warn task:fix-login script:end-task caller:merge since:1h", now)
+	if err != nil {
+		t.Fatalf("ParseFilterQuery() error = %v", err)
+	}
+	if f.MinLevel != LevelWarn || f.Task != "fix-login" || f.Script != "end-task" || f.Caller != "merge" || !f.Since.Equal(now.Add(-time.Hour)) {
+		t.Errorf("filter = %+v", f)
+	}
+
+	match := Entry{Time: now.Add(-time.Minute), Level: LevelError, Script: "end-task", Task: "fix-login", Caller: "Manager.Merge"}
+	if !f.Match(match) {
+		t.Error("Match() should accept a matching entry")
+	}
+	for name, e := range map[string]Entry{
+		"level":  {Time: match.Time, Level: LevelInfo, Script: "end-task", Task: "fix-login", Caller: "Manager.Merge"},
+		"task":   {Time: match.Time, Level: LevelError, Script: "end-task", Task: "other", Caller: "Manager.Merge"},
+		"caller": {Time: match.Time, Level: LevelError, Script: "end-task", Task: "fix-login", Caller: "run"},
+		"since":  {Time: now.Add(-2 * time.Hour), Level: LevelError, Script: "end-task", Task: "fix-login", Caller: "Manager.Merge"},
+	} {
+		if f.Match(e) {
+			t.Errorf("Match() should reject entry failing %s", name)
+		}
+	}
+
+	for _, bad := range []string{"level:9", "color:red", "task"} {
+		if _, err := ParseFilterQuery(bad, now); err == nil {
+			t.Errorf("ParseFilterQuery(%q) expected error", bad)
+		}
+	}
+}
+
+func TestBuildTimeline(t *testing.T) {
+	base := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+	at := func(sec float64) time.Time { return base.Add(time.Duration(sec * float64(time.Second))) }
+	entries := []Entry{
+		{Time: at(0), Level: LevelDebug, Task: "a", Message: "worktree setup started"},
+		{Time: at(1), Level: LevelDebug, Task: "b", Message: "worktree setup started"},
+		{Time: at(2.5), Level: LevelDebug, Task: "a", Message: "worktree setup completed in 2.5s"},
+		{Time: at(4), Level: LevelWarn, Task: "a", Message: "merge failed in 500ms: conflict"},
+		{Time: at(5), Level: LevelInfo, Task: "a", Message: "Task review started"},
+	}
+	spans := BuildTimeline(entries)
+	if len(spans) != 3 {
+		t.Fatalf("got %d spans, want 3: %+v", len(spans), spans)
+	}
+	if spans[0].Task != "a" || spans[0].Duration != 2500*time.Millisecond || !spans[0].Success || spans[0].Running {
+		t.Errorf("spans[0] = %+v", spans[0])
+	}
+	if spans[1].Task != "b" || !spans[1].Running {
+		t.Errorf("spans[1] = %+v, want task b still running", spans[1])
+	}
+	if spans[2].Operation != "merge" || spans[2].Success || spans[2].Detail != "conflict" || !spans[2].Start.Equal(at(3.5)) {
+		t.Errorf("spans[2] = %+v", spans[2])
+	}
+}
+
+func TestParseTimeBound(t *testing.T) {
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.Local)
+	tests := map[string]time.Time{
+		"":           {},
+		"2h":         now.Add(-2 * time.Hour),
+		"7d":         now.AddDate(0, 0, -7),
+		"2026-01-02": time.Date(2026, 1, 2, 0, 0, 0, 0, time.Local),
+	}
+	for value, want := range tests {
+		got, err := ParseTimeBound(value, now)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("ParseTimeBound(%q) = %v, %v, want %v", value, got, err, want)
+		}
+	}
+	if _, err := ParseTimeBound("xd", now); err == nil {
+		t.Error("ParseTimeBound(xd) should fail")
+	}
+}
@@ -0,0 +1,166 @@
+// Package service provides business logic services for PAW.
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/fileutil"
+)
+
+// backlogTitleLimit caps the length of a backlog entry title.
+const backlogTitleLimit = 60
+
+// BacklogEntry is a drafted task that has not been started yet.
+type BacklogEntry struct {
+	ID        string              `json:"id"`
+	Content   string              `json:"content"`
+	Options   *config.TaskOptions `json:"options,omitempty"`
+	CreatedAt time.Time           `json:"created_at"`
+}
+
+// Title returns the first non-empty line of the content, truncated for display.
+func (e BacklogEntry) Title() string {
+	for _, line := range strings.Split(e.Content, "\n") {
+		line = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "#-* "))
+		if line == "" {
+			continue
+		}
+		if runes := []rune(line); len(runes) > backlogTitleLimit {
+			line = string(runes[:backlogTitleLimit-1]) + "…"
+		}
+		return line
+	}
+	return e.ID
+}
+
+// Backlog manages the drafted tasks of a workspace.
+type Backlog struct {
+	pawDir string
+}
+
+// NewBacklog creates a backlog for the given workspace.
+func NewBacklog(pawDir string) *Backlog {
+	return &Backlog{pawDir: pawDir}
+}
+
+func (b *Backlog) path() string {
+	return filepath.Join(b.pawDir, constants.BacklogFileName)
+}
+
+// lock takes the backlog file lock, so concurrent adds and removes from other
+// sessions don't drop each other's entries. The returned function releases the lock.
+func (b *Backlog) lock() (func(), error) {
+	if err := os.MkdirAll(b.pawDir, 0755); err != nil { //nolint:gosec // G301: standard directory permissions
+		return nil, err
+	}
+	return lockFile(filepath.Join(b.pawDir, constants.BacklogLockName), "backlog")
+}
+
+// Load reads the backlog entries, oldest first. A missing or corrupt file yields an empty backlog.
+func (b *Backlog) Load() ([]BacklogEntry, error) {
+	data, err := os.ReadFile(b.path()) //nolint:gosec // G304: path is constructed from pawDir
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read backlog: %w", err)
+	}
+
+	var entries []BacklogEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		_ = fileutil.BackupCorruptFile(b.path())
+		return nil, nil //nolint:nilerr // Intentional: start fresh on corrupt file
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].CreatedAt.Before(entries[j].CreatedAt)
+	})
+	return entries, nil
+}
+
+// Add drafts a new task.
+func (b *Backlog) Add(content string, opts *config.TaskOptions) (*BacklogEntry, error) {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return nil, errors.New("backlog entry content is empty")
+	}
+
+	unlock, err := b.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	entries, err := b.Load()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	entry := BacklogEntry{
+		ID:        strconv.FormatInt(now.UnixNano(), 36),
+		Content:   content,
+		Options:   opts,
+		CreatedAt: now,
+	}
+	if err := b.save(append(entries, entry)); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// Get returns a backlog entry by ID.
+func (b *Backlog) Get(id string) (*BacklogEntry, error) {
+	entries, err := b.Load()
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.ID == id {
+			return &entry, nil
+		}
+	}
+	return nil, fmt.Errorf("backlog entry %s not found", id)
+}
+
+// Remove deletes a backlog entry. Removing a missing entry is not an error.
+func (b *Backlog) Remove(id string) error {
+	unlock, err := b.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	entries, err := b.Load()
+	if err != nil {
+		return err
+	}
+	for i, entry := range entries {
+		if entry.ID == id {
+			return b.save(append(entries[:i], entries[i+1:]...))
+		}
+	}
+	return nil
+}
+
+func (b *Backlog) save(entries []BacklogEntry) error {
+	if err := os.MkdirAll(b.pawDir, 0755); err != nil { //nolint:gosec // G301: standard directory permissions
+		return err
+	}
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal backlog: %w", err)
+	}
+	if err := fileutil.WriteFileAtomic(b.path(), data, 0644); err != nil {
+		return fmt.Errorf("failed to write backlog: %w", err)
+	}
+	return nil
+}
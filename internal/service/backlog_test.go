@@ -0,0 +1,147 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/constants"
+)
+
+func TestBacklog_AddGetRemove(t *testing.T) {
+	backlog := NewBacklog(t.TempDir())
+
+	opts := config.DefaultTaskOptions()
+	opts.Model = config.ModelSonnet
+	first, err := backlog.Add("  # Fix login\n\nRedirect after OAuth  ", opts)
+	if err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	second, err := backlog.Add("Add dark mode", nil)
+	if err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if _, err := backlog.Add("   ", nil); err == nil {
+		t.Error("Add with empty content should fail")
+	}
+
+	entries, err := backlog.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(entries) != 2 || entries[0].ID != first.ID || entries[1].ID != second.ID {
+		t.Fatalf("Load() = %+v, want entries in creation order", entries)
+	}
+
+	got, err := backlog.Get(first.ID)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got.Content != "# Fix login\n\nRedirect after OAuth" {
+		t.Errorf("Content = %q", got.Content)
+	}
+	if got.Options == nil || got.Options.Model != config.ModelSonnet {
+		t.Errorf("Options = %+v, want model %s", got.Options, config.ModelSonnet)
+	}
+
+	if err := backlog.Remove(first.ID); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if _, err := backlog.Get(first.ID); err == nil {
+		t.Error("Get after Remove should fail")
+	}
+	// Removing a missing entry is not an error
+	if err := backlog.Remove("missing"); err != nil {
+		t.Errorf("Remove(missing) = %v", err)
+	}
+}
+
+func TestBacklog_ConcurrentAddAndRemove(t *testing.T) {
+	backlog := NewBacklog(t.TempDir())
+	removed, err := backlog.Add("removed", nil)
+	if err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := backlog.Add(fmt.Sprintf("task %d", i), nil); err != nil {
+				t.Errorf("Add failed: %v", err)
+			}
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := backlog.Remove(removed.ID); err != nil {
+			t.Errorf("Remove failed: %v", err)
+		}
+	}()
+	wg.Wait()
+
+	entries, err := backlog.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(entries) != 20 {
+		t.Errorf("Expected 20 entries, got %d", len(entries))
+	}
+}
+
+func TestBacklog_LoadCorruptFile(t *testing.T) {
+	pawDir := t.TempDir()
+	path := filepath.Join(pawDir, constants.BacklogFileName)
+	if err := os.WriteFile(path, []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := NewBacklog(pawDir).Load()
+	if err != nil || len(entries) != 0 {
+		t.Errorf("Load() = %v, %v; want empty backlog", entries, err)
+	}
+}
+
+func TestBacklogEntryTitle(t *testing.T) {
+	tests := []struct {
+		content string
+		want    string
+	}{
+		{"## Fix login\nDetails", "Fix login"},
+		{"\n\n- Add dark mode", "Add dark mode"},
+		{strings.Repeat("a", 80), strings.Repeat("a", 59) + "…"},
+		{"", "id"},
+	}
+	for _, tt := range tests {
+		entry := BacklogEntry{ID: "id", Content: tt.content}
+		if got := entry.Title(); got != tt.want {
+			t.Errorf("Title(%q) = %q, want %q", tt.content, got, tt.want)
+		}
+	}
+}
+
+func TestDiscoverBacklog(t *testing.T) {
+	pawDir := t.TempDir()
+	entry, err := NewBacklog(pawDir).Add("Fix login", &config.TaskOptions{Model: config.ModelOpus})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tasks := discoverBacklog(pawDir, "api")
+	if len(tasks) != 1 {
+		t.Fatalf("discoverBacklog() returned %d tasks, want 1", len(tasks))
+	}
+	task := tasks[0]
+	if task.Status != DiscoveredBacklog || task.BacklogID != entry.ID || task.WindowID != "" {
+		t.Errorf("unexpected backlog task: %+v", task)
+	}
+	if task.Name != "Fix login" || task.Session != "api" || task.Model != string(config.ModelOpus) {
+		t.Errorf("unexpected backlog task: %+v", task)
+	}
+}
@@ -0,0 +1,151 @@
+// Package service provides business logic services for PAW.
+package service
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/git"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/task"
+)
+
+// Verification results shown on kanban cards.
+const (
+	VerifyPassed = "passed"
+	VerifyFailed = "failed"
+)
+
+// CardDetails are the kanban card details of a task that need git or several
+// task files. Task discovery loads them in the background.
+type CardDetails struct {
+	Diff       git.DiffStat  // Changes against the target branch (committed or not)
+	Ahead      int           // Task commits not on the target branch
+	Behind     int           // Target branch commits since the task branched
+	LastCommit string        // Subject of the last task commit
+	Verify     string        // VerifyPassed or VerifyFailed, empty if never run
+	Hook       *HookMetadata // Last hook run, if any
+	PRNumber   int           // Pull request number, 0 if none
+	PRState    string        // Last PR state seen by the PR watcher ("open", "closed", "merged")
+	Unreviewed int           // Changed files not marked viewed in the diff viewer (reviewed tasks only)
+}
+
+// cardDetailsEntry is the cached card details of a task.
+type cardDetailsEntry struct {
+	checked time.Time
+	details CardDetails
+	loading bool
+}
+
+// LoadCardDetails reads the card details of a task from its agent directory,
+// its worktree and the workspace PR watch list.
+func LoadCardDetails(pawDir, agentDir, taskName string) CardDetails {
+	var details CardDetails
+
+	worktreeDir := filepath.Join(agentDir, constants.WorktreeDirName)
+	if _, err := os.Stat(worktreeDir); err == nil {
+		loadGitDetails(&details, pawDir, agentDir, worktreeDir)
+		details.Unreviewed = countUnreviewed(agentDir, worktreeDir)
+	}
+
+	if verification, err := LoadVerificationMetadata(filepath.Join(agentDir, constants.VerifyJSONFile)); err == nil {
+		details.Verify = VerifyFailed
+		if verification.Success {
+			details.Verify = VerifyPassed
+		}
+	}
+	details.Hook = lastHook(agentDir)
+
+	if data, err := os.ReadFile(filepath.Join(agentDir, constants.PRFileName)); err == nil { //nolint:gosec // G304: path is from the agents directory
+		details.PRNumber, _ = strconv.Atoi(strings.TrimSpace(string(data)))
+	}
+	if details.PRNumber > 0 {
+		if state, err := NewPRWatchList(pawDir).Load(); err == nil {
+			for _, e := range state.Entries {
+				if e.TaskName == taskName && e.PRNumber == details.PRNumber {
+					details.PRState = e.LastState
+				}
+			}
+		}
+	}
+	return details
+}
+
+// loadGitDetails fills the diff, commit counts and last commit of a task worktree.
+func loadGitDetails(details *CardDetails, pawDir, agentDir, worktreeDir string) {
+	gitClient := git.New()
+	base := cardBaseBranch(gitClient, pawDir, agentDir, worktreeDir)
+
+	var err error
+	if details.Diff, err = gitClient.DiffNumstat(worktreeDir, base); err != nil {
+		logging.Debug("Failed to diff %s against %s: %v", worktreeDir, base, err)
+	}
+	if details.Ahead, details.Behind, err = gitClient.AheadBehind(worktreeDir, base, "HEAD"); err != nil {
+		logging.Debug("Failed to count commits of %s: %v", worktreeDir, err)
+	}
+	if details.Ahead > 0 {
+		if commits, err := gitClient.GetBranchCommits(worktreeDir, "HEAD", base, 1); err == nil && len(commits) > 0 {
+			details.LastCommit = commits[0].Subject
+		}
+	}
+}
+
+// countUnreviewed counts the changed files of a reviewed task that are not
+// marked viewed at their current content. Tasks never reviewed count zero.
+func countUnreviewed(agentDir, worktreeDir string) int {
+	state, err := NewReviewStore(agentDir).Load()
+	if err != nil || state.Base == "" {
+		return 0
+	}
+	blobs, err := git.New().ChangedBlobs(worktreeDir, state.Base, "HEAD")
+	if err != nil {
+		logging.Debug("Failed to count unreviewed files in %s: %v", worktreeDir, err)
+		return 0
+	}
+	return state.Unreviewed(blobs)
+}
+
+// cardBaseBranch returns the branch a task is compared against, resolved as
+// merges and syncs do: the task's target branch, on the fetch remote when it
+// has been fetched (sync rebases onto it), otherwise the local branch.
+func cardBaseBranch(gitClient git.Client, pawDir, agentDir, worktreeDir string) string {
+	cfg, err := config.Load(pawDir)
+	if err != nil {
+		logging.Debug("Failed to load config for card details: %v", err)
+		cfg = nil
+	}
+	mgr := task.NewManager(filepath.Dir(agentDir), worktreeDir, pawDir, true, cfg)
+	target := mgr.TargetBranch(&task.Task{AgentDir: agentDir})
+	if remoteRef := cfg.GetFetchRemote() + "/" + target; gitClient.RefExists(worktreeDir, "refs/remotes/"+remoteRef) {
+		return remoteRef
+	}
+	return target
+}
+
+// lastHook returns the metadata of the most recently run hook of a task.
+func lastHook(agentDir string) *HookMetadata {
+	paths, _ := filepath.Glob(filepath.Join(agentDir, ".hook-*.json"))
+	var last *HookMetadata
+	var lastTime time.Time
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil || (last != nil && !info.ModTime().After(lastTime)) {
+			continue
+		}
+		data, err := os.ReadFile(path) //nolint:gosec // G304: path is from the agents directory
+		if err != nil {
+			continue
+		}
+		var meta HookMetadata
+		if json.Unmarshal(data, &meta) == nil {
+			last, lastTime = &meta, info.ModTime()
+		}
+	}
+	return last
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/constants"
+)
+
+func TestLoadCardDetails(t *testing.T) {
+	pawDir := t.TempDir()
+	agentDir := filepath.Join(pawDir, constants.AgentsDirName, "my-task")
+	if err := os.MkdirAll(agentDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if details := LoadCardDetails(pawDir, agentDir, "my-task"); details != (CardDetails{}) {
+		t.Errorf("empty task details = %+v", details)
+	}
+
+	files := map[string]string{
+		constants.VerifyJSONFile: `{"command":"make test","success":false,"exit_code":2}`,
+		".hook-pre-task.json":    `{"name":"pre-task","status":"success"}`,
+		constants.PRFileName:     "42\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(agentDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The most recent hook wins
+	later := filepath.Join(agentDir, ".hook-pre-merge.json")
+	if err := os.WriteFile(later, []byte(`{"name":"pre-merge","status":"failed","exit_code":1}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	future := time.Now().Add(time.Minute)
+	if err := os.Chtimes(later, future, future); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewPRWatchList(pawDir).Save(&PRWatchState{Entries: []PRWatchEntry{
+		{TaskName: "my-task", PRNumber: 42, LastState: "merged"},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+
+	details := LoadCardDetails(pawDir, agentDir, "my-task")
+	if details.Verify != VerifyFailed {
+		t.Errorf("Verify = %q, want %q", details.Verify, VerifyFailed)
+	}
+	if details.Hook == nil || details.Hook.Name != "pre-merge" || details.Hook.Status != "failed" {
+		t.Errorf("Hook = %+v, want failed pre-merge", details.Hook)
+	}
+	if details.PRNumber != 42 || details.PRState != "merged" {
+		t.Errorf("PR = #%d %q, want #42 merged", details.PRNumber, details.PRState)
+	}
+}
+
+func TestApplyCardDetailsLoadsInBackground(t *testing.T) {
+	pawDir := t.TempDir()
+	agentDir := filepath.Join(pawDir, constants.AgentsDirName, "my-task")
+	if err := os.MkdirAll(agentDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(agentDir, constants.PRFileName), []byte("7"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	svc := NewTaskDiscoveryService()
+	first := &DiscoveredTask{Name: "my-task"}
+	svc.applyCardDetails(first, pawDir, agentDir)
+	if first.Details.PRNumber != 0 {
+		t.Error("first discovery should not wait for the details")
+	}
+
+	svc.detailsLoading.Wait()
+	second := &DiscoveredTask{Name: "my-task"}
+	svc.applyCardDetails(second, pawDir, agentDir)
+	if second.Details.PRNumber != 7 {
+		t.Errorf("second discovery PR = %d, want 7", second.Details.PRNumber)
+	}
+
+	// Fresh details are reused without reloading
+	if err := os.Remove(filepath.Join(agentDir, constants.PRFileName)); err != nil {
+		t.Fatal(err)
+	}
+	svc.applyCardDetails(second, pawDir, agentDir)
+	svc.detailsLoading.Wait()
+	svc.applyCardDetails(second, pawDir, agentDir)
+	if second.Details.PRNumber != 7 {
+		t.Error("details within the TTL should be reused")
+	}
+}
+
+func TestLoadCardDetailsUsesTargetBranch(t *testing.T) {
+	runGit := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+		}
+	}
+	commit := func(dir, name string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(name+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		runGit(dir, "add", name)
+		runGit(dir, "commit", "-q", "-m", "add "+name)
+	}
+
+	projectDir := t.TempDir()
+	runGit(projectDir, "init", "-q", "-b", "main")
+	runGit(projectDir, "config", "user.name", "Test User")
+	runGit(projectDir, "config", "user.email", "test@example.com")
+	commit(projectDir, "base.txt")
+	runGit(projectDir, "branch", "release/1.0")
+	commit(projectDir, "main-only.txt") // Only on main; must not count for a release task
+
+	pawDir := filepath.Join(projectDir, ".paw")
+	agentDir := filepath.Join(pawDir, constants.AgentsDirName, "fix")
+	worktreeDir := filepath.Join(agentDir, constants.WorktreeDirName)
+	if err := os.MkdirAll(agentDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := (&config.TaskOptions{TargetBranch: "release/1.0"}).Save(agentDir); err != nil {
+		t.Fatal(err)
+	}
+	runGit(projectDir, "worktree", "add", "-q", "-b", "fix", worktreeDir, "release/1.0")
+	commit(worktreeDir, "fix.txt")
+
+	details := LoadCardDetails(pawDir, agentDir, "fix")
+	if details.Ahead != 1 || details.Behind != 0 {
+		t.Errorf("ahead/behind = %d/%d, want 1/0 against release/1.0", details.Ahead, details.Behind)
+	}
+	if details.Diff.Files != 1 {
+		t.Errorf("diff files = %d, want 1 (fix.txt only)", details.Diff.Files)
+	}
+	if details.LastCommit != "add fix.txt" {
+		t.Errorf("LastCommit = %q", details.LastCommit)
+	}
+}
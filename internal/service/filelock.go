@@ -0,0 +1,59 @@
+// Package service provides business logic services for PAW.
+package service
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/constants"
+)
+
+// lockFile takes a file lock guarding read-modify-write of a shared file,
+// waiting while another process holds it. Locks left by processes that are no
+// longer running are replaced. name describes the guarded file in errors.
+// The returned function releases the lock.
+func lockFile(path, name string) (func(), error) {
+	for retries := 0; retries < constants.FileLockMaxRetries; retries++ {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644) //nolint:gosec // G302: lock file just needs to exist
+		if err == nil {
+			_, writeErr := fmt.Fprintf(f, "%d", os.Getpid())
+			closeErr := f.Close()
+			if writeErr != nil || closeErr != nil {
+				_ = os.Remove(path)
+				return nil, fmt.Errorf("failed to write %s lock: write=%v, close=%v", name, writeErr, closeErr)
+			}
+			return func() { _ = os.Remove(path) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("failed to lock %s: %w", name, err)
+		}
+
+		if isStaleLock(path) {
+			_ = os.Remove(path)
+			continue
+		}
+		time.Sleep(constants.FileLockRetryInterval)
+	}
+	return nil, fmt.Errorf("timed out waiting for %s lock", name)
+}
+
+// isStaleLock reports whether the process holding a lock file is gone.
+func isStaleLock(path string) bool {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: lock paths are constructed by callers
+	if err != nil {
+		return false
+	}
+	var pid int
+	if _, err := fmt.Sscanf(string(data), "%d", &pid); err != nil {
+		// The holder may not have written its PID yet; only a lingering lock is stale
+		info, statErr := os.Stat(path)
+		return statErr == nil && time.Since(info.ModTime()) > time.Second
+	}
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return true
+	}
+	return process.Signal(syscall.Signal(0)) != nil
+}
@@ -78,22 +78,33 @@ func (s *HistoryService) SetClaudeClient(client claude.Client) {
 
 // SaveCompleted saves a completed task to history.
 func (s *HistoryService) SaveCompleted(taskName, taskContent, paneContent string) error {
+	_, err := s.save(taskName, taskContent, paneContent, false, nil, nil)
+	return err
+}
+
+// SaveCompletedSummary saves a completed task to history and returns the
+// generated summary, so callers can reuse it without asking Claude again.
+// The summary is "" if it could not be generated.
+func (s *HistoryService) SaveCompletedSummary(taskName, taskContent, paneContent string) (string, error) {
 	return s.save(taskName, taskContent, paneContent, false, nil, nil)
 }
 
 // SaveCancelled saves a cancelled task to history with .cancelled extension.
 func (s *HistoryService) SaveCancelled(taskName, taskContent, paneContent string) error {
-	return s.save(taskName, taskContent, paneContent, true, nil, nil)
+	_, err := s.save(taskName, taskContent, paneContent, true, nil, nil)
+	return err
 }
 
 // SaveCompletedWithDetails saves a completed task with extra metadata and hook outputs.
 func (s *HistoryService) SaveCompletedWithDetails(taskName, taskContent, paneContent string, meta *HistoryMetadata, hookOutputs map[string]string) error {
-	return s.save(taskName, taskContent, paneContent, false, meta, hookOutputs)
+	_, err := s.save(taskName, taskContent, paneContent, false, meta, hookOutputs)
+	return err
 }
 
 // SaveCancelledWithDetails saves a cancelled task with extra metadata and hook outputs.
 func (s *HistoryService) SaveCancelledWithDetails(taskName, taskContent, paneContent string, meta *HistoryMetadata, hookOutputs map[string]string) error {
-	return s.save(taskName, taskContent, paneContent, true, meta, hookOutputs)
+	_, err := s.save(taskName, taskContent, paneContent, true, meta, hookOutputs)
+	return err
 }
 
 // RecordStatusTransition records a status transition for a task.
@@ -132,14 +143,96 @@ func (s *HistoryService) RecordStatusTransition(taskName string, from, to task.S
 	return nil
 }
 
-// save saves a task to history.
-func (s *HistoryService) save(taskName, taskContent, paneContent string, cancelled bool, meta *HistoryMetadata, hookOutputs map[string]string) error {
+// RecordReview records a submitted diff viewer review round for a task.
+func (s *HistoryService) RecordReview(taskName string, round int, comments []ReviewComment) error {
+	reviewDir := filepath.Join(s.historyDir, "reviews")
+	if err := os.MkdirAll(reviewDir, 0755); err != nil { //nolint:gosec // G301: standard directory permissions
+		return fmt.Errorf("failed to create review history directory: %w", err)
+	}
+
+	record := map[string]any{
+		"ts":       time.Now().Format(time.RFC3339Nano),
+		"task":     taskName,
+		"round":    round,
+		"comments": comments,
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal review: %w", err)
+	}
+
+	reviewFile := filepath.Join(reviewDir, taskName+".jsonl")
+	f, err := os.OpenFile(reviewFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644) //nolint:gosec // G302: review history file needs to be readable by other tools
+	if err != nil {
+		return fmt.Errorf("failed to open review history file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write review history: %w", err)
+	}
+
+	return nil
+}
+
+// OutcomeCancelled is the outcome of a task cancelled with cancel-task.
+// Other outcomes are the finish actions (merge, pr, done, drop, ...).
+const OutcomeCancelled = "cancelled"
+
+// RecordOutcome records how a task was finished and the model it ran on.
+func (s *HistoryService) RecordOutcome(taskName, outcome string, model config.Model) error {
+	return s.appendRecord("outcomes", taskName, map[string]any{
+		"ts":      time.Now().Format(time.RFC3339Nano),
+		"task":    taskName,
+		"outcome": outcome,
+		"model":   string(model),
+	})
+}
+
+// RecordMergeConflict records merge conflicts hit while merging a task and
+// whether they were resolved automatically.
+func (s *HistoryService) RecordMergeConflict(taskName string, files []string, resolved bool) error {
+	return s.appendRecord("conflicts", taskName, map[string]any{
+		"ts":       time.Now().Format(time.RFC3339Nano),
+		"task":     taskName,
+		"files":    files,
+		"resolved": resolved,
+	})
+}
+
+// appendRecord appends a JSON line to the task's file in a history subdirectory.
+func (s *HistoryService) appendRecord(kind, taskName string, record map[string]any) error {
+	dir := filepath.Join(s.historyDir, kind)
+	if err := os.MkdirAll(dir, 0755); err != nil { //nolint:gosec // G301: standard directory permissions
+		return fmt.Errorf("failed to create %s history directory: %w", kind, err)
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s record: %w", kind, err)
+	}
+
+	f, err := os.OpenFile(filepath.Join(dir, taskName+".jsonl"), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644) //nolint:gosec // G302: history files need to be readable by other tools
+	if err != nil {
+		return fmt.Errorf("failed to open %s history file: %w", kind, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write %s history: %w", kind, err)
+	}
+	return nil
+}
+
+// save saves a task to history and returns the generated summary.
+func (s *HistoryService) save(taskName, taskContent, paneContent string, cancelled bool, meta *HistoryMetadata, hookOutputs map[string]string) (string, error) {
 	if err := os.MkdirAll(s.historyDir, 0755); err != nil { //nolint:gosec // G301: standard directory permissions
-		return fmt.Errorf("failed to create history directory: %w", err)
+		return "", fmt.Errorf("failed to create history directory: %w", err)
 	}
 
 	if paneContent == "" {
-		return errors.New("empty pane content")
+		return "", errors.New("empty pane content")
 	}
 
 	// Generate summary using Claude
@@ -203,7 +296,7 @@ func (s *HistoryService) save(taskName, taskContent, paneContent string, cancell
 
 	historyFile := filepath.Join(s.historyDir, filename)
 	if err := fileutil.WriteFileAtomic(historyFile, []byte(historyContent.String()), 0644); err != nil {
-		return fmt.Errorf("failed to write history file: %w", err)
+		return "", fmt.Errorf("failed to write history file: %w", err)
 	}
 
 	status := "completed"
@@ -212,7 +305,7 @@ func (s *HistoryService) save(taskName, taskContent, paneContent string, cancell
 	}
 	logging.Debug("Task history saved (%s): %s", status, historyFile)
 
-	return nil
+	return summary, nil
 }
 
 // LoadTaskContent loads the task content from a history file.
@@ -27,6 +27,10 @@ func (m *mockClaudeClient) GenerateSummary(paneContent string) (string, error) {
 	return m.summaryToReturn, nil
 }
 
+func (m *mockClaudeClient) GenerateCommitMessage(diff, guidelines string) (string, error) {
+	return "test commit", nil
+}
+
 func (m *mockClaudeClient) WaitForReady(tm tmux.Client, target string) error {
 	return nil
 }
@@ -107,6 +111,31 @@ func TestHistoryService_SaveCompleted(t *testing.T) {
 	}
 }
 
+func TestHistoryService_SaveCompletedSummary(t *testing.T) {
+	svc := NewHistoryService(t.TempDir())
+	svc.SetClaudeClient(&mockClaudeClient{summaryToReturn: "Merged summary"})
+
+	summary, err := svc.SaveCompletedSummary("merged-task", "Task content", "Pane content")
+	if err != nil {
+		t.Fatalf("SaveCompletedSummary failed: %v", err)
+	}
+	if summary != "Merged summary" {
+		t.Errorf("summary = %q, want the saved summary", summary)
+	}
+
+	files, err := svc.ListHistoryFiles()
+	if err != nil || len(files) != 1 {
+		t.Fatalf("ListHistoryFiles = %v, %v; want 1 file", files, err)
+	}
+	content, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "---summary---\nMerged summary") {
+		t.Errorf("history file should contain the returned summary:\n%s", content)
+	}
+}
+
 func TestHistoryService_SaveCancelled(t *testing.T) {
 	// Create temp directory
 	tmpDir, err := os.MkdirTemp("", "paw-history-test-*")
@@ -0,0 +1,144 @@
+// Package service provides business logic services for PAW.
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/fileutil"
+)
+
+// InboxOption is an answer offered by an agent question.
+type InboxOption struct {
+	Label       string `json:"label"`
+	Description string `json:"description,omitempty"`
+}
+
+// InboxQuestion is a question asked with AskUserQuestion.
+// The JSON fields match the tool input of the hook payload.
+type InboxQuestion struct {
+	Header      string        `json:"header,omitempty"`
+	Question    string        `json:"question"`
+	Options     []InboxOption `json:"options"`
+	MultiSelect bool          `json:"multiSelect,omitempty"`
+}
+
+// InboxEntry is an agent waiting for the answers to its questions.
+type InboxEntry struct {
+	Session   string          `json:"session"`
+	WindowID  string          `json:"window_id"`
+	Task      string          `json:"task"`
+	Questions []InboxQuestion `json:"questions"`
+	AskedAt   time.Time       `json:"asked_at"`
+}
+
+// InboxAnswer is the answer to one question: the chosen options, or text
+// typed instead of choosing.
+type InboxAnswer struct {
+	Options []int
+	Text    string
+}
+
+// Inbox stores the questions agents are waiting on, one file per task
+// window, so a single popup can list them across sessions.
+type Inbox struct {
+	dir string
+}
+
+// NewInbox creates an inbox in the given directory.
+func NewInbox(dir string) *Inbox {
+	return &Inbox{dir: dir}
+}
+
+func (b *Inbox) path(session, windowID string) string {
+	name := strings.NewReplacer("/", "_", "@", "").Replace(session + "_" + windowID)
+	return filepath.Join(b.dir, name+".json")
+}
+
+// ParseAskUserQuestionPayload extracts the questions from an AskUserQuestion
+// hook payload.
+func ParseAskUserQuestionPayload(data []byte) ([]InboxQuestion, error) {
+	var payload struct {
+		ToolInput struct {
+			Questions []InboxQuestion `json:"questions"`
+		} `json:"tool_input"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("failed to parse hook payload: %w", err)
+	}
+
+	var questions []InboxQuestion
+	for _, q := range payload.ToolInput.Questions {
+		if strings.TrimSpace(q.Question) != "" {
+			questions = append(questions, q)
+		}
+	}
+	if len(questions) == 0 {
+		return nil, errors.New("hook payload has no questions")
+	}
+	return questions, nil
+}
+
+// Put adds or replaces the entry of a task window.
+func (b *Inbox) Put(entry InboxEntry) error {
+	if entry.AskedAt.IsZero() {
+		entry.AskedAt = time.Now()
+	}
+	if err := os.MkdirAll(b.dir, 0755); err != nil { //nolint:gosec // G301: standard directory permissions
+		return err
+	}
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return err
+	}
+	return fileutil.WriteFileAtomic(b.path(entry.Session, entry.WindowID), data, 0644)
+}
+
+// Remove deletes the entry of a task window, if any.
+func (b *Inbox) Remove(session, windowID string) error {
+	if err := os.Remove(b.path(session, windowID)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// List returns all entries, oldest question first. Unreadable entries are
+// removed.
+func (b *Inbox) List() ([]InboxEntry, error) {
+	files, err := os.ReadDir(b.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var entries []InboxEntry
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ".json" {
+			continue
+		}
+		path := filepath.Join(b.dir, f.Name())
+		data, err := os.ReadFile(path) //nolint:gosec // G304: path is inside the inbox directory
+		if err != nil {
+			continue
+		}
+		var entry InboxEntry
+		if err := json.Unmarshal(data, &entry); err != nil || entry.Session == "" || entry.WindowID == "" {
+			_ = os.Remove(path)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].AskedAt.Before(entries[j].AskedAt)
+	})
+	return entries, nil
+}
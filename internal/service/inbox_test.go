@@ -0,0 +1,91 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseAskUserQuestionPayload(t *testing.T) {
+	payload := `{
+		"hook_event_name": "PreToolUse",
+		"tool_name": "AskUserQuestion",
+		"tool_input": {"questions": [
+			{"question": "Which database?", "header": "DB", "multiSelect": false,
+			 "options": [{"label": "Postgres", "description": "Relational"}, {"label": "SQLite"}]},
+			{"question": "  ", "options": []}
+		]}
+	}`
+
+	questions, err := ParseAskUserQuestionPayload([]byte(payload))
+	if err != nil {
+		t.Fatalf("ParseAskUserQuestionPayload() error = %v", err)
+	}
+	if len(questions) != 1 {
+		t.Fatalf("questions = %+v, want one", questions)
+	}
+	q := questions[0]
+	if q.Header != "DB" || q.Question != "Which database?" || len(q.Options) != 2 || q.Options[0].Description != "Relational" {
+		t.Errorf("question = %+v", q)
+	}
+
+	if _, err := ParseAskUserQuestionPayload([]byte(`{"tool_input": {}}`)); err == nil {
+		t.Error("payload without questions should fail")
+	}
+	if _, err := ParseAskUserQuestionPayload([]byte(`{`)); err == nil {
+		t.Error("invalid payload should fail")
+	}
+}
+
+func TestInbox_PutListRemove(t *testing.T) {
+	dir := t.TempDir()
+	inbox := NewInbox(dir)
+	now := time.Now()
+
+	entries := []InboxEntry{
+		{Session: "api", WindowID: "@3", Task: "later", Questions: []InboxQuestion{{Question: "B?"}}, AskedAt: now},
+		{Session: "web", WindowID: "@1", Task: "earlier", Questions: []InboxQuestion{{Question: "A?"}}, AskedAt: now.Add(-time.Minute)},
+	}
+	for _, e := range entries {
+		if err := inbox.Put(e); err != nil {
+			t.Fatalf("Put() error = %v", err)
+		}
+	}
+	// A new question of the same window replaces the old one
+	entries[0].Questions = []InboxQuestion{{Question: "C?"}}
+	if err := inbox.Put(entries[0]); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "broken.json"), []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := inbox.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(got) != 2 || got[0].Task != "earlier" || got[1].Questions[0].Question != "C?" {
+		t.Fatalf("List() = %+v", got)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "broken.json")); !os.IsNotExist(err) {
+		t.Error("List() should remove unreadable entries")
+	}
+
+	if err := inbox.Remove("web", "@1"); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if err := inbox.Remove("web", "@1"); err != nil {
+		t.Errorf("Remove() of a missing entry error = %v", err)
+	}
+	if got, _ := inbox.List(); len(got) != 1 || got[0].Session != "api" {
+		t.Errorf("List() after Remove = %+v", got)
+	}
+}
+
+func TestInbox_ListMissingDir(t *testing.T) {
+	entries, err := NewInbox(filepath.Join(t.TempDir(), "missing")).List()
+	if err != nil || len(entries) != 0 {
+		t.Errorf("List() = %v, %v; want empty", entries, err)
+	}
+}
@@ -0,0 +1,83 @@
+// Package service provides business logic services for PAW.
+package service
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/dongho-jung/paw/internal/fileutil"
+)
+
+const (
+	// PaletteHistoryFile is the name of the recently used palette commands file.
+	PaletteHistoryFile = "palette-history"
+	// MaxPaletteHistoryEntries is the maximum number of command IDs to keep.
+	MaxPaletteHistoryEntries = 20
+)
+
+// PaletteHistory tracks recently used command palette commands.
+type PaletteHistory struct {
+	pawDir string
+}
+
+// NewPaletteHistory creates a palette history for a project.
+func NewPaletteHistory(pawDir string) *PaletteHistory {
+	return &PaletteHistory{pawDir: pawDir}
+}
+
+func (h *PaletteHistory) path() string {
+	return filepath.Join(h.pawDir, PaletteHistoryFile)
+}
+
+// Recent returns the recently used command IDs, most recent first.
+func (h *PaletteHistory) Recent() ([]string, error) {
+	path := h.path()
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is from path()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var ids []string
+	if err := json.Unmarshal(data, &ids); err != nil {
+		// Corrupt JSON: backup and start over
+		_ = fileutil.BackupCorruptFile(path)
+		return nil, nil //nolint:nilerr // Intentional: return empty list on corrupt file
+	}
+	return ids, nil
+}
+
+// Record moves a command ID to the front of the history.
+func (h *PaletteHistory) Record(id string) error {
+	if id == "" {
+		return nil
+	}
+
+	ids, err := h.Recent()
+	if err != nil {
+		ids = nil
+	}
+
+	updated := make([]string, 0, len(ids)+1)
+	updated = append(updated, id)
+	for _, existing := range ids {
+		if existing != id {
+			updated = append(updated, existing)
+		}
+	}
+	if len(updated) > MaxPaletteHistoryEntries {
+		updated = updated[:MaxPaletteHistoryEntries]
+	}
+
+	if err := os.MkdirAll(h.pawDir, 0755); err != nil { //nolint:gosec // G301: standard directory permissions
+		return err
+	}
+	data, err := json.MarshalIndent(updated, "", "  ")
+	if err != nil {
+		return err
+	}
+	return fileutil.WriteFileAtomic(h.path(), data, 0644)
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestPaletteHistory_Record(t *testing.T) {
+	h := NewPaletteHistory(t.TempDir())
+
+	for _, id := range []string{"toggle-log", "new-task", "toggle-log"} {
+		if err := h.Record(id); err != nil {
+			t.Fatalf("Record(%s) error = %v", id, err)
+		}
+	}
+
+	ids, err := h.Recent()
+	if err != nil {
+		t.Fatalf("Recent() error = %v", err)
+	}
+	if want := []string{"toggle-log", "new-task"}; !slices.Equal(ids, want) {
+		t.Errorf("Recent() = %v, want %v", ids, want)
+	}
+}
+
+func TestPaletteHistory_Limit(t *testing.T) {
+	h := NewPaletteHistory(t.TempDir())
+	for i := 0; i < MaxPaletteHistoryEntries+5; i++ {
+		if err := h.Record(fmt.Sprintf("cmd-%d", i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ids, _ := h.Recent()
+	if len(ids) != MaxPaletteHistoryEntries {
+		t.Fatalf("len(Recent()) = %d, want %d", len(ids), MaxPaletteHistoryEntries)
+	}
+	if want := fmt.Sprintf("cmd-%d", MaxPaletteHistoryEntries+4); ids[0] != want {
+		t.Errorf("Recent()[0] = %s, want %s", ids[0], want)
+	}
+}
+
+func TestPaletteHistory_Corrupt(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, PaletteHistoryFile), []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ids, err := NewPaletteHistory(dir).Recent()
+	if err != nil || len(ids) != 0 {
+		t.Errorf("Recent() = %v, %v; want empty list", ids, err)
+	}
+}
@@ -0,0 +1,214 @@
+// Package service provides business logic services for PAW.
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/fileutil"
+)
+
+// RegisteredProject is a project in the global project registry.
+type RegisteredProject struct {
+	Name     string    `json:"name"`    // Session name
+	Dir      string    `json:"dir"`     // Project directory
+	PawDir   string    `json:"paw_dir"` // Workspace directory
+	Git      bool      `json:"git"`
+	Pinned   bool      `json:"pinned,omitempty"`
+	LastUsed time.Time `json:"last_used,omitzero"`
+}
+
+// Local reports whether the project uses a local .paw workspace.
+func (p RegisteredProject) Local() bool {
+	return p.PawDir == filepath.Join(p.Dir, constants.PawDirName)
+}
+
+// TaskCount returns the number of tasks in the project's workspace.
+func (p RegisteredProject) TaskCount() int {
+	entries, err := os.ReadDir(filepath.Join(p.PawDir, constants.AgentsDirName))
+	if err != nil {
+		return 0
+	}
+	count := 0
+	for _, entry := range entries {
+		if entry.IsDir() {
+			count++
+		}
+	}
+	return count
+}
+
+// ProjectRegistry stores the projects PAW has run in, so they can be listed
+// and started while their sessions are not running.
+type ProjectRegistry struct {
+	path string
+}
+
+// NewProjectRegistry creates a registry in the global PAW directory.
+func NewProjectRegistry(globalDir string) *ProjectRegistry {
+	return &ProjectRegistry{path: filepath.Join(globalDir, constants.ProjectRegistryFile)}
+}
+
+// List returns the registered projects: pinned first, then most recently used.
+func (r *ProjectRegistry) List() ([]RegisteredProject, error) {
+	projects, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(projects, func(i, j int) bool {
+		a, b := projects[i], projects[j]
+		if a.Pinned != b.Pinned {
+			return a.Pinned
+		}
+		if !a.LastUsed.Equal(b.LastUsed) {
+			return a.LastUsed.After(b.LastUsed)
+		}
+		return a.Name < b.Name
+	})
+	return projects, nil
+}
+
+// Find returns the project with the given session name or directory.
+func (r *ProjectRegistry) Find(ref string) (RegisteredProject, bool) {
+	projects, err := r.load()
+	if err != nil {
+		return RegisteredProject{}, false
+	}
+	if i := findProject(projects, ref); i >= 0 {
+		return projects[i], true
+	}
+	return RegisteredProject{}, false
+}
+
+// Add registers a project, or updates the workspace of a registered one.
+// touch marks the project as used now.
+func (r *ProjectRegistry) Add(p RegisteredProject, touch bool) error {
+	unlock, err := r.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	projects, err := r.load()
+	if err != nil {
+		return err
+	}
+	if touch {
+		p.LastUsed = time.Now()
+	}
+
+	if i := findProject(projects, p.Dir); i >= 0 {
+		p.Pinned = projects[i].Pinned
+		if !touch {
+			p.LastUsed = projects[i].LastUsed
+		}
+		projects[i] = p
+	} else {
+		projects = append(projects, p)
+	}
+	return r.save(projects)
+}
+
+// Remove unregisters a project by session name or directory.
+func (r *ProjectRegistry) Remove(ref string) (RegisteredProject, error) {
+	unlock, err := r.lock()
+	if err != nil {
+		return RegisteredProject{}, err
+	}
+	defer unlock()
+
+	projects, err := r.load()
+	if err != nil {
+		return RegisteredProject{}, err
+	}
+	i := findProject(projects, ref)
+	if i < 0 {
+		return RegisteredProject{}, fmt.Errorf("project not registered: %s", ref)
+	}
+	removed := projects[i]
+	projects = append(projects[:i], projects[i+1:]...)
+	return removed, r.save(projects)
+}
+
+// SetPinned pins or unpins a project by session name or directory.
+func (r *ProjectRegistry) SetPinned(ref string, pinned bool) (RegisteredProject, error) {
+	unlock, err := r.lock()
+	if err != nil {
+		return RegisteredProject{}, err
+	}
+	defer unlock()
+
+	projects, err := r.load()
+	if err != nil {
+		return RegisteredProject{}, err
+	}
+	i := findProject(projects, ref)
+	if i < 0 {
+		return RegisteredProject{}, fmt.Errorf("project not registered: %s", ref)
+	}
+	projects[i].Pinned = pinned
+	return projects[i], r.save(projects)
+}
+
+// findProject returns the index of the project whose directory or session
+// name is ref, preferring directories, or -1.
+func findProject(projects []RegisteredProject, ref string) int {
+	if abs, err := filepath.Abs(ref); err == nil {
+		for i, p := range projects {
+			if p.Dir == abs {
+				return i
+			}
+		}
+	}
+	for i, p := range projects {
+		if p.Name == ref {
+			return i
+		}
+	}
+	return -1
+}
+
+// lock takes the registry file lock, so sessions of different projects
+// registering at the same time don't drop each other's entries.
+// The returned function releases the lock.
+func (r *ProjectRegistry) lock() (func(), error) {
+	dir := filepath.Dir(r.path)
+	if err := os.MkdirAll(dir, 0755); err != nil { //nolint:gosec // G301: standard directory permissions
+		return nil, err
+	}
+	return lockFile(filepath.Join(dir, constants.ProjectRegistryLock), "project registry")
+}
+
+func (r *ProjectRegistry) load() ([]RegisteredProject, error) {
+	data, err := os.ReadFile(r.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var projects []RegisteredProject
+	if err := json.Unmarshal(data, &projects); err != nil {
+		// Corrupt JSON: backup and start over
+		_ = fileutil.BackupCorruptFile(r.path)
+		return nil, nil //nolint:nilerr // Intentional: return empty list on corrupt file
+	}
+	return projects, nil
+}
+
+func (r *ProjectRegistry) save(projects []RegisteredProject) error {
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil { //nolint:gosec // G301: standard directory permissions
+		return err
+	}
+	data, err := json.MarshalIndent(projects, "", "  ")
+	if err != nil {
+		return err
+	}
+	return fileutil.WriteFileAtomic(r.path, data, 0644)
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/dongho-jung/paw/internal/constants"
+)
+
+func projectNames(t *testing.T, r *ProjectRegistry) []string {
+	t.Helper()
+	projects, err := r.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	var names []string
+	for _, p := range projects {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+func TestProjectRegistry_Order(t *testing.T) {
+	r := NewProjectRegistry(t.TempDir())
+
+	for _, name := range []string{"alpha", "beta", "gamma"} {
+		if err := r.Add(RegisteredProject{Name: name, Dir: "/src/" + name}, true); err != nil {
+			t.Fatalf("Add(%s) error = %v", name, err)
+		}
+	}
+	if want := []string{"gamma", "beta", "alpha"}; !slices.Equal(projectNames(t, r), want) {
+		t.Errorf("List() = %v, want %v", projectNames(t, r), want)
+	}
+
+	if _, err := r.SetPinned("alpha", true); err != nil {
+		t.Fatalf("SetPinned() error = %v", err)
+	}
+	if err := r.Add(RegisteredProject{Name: "beta", Dir: "/src/beta"}, true); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"alpha", "beta", "gamma"}; !slices.Equal(projectNames(t, r), want) {
+		t.Errorf("List() = %v, want %v", projectNames(t, r), want)
+	}
+}
+
+func TestProjectRegistry_AddKeepsState(t *testing.T) {
+	r := NewProjectRegistry(t.TempDir())
+	if err := r.Add(RegisteredProject{Name: "app", Dir: "/src/app", PawDir: "/old"}, true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.SetPinned("/src/app", true); err != nil {
+		t.Fatal(err)
+	}
+	before, _ := r.Find("app")
+
+	if err := r.Add(RegisteredProject{Name: "app", Dir: "/src/app", PawDir: "/new"}, false); err != nil {
+		t.Fatal(err)
+	}
+	after, ok := r.Find("/src/app")
+	if !ok {
+		t.Fatal("Find() did not find the project")
+	}
+	if !after.Pinned || !after.LastUsed.Equal(before.LastUsed) || after.PawDir != "/new" {
+		t.Errorf("project = %+v, want pinned with LastUsed %v and PawDir /new", after, before.LastUsed)
+	}
+	if names := projectNames(t, r); len(names) != 1 {
+		t.Errorf("List() = %v, want a single project", names)
+	}
+}
+
+func TestProjectRegistry_Remove(t *testing.T) {
+	r := NewProjectRegistry(t.TempDir())
+	if err := r.Add(RegisteredProject{Name: "app", Dir: "/src/app"}, true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Remove("missing"); err == nil {
+		t.Error("Remove(missing) should fail")
+	}
+	removed, err := r.Remove("app")
+	if err != nil || removed.Dir != "/src/app" {
+		t.Fatalf("Remove(app) = %+v, %v", removed, err)
+	}
+	if names := projectNames(t, r); len(names) != 0 {
+		t.Errorf("List() = %v, want empty", names)
+	}
+}
+
+func TestProjectRegistry_ConcurrentChanges(t *testing.T) {
+	r := NewProjectRegistry(t.TempDir())
+	if err := r.Add(RegisteredProject{Name: "pinned", Dir: "/src/pinned"}, true); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			name := fmt.Sprintf("app-%d", i)
+			if err := r.Add(RegisteredProject{Name: name, Dir: "/src/" + name}, true); err != nil {
+				t.Errorf("Add(%s) error = %v", name, err)
+			}
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if _, err := r.SetPinned("pinned", true); err != nil {
+			t.Errorf("SetPinned() error = %v", err)
+		}
+	}()
+	wg.Wait()
+
+	if names := projectNames(t, r); len(names) != 21 || names[0] != "pinned" {
+		t.Errorf("List() = %v, want 21 projects with pinned first", names)
+	}
+}
+
+func TestRegisteredProject_TaskCount(t *testing.T) {
+	pawDir := t.TempDir()
+	for _, task := range []string{"fix-bug", "add-feature"} {
+		if err := os.MkdirAll(filepath.Join(pawDir, constants.AgentsDirName, task), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(pawDir, constants.AgentsDirName, "stray"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	p := RegisteredProject{Dir: "/src/app", PawDir: pawDir}
+	if got := p.TaskCount(); got != 2 {
+		t.Errorf("TaskCount() = %d, want 2", got)
+	}
+	if p.Local() {
+		t.Error("Local() = true for a global workspace")
+	}
+}
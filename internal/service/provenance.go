@@ -0,0 +1,97 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/constants"
+)
+
+// Provenance describes the task that produced a merge commit.
+// It is stored as a git note (refs/notes/paw) in the same layout as history files.
+type Provenance struct {
+	Meta        *HistoryMetadata
+	TaskContent string
+	Summary     string
+}
+
+// ProvenanceTrailers returns the commit trailers enabled in cfg for a task.
+func ProvenanceTrailers(cfg *config.Config, taskName string, model config.Model) []string {
+	var trailers []string
+	if cfg.HasCommitTrailer(config.TrailerTask) {
+		trailers = append(trailers, fmt.Sprintf("%s: %s", constants.TrailerTask, taskName))
+	}
+	if cfg.HasCommitTrailer(config.TrailerModel) && model != "" {
+		trailers = append(trailers, fmt.Sprintf("%s: %s", constants.TrailerModel, model))
+	}
+	if cfg.HasCommitTrailer(config.TrailerCoAuthor) {
+		trailers = append(trailers, fmt.Sprintf("%s: %s", constants.TrailerCoAuthor, cfg.GetCoAuthor()))
+	}
+	return trailers
+}
+
+// FormatNote renders the provenance as a git note body.
+func (p *Provenance) FormatNote() string {
+	var note strings.Builder
+	if p.Meta != nil {
+		if metaData, err := json.MarshalIndent(p.Meta, "", "  "); err == nil {
+			note.WriteString("---meta---\n")
+			note.Write(metaData)
+			note.WriteString("\n")
+		}
+	}
+	note.WriteString("---task---\n")
+	note.WriteString(strings.TrimSpace(p.TaskContent))
+	note.WriteString("\n---summary---\n")
+	note.WriteString(strings.TrimSpace(p.Summary))
+	note.WriteString("\n")
+	return note.String()
+}
+
+// ParseProvenanceNote parses a note written by FormatNote.
+func ParseProvenanceNote(note string) (*Provenance, error) {
+	const (
+		metaMarker    = "---meta---\n"
+		taskMarker    = "---task---\n"
+		summaryMarker = "\n---summary---\n"
+	)
+
+	taskIdx := strings.Index(note, taskMarker)
+	if taskIdx == -1 {
+		return nil, errors.New("not a PAW provenance note")
+	}
+
+	p := &Provenance{}
+	if metaIdx := strings.Index(note, metaMarker); metaIdx != -1 && metaIdx < taskIdx {
+		var meta HistoryMetadata
+		if err := json.Unmarshal([]byte(note[metaIdx+len(metaMarker):taskIdx]), &meta); err != nil {
+			return nil, fmt.Errorf("invalid provenance metadata: %w", err)
+		}
+		p.Meta = &meta
+	}
+
+	body := note[taskIdx+len(taskMarker):]
+	if summaryIdx := strings.Index(body, summaryMarker); summaryIdx != -1 {
+		p.Summary = strings.TrimSpace(body[summaryIdx+len(summaryMarker):])
+		body = body[:summaryIdx]
+	}
+	p.TaskContent = strings.TrimSpace(body)
+	return p, nil
+}
+
+// LoadVerificationMetadata reads a task's verification result (.verify.json).
+func LoadVerificationMetadata(path string) (*VerificationMetadata, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is from the task agent directory
+	if err != nil {
+		return nil, err
+	}
+	var meta VerificationMetadata
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, fmt.Errorf("failed to parse verification metadata: %w", err)
+	}
+	return &meta, nil
+}
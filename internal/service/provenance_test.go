@@ -0,0 +1,77 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dongho-jung/paw/internal/config"
+)
+
+func TestProvenanceTrailers(t *testing.T) {
+	cfg := config.DefaultConfig()
+	got := ProvenanceTrailers(cfg, "add-login", config.ModelOpus)
+	if len(got) != 2 || got[0] != "Paw-Task: add-login" || got[1] != "Paw-Model: opus" {
+		t.Errorf("ProvenanceTrailers(default) = %v", got)
+	}
+
+	cfg.CommitTrailers = "co-author"
+	cfg.CoAuthor = "Bot <bot@example.com>"
+	got = ProvenanceTrailers(cfg, "add-login", config.ModelOpus)
+	if len(got) != 1 || got[0] != "Co-authored-by: Bot <bot@example.com>" {
+		t.Errorf("ProvenanceTrailers(co-author) = %v", got)
+	}
+
+	cfg.CommitTrailers = config.TrailersNone
+	if got = ProvenanceTrailers(cfg, "add-login", config.ModelOpus); len(got) != 0 {
+		t.Errorf("ProvenanceTrailers(none) = %v, want empty", got)
+	}
+}
+
+func TestProvenanceNoteRoundTrip(t *testing.T) {
+	p := &Provenance{
+		Meta: &HistoryMetadata{
+			TaskName:     "add-login",
+			TaskOptions:  &config.TaskOptions{Model: config.ModelSonnet},
+			Verification: &VerificationMetadata{Command: "go test ./...", Success: true},
+		},
+		TaskContent: "Add a login form\n",
+		Summary:     "Added the login form.",
+	}
+
+	parsed, err := ParseProvenanceNote(p.FormatNote())
+	if err != nil {
+		t.Fatalf("ParseProvenanceNote() error = %v", err)
+	}
+	if parsed.Meta == nil || parsed.Meta.TaskName != "add-login" || parsed.Meta.TaskOptions.Model != config.ModelSonnet {
+		t.Errorf("parsed meta = %+v", parsed.Meta)
+	}
+	if parsed.Meta.Verification == nil || !parsed.Meta.Verification.Success {
+		t.Errorf("parsed verification = %+v", parsed.Meta.Verification)
+	}
+	if parsed.TaskContent != "Add a login form" {
+		t.Errorf("TaskContent = %q", parsed.TaskContent)
+	}
+	if parsed.Summary != "Added the login form." {
+		t.Errorf("Summary = %q", parsed.Summary)
+	}
+
+	if _, err := ParseProvenanceNote("unrelated note"); err == nil {
+		t.Error("ParseProvenanceNote() expected error for foreign note")
+	}
+}
+
+func TestLoadVerificationMetadata(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".verify.json")
+	if err := os.WriteFile(path, []byte(`{"command":"make test","success":false,"exit_code":2}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	meta, err := LoadVerificationMetadata(path)
+	if err != nil {
+		t.Fatalf("LoadVerificationMetadata() error = %v", err)
+	}
+	if meta.Command != "make test" || meta.Success || meta.ExitCode != 2 {
+		t.Errorf("LoadVerificationMetadata() = %+v", meta)
+	}
+}
@@ -0,0 +1,158 @@
+// Package service provides business logic services for PAW.
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/fileutil"
+)
+
+// PRWatchEntry is a pull request tracked by the session PR watcher.
+type PRWatchEntry struct {
+	TaskName  string    `json:"task"`
+	PRNumber  int       `json:"pr"`
+	LastState string    `json:"last_state,omitempty"` // Last observed state ("open", "closed", "merged")
+	AddedAt   time.Time `json:"added_at"`
+}
+
+// PRWatchState is the persisted state of the session PR watcher.
+type PRWatchState struct {
+	ETag    string         `json:"etag,omitempty"` // ETag of the last PR list response
+	Entries []PRWatchEntry `json:"entries"`
+}
+
+// PRWatchList manages the persisted PR watch list of a workspace.
+// The list survives tmux restarts so the watcher can resume on attach.
+type PRWatchList struct {
+	pawDir string
+}
+
+// NewPRWatchList creates a new PR watch list for the given workspace.
+func NewPRWatchList(pawDir string) *PRWatchList {
+	return &PRWatchList{pawDir: pawDir}
+}
+
+func (l *PRWatchList) path() string {
+	return filepath.Join(l.pawDir, constants.PRWatchFileName)
+}
+
+// lock takes the watch list file lock, waiting while another process holds it.
+// The returned function releases the lock.
+func (l *PRWatchList) lock() (func(), error) {
+	return lockFile(filepath.Join(l.pawDir, constants.PRWatchListLockName), "PR watch list")
+}
+
+// Load reads the watch state from disk. A missing or corrupt file yields an empty state.
+func (l *PRWatchList) Load() (*PRWatchState, error) {
+	state := &PRWatchState{}
+	data, err := os.ReadFile(l.path()) //nolint:gosec // G304: path is constructed from pawDir
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return nil, fmt.Errorf("failed to read PR watch list: %w", err)
+	}
+
+	if err := json.Unmarshal(data, state); err != nil {
+		_ = fileutil.BackupCorruptFile(l.path())
+		return &PRWatchState{}, nil //nolint:nilerr // Intentional: start fresh on corrupt file
+	}
+	return state, nil
+}
+
+// Save writes the watch state to disk atomically.
+func (l *PRWatchList) Save(state *PRWatchState) error {
+	sort.Slice(state.Entries, func(i, j int) bool {
+		return state.Entries[i].AddedAt.Before(state.Entries[j].AddedAt)
+	})
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal PR watch list: %w", err)
+	}
+	if err := fileutil.WriteFileAtomic(l.path(), data, 0644); err != nil {
+		return fmt.Errorf("failed to write PR watch list: %w", err)
+	}
+	return nil
+}
+
+// Add starts watching a PR for a task, replacing any previous entry for the task.
+// The cached ETag is cleared so the next poll fetches fresh data.
+func (l *PRWatchList) Add(taskName string, prNumber int) error {
+	unlock, err := l.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	state, err := l.Load()
+	if err != nil {
+		return err
+	}
+
+	entries := state.Entries[:0]
+	for _, e := range state.Entries {
+		if e.TaskName != taskName {
+			entries = append(entries, e)
+		}
+	}
+	state.Entries = append(entries, PRWatchEntry{
+		TaskName: taskName,
+		PRNumber: prNumber,
+		AddedAt:  time.Now(),
+	})
+	state.ETag = ""
+	return l.Save(state)
+}
+
+// Remove stops watching the PR of a task.
+func (l *PRWatchList) Remove(taskName string) error {
+	unlock, err := l.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	state, err := l.Load()
+	if err != nil {
+		return err
+	}
+
+	entries := state.Entries[:0]
+	removed := false
+	for _, e := range state.Entries {
+		if e.TaskName == taskName {
+			removed = true
+			continue
+		}
+		entries = append(entries, e)
+	}
+	if !removed {
+		return nil
+	}
+	state.Entries = entries
+	return l.Save(state)
+}
+
+// Update applies fn to the loaded state and saves the result under the watch
+// list lock. It is used by the watcher to merge its changes with entries added
+// concurrently.
+func (l *PRWatchList) Update(fn func(state *PRWatchState)) error {
+	unlock, err := l.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	state, err := l.Load()
+	if err != nil {
+		return err
+	}
+	fn(state)
+	return l.Save(state)
+}
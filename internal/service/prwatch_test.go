@@ -0,0 +1,127 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/dongho-jung/paw/internal/constants"
+)
+
+func TestPRWatchList_AddAndRemove(t *testing.T) {
+	list := NewPRWatchList(t.TempDir())
+
+	if err := list.Add("task-a", 1); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if err := list.Add("task-b", 2); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	// Re-adding a task replaces its entry
+	if err := list.Add("task-a", 3); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	state, err := list.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(state.Entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(state.Entries))
+	}
+	for _, e := range state.Entries {
+		if e.TaskName == "task-a" && e.PRNumber != 3 {
+			t.Errorf("task-a PR = %d, want 3", e.PRNumber)
+		}
+	}
+
+	if err := list.Remove("task-b"); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	state, _ = list.Load()
+	if len(state.Entries) != 1 || state.Entries[0].TaskName != "task-a" {
+		t.Errorf("Unexpected entries after remove: %+v", state.Entries)
+	}
+}
+
+func TestPRWatchList_AddClearsETag(t *testing.T) {
+	list := NewPRWatchList(t.TempDir())
+
+	if err := list.Update(func(s *PRWatchState) { s.ETag = `W/"abc"` }); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if err := list.Add("task-a", 1); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	state, _ := list.Load()
+	if state.ETag != "" {
+		t.Errorf("ETag = %q, want empty after Add", state.ETag)
+	}
+}
+
+func TestPRWatchList_CorruptFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, constants.PRWatchFileName), []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	state, err := NewPRWatchList(dir).Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(state.Entries) != 0 {
+		t.Errorf("Expected empty state for corrupt file, got %+v", state.Entries)
+	}
+}
+
+func TestPRWatchList_ConcurrentAddAndUpdate(t *testing.T) {
+	list := NewPRWatchList(t.TempDir())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if err := list.Add(fmt.Sprintf("task-%d", i), i); err != nil {
+				t.Errorf("Add failed: %v", err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			err := list.Update(func(state *PRWatchState) {
+				state.ETag = fmt.Sprintf("etag-%d", i)
+			})
+			if err != nil {
+				t.Errorf("Update failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	state, err := list.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(state.Entries) != 20 {
+		t.Errorf("Expected 20 entries, got %d", len(state.Entries))
+	}
+}
+
+func TestPRWatchList_StaleLock(t *testing.T) {
+	dir := t.TempDir()
+	// PIDs above the kernel maximum are never running
+	if err := os.WriteFile(filepath.Join(dir, constants.PRWatchListLockName), []byte("99999999"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	list := NewPRWatchList(dir)
+	if err := list.Add("task", 1); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, constants.PRWatchListLockName)); !os.IsNotExist(err) {
+		t.Error("Lock file left behind after Add")
+	}
+}
@@ -0,0 +1,86 @@
+// Package service provides business logic services for PAW.
+package service
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/dongho-jung/paw/internal/fileutil"
+)
+
+const (
+	// RecentFilesFile is the name of the recently referenced files file.
+	RecentFilesFile = "recent-files"
+	// MaxRecentFiles is the maximum number of file paths to keep.
+	MaxRecentFiles = 50
+)
+
+// RecentFiles tracks the files recently attached to tasks with the file picker.
+type RecentFiles struct {
+	pawDir string
+}
+
+// NewRecentFiles creates a recent files list for a project.
+func NewRecentFiles(pawDir string) *RecentFiles {
+	return &RecentFiles{pawDir: pawDir}
+}
+
+func (r *RecentFiles) path() string {
+	return filepath.Join(r.pawDir, RecentFilesFile)
+}
+
+// Recent returns the recently referenced file paths (relative to the
+// project), most recent first.
+func (r *RecentFiles) Recent() ([]string, error) {
+	path := r.path()
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is from path()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var files []string
+	if err := json.Unmarshal(data, &files); err != nil {
+		// Corrupt JSON: backup and start over
+		_ = fileutil.BackupCorruptFile(path)
+		return nil, nil //nolint:nilerr // Intentional: return empty list on corrupt file
+	}
+	return files, nil
+}
+
+// Record moves file paths to the front of the list, keeping their order.
+func (r *RecentFiles) Record(files ...string) error {
+	if len(files) == 0 {
+		return nil
+	}
+
+	existing, err := r.Recent()
+	if err != nil {
+		existing = nil
+	}
+
+	seen := make(map[string]bool, len(files))
+	updated := make([]string, 0, len(files)+len(existing))
+	for _, file := range slices.Concat(files, existing) {
+		if file != "" && !seen[file] {
+			seen[file] = true
+			updated = append(updated, file)
+		}
+	}
+	if len(updated) > MaxRecentFiles {
+		updated = updated[:MaxRecentFiles]
+	}
+
+	if err := os.MkdirAll(r.pawDir, 0755); err != nil { //nolint:gosec // G301: standard directory permissions
+		return err
+	}
+	data, err := json.MarshalIndent(updated, "", "  ")
+	if err != nil {
+		return err
+	}
+	return fileutil.WriteFileAtomic(r.path(), data, 0644)
+}
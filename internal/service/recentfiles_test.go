@@ -0,0 +1,43 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestRecentFiles_Record(t *testing.T) {
+	r := NewRecentFiles(t.TempDir())
+
+	if err := r.Record("a.go", "b.go"); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if err := r.Record("c.go", "a.go", "c.go"); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+
+	files, err := r.Recent()
+	if err != nil {
+		t.Fatalf("Recent() error = %v", err)
+	}
+	if want := []string{"c.go", "a.go", "b.go"}; !slices.Equal(files, want) {
+		t.Errorf("Recent() = %v, want %v", files, want)
+	}
+}
+
+func TestRecentFiles_Limit(t *testing.T) {
+	r := NewRecentFiles(t.TempDir())
+	for i := 0; i < MaxRecentFiles+5; i++ {
+		if err := r.Record(fmt.Sprintf("file-%d.go", i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, _ := r.Recent()
+	if len(files) != MaxRecentFiles {
+		t.Fatalf("len(Recent()) = %d, want %d", len(files), MaxRecentFiles)
+	}
+	if files[0] != fmt.Sprintf("file-%d.go", MaxRecentFiles+4) {
+		t.Errorf("Recent()[0] = %s, want the last recorded file", files[0])
+	}
+}
@@ -0,0 +1,224 @@
+// Package service provides business logic services for PAW.
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/fileutil"
+)
+
+// ReviewSide is the side of a diff a review comment is anchored to.
+type ReviewSide string
+
+// Review comment sides.
+const (
+	ReviewSideNew ReviewSide = "new" // Added or unchanged line (new file line number)
+	ReviewSideOld ReviewSide = "old" // Removed line (old file line number)
+)
+
+// ReviewComment is a diff viewer comment on a line or hunk of a task's changes.
+type ReviewComment struct {
+	ID        string     `json:"id"`
+	Path      string     `json:"path"`
+	Side      ReviewSide `json:"side"`
+	Line      int        `json:"line"`
+	EndLine   int        `json:"end_line,omitempty"` // Last line of a hunk comment (0 for line comments)
+	Code      string     `json:"code"`               // Quoted code at comment time
+	Body      string     `json:"body"`
+	CreatedAt time.Time  `json:"created_at"`
+	Round     int        `json:"round,omitempty"` // Review round the comment was submitted in (0: draft)
+	Resolved  bool       `json:"resolved,omitempty"`
+}
+
+// IsDraft reports whether the comment has not been submitted yet.
+func (c ReviewComment) IsDraft() bool {
+	return c.Round == 0
+}
+
+// IsHunk reports whether the comment is on a whole hunk.
+func (c ReviewComment) IsHunk() bool {
+	return c.EndLine > 0
+}
+
+// Location returns "path:line" (or "path:start-end" for hunk comments).
+func (c ReviewComment) Location() string {
+	loc := c.Path + ":" + strconv.Itoa(c.Line)
+	if c.IsHunk() && c.EndLine != c.Line {
+		loc += "-" + strconv.Itoa(c.EndLine)
+	}
+	return loc
+}
+
+// ReviewState is the persisted review of a task.
+type ReviewState struct {
+	Rounds   int               `json:"rounds"` // Number of submitted review rounds
+	Comments []ReviewComment   `json:"comments"`
+	Base     string            `json:"base,omitempty"`     // Branch the task is diffed against
+	Viewed   map[string]string `json:"viewed,omitempty"`   // File path → blob hash when marked viewed
+	Snapshot string            `json:"snapshot,omitempty"` // Commit of the last finished review
+}
+
+// IsViewed reports whether a file is marked viewed at the given blob hash.
+func (s *ReviewState) IsViewed(path, blob string) bool {
+	viewed, ok := s.Viewed[path]
+	return ok && blob != "" && viewed == blob
+}
+
+// SetViewed marks a file viewed at a blob hash, or clears the mark.
+func (s *ReviewState) SetViewed(path, blob string, viewed bool) {
+	if !viewed {
+		delete(s.Viewed, path)
+		return
+	}
+	if s.Viewed == nil {
+		s.Viewed = make(map[string]string)
+	}
+	s.Viewed[path] = blob
+}
+
+// Unreviewed counts the changed files (path → current blob hash) that are not
+// marked viewed at their current content.
+func (s *ReviewState) Unreviewed(blobs map[string]string) int {
+	count := 0
+	for path, blob := range blobs {
+		if !s.IsViewed(path, blob) {
+			count++
+		}
+	}
+	return count
+}
+
+// Drafts returns the comments that have not been submitted yet.
+func (s *ReviewState) Drafts() []ReviewComment {
+	var drafts []ReviewComment
+	for _, c := range s.Comments {
+		if c.IsDraft() {
+			drafts = append(drafts, c)
+		}
+	}
+	return drafts
+}
+
+// AddDraft adds a draft comment and returns it with its ID set.
+func (s *ReviewState) AddDraft(c ReviewComment) ReviewComment {
+	c.CreatedAt = time.Now()
+	c.ID = strconv.FormatInt(c.CreatedAt.UnixNano(), 36) + "-" + strconv.Itoa(len(s.Comments))
+	c.Round = 0
+	c.Resolved = false
+	s.Comments = append(s.Comments, c)
+	return c
+}
+
+// Remove deletes a comment by ID.
+func (s *ReviewState) Remove(id string) {
+	for i, c := range s.Comments {
+		if c.ID == id {
+			s.Comments = append(s.Comments[:i], s.Comments[i+1:]...)
+			return
+		}
+	}
+}
+
+// Submit starts a new review round with all draft comments and returns them.
+func (s *ReviewState) Submit() ([]ReviewComment, error) {
+	if len(s.Drafts()) == 0 {
+		return nil, errors.New("no draft comments to submit")
+	}
+	s.Rounds++
+	var submitted []ReviewComment
+	for i := range s.Comments {
+		if s.Comments[i].IsDraft() {
+			s.Comments[i].Round = s.Rounds
+			submitted = append(submitted, s.Comments[i])
+		}
+	}
+	return submitted, nil
+}
+
+// ResolveChanged marks submitted comments resolved when the code they quote
+// changed. current returns the code at a comment's location now, and false if
+// the location is no longer part of the diff. Returns true if any comment changed.
+func (s *ReviewState) ResolveChanged(current func(c ReviewComment) (string, bool)) bool {
+	changed := false
+	for i := range s.Comments {
+		c := &s.Comments[i]
+		if c.IsDraft() || c.Resolved {
+			continue
+		}
+		if code, ok := current(*c); !ok || code != c.Code {
+			c.Resolved = true
+			changed = true
+		}
+	}
+	return changed
+}
+
+// FormatReview formats submitted comments as an instruction for the agent.
+func FormatReview(round int, comments []ReviewComment) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Code review (round %d): please address each comment below, then summarize what you changed.\n", round))
+	for i, c := range comments {
+		sb.WriteString(fmt.Sprintf("\n%d. %s", i+1, c.Location()))
+		if c.Side == ReviewSideOld {
+			sb.WriteString(" (removed line)")
+		}
+		sb.WriteString("\n```\n")
+		sb.WriteString(strings.TrimRight(c.Code, "\n"))
+		sb.WriteString("\n```\n")
+		sb.WriteString(strings.TrimSpace(c.Body))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// ReviewStore persists the review of a task in its agent directory.
+type ReviewStore struct {
+	agentDir string
+}
+
+// NewReviewStore creates a review store for a task's agent directory.
+func NewReviewStore(agentDir string) *ReviewStore {
+	return &ReviewStore{agentDir: agentDir}
+}
+
+func (r *ReviewStore) path() string {
+	return filepath.Join(r.agentDir, constants.ReviewFileName)
+}
+
+// Load reads the review. A missing or corrupt file yields an empty review.
+func (r *ReviewStore) Load() (*ReviewState, error) {
+	state := &ReviewState{}
+	data, err := os.ReadFile(r.path()) //nolint:gosec // G304: path is constructed from agentDir
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return nil, fmt.Errorf("failed to read review: %w", err)
+	}
+
+	if err := json.Unmarshal(data, state); err != nil {
+		_ = fileutil.BackupCorruptFile(r.path())
+		return &ReviewState{}, nil //nolint:nilerr // Intentional: start fresh on corrupt file
+	}
+	return state, nil
+}
+
+// Save writes the review.
+func (r *ReviewStore) Save(state *ReviewState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal review: %w", err)
+	}
+	if err := fileutil.WriteFileAtomic(r.path(), data, 0644); err != nil {
+		return fmt.Errorf("failed to write review: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dongho-jung/paw/internal/constants"
+)
+
+func TestReviewStore_SaveLoad(t *testing.T) {
+	store := NewReviewStore(t.TempDir())
+
+	state, err := store.Load()
+	if err != nil || len(state.Comments) != 0 {
+		t.Fatalf("Load() on missing file = %+v, %v; want empty review", state, err)
+	}
+
+	state.AddDraft(ReviewComment{Path: "main.go", Side: ReviewSideNew, Line: 3, Code: "x := 1", Body: "rename x"})
+	if err := store.Save(state); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	loaded, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(loaded.Comments) != 1 || loaded.Comments[0].Body != "rename x" || loaded.Comments[0].ID == "" {
+		t.Errorf("Load() = %+v, want the saved draft", loaded.Comments)
+	}
+}
+
+func TestReviewStore_LoadCorruptFile(t *testing.T) {
+	agentDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(agentDir, constants.ReviewFileName), []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	state, err := NewReviewStore(agentDir).Load()
+	if err != nil || len(state.Comments) != 0 {
+		t.Errorf("Load() = %+v, %v; want empty review", state, err)
+	}
+}
+
+func TestReviewState_SubmitRounds(t *testing.T) {
+	state := &ReviewState{}
+	if _, err := state.Submit(); err == nil {
+		t.Error("Submit without drafts should fail")
+	}
+
+	first := state.AddDraft(ReviewComment{Path: "a.go", Line: 1, Body: "one"})
+	state.AddDraft(ReviewComment{Path: "a.go", Line: 2, Body: "two"})
+	submitted, err := state.Submit()
+	if err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+	if len(submitted) != 2 || state.Rounds != 1 || len(state.Drafts()) != 0 {
+		t.Fatalf("Submit() = %d comments, rounds %d, %d drafts; want 2, 1, 0", len(submitted), state.Rounds, len(state.Drafts()))
+	}
+
+	state.AddDraft(ReviewComment{Path: "b.go", Line: 5, Body: "three"})
+	submitted, _ = state.Submit()
+	if len(submitted) != 1 || submitted[0].Round != 2 {
+		t.Errorf("second Submit() = %+v, want one comment in round 2", submitted)
+	}
+
+	state.Remove(first.ID)
+	if len(state.Comments) != 2 {
+		t.Errorf("Remove left %d comments, want 2", len(state.Comments))
+	}
+}
+
+func TestReviewState_ResolveChanged(t *testing.T) {
+	state := &ReviewState{}
+	state.AddDraft(ReviewComment{Path: "a.go", Line: 1, Code: "same", Body: "kept"})
+	state.AddDraft(ReviewComment{Path: "a.go", Line: 2, Code: "old", Body: "edited"})
+	state.AddDraft(ReviewComment{Path: "a.go", Line: 3, Code: "gone", Body: "removed"})
+	if _, err := state.Submit(); err != nil {
+		t.Fatal(err)
+	}
+	draft := state.AddDraft(ReviewComment{Path: "a.go", Line: 2, Code: "old", Body: "draft"})
+
+	current := map[int]string{1: "same", 2: "new"}
+	changed := state.ResolveChanged(func(c ReviewComment) (string, bool) {
+		code, ok := current[c.Line]
+		return code, ok
+	})
+	if !changed {
+		t.Fatal("ResolveChanged() = false, want true")
+	}
+
+	resolved := map[string]bool{}
+	for _, c := range state.Comments {
+		resolved[c.Body] = c.Resolved
+	}
+	want := map[string]bool{"kept": false, "edited": true, "removed": true, draft.Body: false}
+	for body, r := range want {
+		if resolved[body] != r {
+			t.Errorf("comment %q resolved = %v, want %v", body, resolved[body], r)
+		}
+	}
+}
+
+func TestFormatReview(t *testing.T) {
+	got := FormatReview(2, []ReviewComment{
+		{Path: "main.go", Side: ReviewSideNew, Line: 10, Code: "x := 1", Body: "Use a descriptive name"},
+		{Path: "util.go", Side: ReviewSideOld, Line: 4, Code: "return nil", Body: "Why was this removed?"},
+		{Path: "api.go", Side: ReviewSideNew, Line: 20, EndLine: 25, Code: "+a\n-b", Body: "Split this hunk"},
+	})
+
+	for _, want := range []string{
+		"Code review (round 2)",
+		"1. main.go:10\n```\nx := 1\n```\nUse a descriptive name\n",
+		"2. util.go:4 (removed line)\n",
+		"3. api.go:20-25\n```\n+a\n-b\n```\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("FormatReview() missing %q in:\n%s", want, got)
+		}
+	}
+}
+
+func TestReviewState_Viewed(t *testing.T) {
+	state := &ReviewState{}
+	state.SetViewed("a.go", "blob-a1", true)
+	state.SetViewed("b.go", "blob-b1", true)
+
+	blobs := map[string]string{"a.go": "blob-a1", "b.go": "blob-b2", "c.go": "blob-c1"}
+	if !state.IsViewed("a.go", "blob-a1") || state.IsViewed("b.go", "blob-b2") {
+		t.Error("IsViewed should only match the blob the file was viewed at")
+	}
+	if got := state.Unreviewed(blobs); got != 2 {
+		t.Errorf("Unreviewed() = %d, want 2 (b.go changed, c.go new)", got)
+	}
+
+	state.SetViewed("a.go", "", false)
+	if got := state.Unreviewed(blobs); got != 3 {
+		t.Errorf("Unreviewed() after clearing a.go = %d, want 3", got)
+	}
+}
@@ -0,0 +1,301 @@
+// Package service provides business logic services for PAW.
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/task"
+)
+
+// TaskOutcome is a finish recorded by RecordOutcome.
+type TaskOutcome struct {
+	Time    time.Time `json:"ts"`
+	Task    string    `json:"task"`
+	Outcome string    `json:"outcome"`
+	Model   string    `json:"model"`
+}
+
+// MergeConflict is a merge conflict recorded by RecordMergeConflict.
+type MergeConflict struct {
+	Time     time.Time `json:"ts"`
+	Task     string    `json:"task"`
+	Files    []string  `json:"files"`
+	Resolved bool      `json:"resolved"`
+}
+
+// StatsTask is a task as counted by the statistics.
+type StatsTask struct {
+	Project   string        `json:"project"`
+	Task      string        `json:"task"`
+	Model     string        `json:"model,omitempty"`
+	Outcome   string        `json:"outcome,omitempty"` // Finish action or "cancelled", empty while open
+	Started   time.Time     `json:"started"`
+	Done      time.Time     `json:"done,omitzero"` // First time the task was done
+	Finished  time.Time     `json:"finished,omitzero"`
+	Working   time.Duration `json:"working_ns"`
+	Waiting   time.Duration `json:"waiting_ns"`
+	Conflicts int           `json:"merge_conflicts,omitempty"`
+	Resolved  int           `json:"conflicts_resolved,omitempty"`
+}
+
+// Open reports whether the task has not been finished yet.
+func (t StatsTask) Open() bool {
+	return t.Outcome == ""
+}
+
+// Completed reports whether the task was finished with its work kept
+// (merged, PR, pushed or done) rather than dropped or cancelled.
+func (t StatsTask) Completed() bool {
+	return !t.Open() && t.Outcome != constants.ActionDrop && t.Outcome != OutcomeCancelled
+}
+
+// TimeToDone returns how long the task took from start to done, or 0 if it
+// was never done.
+func (t StatsTask) TimeToDone() time.Duration {
+	if t.Done.IsZero() {
+		return 0
+	}
+	return t.Done.Sub(t.Started)
+}
+
+// LoadStatsTasks returns the tasks of a project started since the given time
+// (all tasks if since is zero), from the status, outcome and merge conflict
+// history.
+func (s *HistoryService) LoadStatsTasks(project string, since, now time.Time) ([]StatsTask, error) {
+	statusDir := filepath.Join(s.historyDir, "status")
+	files, err := os.ReadDir(statusDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	outcomes := s.loadOutcomes()
+	finished := s.finishTimes()
+
+	var tasks []StatsTask
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ".jsonl" {
+			continue
+		}
+		transitions, err := readStatusTransitions(filepath.Join(statusDir, f.Name()))
+		if err != nil || len(transitions) == 0 {
+			continue
+		}
+		taskName := strings.TrimSuffix(f.Name(), ".jsonl")
+		tl := BuildTaskTimeline(taskName, transitions, finished[taskName], now)
+		if len(tl.Segments) == 0 || (!since.IsZero() && tl.Segments[0].Start.Before(since)) {
+			continue
+		}
+
+		st := StatsTask{
+			Project: project,
+			Task:    taskName,
+			Started: tl.Segments[0].Start,
+			Working: tl.Total(task.StatusWorking),
+			Waiting: tl.WaitTime(),
+		}
+		for _, seg := range tl.Segments {
+			if seg.Status == task.StatusDone {
+				st.Done = seg.Start
+				break
+			}
+		}
+		if outcome, ok := outcomes[taskName]; ok {
+			st.Outcome = outcome.Outcome
+			st.Model = outcome.Model
+			st.Finished = outcome.Time
+		}
+		conflicts, _ := readJSONLines[MergeConflict](filepath.Join(s.historyDir, "conflicts", taskName+".jsonl"))
+		for _, c := range conflicts {
+			st.Conflicts++
+			if c.Resolved {
+				st.Resolved++
+			}
+		}
+		tasks = append(tasks, st)
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return tasks[i].Started.Before(tasks[j].Started)
+	})
+	return tasks, nil
+}
+
+// loadOutcomes returns the latest outcome of each task.
+func (s *HistoryService) loadOutcomes() map[string]TaskOutcome {
+	outcomes := make(map[string]TaskOutcome)
+	dir := filepath.Join(s.historyDir, "outcomes")
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return outcomes
+	}
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ".jsonl" {
+			continue
+		}
+		records, _ := readJSONLines[TaskOutcome](filepath.Join(dir, f.Name()))
+		for _, r := range records {
+			name := strings.TrimSuffix(f.Name(), ".jsonl")
+			if r.Outcome != "" && !r.Time.Before(outcomes[name].Time) {
+				outcomes[name] = r
+			}
+		}
+	}
+	return outcomes
+}
+
+// DayStats counts the tasks started and completed on one day.
+type DayStats struct {
+	Date      string `json:"date"` // YYYY-MM-DD, local time
+	Started   int    `json:"started"`
+	Completed int    `json:"completed"`
+}
+
+// ModelStats compares the finished tasks of one model.
+type ModelStats struct {
+	Model            string        `json:"model"`
+	Finished         int           `json:"finished"`
+	Completed        int           `json:"completed"`
+	SuccessRate      float64       `json:"success_rate"`
+	MedianTimeToDone time.Duration `json:"median_time_to_done_ns"`
+	MedianWorking    time.Duration `json:"median_working_ns"`
+}
+
+// Stats summarizes how tasks went over a period.
+type Stats struct {
+	Since             time.Time     `json:"since,omitzero"`
+	Until             time.Time     `json:"until"`
+	TaskCount         int           `json:"task_count"`
+	Completed         int           `json:"completed"`
+	Dropped           int           `json:"dropped"`
+	Cancelled         int           `json:"cancelled"`
+	Open              int           `json:"open"`
+	CompletionRate    float64       `json:"completion_rate"` // Completed share of finished tasks
+	MedianTimeToDone  time.Duration `json:"median_time_to_done_ns"`
+	TotalWaiting      time.Duration `json:"total_waiting_ns"`
+	MedianWaiting     time.Duration `json:"median_waiting_ns"`
+	MergeConflicts    int           `json:"merge_conflicts"`
+	ConflictsResolved int           `json:"conflicts_resolved"`
+	PerDay            []DayStats    `json:"per_day"`
+	Models            []ModelStats  `json:"models"`
+	Tasks             []StatsTask   `json:"tasks"`
+}
+
+// ComputeStats summarizes tasks started between since and until. Days run
+// from since (or the first task) to until.
+func ComputeStats(tasks []StatsTask, since, until time.Time) Stats {
+	stats := Stats{Since: since, Until: until, TaskCount: len(tasks), Tasks: tasks}
+
+	var toDone, waiting []time.Duration
+	models := make(map[string][]StatsTask)
+	for _, t := range tasks {
+		switch {
+		case t.Open():
+			stats.Open++
+		case t.Completed():
+			stats.Completed++
+		case t.Outcome == constants.ActionDrop:
+			stats.Dropped++
+		default:
+			stats.Cancelled++
+		}
+		if d := t.TimeToDone(); d > 0 {
+			toDone = append(toDone, d)
+		}
+		waiting = append(waiting, t.Waiting)
+		stats.TotalWaiting += t.Waiting
+		stats.MergeConflicts += t.Conflicts
+		stats.ConflictsResolved += t.Resolved
+		if !t.Open() {
+			model := t.Model
+			if model == "" {
+				model = "unknown"
+			}
+			models[model] = append(models[model], t)
+		}
+	}
+	if finished := stats.TaskCount - stats.Open; finished > 0 {
+		stats.CompletionRate = float64(stats.Completed) / float64(finished)
+	}
+	stats.MedianTimeToDone = medianDuration(toDone)
+	stats.MedianWaiting = medianDuration(waiting)
+	stats.PerDay = statsPerDay(tasks, since, until)
+
+	for model, modelTasks := range models {
+		ms := ModelStats{Model: model, Finished: len(modelTasks)}
+		var modelToDone, modelWorking []time.Duration
+		for _, t := range modelTasks {
+			if t.Completed() {
+				ms.Completed++
+			}
+			if d := t.TimeToDone(); d > 0 {
+				modelToDone = append(modelToDone, d)
+			}
+			modelWorking = append(modelWorking, t.Working)
+		}
+		ms.SuccessRate = float64(ms.Completed) / float64(ms.Finished)
+		ms.MedianTimeToDone = medianDuration(modelToDone)
+		ms.MedianWorking = medianDuration(modelWorking)
+		stats.Models = append(stats.Models, ms)
+	}
+	sort.Slice(stats.Models, func(i, j int) bool {
+		if stats.Models[i].Finished != stats.Models[j].Finished {
+			return stats.Models[i].Finished > stats.Models[j].Finished
+		}
+		return stats.Models[i].Model < stats.Models[j].Model
+	})
+	return stats
+}
+
+// statsPerDay counts started and completed tasks for every day of the period.
+func statsPerDay(tasks []StatsTask, since, until time.Time) []DayStats {
+	first := since
+	for _, t := range tasks {
+		if first.IsZero() || t.Started.Before(first) {
+			first = t.Started
+		}
+	}
+	if first.IsZero() {
+		return nil
+	}
+
+	const layout = "2006-01-02"
+	var days []DayStats
+	index := make(map[string]int)
+	local := first.Local()
+	for day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.Local); !day.After(until); day = day.AddDate(0, 0, 1) {
+		index[day.Format(layout)] = len(days)
+		days = append(days, DayStats{Date: day.Format(layout)})
+	}
+	for _, t := range tasks {
+		if i, ok := index[t.Started.Local().Format(layout)]; ok {
+			days[i].Started++
+		}
+		if t.Completed() {
+			if i, ok := index[t.Finished.Local().Format(layout)]; ok {
+				days[i].Completed++
+			}
+		}
+	}
+	return days
+}
+
+func medianDuration(durations []time.Duration) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
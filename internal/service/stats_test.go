@@ -0,0 +1,136 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/constants"
+)
+
+// writeStatusHistory writes status transitions of a task as "minutes-ago:status" pairs.
+func writeStatusHistory(t *testing.T, historyDir, taskName string, now time.Time, steps ...string) {
+	t.Helper()
+	var lines []string
+	for _, step := range steps {
+		minutes, status, _ := strings.Cut(step, ":")
+		var ago time.Duration
+		if d, err := time.ParseDuration(minutes + "m"); err == nil {
+			ago = d
+		}
+		ts := now.Add(-ago).Format(time.RFC3339Nano)
+		lines = append(lines, `{"ts":"`+ts+`","task":"`+taskName+`","to":"`+status+`"}`)
+	}
+	dir := filepath.Join(historyDir, "status")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, taskName+".jsonl"), []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHistoryService_LoadStatsTasks(t *testing.T) {
+	dir := t.TempDir()
+	svc := NewHistoryService(dir)
+	now := time.Now()
+
+	writeStatusHistory(t, dir, "merged", now, "100:working", "80:waiting", "60:working", "40:done")
+	writeStatusHistory(t, dir, "open", now, "30:working")
+	writeStatusHistory(t, dir, "ancient", now, "5000:working")
+	if err := svc.RecordOutcome("merged", constants.ActionMerge, config.ModelSonnet); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.RecordMergeConflict("merged", []string{"a.go"}, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.RecordMergeConflict("merged", []string{"a.go"}, true); err != nil {
+		t.Fatal(err)
+	}
+
+	tasks, err := svc.LoadStatsTasks("api", now.Add(-24*time.Hour), now)
+	if err != nil {
+		t.Fatalf("LoadStatsTasks() error = %v", err)
+	}
+	if len(tasks) != 2 || tasks[0].Task != "merged" || tasks[1].Task != "open" {
+		t.Fatalf("tasks = %+v, want merged and open", tasks)
+	}
+
+	merged := tasks[0]
+	if merged.Project != "api" || merged.Outcome != constants.ActionMerge || merged.Model != "sonnet" || !merged.Completed() {
+		t.Errorf("merged = %+v", merged)
+	}
+	if merged.TimeToDone() != 60*time.Minute || merged.Waiting != 20*time.Minute || merged.Working != 40*time.Minute {
+		t.Errorf("merged durations: to done %v, waiting %v, working %v", merged.TimeToDone(), merged.Waiting, merged.Working)
+	}
+	if merged.Conflicts != 2 || merged.Resolved != 1 {
+		t.Errorf("merged conflicts = %d/%d, want 2/1", merged.Conflicts, merged.Resolved)
+	}
+	if !tasks[1].Open() || tasks[1].TimeToDone() != 0 {
+		t.Errorf("open = %+v", tasks[1])
+	}
+
+	// All history when since is zero
+	if all, _ := svc.LoadStatsTasks("api", time.Time{}, now); len(all) != 3 {
+		t.Errorf("all tasks = %d, want 3", len(all))
+	}
+}
+
+func TestComputeStats(t *testing.T) {
+	until := time.Date(2026, 3, 4, 18, 0, 0, 0, time.Local)
+	day := func(d, hour int) time.Time { return time.Date(2026, 3, d, hour, 0, 0, 0, time.Local) }
+
+	tasks := []StatsTask{
+		{Task: "a", Model: "opus", Outcome: constants.ActionMerge, Started: day(2, 9), Done: day(2, 10), Finished: day(2, 11), Working: time.Hour, Waiting: 10 * time.Minute, Conflicts: 1, Resolved: 1},
+		{Task: "b", Model: "opus", Outcome: constants.ActionPR, Started: day(3, 9), Done: day(3, 12), Finished: day(4, 9), Working: 2 * time.Hour},
+		{Task: "c", Model: "haiku", Outcome: constants.ActionDrop, Started: day(3, 10), Finished: day(3, 11), Waiting: 30 * time.Minute},
+		{Task: "d", Model: "opus", Outcome: OutcomeCancelled, Started: day(4, 9), Finished: day(4, 10)},
+		{Task: "e", Started: day(4, 12)},
+	}
+
+	stats := ComputeStats(tasks, day(2, 0), until)
+	if stats.TaskCount != 5 || stats.Completed != 2 || stats.Dropped != 1 || stats.Cancelled != 1 || stats.Open != 1 {
+		t.Errorf("counts = %+v", stats)
+	}
+	if stats.CompletionRate != 0.5 {
+		t.Errorf("CompletionRate = %v, want 0.5", stats.CompletionRate)
+	}
+	if stats.MedianTimeToDone != 2*time.Hour {
+		t.Errorf("MedianTimeToDone = %v, want 2h (median of 1h and 3h)", stats.MedianTimeToDone)
+	}
+	if stats.TotalWaiting != 40*time.Minute || stats.MergeConflicts != 1 || stats.ConflictsResolved != 1 {
+		t.Errorf("waiting %v, conflicts %d/%d", stats.TotalWaiting, stats.MergeConflicts, stats.ConflictsResolved)
+	}
+
+	wantDays := []DayStats{
+		{Date: "2026-03-02", Started: 1, Completed: 1},
+		{Date: "2026-03-03", Started: 2, Completed: 0},
+		{Date: "2026-03-04", Started: 2, Completed: 1},
+	}
+	if len(stats.PerDay) != len(wantDays) {
+		t.Fatalf("PerDay = %+v, want %+v", stats.PerDay, wantDays)
+	}
+	for i, d := range wantDays {
+		if stats.PerDay[i] != d {
+			t.Errorf("PerDay[%d] = %+v, want %+v", i, stats.PerDay[i], d)
+		}
+	}
+
+	if len(stats.Models) != 2 || stats.Models[0].Model != "opus" || stats.Models[1].Model != "haiku" {
+		t.Fatalf("Models = %+v, want opus then haiku", stats.Models)
+	}
+	opus := stats.Models[0]
+	if opus.Finished != 3 || opus.Completed != 2 || opus.MedianTimeToDone != 2*time.Hour || opus.MedianWorking != time.Hour {
+		t.Errorf("opus = %+v", opus)
+	}
+	if stats.Models[1].SuccessRate != 0 {
+		t.Errorf("haiku success = %v, want 0", stats.Models[1].SuccessRate)
+	}
+
+	if empty := ComputeStats(nil, time.Time{}, until); empty.TaskCount != 0 || empty.PerDay != nil || empty.MedianTimeToDone != 0 {
+		t.Errorf("empty stats = %+v", empty)
+	}
+}
@@ -8,8 +8,10 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/dongho-jung/paw/internal/config"
 	"github.com/dongho-jung/paw/internal/constants"
 	"github.com/dongho-jung/paw/internal/logging"
 	"github.com/dongho-jung/paw/internal/tmux"
@@ -20,13 +22,30 @@ type DiscoveredTask struct {
 	Name          string // Task name (without emoji)
 	Session       string // Session name (project name)
 	Status        DiscoveredStatus
-	StatusEmoji   string    // Emoji prefix from the window name
-	WindowID      string    // Tmux window ID
-	Preview       string    // Last 3 lines from agent pane
-	CurrentAction string    // Agent's current action (extracted from ⏺ spinner line)
-	Duration      string    // Task duration (e.g., "1m 36s") extracted from Claude status
-	Tokens        string    // Token count (e.g., "↓ 5.9k") extracted from Claude status
-	CreatedAt     time.Time // Estimated creation time
+	StatusEmoji   string      // Emoji prefix from the window name
+	WindowID      string      // Tmux window ID
+	Preview       string      // Last 3 lines from agent pane
+	CurrentAction string      // Agent's current action (extracted from ⏺ spinner line)
+	Duration      string      // Task duration (e.g., "1m 36s") extracted from Claude status
+	Tokens        string      // Token count (e.g., "↓ 5.9k") extracted from Claude status
+	CreatedAt     time.Time   // Creation time (task file time, or discovery time if unknown)
+	LastActivity  time.Time   // Time of the last output in the task window
+	Content       string      // Task content (truncated), for search
+	Model         string      // Model from the task options, if any
+	BlockedOn     string      // Dependency task name (blocked tasks only)
+	BacklogID     string      // Backlog entry ID (backlog tasks only; no window)
+	Unreviewed    int         // Changed files not marked viewed in the diff viewer (reviewed tasks only)
+	Details       CardDetails // Diff, commits, verification and PR (loaded in the background)
+}
+
+// taskMetaContentLimit caps how much task content is kept for search.
+const taskMetaContentLimit = 2048
+
+// taskMeta is task metadata read from the agent directory.
+type taskMeta struct {
+	modTime time.Time
+	content string
+	model   string
 }
 
 // DiscoveredStatus represents the status of a discovered task.
@@ -34,14 +53,34 @@ type DiscoveredStatus string
 
 // Discovered task status values.
 const (
+	DiscoveredBacklog DiscoveredStatus = "backlog" // Drafted, not started
+	DiscoveredBlocked DiscoveredStatus = "blocked" // Waiting for a dependency task
 	DiscoveredWorking DiscoveredStatus = "working"
-	DiscoveredWaiting DiscoveredStatus = "waiting"
+	DiscoveredWaiting DiscoveredStatus = "waiting" // Needs user input
+	DiscoveredWarning DiscoveredStatus = "warning" // Needs attention (corrupted, failed merge, ...)
+	DiscoveredReview  DiscoveredStatus = "review"  // Open pull request
 	DiscoveredDone    DiscoveredStatus = "done"
 )
 
+// DiscoveredStatuses lists all discovered task statuses in board order.
+var DiscoveredStatuses = []DiscoveredStatus{
+	DiscoveredBacklog,
+	DiscoveredBlocked,
+	DiscoveredWorking,
+	DiscoveredWaiting,
+	DiscoveredWarning,
+	DiscoveredReview,
+	DiscoveredDone,
+}
+
 // TaskDiscoveryService discovers tasks across all PAW sessions.
 type TaskDiscoveryService struct {
 	socketDir string
+	meta      map[string]taskMeta // Agent dir → metadata, reloaded when the task file changes
+
+	detailsMu      sync.Mutex
+	details        map[string]cardDetailsEntry // Agent dir → card details, refreshed in the background
+	detailsLoading sync.WaitGroup
 }
 
 // NewTaskDiscoveryService creates a new task discovery service.
@@ -57,12 +96,14 @@ func NewTaskDiscoveryService() *TaskDiscoveryService {
 
 	return &TaskDiscoveryService{
 		socketDir: socketDir,
+		meta:      make(map[string]taskMeta),
+		details:   make(map[string]cardDetailsEntry),
 	}
 }
 
-// DiscoverAll finds all PAW tasks across all sessions.
-// Returns tasks grouped by status for Kanban display (3 columns: Working, Waiting, Done).
-func (s *TaskDiscoveryService) DiscoverAll() (working, waiting, done []*DiscoveredTask) {
+// DiscoverAll finds all PAW tasks (and backlog drafts) across all sessions.
+// Tasks are sorted by creation time (oldest first); the Kanban groups them into columns.
+func (s *TaskDiscoveryService) DiscoverAll() []*DiscoveredTask {
 	sockets := s.findPawSockets()
 
 	var allTasks []*DiscoveredTask
@@ -72,24 +113,11 @@ func (s *TaskDiscoveryService) DiscoverAll() (working, waiting, done []*Discover
 		allTasks = append(allTasks, tasks...)
 	}
 
-	// Sort by creation time (oldest first within each status)
-	sort.Slice(allTasks, func(i, j int) bool {
+	sort.SliceStable(allTasks, func(i, j int) bool {
 		return allTasks[i].CreatedAt.Before(allTasks[j].CreatedAt)
 	})
 
-	// Group by status
-	for _, task := range allTasks {
-		switch task.Status {
-		case DiscoveredWorking:
-			working = append(working, task)
-		case DiscoveredWaiting:
-			waiting = append(waiting, task)
-		case DiscoveredDone:
-			done = append(done, task)
-		}
-	}
-
-	return working, waiting, done
+	return allTasks
 }
 
 // findPawSockets finds all PAW tmux sockets.
@@ -150,12 +178,21 @@ func (s *TaskDiscoveryService) discoverFromSocket(socketName string) []*Discover
 		taskName := resolveTaskName(taskToken, tokenMap)
 
 		task := &DiscoveredTask{
-			Name:        taskName,
-			Session:     sessionName,
-			Status:      status,
-			StatusEmoji: extractWindowEmoji(w.Name),
-			WindowID:    w.ID,
-			CreatedAt:   time.Now(), // We don't have exact creation time
+			Name:         taskName,
+			Session:      sessionName,
+			Status:       status,
+			StatusEmoji:  extractWindowEmoji(w.Name),
+			WindowID:     w.ID,
+			CreatedAt:    time.Now(), // Replaced by the task file time below when available
+			LastActivity: w.Activity,
+		}
+		if pawDir != "" {
+			agentDir := filepath.Join(pawDir, constants.AgentsDirName, taskName)
+			s.applyTaskMeta(task, agentDir)
+			s.applyCardDetails(task, pawDir, agentDir)
+			if status == DiscoveredWaiting {
+				applyBlockedOn(task, agentDir)
+			}
 		}
 
 		// Only capture pane content for Working tasks (performance optimization)
@@ -178,9 +215,130 @@ func (s *TaskDiscoveryService) discoverFromSocket(socketName string) []*Discover
 		tasks = append(tasks, task)
 	}
 
+	if pawDir != "" {
+		tasks = append(tasks, discoverBacklog(pawDir, sessionName)...)
+	}
+
 	return tasks
 }
 
+// applyCardDetails sets the cached card details of a task. Details older than
+// CardDetailsTTL are reloaded in the background, so discovery never waits on git;
+// the next discovery picks up the new details.
+func (s *TaskDiscoveryService) applyCardDetails(task *DiscoveredTask, pawDir, agentDir string) {
+	s.detailsMu.Lock()
+	defer s.detailsMu.Unlock()
+
+	entry, ok := s.details[agentDir]
+	task.Details = entry.details
+	task.Unreviewed = entry.details.Unreviewed
+	if entry.loading || (ok && time.Since(entry.checked) < constants.CardDetailsTTL) {
+		return
+	}
+	entry.loading = true
+	s.details[agentDir] = entry
+
+	taskName := task.Name
+	s.detailsLoading.Add(1)
+	go func() {
+		defer s.detailsLoading.Done()
+		details := LoadCardDetails(pawDir, agentDir, taskName)
+
+		s.detailsMu.Lock()
+		s.details[agentDir] = cardDetailsEntry{checked: time.Now(), details: details}
+		s.detailsMu.Unlock()
+	}()
+}
+
+// applyBlockedOn moves a waiting task to blocked while it waits for a dependency.
+func applyBlockedOn(task *DiscoveredTask, agentDir string) {
+	data, err := os.ReadFile(filepath.Join(agentDir, constants.BlockedFileName)) //nolint:gosec // G304: path is from the agents directory
+	if err != nil {
+		return
+	}
+	task.Status = DiscoveredBlocked
+	task.BlockedOn = strings.TrimSpace(string(data))
+	if task.BlockedOn != "" {
+		task.CurrentAction = "Waiting for " + task.BlockedOn
+	}
+}
+
+// discoverBacklog returns the drafted tasks of a session as window-less tasks.
+func discoverBacklog(pawDir, sessionName string) []*DiscoveredTask {
+	entries, err := NewBacklog(pawDir).Load()
+	if err != nil {
+		logging.Debug("Failed to load backlog for session %s: %v", sessionName, err)
+		return nil
+	}
+	tasks := make([]*DiscoveredTask, 0, len(entries))
+	for _, entry := range entries {
+		task := &DiscoveredTask{
+			Name:      entry.Title(),
+			Session:   sessionName,
+			Status:    DiscoveredBacklog,
+			CreatedAt: entry.CreatedAt,
+			Content:   entry.Content,
+			BacklogID: entry.ID,
+		}
+		if entry.Options != nil {
+			task.Model = string(entry.Options.Model)
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks
+}
+
+// applyTaskMeta fills creation time, content and model from a task's agent directory.
+// Metadata is cached per agent directory and only reloaded when the task file changes.
+func (s *TaskDiscoveryService) applyTaskMeta(task *DiscoveredTask, agentDir string) {
+	info, err := os.Stat(filepath.Join(agentDir, constants.TaskFileName))
+	if err != nil {
+		return
+	}
+	task.CreatedAt = info.ModTime()
+
+	meta, ok := s.meta[agentDir]
+	if !ok || !meta.modTime.Equal(info.ModTime()) {
+		meta = taskMeta{modTime: info.ModTime()}
+		if data, err := os.ReadFile(filepath.Join(agentDir, constants.TaskFileName)); err == nil { //nolint:gosec // G304: path is from the agents directory
+			if len(data) > taskMetaContentLimit {
+				data = data[:taskMetaContentLimit]
+			}
+			meta.content = string(data)
+		}
+		if opts, err := config.LoadTaskOptions(agentDir); err == nil && opts != nil {
+			meta.model = string(opts.Model)
+		}
+		s.meta[agentDir] = meta
+	}
+	task.Content = meta.content
+	task.Model = meta.model
+}
+
+// ParseTokenCount converts a token display string (e.g., "↓ 5.9k") to a number.
+// Returns 0 if the string has no parsable count.
+func ParseTokenCount(tokens string) float64 {
+	fields := strings.Fields(tokens)
+	if len(fields) == 0 {
+		return 0
+	}
+	value := strings.ToLower(fields[len(fields)-1])
+	multiplier := 1.0
+	switch {
+	case strings.HasSuffix(value, "k"):
+		multiplier = 1e3
+		value = strings.TrimSuffix(value, "k")
+	case strings.HasSuffix(value, "m"):
+		multiplier = 1e6
+		value = strings.TrimSuffix(value, "m")
+	}
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0
+	}
+	return n * multiplier
+}
+
 func resolvePawDir(tm tmux.Client, sessionName string) string {
 	sessionPath, err := tm.RunWithOutput("display-message", "-p", "-t", sessionName, "#{session_path}")
 	if err != nil {
@@ -251,9 +409,9 @@ func parseWindowName(windowName string) (string, DiscoveredStatus) {
 	case strings.HasPrefix(windowName, constants.EmojiWaiting):
 		return strings.TrimPrefix(windowName, constants.EmojiWaiting), DiscoveredWaiting
 	case strings.HasPrefix(windowName, constants.EmojiReview):
-		return strings.TrimPrefix(windowName, constants.EmojiReview), DiscoveredWaiting
+		return strings.TrimPrefix(windowName, constants.EmojiReview), DiscoveredReview
 	case strings.HasPrefix(windowName, constants.EmojiWarning):
-		return strings.TrimPrefix(windowName, constants.EmojiWarning), DiscoveredWaiting
+		return strings.TrimPrefix(windowName, constants.EmojiWarning), DiscoveredWarning
 	case strings.HasPrefix(windowName, constants.EmojiDone):
 		return strings.TrimPrefix(windowName, constants.EmojiDone), DiscoveredDone
 	}
@@ -2,7 +2,13 @@
 package service
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/constants"
 )
 
 func TestExtractCurrentAction(t *testing.T) {
@@ -260,13 +266,13 @@ func TestParseWindowName(t *testing.T) {
 			name:           "review task",
 			windowName:     "👀my-task",
 			expectedTask:   "my-task",
-			expectedStatus: DiscoveredWaiting,
+			expectedStatus: DiscoveredReview,
 		},
 		{
 			name:           "warning task",
 			windowName:     "⚠️my-task",
 			expectedTask:   "my-task",
-			expectedStatus: DiscoveredWaiting,
+			expectedStatus: DiscoveredWarning,
 		},
 		{
 			name:           "non-task window",
@@ -294,3 +300,76 @@ func TestParseWindowName(t *testing.T) {
 		})
 	}
 }
+
+func TestParseTokenCount(t *testing.T) {
+	tests := map[string]float64{
+		"":        0,
+		"↓ 2.7k":  2700,
+		"↑ 100k":  100000,
+		"↓ 1.2m":  1200000,
+		"↓ 512":   512,
+		"unknown": 0,
+	}
+	for input, want := range tests {
+		if got := ParseTokenCount(input); got != want {
+			t.Errorf("ParseTokenCount(%q) = %v, want %v", input, got, want)
+		}
+	}
+}
+
+func TestApplyTaskMeta(t *testing.T) {
+	agentDir := t.TempDir()
+	taskPath := filepath.Join(agentDir, constants.TaskFileName)
+	if err := os.WriteFile(taskPath, []byte("Fix the login redirect"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	created := time.Now().Add(-2 * time.Hour).Truncate(time.Second)
+	if err := os.Chtimes(taskPath, created, created); err != nil {
+		t.Fatal(err)
+	}
+	opts := config.DefaultTaskOptions()
+	opts.Model = config.ModelSonnet
+	if err := opts.Save(agentDir); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewTaskDiscoveryService()
+	task := &DiscoveredTask{Name: "fix-login", CreatedAt: time.Now()}
+	s.applyTaskMeta(task, agentDir)
+
+	if !task.CreatedAt.Equal(created) {
+		t.Errorf("CreatedAt = %v, want %v", task.CreatedAt, created)
+	}
+	if task.Content != "Fix the login redirect" {
+		t.Errorf("Content = %q", task.Content)
+	}
+	if task.Model != string(config.ModelSonnet) {
+		t.Errorf("Model = %q, want %q", task.Model, config.ModelSonnet)
+	}
+
+	// Missing agent directories leave the task untouched
+	other := &DiscoveredTask{Name: "gone"}
+	s.applyTaskMeta(other, filepath.Join(agentDir, "missing"))
+	if other.Content != "" || !other.CreatedAt.IsZero() {
+		t.Errorf("unexpected metadata for missing task: %+v", other)
+	}
+}
+
+func TestApplyBlockedOn(t *testing.T) {
+	agentDir := t.TempDir()
+
+	// Waiting tasks without a marker stay waiting
+	task := &DiscoveredTask{Name: "b", Status: DiscoveredWaiting}
+	applyBlockedOn(task, agentDir)
+	if task.Status != DiscoveredWaiting {
+		t.Errorf("Status = %s, want waiting", task.Status)
+	}
+
+	if err := os.WriteFile(filepath.Join(agentDir, constants.BlockedFileName), []byte("a\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	applyBlockedOn(task, agentDir)
+	if task.Status != DiscoveredBlocked || task.BlockedOn != "a" || task.CurrentAction != "Waiting for a" {
+		t.Errorf("unexpected blocked task: %+v", task)
+	}
+}
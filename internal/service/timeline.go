@@ -0,0 +1,214 @@
+// Package service provides business logic services for PAW.
+package service
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/task"
+)
+
+// StatusTransition is one status change recorded by RecordStatusTransition.
+type StatusTransition struct {
+	Time   time.Time   `json:"ts"`
+	Task   string      `json:"task"`
+	From   task.Status `json:"from"`
+	To     task.Status `json:"to"`
+	Source string      `json:"source"`
+	Detail string      `json:"detail,omitempty"`
+	Valid  bool        `json:"valid"`
+}
+
+// StatusSegment is a span of time a task spent in one status.
+type StatusSegment struct {
+	Status task.Status
+	Start  time.Time
+	End    time.Time
+}
+
+// TaskTimeline is the status history of one task.
+type TaskTimeline struct {
+	Task     string
+	Segments []StatusSegment // Oldest first, without gaps between them
+	Finished time.Time       // When the task was finished or cancelled, zero while it is open
+}
+
+// Total returns the time the task spent in a status.
+func (t TaskTimeline) Total(status task.Status) time.Duration {
+	var total time.Duration
+	for _, seg := range t.Segments {
+		if seg.Status == status {
+			total += seg.End.Sub(seg.Start)
+		}
+	}
+	return total
+}
+
+// WaitTime returns the time the task sat waiting on the user. Corrupted
+// tasks wait on the user too (they are shown as waiting).
+func (t TaskTimeline) WaitTime() time.Duration {
+	return t.Total(task.StatusWaiting) + t.Total(task.StatusCorrupted)
+}
+
+// LoadTimelines builds the status timelines of the tasks that were active
+// between since and now, clipped to that window, oldest task first.
+// The last status of an open task lasts until now; the last status of a
+// finished task lasts until it was finished.
+func (s *HistoryService) LoadTimelines(since, now time.Time) ([]TaskTimeline, error) {
+	statusDir := filepath.Join(s.historyDir, "status")
+	files, err := os.ReadDir(statusDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read status history directory: %w", err)
+	}
+
+	finished := s.finishTimes()
+
+	var timelines []TaskTimeline
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ".jsonl" {
+			continue
+		}
+		transitions, err := readStatusTransitions(filepath.Join(statusDir, f.Name()))
+		if err != nil || len(transitions) == 0 {
+			continue
+		}
+		taskName := strings.TrimSuffix(f.Name(), ".jsonl")
+		tl := BuildTaskTimeline(taskName, transitions, finished[taskName], now)
+		tl.Segments = clipSegments(tl.Segments, since, now)
+		if len(tl.Segments) > 0 {
+			timelines = append(timelines, tl)
+		}
+	}
+
+	sort.SliceStable(timelines, func(i, j int) bool {
+		return timelines[i].Segments[0].Start.Before(timelines[j].Segments[0].Start)
+	})
+	return timelines, nil
+}
+
+// BuildTaskTimeline turns the transitions of a task into status segments.
+// Repeated transitions to the same status extend the current segment.
+func BuildTaskTimeline(taskName string, transitions []StatusTransition, finished, now time.Time) TaskTimeline {
+	sorted := append([]StatusTransition(nil), transitions...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Time.Before(sorted[j].Time)
+	})
+
+	tl := TaskTimeline{Task: taskName}
+	for i, tr := range sorted {
+		end := now
+		if i+1 < len(sorted) {
+			end = sorted[i+1].Time
+		} else if !finished.IsZero() && !finished.Before(tr.Time) {
+			end = finished
+			tl.Finished = finished
+		}
+		if !end.After(tr.Time) {
+			continue
+		}
+		if n := len(tl.Segments); n > 0 && tl.Segments[n-1].Status == tr.To && !tl.Segments[n-1].End.Before(tr.Time) {
+			tl.Segments[n-1].End = end
+			continue
+		}
+		tl.Segments = append(tl.Segments, StatusSegment{Status: tr.To, Start: tr.Time, End: end})
+	}
+	return tl
+}
+
+// clipSegments keeps the parts of segments that fall between start and end.
+func clipSegments(segments []StatusSegment, start, end time.Time) []StatusSegment {
+	var clipped []StatusSegment
+	for _, seg := range segments {
+		if seg.Start.Before(start) {
+			seg.Start = start
+		}
+		if seg.End.After(end) {
+			seg.End = end
+		}
+		if seg.End.After(seg.Start) {
+			clipped = append(clipped, seg)
+		}
+	}
+	return clipped
+}
+
+// readJSONLines reads a JSON lines history file, skipping malformed lines.
+func readJSONLines[T any](path string) ([]T, error) {
+	f, err := os.Open(path) //nolint:gosec // G304: path is inside the history directory
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	var records []T
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var record T
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			continue
+		}
+		records = append(records, record)
+	}
+	return records, scanner.Err()
+}
+
+// readStatusTransitions reads a status history file, skipping malformed lines.
+func readStatusTransitions(path string) ([]StatusTransition, error) {
+	records, err := readJSONLines[StatusTransition](path)
+	transitions := records[:0]
+	for _, tr := range records {
+		if !tr.Time.IsZero() {
+			transitions = append(transitions, tr)
+		}
+	}
+	return transitions, err
+}
+
+// finishTimes returns when each task was last finished: its latest outcome
+// record or history entry.
+func (s *HistoryService) finishTimes() map[string]time.Time {
+	finished := make(map[string]time.Time)
+	for name, outcome := range s.loadOutcomes() {
+		finished[name] = outcome.Time
+	}
+	files, err := s.ListHistoryFiles()
+	if err != nil {
+		return finished
+	}
+	for _, path := range files {
+		ts := HistoryTimestamp(path)
+		if ts.IsZero() {
+			continue
+		}
+		name := ExtractTaskName(path)
+		if ts.After(finished[name]) {
+			finished[name] = ts
+		}
+	}
+	return finished
+}
+
+// HistoryTimestamp parses the save time from a history filename.
+// Format: YYMMDD_HHMMSS_taskname[.cancelled]
+func HistoryTimestamp(historyFile string) time.Time {
+	base := filepath.Base(strings.TrimSuffix(historyFile, ".cancelled"))
+	parts := strings.SplitN(base, "_", 3)
+	if len(parts) < 3 {
+		return time.Time{}
+	}
+	parsed, err := time.ParseInLocation("060102_150405", parts[0]+"_"+parts[1], time.Local)
+	if err != nil {
+		return time.Time{}
+	}
+	return parsed
+}
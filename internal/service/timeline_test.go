@@ -0,0 +1,112 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/task"
+)
+
+func TestBuildTaskTimeline(t *testing.T) {
+	base := time.Date(2026, 1, 2, 10, 0, 0, 0, time.Local)
+	at := func(minutes int) time.Time { return base.Add(time.Duration(minutes) * time.Minute) }
+
+	transitions := []StatusTransition{
+		{Time: at(30), From: task.StatusWorking, To: task.StatusWaiting},
+		{Time: at(0), From: task.StatusPending, To: task.StatusWorking},
+		{Time: at(40), From: task.StatusWaiting, To: task.StatusWorking},
+		{Time: at(50), From: task.StatusWorking, To: task.StatusWorking}, // Repeated status
+		{Time: at(90), From: task.StatusWorking, To: task.StatusDone},
+	}
+
+	tl := BuildTaskTimeline("login", transitions, time.Time{}, at(120))
+	want := []StatusSegment{
+		{task.StatusWorking, at(0), at(30)},
+		{task.StatusWaiting, at(30), at(40)},
+		{task.StatusWorking, at(40), at(90)},
+		{task.StatusDone, at(90), at(120)},
+	}
+	if len(tl.Segments) != len(want) {
+		t.Fatalf("segments = %+v, want %+v", tl.Segments, want)
+	}
+	for i, seg := range tl.Segments {
+		if seg != want[i] {
+			t.Errorf("segment %d = %+v, want %+v", i, seg, want[i])
+		}
+	}
+	if got := tl.Total(task.StatusWorking); got != 80*time.Minute {
+		t.Errorf("Total(working) = %v, want 80m", got)
+	}
+	if got := tl.WaitTime(); got != 10*time.Minute {
+		t.Errorf("WaitTime() = %v, want 10m", got)
+	}
+
+	// A finished task stops at its history entry instead of now
+	finished := BuildTaskTimeline("login", transitions, at(100), at(120))
+	if last := finished.Segments[len(finished.Segments)-1]; !last.End.Equal(at(100)) || !finished.Finished.Equal(at(100)) {
+		t.Errorf("finished task ends at %v (Finished %v), want %v", last.End, finished.Finished, at(100))
+	}
+}
+
+func TestHistoryService_LoadTimelines(t *testing.T) {
+	dir := t.TempDir()
+	svc := NewHistoryService(dir)
+
+	if timelines, err := svc.LoadTimelines(time.Now().Add(-time.Hour), time.Now()); err != nil || len(timelines) != 0 {
+		t.Fatalf("LoadTimelines() without history = %v, %v", timelines, err)
+	}
+
+	for _, tr := range []struct {
+		name     string
+		from, to task.Status
+	}{
+		{"old-task", task.StatusPending, task.StatusWorking},
+		{"new-task", task.StatusPending, task.StatusWorking},
+		{"new-task", task.StatusWorking, task.StatusWaiting},
+	} {
+		if err := svc.RecordStatusTransition(tr.name, tr.from, tr.to, "test", "", true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Backdate the first task and add a malformed line
+	oldFile := filepath.Join(dir, "status", "old-task.jsonl")
+	old := time.Now().Add(-3 * time.Hour).Format(time.RFC3339Nano)
+	if err := os.WriteFile(oldFile, []byte(`{"ts":"`+old+`","task":"old-task","from":"pending","to":"working"}`+"\nnot json\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	timelines, err := svc.LoadTimelines(now.Add(-time.Hour), now)
+	if err != nil {
+		t.Fatalf("LoadTimelines() error = %v", err)
+	}
+	if len(timelines) != 2 || timelines[0].Task != "old-task" || timelines[1].Task != "new-task" {
+		t.Fatalf("timelines = %+v, want old-task then new-task", timelines)
+	}
+	// The old task is clipped to the window
+	if seg := timelines[0].Segments[0]; !seg.Start.Equal(now.Add(-time.Hour)) || seg.Status != task.StatusWorking {
+		t.Errorf("old-task segment = %+v, want working from the window start", seg)
+	}
+	if last := timelines[1].Segments[len(timelines[1].Segments)-1]; last.Status != task.StatusWaiting {
+		t.Errorf("new-task ends in %s, want waiting", last.Status)
+	}
+
+	// Tasks idle before the window are left out
+	if timelines, _ := svc.LoadTimelines(now.Add(-5*time.Hour), now.Add(-4*time.Hour)); len(timelines) != 0 {
+		t.Errorf("timelines before any transition = %+v, want none", timelines)
+	}
+}
+
+func TestHistoryTimestamp(t *testing.T) {
+	want := time.Date(2026, 1, 2, 15, 4, 5, 0, time.Local)
+	for _, name := range []string{"260102_150405_login", "/tmp/history/260102_150405_login_form.cancelled"} {
+		if got := HistoryTimestamp(name); !got.Equal(want) {
+			t.Errorf("HistoryTimestamp(%q) = %v, want %v", name, got, want)
+		}
+	}
+	if got := HistoryTimestamp("login"); !got.IsZero() {
+		t.Errorf("HistoryTimestamp(login) = %v, want zero", got)
+	}
+}
@@ -28,7 +28,7 @@ type Manager struct {
 	config       *config.Config
 	tmuxClient   tmux.Client
 	gitClient    git.Client
-	ghClient     github.Client
+	ghClient     github.Client // Created lazily by GitHub() for the PR target repository
 	claudeClient claude.Client
 
 	// Cache for truncated name lookups (populated lazily, invalidated on task changes)
@@ -44,7 +44,6 @@ func NewManager(agentsDir, projectDir, pawDir string, isGitRepo bool, cfg *confi
 		isGitRepo:    isGitRepo,
 		config:       cfg,
 		gitClient:    git.New(),
-		ghClient:     github.New(),
 		claudeClient: claude.New(),
 	}
 }
@@ -254,6 +253,9 @@ func (m *Manager) GetTask(name string) (*Task, error) {
 	// Load PR number if exists (error is non-fatal)
 	_, _ = task.LoadPRNumber()
 
+	// Load upstream if the task was checked out from an existing branch (error is non-fatal)
+	_, _ = task.LoadUpstream()
+
 	// Set worktree directory
 	if m.shouldUseWorktree() {
 		task.WorktreeDir = m.resolveWorktreeDir(task)
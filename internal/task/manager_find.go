@@ -81,7 +81,7 @@ func (m *Manager) FindIncompleteTasks(_ string) ([]*Task, error) {
 		}
 
 		// Skip if task is merged
-		if mainBranch != "" && m.isTaskMerged(task, mainBranch) {
+		if mainBranch != "" && m.isTaskMerged(task, m.targetBranchOr(task, mainBranch)) {
 			continue
 		}
 
@@ -157,7 +157,7 @@ func (m *Manager) FindMergedTasks() ([]*Task, error) {
 
 	var merged []*Task
 	for _, task := range tasks {
-		if m.isTaskMerged(task, mainBranch) {
+		if m.isTaskMerged(task, m.targetBranchOr(task, mainBranch)) {
 			task.Status = StatusDone
 			merged = append(merged, task)
 		}
@@ -174,7 +174,7 @@ func (m *Manager) isTaskMerged(task *Task, mainBranch string) bool {
 		if err != nil {
 			logging.Trace("isTaskMerged: failed to load PR number task=%s err=%v", task.Name, err)
 		} else if prNumber > 0 {
-			merged, err := m.ghClient.IsPRMerged(m.projectDir, prNumber)
+			merged, err := m.GitHub().IsPRMerged(m.projectDir, prNumber)
 			if err != nil {
 				logging.Trace("isTaskMerged: PR status check failed task=%s pr=%d err=%v", task.Name, prNumber, err)
 			} else if merged {
@@ -184,7 +184,8 @@ func (m *Manager) isTaskMerged(task *Task, mainBranch string) bool {
 	}
 
 	// Check if branch is merged into main
-	if m.gitClient.BranchMerged(m.projectDir, task.Name, mainBranch) {
+	// (skipped for existing branches, which may legitimately start out merged)
+	if task.Upstream == nil && m.gitClient.BranchMerged(m.projectDir, task.Name, mainBranch) {
 		return true
 	}
 
@@ -0,0 +1,66 @@
+package task
+
+import (
+	"strings"
+
+	"github.com/dongho-jung/paw/internal/github"
+	"github.com/dongho-jung/paw/internal/logging"
+)
+
+// FetchRemote returns the remote main is fetched and synced from.
+func (m *Manager) FetchRemote() string {
+	return m.config.GetFetchRemote()
+}
+
+// PushRemote returns the remote task branches and main are pushed to.
+func (m *Manager) PushRemote() string {
+	return m.config.GetPushRemote()
+}
+
+// remoteRepo returns the "owner/name" of a GitHub remote, or "" if unknown.
+func (m *Manager) remoteRepo(remote string) string {
+	url, err := m.gitClient.GetRemoteURL(m.projectDir, remote)
+	if err != nil {
+		return ""
+	}
+	return github.ParseRepoURL(url)
+}
+
+// PRRepo returns the "owner/name" repository PRs are opened against.
+// It is pr_repo if configured, otherwise the fetch remote's repository when it
+// differs from the push remote (fork workflow). An empty result lets gh decide.
+func (m *Manager) PRRepo() string {
+	if m.config != nil && m.config.PRRepo != "" {
+		return m.config.PRRepo
+	}
+	if !m.isGitRepo || m.FetchRemote() == m.PushRemote() {
+		return ""
+	}
+	return m.remoteRepo(m.FetchRemote())
+}
+
+// PRHead returns the --head value for a PR from branch.
+// Branches pushed to a fork are qualified as "owner:branch"; otherwise it returns ""
+// so gh infers the head from the pushed branch.
+func (m *Manager) PRHead(branch string) string {
+	prRepo := m.PRRepo()
+	if prRepo == "" {
+		return ""
+	}
+	pushRepo := m.remoteRepo(m.PushRemote())
+	if pushRepo == "" || pushRepo == prRepo {
+		return ""
+	}
+	owner, _, _ := strings.Cut(pushRepo, "/")
+	return owner + ":" + branch
+}
+
+// GitHub returns a gh client bound to the PR target repository.
+func (m *Manager) GitHub() github.Client {
+	if m.ghClient == nil {
+		repo := m.PRRepo()
+		logging.Trace("Manager.GitHub: repo=%q", repo)
+		m.ghClient = github.NewForRepo(repo)
+	}
+	return m.ghClient
+}
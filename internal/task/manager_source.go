@@ -0,0 +1,215 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/dongho-jung/paw/internal/git"
+	"github.com/dongho-jung/paw/internal/logging"
+)
+
+// prSourcePatterns match the accepted ways of referring to an existing PR.
+var prSourcePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^#(\d+)$`),                      // #123
+	regexp.MustCompile(`^(?i:pr):?(\d+)$`),              // pr:123, pr123
+	regexp.MustCompile(`^refs/pull/(\d+)/head$`),        // refs/pull/123/head
+	regexp.MustCompile(`^https?://[^ ]+/pull/(\d+)/?$`), // https://github.com/owner/repo/pull/123
+}
+
+// parsePRSource returns the PR number if source refers to a pull request.
+func parsePRSource(source string) (int, bool) {
+	for _, re := range prSourcePatterns {
+		if m := re.FindStringSubmatch(source); m != nil {
+			n, err := strconv.Atoi(m[1])
+			if err == nil && n > 0 {
+				return n, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// taskNameFromBranch derives a task name from an existing branch name.
+func taskNameFromBranch(branch string) string {
+	return sanitizeCustomBranchName(strings.ReplaceAll(branch, "/", "-"))
+}
+
+// ResolveSource resolves an existing branch or PR into an Upstream and a suggested task name.
+// Accepted sources are a local branch, remote/branch, #N, pr:N, refs/pull/N/head or a PR URL.
+// Remote sources are fetched so the returned start point exists locally.
+func (m *Manager) ResolveSource(source string) (*Upstream, string, error) {
+	logging.Debug("-> Manager.ResolveSource(source=%s)", source)
+	defer logging.Debug("<- Manager.ResolveSource")
+
+	source = strings.TrimSpace(source)
+	if !m.isGitRepo {
+		return nil, "", errors.New("checking out an existing branch requires a git repository")
+	}
+
+	if prNumber, ok := parsePRSource(source); ok {
+		return m.resolvePRSource(source, prNumber)
+	}
+
+	if !git.IsValidGitRef(source) {
+		return nil, "", fmt.Errorf("invalid branch name: %q", source)
+	}
+
+	if m.gitClient.BranchExists(m.projectDir, source) {
+		return m.resolveLocalBranch(source)
+	}
+
+	remotes, err := m.gitClient.ListRemotes(m.projectDir)
+	if err != nil {
+		logging.Trace("ResolveSource: failed to list remotes: %v", err)
+	}
+	for _, remote := range remotes {
+		if branch, ok := strings.CutPrefix(source, remote+"/"); ok && branch != "" {
+			return m.resolveRemoteBranch(source, remote, branch)
+		}
+	}
+
+	// Bare branch name that only exists on the fetch remote
+	if remote := m.FetchRemote(); m.gitClient.HasRemote(m.projectDir, remote) {
+		return m.resolveRemoteBranch(source, remote, source)
+	}
+
+	return nil, "", fmt.Errorf("branch not found: %s", source)
+}
+
+// resolvePRSource fetches refs/pull/N/head and looks up where pushes should go.
+func (m *Manager) resolvePRSource(source string, prNumber int) (*Upstream, string, error) {
+	ref := fmt.Sprintf("refs/pull/%d/head", prNumber)
+	commit, err := m.gitClient.FetchRef(m.projectDir, m.FetchRemote(), ref)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch PR #%d: %w", prNumber, err)
+	}
+
+	upstream := &Upstream{
+		Source:        source,
+		PRNumber:      prNumber,
+		StartPoint:    commit,
+		CreatedBranch: true,
+	}
+	name := fmt.Sprintf("pr-%d", prNumber)
+
+	// Without gh we can still work on the PR, but cannot push back to it
+	head, err := m.GitHub().GetPRHead(m.projectDir, prNumber)
+	if err != nil {
+		logging.Warn("ResolveSource: failed to get head of PR #%d: %v", prNumber, err)
+		return upstream, name, nil
+	}
+
+	upstream.Branch = head.HeadRefName
+	// Same-repository PRs live on the remote their refs were fetched from
+	upstream.Remote = m.FetchRemote()
+	if head.IsCrossRepository {
+		upstream.Remote = head.CloneURL()
+	}
+	if derived := taskNameFromBranch(head.HeadRefName); derived != "" {
+		name = derived
+	}
+	return upstream, name, nil
+}
+
+// resolveRemoteBranch fetches a remote branch to start a task from.
+func (m *Manager) resolveRemoteBranch(source, remote, branch string) (*Upstream, string, error) {
+	commit, err := m.gitClient.FetchRef(m.projectDir, remote, branch)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch %s/%s: %w", remote, branch, err)
+	}
+
+	name := taskNameFromBranch(branch)
+	if name == "" {
+		return nil, "", fmt.Errorf("cannot derive a task name from branch %q", branch)
+	}
+	return &Upstream{
+		Source:        source,
+		Remote:        remote,
+		Branch:        branch,
+		StartPoint:    commit,
+		CreatedBranch: true,
+	}, name, nil
+}
+
+// resolveLocalBranch reuses an existing local branch.
+// If the branch name is not a valid task name, a task branch is created at the same commit.
+func (m *Manager) resolveLocalBranch(branch string) (*Upstream, string, error) {
+	upstream := &Upstream{
+		Source:     branch,
+		StartPoint: branch,
+	}
+	if tracking, err := m.gitClient.GetUpstream(m.projectDir, branch); err == nil && tracking != "" {
+		if remote, remoteBranch, ok := strings.Cut(tracking, "/"); ok {
+			upstream.Remote = remote
+			upstream.Branch = remoteBranch
+		}
+	}
+
+	name := taskNameFromBranch(branch)
+	if name == "" {
+		return nil, "", fmt.Errorf("cannot derive a task name from branch %q", branch)
+	}
+	upstream.CreatedBranch = name != branch
+	return upstream, name, nil
+}
+
+// CreateTaskFromSource creates a task that works on an existing branch or PR
+// instead of a new branch from main. If customName is non-empty and the source
+// requires a new local branch, it is used as the task name.
+func (m *Manager) CreateTaskFromSource(content, source, customName string) (*Task, error) {
+	logging.Debug("-> Manager.CreateTaskFromSource(source=%s)", source)
+	defer logging.Debug("<- Manager.CreateTaskFromSource")
+
+	upstream, name, err := m.ResolveSource(source)
+	if err != nil {
+		return nil, err
+	}
+
+	var agentDir string
+	if upstream.CreatedBranch {
+		if custom := sanitizeCustomBranchName(customName); custom != "" {
+			name = custom
+		}
+		agentDir, err = m.createTaskDirectory(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create task directory: %w", err)
+		}
+	} else {
+		// Reusing an existing branch: the task name must match it exactly
+		agentDir = filepath.Join(m.agentsDir, name)
+		if err := os.Mkdir(agentDir, 0755); err != nil { //nolint:gosec // G301: standard directory permissions
+			if os.IsExist(err) {
+				return nil, fmt.Errorf("a task for branch %s already exists", name)
+			}
+			return nil, fmt.Errorf("failed to create task directory: %w", err)
+		}
+	}
+	logging.Debug("Task directory created: %s (source=%s)", agentDir, source)
+
+	task := New(filepath.Base(agentDir), agentDir)
+	if m.shouldUseWorktree() {
+		task.WorktreeDir = m.preferredWorktreeDir(task)
+	}
+
+	if err := task.SaveContent(content); err != nil {
+		_ = task.Remove()
+		return nil, fmt.Errorf("failed to save task content: %w", err)
+	}
+	if err := task.SaveUpstream(upstream); err != nil {
+		_ = task.Remove()
+		return nil, err
+	}
+	if upstream.PRNumber > 0 {
+		if err := task.SavePRNumber(upstream.PRNumber); err != nil {
+			logging.Warn("Failed to save PR number: %v", err)
+		}
+	}
+
+	m.InvalidateTruncatedNameCache()
+	return task, nil
+}
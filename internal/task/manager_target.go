@@ -0,0 +1,82 @@
+package task
+
+import (
+	"fmt"
+
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/logging"
+)
+
+// DefaultTargetBranch returns the project's target branch: target_branch if
+// configured, otherwise the detected main branch.
+func (m *Manager) DefaultTargetBranch() string {
+	if m.config != nil && m.config.TargetBranch != "" {
+		return m.config.TargetBranch
+	}
+	return m.gitClient.GetMainBranch(m.projectDir)
+}
+
+// TargetBranch returns the branch a task starts from, merges into, syncs with
+// and opens PRs against. The task's target_branch option overrides the project default.
+func (m *Manager) TargetBranch(task *Task) string {
+	if target := m.explicitTargetBranch(task); target != "" {
+		return target
+	}
+	return m.gitClient.GetMainBranch(m.projectDir)
+}
+
+// targetBranchOr returns the task's configured target branch, or mainBranch if none is set.
+// Used by loops over all tasks to avoid detecting the main branch per task.
+func (m *Manager) targetBranchOr(task *Task, mainBranch string) string {
+	if target := m.explicitTargetBranch(task); target != "" {
+		return target
+	}
+	return mainBranch
+}
+
+// explicitTargetBranch returns the configured target branch of a task, or ""
+// if the task falls back to the detected main branch.
+func (m *Manager) explicitTargetBranch(task *Task) string {
+	if task != nil {
+		opts, err := config.LoadTaskOptions(task.AgentDir)
+		if err != nil {
+			logging.Trace("explicitTargetBranch: failed to load options task=%s err=%v", task.Name, err)
+		} else if opts.TargetBranch != "" {
+			return opts.TargetBranch
+		}
+	}
+	if m.config != nil {
+		return m.config.TargetBranch
+	}
+	return ""
+}
+
+// EnsureLocalBranch makes sure a local branch exists, creating it from the
+// fetch remote if it only exists there. Merges and worktrees need a local branch.
+func (m *Manager) EnsureLocalBranch(branch string) error {
+	if m.gitClient.BranchExists(m.projectDir, branch) {
+		return nil
+	}
+
+	remote := m.FetchRemote()
+	remoteRef := remote + "/" + branch
+	startPoint := remoteRef
+	if !m.gitClient.RefExists(m.projectDir, "refs/remotes/"+remoteRef) {
+		commit, err := m.gitClient.FetchRef(m.projectDir, remote, branch)
+		if err != nil {
+			return fmt.Errorf("branch %s not found locally or on %s: %w", branch, remote, err)
+		}
+		startPoint = commit
+	}
+
+	if err := m.gitClient.BranchCreate(m.projectDir, branch, startPoint); err != nil {
+		return fmt.Errorf("failed to create branch %s: %w", branch, err)
+	}
+	if m.gitClient.RefExists(m.projectDir, "refs/remotes/"+remoteRef) {
+		if err := m.gitClient.SetUpstream(m.projectDir, branch, remoteRef); err != nil {
+			logging.Trace("EnsureLocalBranch: failed to set upstream: %v", err)
+		}
+	}
+	logging.Debug("EnsureLocalBranch: created %s from %s", branch, startPoint)
+	return nil
+}
@@ -3,6 +3,7 @@ package task
 import (
 	"errors"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -218,3 +219,40 @@ func TestSanitizeCustomBranchName(t *testing.T) {
 		}
 	})
 }
+
+func TestCleanupTaskDeletesReviewSnapshot(t *testing.T) {
+	projectDir := t.TempDir()
+	runGit := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = projectDir
+		cmd.Env = append(os.Environ(), "GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_SYSTEM=/dev/null")
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	runGit("init", "-q", "-b", "main")
+	runGit("-c", "user.name=Test", "-c", "user.email=test@example.com", "commit", "-q", "--allow-empty", "-m", "init")
+
+	pawDir := filepath.Join(projectDir, ".paw")
+	agentsDir := filepath.Join(pawDir, "agents")
+	task := New("review-task", filepath.Join(agentsDir, "review-task"))
+	if err := os.MkdirAll(task.AgentDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	reviewRef := constants.ReviewSnapshotRefPrefix + task.Name
+	runGit("update-ref", reviewRef, "HEAD")
+
+	mgr := NewManager(agentsDir, projectDir, pawDir, true, config.DefaultConfig())
+	if err := mgr.CleanupTask(task); err != nil {
+		t.Fatalf("CleanupTask() error = %v", err)
+	}
+	if refs := runGit("for-each-ref", constants.ReviewSnapshotRefPrefix); refs != "" {
+		t.Errorf("review snapshot left behind: %s", refs)
+	}
+	if _, err := os.Stat(task.AgentDir); !os.IsNotExist(err) {
+		t.Errorf("agent dir %s left behind", task.AgentDir)
+	}
+}
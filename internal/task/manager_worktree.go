@@ -96,11 +96,21 @@ func (m *Manager) CleanupTask(task *Task) error {
 		}
 
 		// Delete branch (error is non-fatal)
-		if m.gitClient.BranchExists(m.projectDir, task.Name) {
+		// Branches that existed before the task was checked out are kept
+		ownsBranch := task.Upstream == nil || task.Upstream.CreatedBranch
+		if ownsBranch && m.gitClient.BranchExists(m.projectDir, task.Name) {
 			if err := m.gitClient.BranchDelete(m.projectDir, task.Name, true); err != nil {
 				logging.Trace("BranchDelete failed: %v", err)
 			}
 		}
+
+		// Delete the diff viewer's review snapshot (error is non-fatal)
+		reviewRef := constants.ReviewSnapshotRefPrefix + task.Name
+		if m.gitClient.RefExists(m.projectDir, reviewRef) {
+			if err := m.gitClient.DeleteRef(m.projectDir, reviewRef); err != nil {
+				logging.Trace("DeleteRef failed: %v", err)
+			}
+		}
 	}
 
 	// Remove agent directory
@@ -134,6 +144,43 @@ func (m *Manager) SetupWorktree(task *Task) error {
 	worktreeDir := task.GetWorktreeDir()
 	task.WorktreeDir = worktreeDir
 
+	if task.Upstream != nil {
+		// Existing branch or PR: don't carry over uncommitted changes from the project
+		if err := m.addUpstreamWorktree(task, worktreeDir); err != nil {
+			return err
+		}
+	} else if err := m.addNewBranchWorktree(task, worktreeDir); err != nil {
+		return err
+	}
+
+	// Create .claude symlink in agent directory (outside worktree, avoids git tracking)
+	// Claude Code searches parent directories, so it will find .claude in AgentDir
+	claudeLink := filepath.Join(filepath.Dir(worktreeDir), constants.ClaudeLink)
+	claudeTarget := filepath.Join(m.pawDir, constants.ClaudeLink)
+	if err := os.Symlink(claudeTarget, claudeLink); err != nil && !os.IsExist(err) {
+		logging.Warn("SetupWorktree: failed to create claude symlink: %v", err)
+	} else {
+		logging.Debug("SetupWorktree: created .claude symlink in agent directory (outside git)")
+	}
+
+	// Execute pre-worktree hook if configured (error is non-fatal)
+	if m.config.PreWorktreeHook != "" {
+		m.executePreWorktreeHook(worktreeDir)
+	}
+
+	return nil
+}
+
+// addNewBranchWorktree creates the worktree on a new branch from the current HEAD,
+// carrying over uncommitted and untracked changes from the project directory.
+// Tasks with a target branch other than the checked-out one start from the target instead.
+func (m *Manager) addNewBranchWorktree(task *Task, worktreeDir string) error {
+	if target := m.explicitTargetBranch(task); target != "" {
+		if current, err := m.gitClient.GetCurrentBranch(m.projectDir); err != nil || current != target {
+			return m.addTargetBranchWorktree(task, worktreeDir, target)
+		}
+	}
+
 	// Stash any uncommitted changes (error is non-fatal)
 	stashHash, err := m.gitClient.StashCreate(m.projectDir)
 	if err != nil {
@@ -167,19 +214,45 @@ func (m *Manager) SetupWorktree(task *Task) error {
 		}
 	}
 
-	// Create .claude symlink in agent directory (outside worktree, avoids git tracking)
-	// Claude Code searches parent directories, so it will find .claude in AgentDir
-	claudeLink := filepath.Join(filepath.Dir(worktreeDir), constants.ClaudeLink)
-	claudeTarget := filepath.Join(m.pawDir, constants.ClaudeLink)
-	if err := os.Symlink(claudeTarget, claudeLink); err != nil && !os.IsExist(err) {
-		logging.Warn("SetupWorktree: failed to create claude symlink: %v", err)
-	} else {
-		logging.Debug("SetupWorktree: created .claude symlink in agent directory (outside git)")
+	return nil
+}
+
+// addTargetBranchWorktree creates the worktree on a new branch from the target branch.
+// Uncommitted changes in the project belong to another branch and are not carried over.
+func (m *Manager) addTargetBranchWorktree(task *Task, worktreeDir, target string) error {
+	if err := m.EnsureLocalBranch(target); err != nil {
+		return err
+	}
+	if err := m.gitClient.BranchCreate(m.projectDir, task.Name, target); err != nil {
+		return fmt.Errorf("failed to create branch from %s: %w", target, err)
+	}
+	if err := m.gitClient.WorktreeAdd(m.projectDir, worktreeDir, task.Name, false); err != nil {
+		return fmt.Errorf("failed to create worktree: %w", err)
 	}
+	return nil
+}
 
-	// Execute pre-worktree hook if configured (error is non-fatal)
-	if m.config.PreWorktreeHook != "" {
-		m.executePreWorktreeHook(worktreeDir)
+// addUpstreamWorktree creates the worktree on the existing branch or PR the task was checked out from.
+func (m *Manager) addUpstreamWorktree(task *Task, worktreeDir string) error {
+	upstream := task.Upstream
+	if upstream.CreatedBranch && !m.gitClient.BranchExists(m.projectDir, task.Name) {
+		if err := m.gitClient.BranchCreate(m.projectDir, task.Name, upstream.StartPoint); err != nil {
+			return fmt.Errorf("failed to create branch from %s: %w", upstream.Source, err)
+		}
+	}
+
+	if err := m.gitClient.WorktreeAdd(m.projectDir, worktreeDir, task.Name, false); err != nil {
+		return fmt.Errorf("failed to create worktree: %w", err)
+	}
+
+	// Track the remote branch so plain `git pull`/`git status` work in the worktree (non-fatal)
+	if upstream.CreatedBranch && upstream.Remote != "" && upstream.Branch != "" {
+		tracking := upstream.Remote + "/" + upstream.Branch
+		if m.gitClient.RefExists(m.projectDir, "refs/remotes/"+tracking) {
+			if err := m.gitClient.SetUpstream(m.projectDir, task.Name, tracking); err != nil {
+				logging.Warn("SetupWorktree: failed to set upstream %s: %v", tracking, err)
+			}
+		}
 	}
 
 	return nil
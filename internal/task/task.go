@@ -79,6 +79,7 @@ type Task struct {
 	Content     string
 	Status      Status
 	PRNumber    int
+	Upstream    *Upstream // Non-nil if checked out from an existing branch or PR
 	CreatedAt   time.Time
 
 	// For corrupted tasks
@@ -363,6 +364,24 @@ func (t *Task) HasPR() bool {
 	return err == nil
 }
 
+// GetBlockedFilePath returns the path to the dependency marker file.
+func (t *Task) GetBlockedFilePath() string {
+	return filepath.Join(t.AgentDir, constants.BlockedFileName)
+}
+
+// SaveBlockedOn records the dependency task this task is waiting for.
+func (t *Task) SaveBlockedOn(dependency string) error {
+	return fileutil.WriteFileAtomic(t.GetBlockedFilePath(), []byte(dependency), 0644)
+}
+
+// ClearBlockedOn removes the dependency marker.
+func (t *Task) ClearBlockedOn() error {
+	if err := os.Remove(t.GetBlockedFilePath()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
 // GetWindowName returns the window name with status emoji.
 // Note: StatusCorrupted maps to Waiting emoji.
 func (t *Task) GetWindowName() string {
@@ -430,6 +430,31 @@ func TestTaskSessionMarker(t *testing.T) {
 	}
 }
 
+func TestTaskBlockedOn(t *testing.T) {
+	agentDir := t.TempDir()
+	task := New("test-task", agentDir)
+
+	// Clearing a missing marker is not an error
+	if err := task.ClearBlockedOn(); err != nil {
+		t.Fatalf("ClearBlockedOn() error = %v", err)
+	}
+
+	if err := task.SaveBlockedOn("other-task"); err != nil {
+		t.Fatalf("SaveBlockedOn() error = %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(agentDir, ".blocked"))
+	if err != nil || string(data) != "other-task" {
+		t.Errorf("marker = %q, %v; want other-task", data, err)
+	}
+
+	if err := task.ClearBlockedOn(); err != nil {
+		t.Fatalf("ClearBlockedOn() error = %v", err)
+	}
+	if _, err := os.Stat(task.GetBlockedFilePath()); !os.IsNotExist(err) {
+		t.Errorf("marker should be removed, stat err = %v", err)
+	}
+}
+
 func TestTaskStatus(t *testing.T) {
 	tempDir := t.TempDir()
 	agentDir := filepath.Join(tempDir, "test-task")
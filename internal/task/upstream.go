@@ -0,0 +1,69 @@
+package task
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/fileutil"
+)
+
+// Upstream describes the existing branch or PR a task was checked out from.
+// Tasks with an upstream push back to it instead of merging into main.
+type Upstream struct {
+	Source        string `json:"source"`           // Source as entered by the user (branch, remote/branch, #N, refs/pull/N/head)
+	Remote        string `json:"remote,omitempty"` // Remote name or URL to push to
+	Branch        string `json:"branch,omitempty"` // Branch on Remote to push to
+	PRNumber      int    `json:"pr,omitempty"`     // Existing PR number, if any
+	StartPoint    string `json:"start_point"`      // Commit the worktree starts from
+	CreatedBranch bool   `json:"created_branch"`   // True if PAW created the local branch (safe to delete on cleanup)
+}
+
+// CanPush returns true if the upstream has a push destination.
+func (u *Upstream) CanPush() bool {
+	return u != nil && u.Remote != "" && u.Branch != ""
+}
+
+// PushRefspec returns the refspec that pushes HEAD to the upstream branch.
+func (u *Upstream) PushRefspec() string {
+	return "HEAD:" + u.Branch
+}
+
+// GetUpstreamPath returns the path to the upstream file.
+func (t *Task) GetUpstreamPath() string {
+	return filepath.Join(t.AgentDir, constants.UpstreamFileName)
+}
+
+// SaveUpstream saves the upstream the task was checked out from.
+func (t *Task) SaveUpstream(upstream *Upstream) error {
+	data, err := json.MarshalIndent(upstream, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal upstream: %w", err)
+	}
+	if err := fileutil.WriteFileAtomic(t.GetUpstreamPath(), data, 0644); err != nil {
+		return fmt.Errorf("failed to write upstream: %w", err)
+	}
+	t.Upstream = upstream
+	return nil
+}
+
+// LoadUpstream loads the upstream the task was checked out from.
+// Returns nil without error if the task was created from main.
+func (t *Task) LoadUpstream() (*Upstream, error) {
+	data, err := os.ReadFile(t.GetUpstreamPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var upstream Upstream
+	if err := json.Unmarshal(data, &upstream); err != nil {
+		return nil, fmt.Errorf("failed to parse upstream: %w", err)
+	}
+	t.Upstream = &upstream
+	return &upstream, nil
+}
@@ -0,0 +1,96 @@
+package task
+
+import (
+	"testing"
+)
+
+func TestParsePRSource(t *testing.T) {
+	tests := []struct {
+		source string
+		want   int
+		ok     bool
+	}{
+		{"#123", 123, true},
+		{"pr:42", 42, true},
+		{"PR42", 42, true},
+		{"refs/pull/7/head", 7, true},
+		{"https://github.com/owner/repo/pull/99", 99, true},
+		{"https://github.com/owner/repo/pull/99/", 99, true},
+		{"#0", 0, false},
+		{"main", 0, false},
+		{"feature/pr-12", 0, false},
+		{"origin/main", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			got, ok := parsePRSource(tt.source)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("parsePRSource(%q) = (%d, %v), want (%d, %v)", tt.source, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestTaskNameFromBranch(t *testing.T) {
+	tests := []struct {
+		branch string
+		want   string
+	}{
+		{"fix-login", "fix-login"},
+		{"feature/new-ui", "feature-new-ui"},
+		{"Feature/New_UI", "feature-new-ui"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.branch, func(t *testing.T) {
+			if got := taskNameFromBranch(tt.branch); got != tt.want {
+				t.Errorf("taskNameFromBranch(%q) = %q, want %q", tt.branch, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTaskUpstream(t *testing.T) {
+	task := New("test-task", t.TempDir())
+
+	// Tasks created from main have no upstream
+	upstream, err := task.LoadUpstream()
+	if err != nil {
+		t.Fatalf("LoadUpstream() error = %v", err)
+	}
+	if upstream != nil {
+		t.Fatalf("LoadUpstream() = %+v, want nil", upstream)
+	}
+
+	saved := &Upstream{
+		Source:        "#12",
+		Remote:        "origin",
+		Branch:        "feature/login",
+		PRNumber:      12,
+		StartPoint:    "abc123",
+		CreatedBranch: true,
+	}
+	if err := task.SaveUpstream(saved); err != nil {
+		t.Fatalf("SaveUpstream() error = %v", err)
+	}
+
+	loaded, err := New("test-task", task.AgentDir).LoadUpstream()
+	if err != nil {
+		t.Fatalf("LoadUpstream() error = %v", err)
+	}
+	if *loaded != *saved {
+		t.Errorf("LoadUpstream() = %+v, want %+v", loaded, saved)
+	}
+	if !loaded.CanPush() {
+		t.Error("CanPush() = false, want true")
+	}
+	if got := loaded.PushRefspec(); got != "HEAD:feature/login" {
+		t.Errorf("PushRefspec() = %q, want %q", got, "HEAD:feature/login")
+	}
+
+	var none *Upstream
+	if none.CanPush() {
+		t.Error("nil CanPush() = true, want false")
+	}
+}
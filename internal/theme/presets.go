@@ -0,0 +1,9 @@
+// Package theme loads PAW color themes for the TUI and the tmux chrome.
+package theme
+
+import "embed"
+
+// presets holds the built-in themes shipped with PAW.
+//
+//go:embed presets/*.toml
+var presets embed.FS
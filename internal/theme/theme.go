@@ -0,0 +1,361 @@
+// Package theme loads PAW color themes for the TUI and the tmux chrome.
+package theme
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/constants"
+)
+
+// NameAuto is the theme that follows the terminal background with the
+// built-in light and dark palettes.
+const NameAuto = "auto"
+
+// Theme modes.
+const (
+	ModeDark  = "dark"
+	ModeLight = "light"
+)
+
+// maxExtendsDepth bounds "extends" chains so cycles fail instead of looping.
+const maxExtendsDepth = 8
+
+// TUIKeys lists the TUI color keys of the [tui] section.
+var TUIKeys = []string{
+	"accent", "accent_secondary",
+	"text", "text_dim", "text_bright", "text_inverted",
+	"border", "border_focused", "border_dim",
+	"background", "background_alt", "selection",
+	"status_bar", "status_bar_text",
+	"success", "warning", "error",
+	"search_match", "search_current",
+	"log_trace", "log_debug", "log_info", "log_warn", "log_error", "log_fatal",
+	"status_backlog", "status_blocked", "status_working", "status_waiting",
+	"status_warning", "status_review", "status_done",
+	"diff_add", "diff_delete", "diff_add_bg", "diff_delete_bg",
+	"diff_add_emphasis", "diff_delete_emphasis",
+}
+
+// TmuxKeys lists the tmux color keys of the [tmux] section.
+var TmuxKeys = []string{
+	"status_fg", "status_bg",
+	"window_fg", "window_bg", "window_current_fg", "window_current_bg",
+	"pane_border", "pane_active_border", "popup_border",
+}
+
+// Theme is a named color theme. Colors it leaves out fall back to the
+// built-in palette of its mode.
+type Theme struct {
+	Name string
+	Mode string            // ModeDark, ModeLight or "" (follow the terminal background)
+	TUI  map[string]string // TUI color key -> color
+	Tmux map[string]string // tmux color key -> color
+}
+
+// Auto returns the theme that follows the terminal background.
+func Auto() *Theme {
+	return &Theme{Name: NameAuto, TUI: map[string]string{}, Tmux: map[string]string{}}
+}
+
+// IsDark reports whether the theme is dark, given the detected terminal background.
+func (t *Theme) IsDark(detected bool) bool {
+	switch t.Mode {
+	case ModeDark:
+		return true
+	case ModeLight:
+		return false
+	}
+	return detected
+}
+
+// Dir returns the user theme directory ($HOME/.config/paw/themes).
+func Dir() string {
+	globalDir := config.GlobalPawDir()
+	if globalDir == "" {
+		return ""
+	}
+	return filepath.Join(globalDir, constants.GlobalThemesDir)
+}
+
+// ConfiguredName returns the theme name of a project: the project config's
+// theme, else the global config's, else NameAuto.
+func ConfiguredName(pawDir string) string {
+	name := NameAuto
+	for _, dir := range []string{config.GlobalPawDir(), pawDir} {
+		if dir == "" {
+			continue
+		}
+		if cfg, err := config.Load(dir); err == nil && strings.TrimSpace(cfg.Theme) != "" {
+			name = strings.TrimSpace(cfg.Theme)
+		}
+	}
+	return name
+}
+
+// Load returns the configured theme of a project. On error it returns the
+// auto theme along with the error.
+func Load(pawDir string) (*Theme, error) {
+	t, err := Lookup(ConfiguredName(pawDir))
+	if err != nil {
+		return Auto(), err
+	}
+	return t, nil
+}
+
+// Lookup returns a theme by name. User themes in Dir() take precedence over
+// presets of the same name.
+func Lookup(name string) (*Theme, error) {
+	return lookup(name, 0)
+}
+
+func lookup(name string, depth int) (*Theme, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || name == NameAuto {
+		return Auto(), nil
+	}
+	if depth > maxExtendsDepth {
+		return nil, fmt.Errorf("theme %q: extends chain too deep", name)
+	}
+	if strings.ContainsAny(name, `/\`) {
+		return nil, fmt.Errorf("invalid theme name %q", name)
+	}
+
+	data, err := readTheme(name)
+	if err != nil {
+		return nil, err
+	}
+	t, extends, err := parse(name, data)
+	if err != nil {
+		return nil, err
+	}
+	if extends == "" {
+		return t, nil
+	}
+
+	base, err := lookup(extends, depth+1)
+	if err != nil {
+		return nil, fmt.Errorf("theme %q: %w", name, err)
+	}
+	return merge(base, t), nil
+}
+
+// readTheme reads a user theme file, falling back to the embedded presets.
+func readTheme(name string) ([]byte, error) {
+	if dir := Dir(); dir != "" {
+		data, err := os.ReadFile(filepath.Join(dir, name+".toml")) //nolint:gosec // G304: theme name has no path separators
+		if err == nil {
+			return data, nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	}
+	data, err := presets.ReadFile("presets/" + name + ".toml")
+	if err != nil {
+		return nil, fmt.Errorf("unknown theme %q", name)
+	}
+	return data, nil
+}
+
+// merge returns base with the mode and colors set by t applied on top.
+func merge(base, t *Theme) *Theme {
+	merged := &Theme{Name: t.Name, Mode: base.Mode, TUI: map[string]string{}, Tmux: map[string]string{}}
+	if t.Mode != "" {
+		merged.Mode = t.Mode
+	}
+	for _, m := range []*Theme{base, t} {
+		for key, value := range m.TUI {
+			merged.TUI[key] = value
+		}
+		for key, value := range m.Tmux {
+			merged.Tmux[key] = value
+		}
+	}
+	return merged
+}
+
+// List returns the names of all available themes: auto, the presets and the
+// user themes, sorted after auto.
+func List() []string {
+	var names []string
+	if entries, err := presets.ReadDir("presets"); err == nil {
+		for _, entry := range entries {
+			names = append(names, strings.TrimSuffix(entry.Name(), ".toml"))
+		}
+	}
+	if dir := Dir(); dir != "" {
+		if entries, err := os.ReadDir(dir); err == nil {
+			for _, entry := range entries {
+				if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".toml") {
+					names = append(names, strings.TrimSuffix(entry.Name(), ".toml"))
+				}
+			}
+		}
+	}
+	slices.Sort(names)
+	return append([]string{NameAuto}, slices.Compact(names)...)
+}
+
+// Parse parses a theme file: top-level "mode" and "extends" keys followed by
+// [tui] and [tmux] sections of color keys, e.g.
+//
+//	mode = "dark"
+//	extends = "solarized-dark"
+//
+//	[tui]
+//	accent = "#268bd2"
+//
+//	[tmux]
+//	status_bg = "colour236"
+//
+// Colors are ANSI 256 numbers ("39") or hex ("#268bd2"); tmux colors may also
+// be "colourN" or "default". Extends is resolved by Lookup, not by Parse.
+func Parse(name string, data []byte) (*Theme, error) {
+	t, _, err := parse(name, data)
+	return t, err
+}
+
+func parse(name string, data []byte) (*Theme, string, error) {
+	t := &Theme{Name: name, TUI: map[string]string{}, Tmux: map[string]string{}}
+	extends := ""
+	section := ""
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(stripComment(scanner.Text()))
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			if section != "tui" && section != "tmux" {
+				return nil, "", fmt.Errorf("theme %q line %d: unknown section [%s]", name, lineNum, section)
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, "", fmt.Errorf("theme %q line %d: expected key = value", name, lineNum)
+		}
+		key = strings.TrimSpace(key)
+		value = unquote(strings.TrimSpace(value))
+
+		var err error
+		switch section {
+		case "":
+			switch key {
+			case "mode":
+				if value != ModeDark && value != ModeLight && value != "" {
+					err = fmt.Errorf("mode must be %q or %q", ModeDark, ModeLight)
+				}
+				t.Mode = value
+			case "extends":
+				extends = value
+			case "name", "description":
+				// Informational only
+			default:
+				err = fmt.Errorf("unknown key %q", key)
+			}
+		case "tui":
+			err = setColor(t.TUI, TUIKeys, key, value, false)
+		case "tmux":
+			err = setColor(t.Tmux, TmuxKeys, key, value, true)
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("theme %q line %d: %w", name, lineNum, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, "", err
+	}
+	return t, extends, nil
+}
+
+func setColor(colors map[string]string, keys []string, key, value string, tmux bool) error {
+	if !slices.Contains(keys, key) {
+		return fmt.Errorf("unknown color %q", key)
+	}
+	if !validColor(value, tmux) {
+		return fmt.Errorf("%s: invalid color %q", key, value)
+	}
+	colors[key] = value
+	return nil
+}
+
+// stripComment removes a "#" comment outside of quotes. A "#" directly
+// following "=" or a quote starts a hex color, not a comment.
+func stripComment(line string) string {
+	inQuote := byte(0)
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case inQuote != 0:
+			if c == inQuote {
+				inQuote = 0
+			}
+		case c == '"' || c == '\'':
+			inQuote = c
+		case c == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			if prev := strings.TrimSpace(line[:i]); !strings.HasSuffix(prev, "=") {
+				return line[:i]
+			}
+		}
+	}
+	return line
+}
+
+func unquote(value string) string {
+	if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+		return value[1 : len(value)-1]
+	}
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		return unquoted
+	}
+	return value
+}
+
+// validColor reports whether value is an ANSI 256 number or a hex color, or
+// for tmux also "colourN"/"colorN" or "default".
+func validColor(value string, tmux bool) bool {
+	if tmux {
+		if value == "default" {
+			return true
+		}
+		for _, prefix := range []string{"colour", "color"} {
+			if rest, ok := strings.CutPrefix(value, prefix); ok {
+				return validANSI(rest)
+			}
+		}
+	}
+	if hex, ok := strings.CutPrefix(value, "#"); ok {
+		if len(hex) != 3 && len(hex) != 6 {
+			return false
+		}
+		_, err := strconv.ParseUint(hex, 16, 32)
+		return err == nil
+	}
+	return validANSI(value)
+}
+
+func validANSI(value string) bool {
+	n, err := strconv.Atoi(value)
+	return err == nil && n >= 0 && n <= 255
+}
+
+// TmuxColor converts a theme color to tmux notation ("39" -> "colour39").
+func TmuxColor(value string) string {
+	if validANSI(value) {
+		return "colour" + value
+	}
+	return value
+}
@@ -0,0 +1,124 @@
+package theme
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/dongho-jung/paw/internal/constants"
+)
+
+func TestParse(t *testing.T) {
+	data := `# comment
+mode = "light"
+
+[tui]
+accent = #268bd2   # hex without quotes
+text_dim = "245"
+
+[tmux]
+status_bg = colour236
+window_bg = 'default'
+`
+	th, err := Parse("custom", []byte(data))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if th.Mode != ModeLight || th.IsDark(true) {
+		t.Errorf("mode = %q", th.Mode)
+	}
+	if th.TUI["accent"] != "#268bd2" || th.TUI["text_dim"] != "245" {
+		t.Errorf("tui colors = %v", th.TUI)
+	}
+	if th.Tmux["status_bg"] != "colour236" || th.Tmux["window_bg"] != "default" {
+		t.Errorf("tmux colors = %v", th.Tmux)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := map[string]string{
+		"unknown key":     "[tui]\naccnet = \"39\"\n",
+		"invalid color":   "[tui]\naccent = \"blue\"\n",
+		"out of range":    "[tui]\naccent = \"256\"\n",
+		"tmux-only value": "[tui]\naccent = \"default\"\n",
+		"unknown section": "[colors]\n",
+		"bad mode":        "mode = \"dim\"\n",
+		"missing equals":  "[tui]\naccent\n",
+	}
+	for name, data := range tests {
+		if _, err := Parse("bad", []byte(data)); err == nil {
+			t.Errorf("%s: Parse() should fail", name)
+		}
+	}
+}
+
+func TestPresets(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	names := List()
+	for _, want := range []string{NameAuto, "dark", "light", "solarized-dark", "solarized-light", "high-contrast", "colorblind"} {
+		if !slices.Contains(names, want) {
+			t.Errorf("List() = %v, missing %q", names, want)
+		}
+	}
+	for _, name := range names {
+		if _, err := Lookup(name); err != nil {
+			t.Errorf("Lookup(%q) error = %v", name, err)
+		}
+	}
+
+	// Full presets define every color
+	for _, name := range []string{"solarized-dark", "solarized-light", "high-contrast"} {
+		th, _ := Lookup(name)
+		if len(th.TUI) != len(TUIKeys) || len(th.Tmux) != len(TmuxKeys) {
+			t.Errorf("%s defines %d/%d TUI and %d/%d tmux colors", name, len(th.TUI), len(TUIKeys), len(th.Tmux), len(TmuxKeys))
+		}
+	}
+
+	if _, err := Lookup("nope"); err == nil {
+		t.Error("Lookup() of an unknown theme should fail")
+	}
+	if _, err := Lookup("../config"); err == nil {
+		t.Error("Lookup() should reject path separators")
+	}
+}
+
+func TestUserThemes(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	dir := filepath.Join(home, constants.GlobalConfigDir, constants.GlobalThemesDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	write := func(name, data string) {
+		if err := os.WriteFile(filepath.Join(dir, name+".toml"), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("mine", "extends = \"solarized-dark\"\n[tui]\naccent = \"201\"\n")
+	write("loop", "extends = \"loop\"\n")
+
+	th, err := Lookup("mine")
+	if err != nil {
+		t.Fatalf("Lookup(mine) error = %v", err)
+	}
+	if th.Mode != ModeDark || th.TUI["accent"] != "201" || th.TUI["success"] != "#859900" {
+		t.Errorf("extended theme = %+v", th)
+	}
+	if !slices.Contains(List(), "mine") {
+		t.Error("List() should include user themes")
+	}
+	if _, err := Lookup("loop"); err == nil || !strings.Contains(err.Error(), "too deep") {
+		t.Errorf("Lookup(loop) error = %v", err)
+	}
+}
+
+func TestTmuxColor(t *testing.T) {
+	for in, want := range map[string]string{"39": "colour39", "#ffffff": "#ffffff", "default": "default", "colour12": "colour12"} {
+		if got := TmuxColor(in); got != want {
+			t.Errorf("TmuxColor(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
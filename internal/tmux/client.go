@@ -188,10 +188,11 @@ type BreakOpts struct {
 
 // Window represents a tmux window.
 type Window struct {
-	ID     string
-	Index  int
-	Name   string
-	Active bool
+	ID       string
+	Index    int
+	Name     string
+	Active   bool
+	Activity time.Time // Time of the last output in the window
 }
 
 // tmuxClient implements the Client interface.
@@ -367,7 +368,7 @@ func (c *tmuxClient) RenameWindow(target, name string) error {
 
 func (c *tmuxClient) ListWindows() ([]Window, error) {
 	// Specify session target (-t) to ensure correct results when running from outside tmux
-	output, err := c.RunWithOutput("list-windows", "-t", c.sessionName, "-F", "#{window_id}|#{window_index}|#{window_name}|#{window_active}")
+	output, err := c.RunWithOutput("list-windows", "-t", c.sessionName, "-F", "#{window_id}|#{window_index}|#{window_name}|#{window_active}|#{window_activity}")
 	if err != nil {
 		return nil, err
 	}
@@ -387,12 +388,18 @@ func (c *tmuxClient) ListWindows() ([]Window, error) {
 		var index int
 		_, _ = fmt.Sscanf(parts[1], "%d", &index)
 
-		windows = append(windows, Window{
+		window := Window{
 			ID:     parts[0],
 			Index:  index,
 			Name:   parts[2],
 			Active: parts[3] == "1",
-		})
+		}
+		if len(parts) > 4 {
+			if activity, err := strconv.ParseInt(parts[4], 10, 64); err == nil && activity > 0 {
+				window.Activity = time.Unix(activity, 0)
+			}
+		}
+		windows = append(windows, window)
 	}
 
 	return windows, nil
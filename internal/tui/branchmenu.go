@@ -49,7 +49,7 @@ func (m *BranchMenu) Init() tea.Cmd {
 func (m *BranchMenu) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.BackgroundColorMsg:
-		m.isDark = msg.IsDark()
+		m.isDark = themeIsDark(msg.IsDark())
 		m.colors = NewThemeColors(m.isDark)
 		m.stylesCached = false // Invalidate style cache on theme change
 		setCachedDarkMode(m.isDark)
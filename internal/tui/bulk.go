@@ -0,0 +1,351 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/keymap"
+)
+
+// BulkAction is an action applied to several tasks at once.
+type BulkAction string
+
+// Bulk actions.
+const (
+	BulkMergePush BulkAction = constants.ActionMergePush
+	BulkMerge     BulkAction = constants.ActionMerge
+	BulkDrop      BulkAction = constants.ActionDrop
+	BulkSync      BulkAction = "sync"
+	BulkResume    BulkAction = "resume"
+)
+
+// BulkOption describes a bulk action in the picker.
+type BulkOption struct {
+	Action      BulkAction
+	Name        string
+	Description string
+	Done        string // Result shown for tasks the action succeeded on
+}
+
+// BulkOptions lists the bulk actions in picker order.
+var BulkOptions = []BulkOption{
+	{BulkMergePush, "Merge & Push", "Merge each task to main in order, push and clean up", "merged and pushed"},
+	{BulkMerge, "Merge", "Merge each task to main in order (local only) and clean up", "merged"},
+	{BulkSync, "Sync with main", "Rebase each task onto the latest main", "synced"},
+	{BulkResume, "Resume", "Restart stopped agents with their previous session", "resumed"},
+	{BulkDrop, "Drop", "Discard the changes of each task and clean up", "dropped"},
+}
+
+// BulkOptionFor returns the option of a bulk action.
+func BulkOptionFor(action BulkAction) (BulkOption, bool) {
+	for _, opt := range BulkOptions {
+		if opt.Action == action {
+			return opt, true
+		}
+	}
+	return BulkOption{}, false
+}
+
+// BulkTarget is a task a bulk action runs on.
+type BulkTarget struct {
+	Session  string
+	WindowID string
+	Task     string
+}
+
+// BulkResult is the result of a bulk action on one task.
+type BulkResult struct {
+	OK      bool
+	Message string // Result detail, or what needs attention
+}
+
+// bulkRowState is the progress of one task.
+type bulkRowState int
+
+const (
+	bulkPending bulkRowState = iota
+	bulkRunning
+	bulkFinished
+	bulkSkipped
+)
+
+// bulkPhase is the step the bulk popup is in.
+type bulkPhase int
+
+const (
+	bulkPicking bulkPhase = iota
+	bulkConfirming
+	bulkRunningPhase
+	bulkDone
+)
+
+type bulkResultMsg struct {
+	index  int
+	result BulkResult
+}
+
+// BulkView picks a bulk action and runs it on the targets one at a time,
+// showing the progress and which tasks need attention.
+type BulkView struct {
+	targets []BulkTarget
+	run     func(action BulkAction, target BulkTarget) BulkResult
+
+	phase    bulkPhase
+	cursor   int
+	action   BulkOption
+	states   []bulkRowState
+	results  []BulkResult
+	stopping bool // Stop after the running task
+
+	isDark bool
+	colors ThemeColors
+	width  int
+	height int
+
+	// Style cache (reused across renders)
+	styleTitle    lipgloss.Style
+	styleSelected lipgloss.Style
+	styleDim      lipgloss.Style
+	styleOK       lipgloss.Style
+	styleFailed   lipgloss.Style
+	styleWarning  lipgloss.Style
+	stylesCached  bool
+}
+
+func newBulkView(targets []BulkTarget, run func(BulkAction, BulkTarget) BulkResult) *BulkView {
+	isDark := DetectDarkMode()
+	return &BulkView{
+		targets: targets,
+		run:     run,
+		states:  make([]bulkRowState, len(targets)),
+		results: make([]BulkResult, len(targets)),
+		isDark:  isDark,
+		colors:  NewThemeColors(isDark),
+		width:   80,
+		height:  24,
+	}
+}
+
+// Init requests the terminal background color.
+func (m *BulkView) Init() tea.Cmd {
+	if _, ok := cachedDarkModeValue(); ok {
+		return nil
+	}
+	return tea.RequestBackgroundColor
+}
+
+// Update handles messages.
+func (m *BulkView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+	case tea.BackgroundColorMsg:
+		m.isDark = themeIsDark(msg.IsDark())
+		m.colors = NewThemeColors(m.isDark)
+		m.stylesCached = false
+		setCachedDarkMode(m.isDark)
+		return m, nil
+	case bulkResultMsg:
+		m.states[msg.index] = bulkFinished
+		m.results[msg.index] = msg.result
+		return m, m.runNext()
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+	return m, nil
+}
+
+func (m *BulkView) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	switch m.phase {
+	case bulkPicking:
+		switch translateKey(msg, keymap.ScopeNav) {
+		case "up", "k":
+			m.cursor = (m.cursor - 1 + len(BulkOptions)) % len(BulkOptions)
+		case "down", "j":
+			m.cursor = (m.cursor + 1) % len(BulkOptions)
+		case "enter":
+			m.action = BulkOptions[m.cursor]
+			if m.action.Action == BulkDrop {
+				m.phase = bulkConfirming
+				return m, nil
+			}
+			return m, m.start()
+		}
+		if key == "q" || key == "esc" || key == "ctrl+c" {
+			return m, tea.Quit
+		}
+	case bulkConfirming:
+		if key == "y" {
+			return m, m.start()
+		}
+		m.phase = bulkPicking
+	case bulkRunningPhase:
+		// Tasks run in the background; stop after the running one
+		if key == "q" || key == "esc" || key == "ctrl+c" {
+			m.stopping = true
+		}
+	case bulkDone:
+		if key == "q" || key == "esc" || key == "enter" || key == "ctrl+c" {
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+// start runs the chosen action on the first target.
+func (m *BulkView) start() tea.Cmd {
+	m.phase = bulkRunningPhase
+	return m.runNext()
+}
+
+// runNext runs the action on the next pending target, one at a time so
+// merges take the merge lock in board order.
+func (m *BulkView) runNext() tea.Cmd {
+	for i, state := range m.states {
+		if state != bulkPending {
+			continue
+		}
+		if m.stopping {
+			m.states[i] = bulkSkipped
+			continue
+		}
+		m.states[i] = bulkRunning
+		run, action, target := m.run, m.action.Action, m.targets[i]
+		return func() tea.Msg {
+			return bulkResultMsg{index: i, result: run(action, target)}
+		}
+	}
+	m.phase = bulkDone
+	return nil
+}
+
+// bulkSummary counts the finished tasks, e.g. "3 merged · 2 need attention".
+func bulkSummary(done string, states []bulkRowState, results []BulkResult) string {
+	ok, failed, skipped := 0, 0, 0
+	for i, state := range states {
+		switch {
+		case state == bulkSkipped:
+			skipped++
+		case state == bulkFinished && results[i].OK:
+			ok++
+		case state == bulkFinished:
+			failed++
+		}
+	}
+	parts := []string{fmt.Sprintf("%d %s", ok, done)}
+	if failed > 0 {
+		parts = append(parts, fmt.Sprintf("%d need attention", failed))
+	}
+	if skipped > 0 {
+		parts = append(parts, fmt.Sprintf("%d skipped", skipped))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// View renders the action picker or the progress of the bulk action.
+func (m *BulkView) View() tea.View {
+	c := m.colors
+	if !m.stylesCached {
+		m.styleTitle = lipgloss.NewStyle().Bold(true).Foreground(c.Accent)
+		m.styleSelected = lipgloss.NewStyle().Bold(true).Foreground(c.Accent)
+		m.styleDim = lipgloss.NewStyle().Foreground(c.TextDim)
+		m.styleOK = lipgloss.NewStyle().Foreground(c.StatusDone)
+		m.styleFailed = lipgloss.NewStyle().Foreground(c.StatusWarning)
+		m.styleWarning = lipgloss.NewStyle().Bold(true).Foreground(c.StatusWarning)
+		m.stylesCached = true
+	}
+
+	var lines []string
+	title := fmt.Sprintf("%d tasks", len(m.targets))
+	if len(m.targets) == 1 {
+		title = "1 task"
+	}
+
+	switch m.phase {
+	case bulkPicking, bulkConfirming:
+		lines = append(lines, m.styleTitle.Render("Bulk action")+m.styleDim.Render(" · "+title), "")
+		for i, opt := range BulkOptions {
+			row := "  " + opt.Name
+			if i == m.cursor {
+				row = m.styleSelected.Render("▸ "+opt.Name) + m.styleDim.Render("  "+opt.Description)
+			}
+			lines = append(lines, row)
+		}
+		lines = append(lines, "")
+		for _, t := range m.targets {
+			lines = append(lines, m.styleDim.Render("  "+t.Session+"/"+t.Task))
+		}
+		lines = append(lines, "")
+		if m.phase == bulkConfirming {
+			lines = append(lines, m.styleWarning.Render(fmt.Sprintf("Drop %s and discard their changes? (y/n)", title)))
+		} else {
+			lines = append(lines, m.styleDim.Render("↑↓: Select  Enter: Run  q: Close"))
+		}
+
+	default:
+		header := m.styleTitle.Render(m.action.Name) + m.styleDim.Render(" · "+title)
+		if m.phase == bulkDone {
+			header += m.styleDim.Render(" · " + bulkSummary(m.action.Done, m.states, m.results))
+		}
+		lines = append(lines, header, "")
+		for i, t := range m.targets {
+			lines = append(lines, m.renderRow(t, m.states[i], m.results[i]))
+		}
+		lines = append(lines, "")
+		switch {
+		case m.phase == bulkDone:
+			lines = append(lines, m.styleDim.Render("q: Close"))
+		case m.stopping:
+			lines = append(lines, m.styleDim.Render("Stopping after the running task..."))
+		default:
+			lines = append(lines, m.styleDim.Render("q: Stop after the running task"))
+		}
+	}
+
+	for i, line := range lines {
+		lines[i] = ansi.Truncate(line, m.width, "…")
+	}
+	if len(lines) > m.height && m.height > 0 {
+		lines = lines[len(lines)-m.height:]
+	}
+
+	v := tea.NewView(strings.Join(lines, "\n"))
+	v.AltScreen = true
+	return v
+}
+
+// renderRow renders the progress row of a task.
+func (m *BulkView) renderRow(t BulkTarget, state bulkRowState, result BulkResult) string {
+	name := t.Session + "/" + t.Task
+	switch state {
+	case bulkRunning:
+		return m.styleSelected.Render("▸ "+name) + m.styleDim.Render("  running...")
+	case bulkSkipped:
+		return m.styleDim.Render("- " + name + "  skipped")
+	case bulkFinished:
+		if result.OK {
+			msg := m.action.Done
+			if result.Message != "" {
+				msg = result.Message
+			}
+			return m.styleOK.Render("✓ ") + name + m.styleDim.Render("  "+msg)
+		}
+		return m.styleFailed.Render("✗ "+name) + m.styleFailed.Render("  "+result.Message)
+	}
+	return m.styleDim.Render("· " + name)
+}
+
+// RunBulk runs the bulk action popup on the targets. run applies an action to
+// one target and is called for one target at a time, in order.
+func RunBulk(targets []BulkTarget, run func(action BulkAction, target BulkTarget) BulkResult) error {
+	_, err := tea.NewProgram(newBulkView(targets, run)).Run()
+	return err
+}
@@ -0,0 +1,83 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// runBulkCmds feeds the results of bulk commands back until none is left.
+func runBulkCmds(m *BulkView, cmd tea.Cmd) {
+	for cmd != nil {
+		_, cmd = m.Update(cmd())
+	}
+}
+
+func TestBulkViewRunsInOrder(t *testing.T) {
+	targets := []BulkTarget{
+		{Session: "api", WindowID: "@1", Task: "first"},
+		{Session: "api", WindowID: "@2", Task: "second"},
+		{Session: "web", WindowID: "@3", Task: "third"},
+	}
+	var ran []string
+	run := func(action BulkAction, target BulkTarget) BulkResult {
+		ran = append(ran, string(action)+":"+target.Task)
+		if target.Task == "second" {
+			return BulkResult{Message: "merge conflict"}
+		}
+		return BulkResult{OK: true}
+	}
+
+	m := newBulkView(targets, run)
+	m.Update(keyPress("down")) // Merge
+	_, cmd := m.Update(keyPress("enter"))
+	runBulkCmds(m, cmd)
+
+	want := []string{"merge:first", "merge:second", "merge:third"}
+	if fmt.Sprint(ran) != fmt.Sprint(want) {
+		t.Errorf("ran %v, want %v", ran, want)
+	}
+	view := ansi.Strip(fmt.Sprint(m.View().Layer))
+	for _, s := range []string{"2 merged · 1 need attention", "✓ api/first  merged", "✗ api/second  merge conflict"} {
+		if !strings.Contains(view, s) {
+			t.Errorf("view missing %q:\n%s", s, view)
+		}
+	}
+}
+
+func TestBulkViewDropConfirmAndStop(t *testing.T) {
+	targets := []BulkTarget{{Task: "a"}, {Task: "b"}}
+	runs := 0
+	run := func(BulkAction, BulkTarget) BulkResult {
+		runs++
+		return BulkResult{OK: true}
+	}
+
+	m := newBulkView(targets, run)
+	for m.cursor < len(BulkOptions)-1 {
+		m.Update(keyPress("down"))
+	}
+	if _, cmd := m.Update(keyPress("enter")); cmd != nil || m.phase != bulkConfirming {
+		t.Fatal("drop should ask for confirmation first")
+	}
+	m.Update(keyPress("n"))
+	if m.phase != bulkPicking {
+		t.Fatal("n should return to the picker")
+	}
+
+	m.Update(keyPress("enter"))
+	_, cmd := m.Update(keyPress("y"))
+	// q while the first task runs skips the rest
+	m.Update(keyPress("q"))
+	runBulkCmds(m, cmd)
+
+	if runs != 1 || m.phase != bulkDone {
+		t.Fatalf("runs = %d, phase = %v; want 1 run and done", runs, m.phase)
+	}
+	if got := bulkSummary("dropped", m.states, m.results); got != "1 dropped · 1 skipped" {
+		t.Errorf("summary = %q", got)
+	}
+}
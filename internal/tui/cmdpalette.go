@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/v2/textinput"
@@ -8,6 +9,7 @@ import (
 	"github.com/charmbracelet/lipgloss/v2"
 	"github.com/sahilm/fuzzy"
 
+	"github.com/dongho-jung/paw/internal/keymap"
 	"github.com/dongho-jung/paw/internal/logging"
 )
 
@@ -16,6 +18,8 @@ type Command struct {
 	Name        string
 	Description string
 	ID          string // Internal identifier for action handling
+	Shortcut    string // Key label shown next to the name (optional)
+	Recent      bool   // Recently used; listed first by the caller
 }
 
 // CommandPaletteAction represents the selected action.
@@ -35,6 +39,8 @@ type CommandPalette struct {
 	commands         []Command
 	filtered         []Command
 	cursor           int
+	offset           int // First visible command
+	height           int
 	action           CommandPaletteAction
 	selected         *Command
 	isDark           bool
@@ -73,6 +79,7 @@ func NewCommandPalette(commands []Command) *CommandPalette {
 		commands: commands,
 		filtered: commands,
 		cursor:   0,
+		height:   24,
 		isDark:   isDark,
 		colors:   NewThemeColors(isDark),
 	}
@@ -93,15 +100,19 @@ func (m *CommandPalette) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
 	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+		m.scrollToCursor()
+		return m, nil
 	case tea.BackgroundColorMsg:
-		m.isDark = msg.IsDark()
+		m.isDark = themeIsDark(msg.IsDark())
 		m.colors = NewThemeColors(m.isDark)
 		m.stylesCached = false // Invalidate style cache on theme change
 		setCachedDarkMode(m.isDark)
 		return m, nil
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c", "esc", "ctrl+p":
+		switch toggleKey(msg, keymap.ActionCommandPalette) {
+		case "ctrl+c", "esc":
 			m.action = CommandPaletteCancel
 			return m, tea.Quit
 
@@ -117,18 +128,14 @@ func (m *CommandPalette) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "up", "ctrl+k":
 			if m.cursor > 0 {
 				m.cursor--
+				m.scrollToCursor()
 			}
 			return m, nil
 
-		case "down", "ctrl+j":
-			if m.cursor < len(m.filtered)-1 {
-				m.cursor++
-			}
-			return m, nil
-
-		case "ctrl+n":
+		case "down", "ctrl+j", "ctrl+n":
 			if m.cursor < len(m.filtered)-1 {
 				m.cursor++
+				m.scrollToCursor()
 			}
 			return m, nil
 		}
@@ -154,6 +161,7 @@ func (m *CommandPalette) updateFiltered() {
 	if query == "" {
 		m.filtered = m.commands
 		m.cursor = 0
+		m.offset = 0
 		return
 	}
 
@@ -176,6 +184,26 @@ func (m *CommandPalette) updateFiltered() {
 	if m.cursor >= len(m.filtered) {
 		m.cursor = 0
 	}
+	m.scrollToCursor()
+}
+
+// visibleItems returns how many commands fit in the pane (two lines each).
+func (m *CommandPalette) visibleItems() int {
+	// title(2) + input(3) + blank(1) + more(1) + help(2)
+	items := (m.height - 9) / 2
+	return max(1, min(items, 10))
+}
+
+// scrollToCursor keeps the cursor within the visible commands.
+func (m *CommandPalette) scrollToCursor() {
+	visible := m.visibleItems()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+visible {
+		m.offset = m.cursor - visible + 1
+	}
+	m.offset = max(0, min(m.offset, len(m.filtered)-visible))
 }
 
 // renderInput prepares the text input line and cursor position.
@@ -238,34 +266,37 @@ func (m *CommandPalette) View() tea.View {
 		sb.WriteString(m.styleDim.Render("  No matching commands"))
 		sb.WriteString("\n")
 	} else {
-		// Show up to 10 commands
-		maxItems := 10
-		if len(m.filtered) < maxItems {
-			maxItems = len(m.filtered)
-		}
-
-		for i := 0; i < maxItems; i++ {
+		end := min(m.offset+m.visibleItems(), len(m.filtered))
+		for i := m.offset; i < end; i++ {
 			cmd := m.filtered[i]
+			var suffix string
+			if cmd.Shortcut != "" {
+				suffix += "  " + cmd.Shortcut
+			}
+			if cmd.Recent {
+				suffix += "  recent"
+			}
+			suffix = m.styleDim.Render(suffix)
 			if i == m.cursor {
-				sb.WriteString(m.styleSelected.Render("> " + cmd.Name))
+				sb.WriteString(m.styleSelected.Render("> "+cmd.Name) + suffix)
 				sb.WriteString("\n")
 				sb.WriteString(m.styleSelectedDesc.Render(cmd.Description))
 			} else {
-				sb.WriteString(m.styleItem.Render(cmd.Name))
+				sb.WriteString(m.styleItem.Render(cmd.Name) + suffix)
 				sb.WriteString("\n")
 				sb.WriteString(m.styleDesc.Render(cmd.Description))
 			}
 			sb.WriteString("\n")
 		}
 
-		if len(m.filtered) > maxItems {
-			sb.WriteString(m.styleDim.Render("  ... and more"))
+		if hidden := len(m.filtered) - (end - m.offset); hidden > 0 {
+			sb.WriteString(m.styleDim.Render(fmt.Sprintf("  %d/%d", m.cursor+1, len(m.filtered))))
 			sb.WriteString("\n")
 		}
 	}
 
 	// Help
-	sb.WriteString(m.styleHelp.Render("↑/↓: Navigate  Enter: Execute  Esc/⌃P: Close"))
+	sb.WriteString(m.styleHelp.Render("↑/↓: Navigate  Enter: Execute  " + escLabel(keymap.ActionCommandPalette) + ": Close"))
 
 	v := tea.NewView(sb.String())
 	v.AltScreen = true
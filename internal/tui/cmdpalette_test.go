@@ -0,0 +1,43 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestCommandPaletteScrollsToCursor(t *testing.T) {
+	var commands []Command
+	for i := 0; i < 12; i++ {
+		commands = append(commands, Command{Name: fmt.Sprintf("Command %02d", i), ID: fmt.Sprintf("cmd-%d", i)})
+	}
+	commands[0].Shortcut = "⌃O"
+	commands[0].Recent = true
+
+	m := NewCommandPalette(commands)
+	m.Update(tea.WindowSizeMsg{Width: 80, Height: 15}) // Room for 3 commands
+
+	if got := m.visibleItems(); got != 3 {
+		t.Fatalf("visibleItems() = %d, want 3", got)
+	}
+	view := ansi.Strip(fmt.Sprint(m.View().Layer))
+	if !strings.Contains(view, "Command 00  ⌃O  recent") || strings.Contains(view, "Command 03") {
+		t.Errorf("initial view:\n%s", view)
+	}
+
+	for i := 0; i < 5; i++ {
+		m.Update(keyPress("down"))
+	}
+	view = ansi.Strip(fmt.Sprint(m.View().Layer))
+	if !strings.Contains(view, "> Command 05") || strings.Contains(view, "Command 02") || !strings.Contains(view, "6/12") {
+		t.Errorf("scrolled view:\n%s", view)
+	}
+
+	m.Update(keyPress("enter"))
+	if action, selected := m.Result(); action != CommandPaletteExecute || selected == nil || selected.ID != "cmd-5" {
+		t.Errorf("Result() = %v, %+v", action, selected)
+	}
+}
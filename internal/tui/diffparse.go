@@ -0,0 +1,323 @@
+package tui
+
+import (
+	"strconv"
+	"strings"
+)
+
+// diffTabWidth is the number of spaces a tab expands to in diff lines.
+const diffTabWidth = 4
+
+// diffLineKind is the kind of a line inside a diff hunk.
+type diffLineKind int
+
+const (
+	diffLineContext diffLineKind = iota
+	diffLineAdd
+	diffLineDelete
+)
+
+// diffLine is a single line of a diff hunk.
+type diffLine struct {
+	kind  diffLineKind
+	text  string // Line text without the +/-/space prefix (tabs expanded)
+	oldNo int    // Line number in the old file, 0 for added lines
+	newNo int    // Line number in the new file, 0 for deleted lines
+
+	// Rune range of the intra-line change; empty when the whole line changed
+	changeStart int
+	changeEnd   int
+}
+
+// hasChange reports whether the line has an intra-line change range.
+func (l *diffLine) hasChange() bool {
+	return l.changeStart < l.changeEnd
+}
+
+// diffHunk is a hunk of a file diff.
+type diffHunk struct {
+	header   string // Text after the closing "@@" (usually the enclosing function)
+	oldStart int
+	oldCount int
+	newStart int
+	newCount int
+	lines    []diffLine
+}
+
+// newFirst returns the first new-file line number covered by the hunk.
+// Empty ranges ("+4,0") name the line before the change.
+func (h *diffHunk) newFirst() int {
+	if h.newCount == 0 {
+		return h.newStart + 1
+	}
+	return h.newStart
+}
+
+// newEnd returns the last new-file line number covered by the hunk.
+func (h *diffHunk) newEnd() int {
+	return h.newFirst() + h.newCount - 1
+}
+
+// oldEnd returns the last old-file line number covered by the hunk.
+func (h *diffHunk) oldEnd() int {
+	if h.oldCount == 0 {
+		return h.oldStart
+	}
+	return h.oldStart + h.oldCount - 1
+}
+
+// diffFileStatus is the change type of a file.
+type diffFileStatus string
+
+const (
+	diffFileModified diffFileStatus = "modified"
+	diffFileAdded    diffFileStatus = "added"
+	diffFileDeleted  diffFileStatus = "deleted"
+	diffFileRenamed  diffFileStatus = "renamed"
+)
+
+// diffFile is the diff of a single file.
+type diffFile struct {
+	oldPath   string
+	newPath   string
+	status    diffFileStatus
+	binary    bool
+	additions int
+	deletions int
+	hunks     []diffHunk
+}
+
+// path returns the path shown for the file (the old path for deleted files).
+func (f *diffFile) path() string {
+	if f.status == diffFileDeleted || f.newPath == "" {
+		return f.oldPath
+	}
+	return f.newPath
+}
+
+// parseUnifiedDiff parses "git diff" output (without color) into files.
+func parseUnifiedDiff(text string) []*diffFile {
+	var files []*diffFile
+	var file *diffFile
+	var hunk *diffHunk
+	oldLeft, newLeft := 0, 0 // Lines remaining in the current hunk
+	oldNo, newNo := 0, 0
+
+	finishHunk := func() {
+		if file != nil && hunk != nil {
+			markIntraLineChanges(hunk.lines)
+			file.hunks = append(file.hunks, *hunk)
+		}
+		hunk = nil
+	}
+
+	for _, raw := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		// Hunk body: lines are consumed until both sides are complete
+		if hunk != nil && (oldLeft > 0 || newLeft > 0) {
+			line := diffLine{}
+			prefix := byte(' ')
+			if raw != "" {
+				prefix = raw[0]
+			}
+			switch prefix {
+			case '+':
+				line.kind, line.newNo = diffLineAdd, newNo
+				newNo++
+				newLeft--
+				file.additions++
+			case '-':
+				line.kind, line.oldNo = diffLineDelete, oldNo
+				oldNo++
+				oldLeft--
+				file.deletions++
+			case '\\':
+				continue // "\ No newline at end of file"
+			default:
+				line.oldNo, line.newNo = oldNo, newNo
+				oldNo++
+				newNo++
+				oldLeft--
+				newLeft--
+			}
+			if raw != "" {
+				line.text = expandTabs(raw[1:])
+			}
+			hunk.lines = append(hunk.lines, line)
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(raw, "diff --git "):
+			finishHunk()
+			file = &diffFile{status: diffFileModified}
+			files = append(files, file)
+			rest := strings.TrimPrefix(raw, "diff --git ")
+			if a, b, ok := strings.Cut(rest, " b/"); ok {
+				file.oldPath = strings.TrimPrefix(a, "a/")
+				file.newPath = b
+			}
+		case file == nil:
+			continue
+		case strings.HasPrefix(raw, "@@ "):
+			finishHunk()
+			h, ok := parseHunkHeader(raw)
+			if !ok {
+				continue
+			}
+			hunk = &h
+			oldLeft, newLeft = h.oldCount, h.newCount
+			oldNo, newNo = h.oldStart, h.newStart
+		case strings.HasPrefix(raw, "\\"):
+			continue // "\ No newline at end of file" after the last hunk line
+		case strings.HasPrefix(raw, "new file mode"):
+			file.status = diffFileAdded
+		case strings.HasPrefix(raw, "deleted file mode"):
+			file.status = diffFileDeleted
+		case strings.HasPrefix(raw, "rename from "):
+			file.status = diffFileRenamed
+			file.oldPath = strings.TrimPrefix(raw, "rename from ")
+		case strings.HasPrefix(raw, "rename to "):
+			file.status = diffFileRenamed
+			file.newPath = strings.TrimPrefix(raw, "rename to ")
+		case strings.HasPrefix(raw, "Binary files ") || strings.HasPrefix(raw, "GIT binary patch"):
+			file.binary = true
+		case strings.HasPrefix(raw, "--- "):
+			if p := strings.TrimPrefix(raw, "--- "); p != "/dev/null" {
+				file.oldPath = strings.TrimPrefix(strings.TrimSuffix(p, "\t"), "a/")
+			}
+		case strings.HasPrefix(raw, "+++ "):
+			if p := strings.TrimPrefix(raw, "+++ "); p != "/dev/null" {
+				file.newPath = strings.TrimPrefix(strings.TrimSuffix(p, "\t"), "b/")
+			}
+		}
+	}
+	finishHunk()
+	return files
+}
+
+// parseHunkHeader parses "@@ -oldStart,oldCount +newStart,newCount @@ header".
+func parseHunkHeader(line string) (diffHunk, bool) {
+	rest := strings.TrimPrefix(line, "@@ ")
+	ranges, header, ok := strings.Cut(rest, " @@")
+	if !ok {
+		return diffHunk{}, false
+	}
+	oldRange, newRange, ok := strings.Cut(ranges, " ")
+	if !ok || !strings.HasPrefix(oldRange, "-") || !strings.HasPrefix(newRange, "+") {
+		return diffHunk{}, false
+	}
+	h := diffHunk{header: strings.TrimSpace(header)}
+	var okOld, okNew bool
+	h.oldStart, h.oldCount, okOld = parseHunkRange(oldRange[1:])
+	h.newStart, h.newCount, okNew = parseHunkRange(newRange[1:])
+	return h, okOld && okNew
+}
+
+// parseHunkRange parses "start,count" or "start" (count 1).
+func parseHunkRange(s string) (start, count int, ok bool) {
+	startStr, countStr, hasCount := strings.Cut(s, ",")
+	start, err := strconv.Atoi(startStr)
+	if err != nil {
+		return 0, 0, false
+	}
+	count = 1
+	if hasCount {
+		if count, err = strconv.Atoi(countStr); err != nil {
+			return 0, 0, false
+		}
+	}
+	return start, count, true
+}
+
+// expandTabs replaces tabs with spaces so rune offsets match display columns.
+func expandTabs(s string) string {
+	if !strings.Contains(s, "\t") {
+		return s
+	}
+	var sb strings.Builder
+	col := 0
+	for _, r := range s {
+		if r == '\t' {
+			n := diffTabWidth - col%diffTabWidth
+			sb.WriteString(strings.Repeat(" ", n))
+			col += n
+			continue
+		}
+		sb.WriteRune(r)
+		col++
+	}
+	return sb.String()
+}
+
+// markIntraLineChanges pairs runs of deleted and added lines and marks the
+// changed part of each pair, so small edits stand out within long lines.
+func markIntraLineChanges(lines []diffLine) {
+	for i := 0; i < len(lines); {
+		if lines[i].kind != diffLineDelete {
+			i++
+			continue
+		}
+		delStart := i
+		for i < len(lines) && lines[i].kind == diffLineDelete {
+			i++
+		}
+		addStart := i
+		for i < len(lines) && lines[i].kind == diffLineAdd {
+			i++
+		}
+		dels, adds := addStart-delStart, i-addStart
+		for j := 0; j < dels && j < adds; j++ {
+			markChangedRange(&lines[delStart+j], &lines[addStart+j])
+		}
+	}
+}
+
+// markChangedRange marks the differing middle of two lines (common prefix and
+// suffix excluded). Lines with little in common are left unmarked.
+func markChangedRange(del, add *diffLine) {
+	a, b := []rune(del.text), []rune(add.text)
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	shorter := min(len(a), len(b))
+	if shorter == 0 || (prefix+suffix)*3 < shorter {
+		return
+	}
+	del.changeStart, del.changeEnd = prefix, len(a)-suffix
+	add.changeStart, add.changeEnd = prefix, len(b)-suffix
+}
+
+// diffTreeEntry is a row of the file tree sidebar.
+type diffTreeEntry struct {
+	label string // Directory name (with trailing "/") or file base name
+	depth int
+	file  int // File index, -1 for directories
+}
+
+// buildDiffTree builds a directory tree of the changed files in diff order.
+// Directory rows are emitted whenever a file's directory differs from the
+// previous file's, so files of the same directory are grouped under one row.
+func buildDiffTree(files []*diffFile) []diffTreeEntry {
+	var entries []diffTreeEntry
+	var prevDirs []string
+	for i, f := range files {
+		parts := strings.Split(f.path(), "/")
+		dirs, name := parts[:len(parts)-1], parts[len(parts)-1]
+
+		common := 0
+		for common < len(dirs) && common < len(prevDirs) && dirs[common] == prevDirs[common] {
+			common++
+		}
+		for d := common; d < len(dirs); d++ {
+			entries = append(entries, diffTreeEntry{label: dirs[d] + "/", depth: d, file: -1})
+		}
+		entries = append(entries, diffTreeEntry{label: name, depth: len(dirs), file: i})
+		prevDirs = dirs
+	}
+	return entries
+}
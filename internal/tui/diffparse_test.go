@@ -0,0 +1,220 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+const testDiff = `diff --git a/internal/app/app.go b/internal/app/app.go
+index 1111111..2222222 100644
+--- a/internal/app/app.go
++++ b/internal/app/app.go
+@@ -10,4 +10,4 @@ func New() *App {
+ 	a := &App{}
+-	a.name = "old"
++	a.name = "new"
+ 	return a
+ }
+@@ -40,2 +40,3 @@ func (a *App) Run() {
+ 	a.start()
++	a.wait()
+ }
+diff --git a/internal/app/new.go b/internal/app/new.go
+new file mode 100644
+index 0000000..3333333
+--- /dev/null
++++ b/internal/app/new.go
+@@ -0,0 +1,2 @@
++package app
++// new file
+diff --git a/README.md b/docs/README.md
+similarity index 90%
+rename from README.md
+rename to docs/README.md
+--- a/README.md
++++ b/docs/README.md
+@@ -1 +1 @@
+-# Old title
++# New title
+\ No newline at end of file
+diff --git a/logo.png b/logo.png
+index 4444444..5555555 100644
+Binary files a/logo.png and b/logo.png differ
+`
+
+func TestParseUnifiedDiff(t *testing.T) {
+	files := parseUnifiedDiff(testDiff)
+	if len(files) != 4 {
+		t.Fatalf("parsed %d files, want 4", len(files))
+	}
+
+	app := files[0]
+	if app.path() != "internal/app/app.go" || app.status != diffFileModified {
+		t.Errorf("file 0 = %s (%s)", app.path(), app.status)
+	}
+	if app.additions != 2 || app.deletions != 1 || len(app.hunks) != 2 {
+		t.Errorf("app.go stats = +%d -%d, %d hunks", app.additions, app.deletions, len(app.hunks))
+	}
+	h := app.hunks[0]
+	if h.header != "func New() *App {" || h.oldStart != 10 || h.newCount != 4 {
+		t.Errorf("hunk header = %+v", h)
+	}
+	add := h.lines[2]
+	if add.kind != diffLineAdd || add.newNo != 11 || add.oldNo != 0 || add.text != `    a.name = "new"` {
+		t.Errorf("added line = %+v (tabs should be expanded)", add)
+	}
+	if last := app.hunks[1].lines[2]; last.oldNo != 41 || last.newNo != 42 {
+		t.Errorf("context line numbers = %d/%d, want 41/42", last.oldNo, last.newNo)
+	}
+
+	if files[1].status != diffFileAdded || files[1].additions != 2 {
+		t.Errorf("new file = %+v", files[1])
+	}
+	renamed := files[2]
+	if renamed.status != diffFileRenamed || renamed.oldPath != "README.md" || renamed.path() != "docs/README.md" {
+		t.Errorf("renamed file = %+v", renamed)
+	}
+	if len(renamed.hunks) != 1 || len(renamed.hunks[0].lines) != 2 {
+		t.Errorf("renamed hunk lines = %+v", renamed.hunks)
+	}
+	if !files[3].binary || len(files[3].hunks) != 0 {
+		t.Errorf("binary file = %+v", files[3])
+	}
+}
+
+func TestMarkIntraLineChanges(t *testing.T) {
+	lines := []diffLine{
+		{kind: diffLineDelete, text: `a.name = "old"`},
+		{kind: diffLineDelete, text: "removed entirely"},
+		{kind: diffLineAdd, text: `a.name = "new"`},
+		{kind: diffLineAdd, text: "something else"},
+	}
+	markIntraLineChanges(lines)
+
+	if got := lines[0].text[lines[0].changeStart:lines[0].changeEnd]; got != "old" {
+		t.Errorf("deleted change = %q, want %q", got, "old")
+	}
+	if got := lines[2].text[lines[2].changeStart:lines[2].changeEnd]; got != "new" {
+		t.Errorf("added change = %q, want %q", got, "new")
+	}
+	// Lines with little in common are highlighted as a whole
+	if lines[1].hasChange() || lines[3].hasChange() {
+		t.Errorf("unrelated lines should not get a change range: %+v %+v", lines[1], lines[3])
+	}
+}
+
+func TestBuildDiffTree(t *testing.T) {
+	files := parseUnifiedDiff(testDiff)
+	var got []string
+	for _, e := range buildDiffTree(files) {
+		got = append(got, strings.Repeat(" ", e.depth)+e.label)
+	}
+	want := []string{"internal/", " app/", "  app.go", "  new.go", "docs/", " README.md", "logo.png"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("tree = %q, want %q", got, want)
+	}
+}
+
+func TestFileGaps(t *testing.T) {
+	app := parseUnifiedDiff(testDiff)[0]
+
+	gaps := fileGaps(app, -1)
+	if len(gaps) != 3 {
+		t.Fatalf("gaps = %d, want 3", len(gaps))
+	}
+	if gaps[0] != (diffGap{start: 1, end: 9}) {
+		t.Errorf("leading gap = %+v", gaps[0])
+	}
+	if gaps[1] != (diffGap{start: 14, end: 39}) {
+		t.Errorf("middle gap = %+v", gaps[1])
+	}
+	// The trailing gap is unknown until the file is loaded; hunk 2 added a line
+	if gaps[2] != (diffGap{start: 43, end: -1, delta: 1}) {
+		t.Errorf("trailing gap = %+v", gaps[2])
+	}
+	if gaps := fileGaps(app, 50); gaps[2].end != 50 {
+		t.Errorf("trailing gap end = %d, want 50", gaps[2].end)
+	}
+}
+
+func TestBuildDiffRows(t *testing.T) {
+	files := parseUnifiedDiff(testDiff)
+	none := map[int]bool{}
+	noExpand := map[diffGapKey]int{}
+	noContent := map[int][]string{}
+
+	count := func(rows []diffRow, kind diffRowKind) int {
+		n := 0
+		for _, r := range rows {
+			if r.kind == kind {
+				n++
+			}
+		}
+		return n
+	}
+
+	unified := buildDiffRows(files, false, none, noExpand, noContent)
+	split := buildDiffRows(files, true, none, noExpand, noContent)
+	// Side-by-side pairs each deleted line with an added line
+	if count(unified, diffRowLine)-count(split, diffRowLine) != 2 {
+		t.Errorf("line rows unified=%d split=%d", count(unified, diffRowLine), count(split, diffRowLine))
+	}
+	if count(unified, diffRowFile) != 4 || count(unified, diffRowHunk) != 4 || count(unified, diffRowBinary) != 1 {
+		t.Errorf("unexpected rows: %+v", unified)
+	}
+	// app.go has a leading, a middle and an unknown trailing gap; README.md a trailing one
+	if count(unified, diffRowGap) != 4 {
+		t.Errorf("gap rows = %d, want 4", count(unified, diffRowGap))
+	}
+
+	collapsed := buildDiffRows(files, false, map[int]bool{0: true, 1: true, 2: true, 3: true}, noExpand, noContent)
+	if len(collapsed) != 4 {
+		t.Errorf("collapsed rows = %d, want only the 4 file headers", len(collapsed))
+	}
+
+	// Expanding the middle gap reveals lines 14.. and keeps the rest hidden
+	content := make([]string, 45)
+	for i := range content {
+		content[i] = "line"
+	}
+	rows := buildDiffRows(files[:1], false, none, map[diffGapKey]int{{0, 1}: 20}, map[int][]string{0: content})
+	var revealed []*diffLine
+	var hidden []int
+	for _, r := range rows {
+		if r.hunk != 1 {
+			continue
+		}
+		if r.kind == diffRowLine && r.left.kind == diffLineContext && r.left.newNo >= 14 && r.left.newNo < 40 {
+			revealed = append(revealed, r.left)
+		}
+		if r.kind == diffRowGap {
+			hidden = append(hidden, r.hidden)
+		}
+	}
+	if len(revealed) != 20 || revealed[0].newNo != 14 || revealed[0].oldNo != 14 {
+		t.Errorf("revealed %d lines, first %+v", len(revealed), revealed)
+	}
+	if len(hidden) != 1 || hidden[0] != 6 {
+		t.Errorf("remaining hidden = %v, want [6]", hidden)
+	}
+}
+
+func TestDiffHighlighterRender(t *testing.T) {
+	h := newDiffHighlighter(true)
+	line := &diffLine{kind: diffLineAdd, text: `x := "new" // comment`, changeStart: 6, changeEnd: 9}
+	got := h.render("main.go", line)
+	if ansi.Strip(got) != line.text {
+		t.Errorf("render changed the text: %q", ansi.Strip(got))
+	}
+	if got == line.text {
+		t.Error("render should add syntax and diff colors")
+	}
+
+	// Unknown file types are rendered without syntax colors
+	plain := &diffLine{kind: diffLineContext, text: "just text"}
+	if got := h.render("notes.unknownext", plain); ansi.Strip(got) != "just text" {
+		t.Errorf("plain render = %q", got)
+	}
+}
@@ -0,0 +1,501 @@
+package tui
+
+import (
+	"image/color"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// diffContextExpandStep is how many hidden context lines one expansion reveals.
+const diffContextExpandStep = 20
+
+// diffRowKind is the kind of a rendered diff viewer row.
+type diffRowKind int
+
+const (
+	diffRowFile    diffRowKind = iota // File header (click or c to collapse)
+	diffRowBinary                     // Binary file notice
+	diffRowHunk                       // Hunk header
+	diffRowLine                       // Diff line (unified) or line pair (side-by-side)
+	diffRowGap                        // Hidden unchanged context (click or e to expand)
+	diffRowComment                    // Review comment below the line or hunk it is on
+)
+
+// diffRow is a row of the diff viewer. Rows are rendered to one line each.
+type diffRow struct {
+	kind    diffRowKind
+	file    int
+	hunk    int       // Hunk index; gap index for gap rows and expanded context
+	left    *diffLine // Unified: the line; side-by-side: old side (nil if none)
+	right   *diffLine // Side-by-side: new side (nil if none)
+	hidden  int       // Gap rows: hidden line count, -1 if unknown
+	comment int       // Comment rows: index in the review's comments
+}
+
+// diffGapKey identifies a gap of unchanged lines before hunk (or after the last hunk).
+type diffGapKey struct {
+	file int
+	gap  int
+}
+
+// diffGap is a range of unchanged new-file lines that the diff does not show.
+type diffGap struct {
+	start int // First new-file line number
+	end   int // Last new-file line number, -1 if unknown (file not loaded)
+	delta int // New minus old line number in this gap
+}
+
+// fileGaps returns the gaps before each hunk and after the last hunk of a file.
+// contentLen is the new file's line count, or -1 if it has not been loaded.
+func fileGaps(f *diffFile, contentLen int) []diffGap {
+	if f.binary || len(f.hunks) == 0 || f.status == diffFileAdded || f.status == diffFileDeleted {
+		return nil
+	}
+	gaps := make([]diffGap, len(f.hunks)+1)
+	for i := range gaps {
+		g := diffGap{start: 1, end: contentLen}
+		if i > 0 {
+			prev := &f.hunks[i-1]
+			g.start = prev.newEnd() + 1
+			g.delta = prev.newEnd() - prev.oldEnd()
+		}
+		if i < len(f.hunks) {
+			g.end = f.hunks[i].newFirst() - 1
+		}
+		gaps[i] = g
+	}
+	return gaps
+}
+
+// buildDiffRows lays out the files as rows. Collapsed files show only their
+// header; expanded gaps show the first revealed lines of the new file.
+func buildDiffRows(files []*diffFile, split bool, collapsed map[int]bool, expanded map[diffGapKey]int, contents map[int][]string) []diffRow {
+	var rows []diffRow
+	for fi, f := range files {
+		rows = append(rows, diffRow{kind: diffRowFile, file: fi})
+		if collapsed[fi] {
+			continue
+		}
+		if f.binary {
+			rows = append(rows, diffRow{kind: diffRowBinary, file: fi})
+			continue
+		}
+
+		content, loaded := contents[fi]
+		contentLen := -1
+		if loaded {
+			contentLen = len(content)
+		}
+		gaps := fileGaps(f, contentLen)
+		gapRows := func(gi int) {
+			if gi >= len(gaps) {
+				return
+			}
+			g := gaps[gi]
+			shown := expanded[diffGapKey{fi, gi}]
+			for n := g.start; n < g.start+shown && (g.end < 0 || n <= g.end) && n <= len(content); n++ {
+				line := &diffLine{kind: diffLineContext, text: expandTabs(content[n-1]), oldNo: n - g.delta, newNo: n}
+				rows = append(rows, diffRow{kind: diffRowLine, file: fi, hunk: gi, left: line, right: line})
+			}
+			hidden := -1
+			if g.end >= 0 {
+				hidden = g.end - g.start + 1 - shown
+				if hidden <= 0 {
+					return
+				}
+			}
+			rows = append(rows, diffRow{kind: diffRowGap, file: fi, hunk: gi, hidden: hidden})
+		}
+
+		for hi := range f.hunks {
+			h := &f.hunks[hi]
+			gapRows(hi)
+			rows = append(rows, diffRow{kind: diffRowHunk, file: fi, hunk: hi})
+			if split {
+				rows = appendSplitRows(rows, fi, hi, h.lines)
+				continue
+			}
+			for li := range h.lines {
+				rows = append(rows, diffRow{kind: diffRowLine, file: fi, hunk: hi, left: &h.lines[li]})
+			}
+		}
+		gapRows(len(f.hunks))
+	}
+	return rows
+}
+
+// appendSplitRows pairs deleted and added lines side by side; context lines
+// appear on both sides.
+func appendSplitRows(rows []diffRow, fi, hi int, lines []diffLine) []diffRow {
+	for i := 0; i < len(lines); {
+		if lines[i].kind == diffLineContext {
+			rows = append(rows, diffRow{kind: diffRowLine, file: fi, hunk: hi, left: &lines[i], right: &lines[i]})
+			i++
+			continue
+		}
+		var dels, adds []*diffLine
+		for i < len(lines) && lines[i].kind == diffLineDelete {
+			dels = append(dels, &lines[i])
+			i++
+		}
+		for i < len(lines) && lines[i].kind == diffLineAdd {
+			adds = append(adds, &lines[i])
+			i++
+		}
+		for j := 0; j < len(dels) || j < len(adds); j++ {
+			row := diffRow{kind: diffRowLine, file: fi, hunk: hi}
+			if j < len(dels) {
+				row.left = dels[j]
+			}
+			if j < len(adds) {
+				row.right = adds[j]
+			}
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// diffHighlighter renders diff lines with syntax highlighting by file type.
+// Tokens are cached per line text, so rebuilding rows after a layout change is cheap.
+type diffHighlighter struct {
+	style  *chroma.Style
+	lexers map[string]chroma.Lexer // File extension → lexer (nil: no highlighting)
+	tokens map[string][]chroma.Token
+	styles map[chroma.TokenType]lipgloss.Style
+
+	addBg, addEmphasis color.Color
+	delBg, delEmphasis color.Color
+	addSign, delSign   lipgloss.Style
+}
+
+// newDiffHighlighter creates a highlighter for the terminal background.
+func newDiffHighlighter(isDark bool) *diffHighlighter {
+	h := &diffHighlighter{
+		lexers: make(map[string]chroma.Lexer),
+		tokens: make(map[string][]chroma.Token),
+		styles: make(map[chroma.TokenType]lipgloss.Style),
+	}
+	colors := NewThemeColors(isDark)
+	if colors.IsDark() {
+		h.style = styles.Get("github-dark")
+	} else {
+		h.style = styles.Get("github")
+	}
+	h.addBg, h.addEmphasis = colors.DiffAddBg, colors.DiffAddEmphasis
+	h.delBg, h.delEmphasis = colors.DiffDeleteBg, colors.DiffDeleteEmphasis
+	h.addSign = lipgloss.NewStyle().Foreground(colors.DiffAdd)
+	h.delSign = lipgloss.NewStyle().Foreground(colors.DiffDelete)
+	return h
+}
+
+// lexer returns the lexer for a file path, or nil if the file type is unknown.
+func (h *diffHighlighter) lexer(path string) chroma.Lexer {
+	key := filepath.Ext(path)
+	if key == "" {
+		key = filepath.Base(path) // Makefile, Dockerfile, ...
+	}
+	if lexer, ok := h.lexers[key]; ok {
+		return lexer
+	}
+	lexer := lexers.Match(filepath.Base(path))
+	if lexer != nil {
+		lexer = chroma.Coalesce(lexer)
+	}
+	h.lexers[key] = lexer
+	return lexer
+}
+
+// tokenize splits a line into syntax tokens. Lines are tokenized on their own,
+// so constructs spanning lines (block comments, raw strings) may be approximate.
+func (h *diffHighlighter) tokenize(path, text string) []chroma.Token {
+	lexer := h.lexer(path)
+	if lexer == nil || text == "" {
+		return []chroma.Token{{Type: chroma.Text, Value: text}}
+	}
+	key := lexer.Config().Name + "\x00" + text
+	if tokens, ok := h.tokens[key]; ok {
+		return tokens
+	}
+	tokens := []chroma.Token{{Type: chroma.Text, Value: text}}
+	if it, err := lexer.Tokenise(nil, text); err == nil {
+		tokens = tokens[:0]
+		for _, tok := range it.Tokens() {
+			tok.Value = strings.TrimSuffix(tok.Value, "\n")
+			if tok.Value != "" {
+				tokens = append(tokens, tok)
+			}
+		}
+	}
+	h.tokens[key] = tokens
+	return tokens
+}
+
+// tokenStyle returns the foreground style of a token type.
+func (h *diffHighlighter) tokenStyle(t chroma.TokenType) lipgloss.Style {
+	if st, ok := h.styles[t]; ok {
+		return st
+	}
+	st := lipgloss.NewStyle()
+	if h.style != nil {
+		entry := h.style.Get(t)
+		if entry.Colour.IsSet() {
+			st = st.Foreground(lipgloss.Color(entry.Colour.String()))
+		}
+		if entry.Bold == chroma.Yes {
+			st = st.Bold(true)
+		}
+		if entry.Italic == chroma.Yes {
+			st = st.Italic(true)
+		}
+	}
+	h.styles[t] = st
+	return st
+}
+
+// backgrounds returns the line and change backgrounds of a diff line (nil for context).
+func (h *diffHighlighter) backgrounds(kind diffLineKind) (color.Color, color.Color) {
+	switch kind {
+	case diffLineAdd:
+		return h.addBg, h.addEmphasis
+	case diffLineDelete:
+		return h.delBg, h.delEmphasis
+	case diffLineContext:
+	}
+	return nil, nil
+}
+
+// sign returns the rendered +/- marker of a diff line.
+func (h *diffHighlighter) sign(line *diffLine) string {
+	bg, _ := h.backgrounds(line.kind)
+	switch line.kind {
+	case diffLineAdd:
+		return h.addSign.Background(bg).Render("+")
+	case diffLineDelete:
+		return h.delSign.Background(bg).Render("-")
+	case diffLineContext:
+	}
+	return " "
+}
+
+// render returns a line's text with syntax colors, its add/delete background
+// and a stronger background on the intra-line change.
+func (h *diffHighlighter) render(path string, line *diffLine) string {
+	bg, emphasis := h.backgrounds(line.kind)
+	var sb strings.Builder
+	pos := 0 // Rune offset in line.text
+	for _, tok := range h.tokenize(path, line.text) {
+		runes := []rune(tok.Value)
+		for len(runes) > 0 {
+			// Split tokens at the change boundaries
+			n := len(runes)
+			inChange := line.hasChange() && pos >= line.changeStart && pos < line.changeEnd
+			switch {
+			case inChange && pos+n > line.changeEnd:
+				n = line.changeEnd - pos
+			case line.hasChange() && pos < line.changeStart && pos+n > line.changeStart:
+				n = line.changeStart - pos
+			}
+
+			st := h.tokenStyle(tok.Type)
+			if inChange {
+				st = st.Background(emphasis)
+			} else if bg != nil {
+				st = st.Background(bg)
+			}
+			sb.WriteString(st.Render(string(runes[:n])))
+			pos += n
+			runes = runes[n:]
+		}
+	}
+	return sb.String()
+}
+
+// fill pads a rendered line to width with the line's background.
+func (h *diffHighlighter) fill(kind diffLineKind, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	bg, _ := h.backgrounds(kind)
+	if bg == nil {
+		return getPadding(width)
+	}
+	return lipgloss.NewStyle().Background(bg).Render(getPadding(width))
+}
+
+// diffLineNumberWidth returns the gutter width needed for the largest line number.
+func diffLineNumberWidth(files []*diffFile, contents map[int][]string) int {
+	maxNo := 0
+	for fi, f := range files {
+		for _, h := range f.hunks {
+			maxNo = max(maxNo, h.oldStart+h.oldCount, h.newStart+h.newCount)
+		}
+		maxNo = max(maxNo, len(contents[fi]))
+	}
+	return max(3, len(strconv.Itoa(maxNo)))
+}
+
+// formatLineNumber right-aligns a line number in the gutter (blank for 0).
+func formatLineNumber(n, width int) string {
+	if n == 0 {
+		return getPadding(width)
+	}
+	s := strconv.Itoa(n)
+	return getPadding(width-len(s)) + s
+}
+
+// renderDiffRow renders a row. Side-by-side rows are exactly width wide;
+// unified rows may be wider and are scrolled horizontally.
+func (m *DiffViewer) renderDiffRow(row diffRow, width int) string {
+	f := m.files[row.file]
+	h := m.highlighter
+	numWidth := m.lineNumberWidth
+	dim := lipgloss.NewStyle().Foreground(m.colors.TextDim)
+
+	switch row.kind {
+	case diffRowFile:
+		return m.renderFileHeader(row.file, width)
+
+	case diffRowBinary:
+		return dim.Render(getPadding(numWidth) + "  Binary file not shown")
+
+	case diffRowHunk:
+		hunk := &f.hunks[row.hunk]
+		header := "@@ -" + strconv.Itoa(hunk.oldStart) + "," + strconv.Itoa(hunk.oldCount) +
+			" +" + strconv.Itoa(hunk.newStart) + "," + strconv.Itoa(hunk.newCount) + " @@"
+		if hunk.header != "" {
+			header += " " + hunk.header
+		}
+		return lipgloss.NewStyle().Foreground(m.colors.Accent).Faint(true).Render(header)
+
+	case diffRowGap:
+		label := "⋯ more lines (e to expand)"
+		if row.hidden > 0 {
+			label = "⋯ " + strconv.Itoa(row.hidden) + " unchanged lines (e to expand)"
+		}
+		return dim.Render(getPadding(numWidth) + "  " + label)
+
+	case diffRowComment:
+		return m.renderCommentRow(row, width)
+
+	case diffRowLine:
+	}
+
+	if !m.splitView {
+		line := row.left
+		return dim.Render(formatLineNumber(line.oldNo, numWidth)+" "+formatLineNumber(line.newNo, numWidth)) +
+			" " + h.sign(line) + h.render(f.path(), line)
+	}
+
+	sideWidth := (width - 1) / 2
+	left := m.renderSplitSide(f, row.left, numWidth, sideWidth, true)
+	right := m.renderSplitSide(f, row.right, numWidth, width-1-sideWidth, false)
+	return left + dim.Render("│") + right
+}
+
+// renderSplitSide renders one side of a side-by-side row, padded to width.
+func (m *DiffViewer) renderSplitSide(f *diffFile, line *diffLine, numWidth, width int, old bool) string {
+	if line == nil {
+		return getPadding(width)
+	}
+	h := m.highlighter
+	n := line.newNo
+	if old {
+		n = line.oldNo
+	}
+	gutter := lipgloss.NewStyle().Foreground(m.colors.TextDim).Render(formatLineNumber(n, numWidth)) + " " + h.sign(line)
+
+	textWidth := width - numWidth - 2
+	if textWidth <= 0 {
+		return ansi.Truncate(gutter, width, "")
+	}
+	text := h.render(f.path(), line)
+	textLen := ansi.StringWidth(text)
+	if textLen > textWidth {
+		text = ansi.Truncate(text, textWidth, "…")
+		textLen = textWidth
+	}
+	return gutter + text + h.fill(line.kind, textWidth-textLen)
+}
+
+// renderFileHeader renders a file header row padded to width.
+func (m *DiffViewer) renderFileHeader(fi, width int) string {
+	f := m.files[fi]
+	arrow := "▾ "
+	if m.collapsed[fi] {
+		arrow = "▸ "
+	}
+	title := arrow + f.path()
+	switch f.status {
+	case diffFileAdded:
+		title += " (new)"
+	case diffFileDeleted:
+		title += " (deleted)"
+	case diffFileRenamed:
+		title += " (renamed from " + f.oldPath + ")"
+	case diffFileModified:
+	}
+	if m.isViewed(fi) {
+		title += "  ✓ viewed"
+	}
+
+	bg := m.colors.BackgroundAlt
+	base := lipgloss.NewStyle().Background(bg)
+	header := base.Bold(true).Foreground(m.colors.TextBright).Render(title) +
+		base.Render("  ") +
+		m.highlighter.addSign.Background(bg).Render("+"+strconv.Itoa(f.additions)) +
+		base.Render(" ") +
+		m.highlighter.delSign.Background(bg).Render("-"+strconv.Itoa(f.deletions))
+	if w := ansi.StringWidth(header); w < width {
+		header += base.Render(getPadding(width - w))
+	}
+	return header
+}
+
+// renderTreeEntry renders a file tree sidebar row padded to width.
+func (m *DiffViewer) renderTreeEntry(entry diffTreeEntry, width int, current bool) string {
+	label := strings.Repeat("  ", entry.depth) + entry.label
+	var stats, statsPlain string // Rendered and plain per-file stats
+	style := lipgloss.NewStyle()
+	if current {
+		style = style.Background(m.colors.Selection)
+	}
+
+	if entry.file < 0 {
+		style = style.Foreground(m.colors.TextDim)
+	} else if m.isViewed(entry.file) {
+		// Viewed files are dimmed and checked, without stats
+		label = strings.Repeat("  ", entry.depth) + "✓ " + entry.label
+		style = style.Foreground(m.colors.TextDim)
+	} else {
+		f := m.files[entry.file]
+		add, del := "+"+strconv.Itoa(f.additions), "-"+strconv.Itoa(f.deletions)
+		statsPlain = " " + add + " " + del
+		stats = style.Render(" ") + m.highlighter.addSign.Inherit(style).Render(add) +
+			style.Render(" ") + m.highlighter.delSign.Inherit(style).Render(del)
+		switch f.status {
+		case diffFileAdded:
+			style = style.Foreground(m.colors.SuccessColor)
+		case diffFileDeleted:
+			style = style.Foreground(m.colors.TextDim).Strikethrough(true)
+		case diffFileModified, diffFileRenamed:
+		}
+	}
+
+	labelWidth := width - len(statsPlain)
+	if labelWidth < diffTreeMinWidth/2 {
+		// Too narrow for stats
+		labelWidth, stats = width, ""
+	}
+	if ansi.StringWidth(label) > labelWidth {
+		label = ansi.Truncate(label, labelWidth, "…")
+	}
+	return style.Render(label+getPadding(labelWidth-ansi.StringWidth(label))) + stats
+}
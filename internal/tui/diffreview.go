@@ -0,0 +1,498 @@
+package tui
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/v2/textinput"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/dongho-jung/paw/internal/claude"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/git"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/tmux"
+)
+
+// DiffReviewTarget is the task a diff viewer review is kept for and sent to.
+type DiffReviewTarget struct {
+	TaskName   string
+	AgentDir   string // Review comments are stored here
+	HistoryDir string // Submitted reviews are recorded here
+	Session    string
+	WindowID   string // Task window whose agent pane receives submitted reviews
+}
+
+// diffReviewSentMsg is sent when a submitted review was delivered (or failed).
+type diffReviewSentMsg struct {
+	round int
+	ids   []string
+	err   error
+}
+
+// setReviewTarget enables review comments for a task and loads its review.
+func (m *DiffViewer) setReviewTarget(target *DiffReviewTarget) {
+	m.review = target
+	state, err := service.NewReviewStore(target.AgentDir).Load()
+	if err != nil {
+		logging.Warn("diff viewer: failed to load review for %s: %v", target.TaskName, err)
+		m.notice = "Failed to load review: " + err.Error()
+		return
+	}
+	m.reviewState = state
+}
+
+// saveReview persists the review, reporting failures in the status bar.
+func (m *DiffViewer) saveReview() {
+	if m.review == nil {
+		return
+	}
+	m.reviewState.Base = m.mainBranch // Lets the kanban count unreviewed files
+	if err := service.NewReviewStore(m.review.AgentDir).Save(m.reviewState); err != nil {
+		logging.Warn("diff viewer: failed to save review for %s: %v", m.review.TaskName, err)
+		m.notice = "Failed to save review: " + err.Error()
+	}
+}
+
+// resolveReviewComments marks submitted comments resolved when their code changed
+// in a freshly loaded diff.
+func (m *DiffViewer) resolveReviewComments() {
+	if m.review == nil {
+		return
+	}
+	if m.reviewState.ResolveChanged(func(c service.ReviewComment) (string, bool) {
+		return currentReviewCode(m.files, c)
+	}) {
+		m.saveReview()
+	}
+}
+
+// hunkCode returns a hunk's lines with their +/-/space prefixes.
+func hunkCode(h *diffHunk) string {
+	lines := make([]string, len(h.lines))
+	for i, line := range h.lines {
+		prefix := " "
+		switch line.kind {
+		case diffLineAdd:
+			prefix = "+"
+		case diffLineDelete:
+			prefix = "-"
+		case diffLineContext:
+		}
+		lines[i] = prefix + line.text
+	}
+	return strings.Join(lines, "\n")
+}
+
+// hunkHasLine reports whether line belongs to the hunk (and not to revealed context).
+func hunkHasLine(h *diffHunk, line *diffLine) bool {
+	for i := range h.lines {
+		if &h.lines[i] == line {
+			return true
+		}
+	}
+	return false
+}
+
+// reviewAnchor returns the comment location of a row: a hunk header, or a
+// changed or context line of a hunk. Side-by-side rows anchor to the new side
+// unless only the old side is present.
+func reviewAnchor(f *diffFile, row diffRow) (service.ReviewComment, bool) {
+	c := service.ReviewComment{Path: f.path(), Side: service.ReviewSideNew}
+	if row.hunk >= len(f.hunks) {
+		return c, false
+	}
+	h := &f.hunks[row.hunk]
+	switch row.kind { //nolint:exhaustive // Only hunks and their lines take comments
+	case diffRowHunk:
+		c.Line = h.newFirst()
+		c.EndLine = max(h.newEnd(), c.Line)
+		c.Code = hunkCode(h)
+		return c, true
+	case diffRowLine:
+		line := row.right
+		if line == nil {
+			line = row.left
+		}
+		if line == nil || !hunkHasLine(h, line) {
+			return c, false
+		}
+		c.Line = line.newNo
+		if line.kind == diffLineDelete {
+			c.Side, c.Line = service.ReviewSideOld, line.oldNo
+		}
+		c.Code = line.text
+		return c, true
+	}
+	return c, false
+}
+
+// sameReviewAnchor reports whether two comments are on the same line or hunk.
+func sameReviewAnchor(a, b service.ReviewComment) bool {
+	return a.Path == b.Path && a.Side == b.Side && a.Line == b.Line && a.IsHunk() == b.IsHunk()
+}
+
+// currentReviewCode returns the code now at a comment's location, and false
+// if the location is no longer part of the diff.
+func currentReviewCode(files []*diffFile, c service.ReviewComment) (string, bool) {
+	for _, f := range files {
+		if f.path() != c.Path {
+			continue
+		}
+		for hi := range f.hunks {
+			h := &f.hunks[hi]
+			if c.IsHunk() {
+				if h.newFirst() == c.Line {
+					return hunkCode(h), true
+				}
+				continue
+			}
+			for _, line := range h.lines {
+				if c.Side == service.ReviewSideOld && line.kind == diffLineDelete && line.oldNo == c.Line {
+					return line.text, true
+				}
+				if c.Side == service.ReviewSideNew && line.kind != diffLineDelete && line.newNo == c.Line {
+					return line.text, true
+				}
+			}
+		}
+	}
+	return "", false
+}
+
+// withCommentRows inserts a row for each review comment below the row it is on.
+func (m *DiffViewer) withCommentRows(rows []diffRow) []diffRow {
+	if m.reviewState == nil || len(m.reviewState.Comments) == 0 {
+		return rows
+	}
+	out := make([]diffRow, 0, len(rows)+len(m.reviewState.Comments))
+	for _, row := range rows {
+		out = append(out, row)
+		anchor, ok := reviewAnchor(m.files[row.file], row)
+		if !ok {
+			continue
+		}
+		for ci, c := range m.reviewState.Comments {
+			if sameReviewAnchor(anchor, c) {
+				out = append(out, diffRow{kind: diffRowComment, file: row.file, hunk: row.hunk, comment: ci})
+			}
+		}
+	}
+	return out
+}
+
+// renderCommentRow renders a review comment below its line, truncated to width.
+func (m *DiffViewer) renderCommentRow(row diffRow, width int) string {
+	c := m.reviewState.Comments[row.comment]
+	body, _, _ := strings.Cut(strings.TrimSpace(c.Body), "\n")
+	var icon, label string
+	style := lipgloss.NewStyle().Foreground(m.colors.Accent)
+	switch {
+	case c.Resolved:
+		icon, label = "✓", "resolved"
+		style = lipgloss.NewStyle().Foreground(m.colors.TextDim)
+	case c.IsDraft():
+		icon, label = "✎", "draft"
+		style = lipgloss.NewStyle().Foreground(m.colors.WarningColor)
+	default:
+		icon, label = "●", "round "+strconv.Itoa(c.Round)
+	}
+	text := getPadding(m.lineNumberWidth) + "  " + icon + " " + label + ": " + body
+	return style.Render(ansi.Truncate(text, max(width, 1), "…"))
+}
+
+// toggleReviewMode enters or leaves review mode. Entering puts the cursor on
+// the top visible row.
+func (m *DiffViewer) toggleReviewMode() {
+	if m.review == nil {
+		m.notice = "Review needs a task (open the diff from a task window)"
+		return
+	}
+	m.reviewMode = !m.reviewMode
+	if m.reviewMode {
+		m.cursor = max(0, m.rowAtDisplay(m.scrollPos))
+		m.clearSelection()
+	}
+}
+
+// moveCursor moves the review cursor by delta rows and keeps it on screen.
+func (m *DiffViewer) moveCursor(delta int) {
+	m.cursor = min(max(0, m.cursor+delta), max(0, len(m.rows)-1))
+	m.ensureCursorVisible()
+}
+
+// ensureCursorVisible scrolls so the review cursor row is on screen.
+func (m *DiffViewer) ensureCursorVisible() {
+	idx := m.displayIndexOfRow(m.cursor)
+	height := m.contentHeight()
+	if idx < m.scrollPos {
+		m.scrollPos = idx
+	} else if idx >= m.scrollPos+height {
+		m.scrollPos = idx - height + 1
+	}
+	m.clampScroll()
+}
+
+// handleReviewKey handles review mode keys, translated through the keymap.
+// Returns false for keys that keep their normal meaning.
+func (m *DiffViewer) handleReviewKey(key string) (bool, tea.Cmd) {
+	switch key {
+	case "down", "j":
+		m.moveCursor(1)
+	case "up", "k":
+		m.moveCursor(-1)
+	case "a", "enter":
+		return true, m.openCommentInput()
+	case "x":
+		m.deleteDraftAtCursor()
+	case "S":
+		return true, m.submitReview()
+	case "r", "esc":
+		m.reviewMode = false
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+// openCommentInput starts a draft comment on the cursor row.
+func (m *DiffViewer) openCommentInput() tea.Cmd {
+	if m.cursor < 0 || m.cursor >= len(m.rows) {
+		return nil
+	}
+	row := m.rows[m.cursor]
+	anchor, ok := reviewAnchor(m.files[row.file], row)
+	if !ok {
+		m.notice = "Comments go on diff lines or hunk headers"
+		return nil
+	}
+	ti := textinput.New()
+	ti.Prompt = ""
+	ti.Placeholder = "Comment and press Enter (Esc to cancel)"
+	ti.CharLimit = 0
+	ti.SetWidth(max(20, m.width-len(anchor.Location())-4))
+	ti.VirtualCursor = true
+	cmd := ti.Focus()
+	m.commentInput = ti
+	m.commentAnchor = anchor
+	m.commenting = true
+	return cmd
+}
+
+// handleCommentKey handles keys while a comment is being typed.
+func (m *DiffViewer) handleCommentKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		m.commenting = false
+		body := strings.TrimSpace(m.commentInput.Value())
+		if body == "" {
+			return m, nil
+		}
+		c := m.commentAnchor
+		c.Body = body
+		m.reviewState.AddDraft(c)
+		m.saveReview()
+		m.rebuildRows()
+		return m, nil
+	case "esc":
+		m.commenting = false
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.commentInput, cmd = m.commentInput.Update(msg)
+	return m, cmd
+}
+
+// deleteDraftAtCursor deletes the draft comment under the cursor.
+func (m *DiffViewer) deleteDraftAtCursor() {
+	if m.cursor < 0 || m.cursor >= len(m.rows) || m.rows[m.cursor].kind != diffRowComment {
+		m.notice = "Move the cursor to a draft comment to delete it"
+		return
+	}
+	c := m.reviewState.Comments[m.rows[m.cursor].comment]
+	if !c.IsDraft() {
+		m.notice = "Submitted comments cannot be deleted"
+		return
+	}
+	m.reviewState.Remove(c.ID)
+	m.saveReview()
+	m.rebuildRows()
+}
+
+// submitReview sends the draft comments to the task's agent as one review round.
+func (m *DiffViewer) submitReview() tea.Cmd {
+	submitted, err := m.reviewState.Submit()
+	if err != nil {
+		m.notice = "No draft comments to submit"
+		return nil
+	}
+	round := m.reviewState.Rounds
+	m.takeSnapshot()
+	m.saveReview()
+	m.rebuildRows()
+	m.notice = "Sending review round " + strconv.Itoa(round) + "..."
+
+	target := *m.review
+	ids := make([]string, len(submitted))
+	for i, c := range submitted {
+		ids[i] = c.ID
+	}
+	text := service.FormatReview(round, submitted)
+	return func() tea.Msg {
+		tm := tmux.New(target.Session)
+		if err := claude.New().SendInputWithRetry(tm, target.WindowID+".0", text, 5); err != nil {
+			logging.Warn("diff viewer: review for %s failed: %v", target.TaskName, err)
+			return diffReviewSentMsg{round: round, ids: ids, err: err}
+		}
+		if target.HistoryDir != "" {
+			if err := service.NewHistoryService(target.HistoryDir).RecordReview(target.TaskName, round, submitted); err != nil {
+				logging.Warn("diff viewer: failed to record review for %s: %v", target.TaskName, err)
+			}
+		}
+		return diffReviewSentMsg{round: round, ids: ids}
+	}
+}
+
+// handleReviewSent reports a delivered review, or turns its comments back into
+// drafts if it could not be sent.
+func (m *DiffViewer) handleReviewSent(msg diffReviewSentMsg) {
+	if msg.err == nil {
+		m.notice = "✓ Review round " + strconv.Itoa(msg.round) + " sent to " + m.review.TaskName
+		return
+	}
+	m.notice = "✗ Failed to send review: " + msg.err.Error()
+	sent := make(map[string]bool, len(msg.ids))
+	for _, id := range msg.ids {
+		sent[id] = true
+	}
+	for i := range m.reviewState.Comments {
+		if sent[m.reviewState.Comments[i].ID] {
+			m.reviewState.Comments[i].Round = 0
+		}
+	}
+	if m.reviewState.Rounds == msg.round {
+		m.reviewState.Rounds--
+	}
+	m.saveReview()
+	m.rebuildRows()
+}
+
+// sameRow reports whether two rows show the same content, so the review
+// cursor can follow its row when rows are rebuilt.
+func sameRow(a, b diffRow) bool {
+	if a.kind != b.kind || a.file != b.file || a.hunk != b.hunk || a.comment != b.comment {
+		return false
+	}
+	if a.kind != diffRowLine {
+		return true
+	}
+	return (a.left != nil && a.left == b.left) || (a.right != nil && a.right == b.right)
+}
+
+// isViewed reports whether a file is marked viewed at its current content.
+func (m *DiffViewer) isViewed(fi int) bool {
+	if m.review == nil || fi < 0 || fi >= len(m.files) {
+		return false
+	}
+	path := m.files[fi].path()
+	return m.reviewState.IsViewed(path, m.blobs[path])
+}
+
+// toggleViewed marks the current file viewed (collapsing it and moving on to
+// the next file) or clears its mark.
+func (m *DiffViewer) toggleViewed() {
+	if m.review == nil {
+		m.notice = "Viewed marks need a task (open the diff from a kanban card)"
+		return
+	}
+	fi := m.currentFile()
+	if fi < 0 {
+		return
+	}
+	path := m.files[fi].path()
+	blob, ok := m.blobs[path]
+	if !ok {
+		m.notice = "Cannot mark " + path + " viewed (not changed since " + m.mainBranch + ")"
+		return
+	}
+	viewed := !m.isViewed(fi)
+	m.reviewState.SetViewed(path, blob, viewed)
+	m.saveReview()
+	m.collapsed[fi] = viewed
+	m.rebuildRows()
+	if viewed && fi+1 < len(m.files) {
+		m.scrollToFile(fi + 1)
+	} else {
+		m.scrollToFile(fi)
+	}
+}
+
+// finishReview marks all changed files viewed and records the review snapshot,
+// so the next round can be reviewed with "since last review".
+func (m *DiffViewer) finishReview() {
+	if m.review == nil {
+		m.notice = "Review needs a task (open the diff from a kanban card)"
+		return
+	}
+	for path, blob := range m.blobs {
+		m.reviewState.SetViewed(path, blob, true)
+	}
+	if !m.takeSnapshot() {
+		return
+	}
+	m.saveReview()
+	for fi := range m.files {
+		m.collapsed[fi] = m.isViewed(fi)
+	}
+	m.rebuildRows()
+	m.notice = "✓ Review finished at " + shortHash(m.reviewState.Snapshot)
+}
+
+// takeSnapshot records HEAD as the reviewed commit under the task's review ref.
+// The ref keeps the commit reachable if the agent rewrites its branch.
+func (m *DiffViewer) takeSnapshot() bool {
+	gitClient := git.New()
+	head, err := gitClient.GetHeadCommit(m.workDir)
+	if err != nil {
+		m.notice = "Failed to read HEAD: " + err.Error()
+		return false
+	}
+	if err := gitClient.UpdateRef(m.workDir, constants.ReviewSnapshotRefPrefix+m.review.TaskName, head); err != nil {
+		logging.Warn("diff viewer: failed to update review ref for %s: %v", m.review.TaskName, err)
+	}
+	m.reviewState.Snapshot = head
+	return true
+}
+
+// snapshotRef returns the ref of the last review snapshot, falling back to its
+// commit if the ref is missing.
+func (m *DiffViewer) snapshotRef() string {
+	ref := constants.ReviewSnapshotRefPrefix + m.review.TaskName
+	if git.New().RefExists(m.workDir, ref) {
+		return ref
+	}
+	return m.reviewState.Snapshot
+}
+
+// toggleSinceReview switches between the full diff and the changes since the
+// last review snapshot, and reloads the diff.
+func (m *DiffViewer) toggleSinceReview() tea.Cmd {
+	if m.review == nil || m.reviewState.Snapshot == "" {
+		m.notice = "No review snapshot yet (submit a review or press M)"
+		return nil
+	}
+	m.sinceReview = !m.sinceReview
+	m.reviewMode = false
+	return m.loadDiffOutput()
+}
+
+// shortHash abbreviates a commit hash for display.
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
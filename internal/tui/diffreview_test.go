@@ -0,0 +1,143 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+
+	"github.com/dongho-jung/paw/internal/service"
+)
+
+// findDiffRow returns the index of the first row of file fi matching the predicate.
+func findDiffRow(t *testing.T, rows []diffRow, fi int, match func(diffRow) bool) int {
+	t.Helper()
+	for i, row := range rows {
+		if row.file == fi && match(row) {
+			return i
+		}
+	}
+	t.Fatal("row not found")
+	return -1
+}
+
+func TestReviewAnchor(t *testing.T) {
+	files := parseUnifiedDiff(testDiff)
+	rows := buildDiffRows(files, false, nil, nil, nil)
+	app := files[0]
+
+	hunk := findDiffRow(t, rows, 0, func(r diffRow) bool { return r.kind == diffRowHunk })
+	c, ok := reviewAnchor(app, rows[hunk])
+	if !ok || c.Location() != "internal/app/app.go:10-13" || !strings.Contains(c.Code, "\n-    a.name = \"old\"\n+    a.name = \"new\"\n") {
+		t.Errorf("hunk anchor = %+v, %v", c, ok)
+	}
+
+	del := findDiffRow(t, rows, 0, func(r diffRow) bool { return r.kind == diffRowLine && r.left.kind == diffLineDelete })
+	c, ok = reviewAnchor(app, rows[del])
+	if !ok || c.Side != service.ReviewSideOld || c.Line != 11 || c.Code != `    a.name = "old"` {
+		t.Errorf("deleted line anchor = %+v, %v", c, ok)
+	}
+
+	add := findDiffRow(t, rows, 0, func(r diffRow) bool { return r.kind == diffRowLine && r.left.kind == diffLineAdd })
+	c, ok = reviewAnchor(app, rows[add])
+	if !ok || c.Side != service.ReviewSideNew || c.Line != 11 {
+		t.Errorf("added line anchor = %+v, %v", c, ok)
+	}
+
+	if code, ok := currentReviewCode(files, c); !ok || code != c.Code {
+		t.Errorf("currentReviewCode() = %q, %v; want %q", code, ok, c.Code)
+	}
+	changed := parseUnifiedDiff(strings.Replace(testDiff, `+	a.name = "new"`, `+	a.name = "newer"`, 1))
+	if code, _ := currentReviewCode(changed, c); code == c.Code {
+		t.Error("currentReviewCode() should see the changed line")
+	}
+
+	gap := findDiffRow(t, rows, 0, func(r diffRow) bool { return r.kind == diffRowGap })
+	if _, ok := reviewAnchor(app, rows[gap]); ok {
+		t.Error("gap rows should not take comments")
+	}
+}
+
+func TestDiffViewerReviewComment(t *testing.T) {
+	agentDir := t.TempDir()
+	m := NewDiffViewer(t.TempDir(), "main")
+	m.setReviewTarget(&DiffReviewTarget{TaskName: "task", AgentDir: agentDir})
+	m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	m.Update(diffOutputMsg{files: parseUnifiedDiff(testDiff)})
+
+	m.Update(keyPress("r"))
+	if !m.reviewMode {
+		t.Fatal("r should enter review mode")
+	}
+	add := findDiffRow(t, m.rows, 0, func(r diffRow) bool { return r.kind == diffRowLine && r.left.kind == diffLineAdd })
+	for m.cursor < add {
+		m.Update(keyPress("j"))
+	}
+	m.Update(keyPress("a"))
+	if !m.commenting {
+		t.Fatal("a should open the comment input")
+	}
+	for _, r := range "rename" {
+		m.Update(keyPress(string(r)))
+	}
+	m.Update(keyPress("enter"))
+
+	if m.rows[add+1].kind != diffRowComment {
+		t.Fatalf("row after the commented line = %v, want comment row", m.rows[add+1].kind)
+	}
+	state, err := service.NewReviewStore(agentDir).Load()
+	if err != nil || len(state.Drafts()) != 1 || state.Drafts()[0].Location() != "internal/app/app.go:11" || state.Drafts()[0].Body != "rename" {
+		t.Fatalf("saved review = %+v, %v", state, err)
+	}
+
+	// Deleting the draft from its comment row
+	m.cursor = add + 1
+	m.Update(keyPress("x"))
+	if len(m.reviewState.Comments) != 0 || m.rows[add+1].kind == diffRowComment {
+		t.Errorf("x should delete the draft, comments = %+v", m.reviewState.Comments)
+	}
+}
+
+func TestDiffViewerReviewNeedsTask(t *testing.T) {
+	m := NewDiffViewer(t.TempDir(), "main")
+	m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	m.Update(diffOutputMsg{files: parseUnifiedDiff(testDiff)})
+	m.Update(keyPress("r"))
+	if m.reviewMode || m.notice == "" {
+		t.Errorf("review mode without a task: mode %v, notice %q", m.reviewMode, m.notice)
+	}
+}
+
+func TestDiffViewerViewedFiles(t *testing.T) {
+	agentDir := t.TempDir()
+	m := NewDiffViewer(t.TempDir(), "main")
+	m.setReviewTarget(&DiffReviewTarget{TaskName: "task", AgentDir: agentDir})
+	m.Update(tea.WindowSizeMsg{Width: 100, Height: 6})
+	blobs := map[string]string{"internal/app/app.go": "a1", "internal/app/new.go": "n1", "docs/README.md": "r1", "logo.png": "l1"}
+	m.Update(diffOutputMsg{files: parseUnifiedDiff(testDiff), blobs: blobs})
+
+	m.Update(keyPress("m"))
+	if !m.collapsed[0] || !m.isViewed(0) || m.currentFile() != 1 {
+		t.Fatalf("m should mark app.go viewed, collapse it and move on (collapsed %v, current %d)", m.collapsed[0], m.currentFile())
+	}
+	state, err := service.NewReviewStore(agentDir).Load()
+	if err != nil || state.Base != "main" || state.Unreviewed(blobs) != 3 {
+		t.Fatalf("saved review = %+v, %v", state, err)
+	}
+
+	// Viewed files start collapsed until their content changes
+	m.Update(diffOutputMsg{files: parseUnifiedDiff(testDiff), blobs: blobs})
+	if !m.collapsed[0] {
+		t.Error("viewed file should start collapsed")
+	}
+	changed := map[string]string{"internal/app/app.go": "a2", "internal/app/new.go": "n1", "docs/README.md": "r1", "logo.png": "l1"}
+	m.Update(diffOutputMsg{files: parseUnifiedDiff(testDiff), blobs: changed})
+	if m.collapsed[0] || m.isViewed(0) {
+		t.Error("changed file should no longer be viewed")
+	}
+
+	m.Update(keyPress("s"))
+	if m.sinceReview || m.notice == "" {
+		t.Error("s without a review snapshot should only show a notice")
+	}
+}
@@ -8,33 +8,66 @@ import (
 	"strings"
 
 	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/v2/textinput"
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/charmbracelet/lipgloss/v2"
 	"github.com/charmbracelet/x/ansi"
+
+	"github.com/dongho-jung/paw/internal/git"
+	"github.com/dongho-jung/paw/internal/keymap"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/service"
 )
 
-// Pre-computed status bar hints and their widths (avoids ansi.StringWidth on each render)
+// Diff viewer file tree sidebar sizing
 const (
-	diffViewerHintFull       = "/:search n/N:match ⌃D/⌃U:scroll w:wrap q:close"
-	diffViewerHintShort      = "q:close"
-	diffViewerHintFullWidth  = 46 // ansi.StringWidth - ⌃ chars are 1 width each
-	diffViewerHintShortWidth = 7  // len("q:close")
+	diffTreeMinWidth      = 20
+	diffTreeMaxWidth      = 40
+	diffTreeMinTotalWidth = 60 // Hide the tree on narrower terminals
 )
 
 // DiffViewer provides an interactive diff viewer with vim-like navigation.
-// It displays the git diff between the main branch and HEAD.
+// It displays the git diff between the main branch and HEAD with a file tree,
+// syntax highlighting, intra-line changes and an optional side-by-side layout.
 type DiffViewer struct {
-	workDir       string
-	mainBranch    string
-	lines         []string
-	scrollPos     int
-	horizontalPos int
-	wordWrap      bool
-	width         int
-	height        int
-	err           error
-	isDark        bool
-	colors        ThemeColors
+	workDir         string
+	mainBranch      string
+	lines           []string // Rendered rows (one per row)
+	files           []*diffFile
+	tree            []diffTreeEntry
+	rows            []diffRow
+	collapsed       map[int]bool       // Collapsed files
+	expanded        map[diffGapKey]int // Revealed lines from the top of each context gap
+	contents        map[int][]string   // New-file contents, loaded on demand to expand context
+	splitView       bool               // Side-by-side layout
+	showTree        bool               // File tree sidebar
+	notice          string             // One-off status bar message
+	highlighter     *diffHighlighter
+	lineNumberWidth int
+	scrollPos       int
+	horizontalPos   int
+	wordWrap        bool
+	width           int
+	height          int
+	err             error
+	isDark          bool
+	colors          ThemeColors
+
+	// Pre-computed status bar hints (avoids ansi.StringWidth on each render)
+	hintFull   statusHint
+	hintShort  statusHint
+	hintReview statusHint
+
+	// Review state (comments and viewed marks are only available for a task)
+	review        *DiffReviewTarget
+	reviewState   *service.ReviewState
+	blobs         map[string]string // Changed file path → blob hash on HEAD (for viewed marks)
+	sinceReview   bool              // Diff against the last review snapshot instead of the main branch
+	reviewMode    bool
+	cursor        int // Review cursor row
+	commenting    bool
+	commentInput  textinput.Model
+	commentAnchor service.ReviewComment
 
 	// Mouse text selection state
 	selecting    bool
@@ -62,6 +95,7 @@ type DiffViewer struct {
 
 	// Display lines cache (invalidated on content/width/wrap change)
 	cachedDisplayLines []string
+	cachedDisplayRows  []int // Display line → row index
 	cachedDisplayWidth int
 	cachedDisplayWrap  bool
 }
@@ -71,11 +105,21 @@ func NewDiffViewer(workDir, mainBranch string) *DiffViewer {
 	// Detect dark mode BEFORE bubbletea starts
 	isDark := DetectDarkMode()
 
+	hintClose := closeHint(keymap.ActionClose)
 	return &DiffViewer{
-		workDir:    workDir,
-		mainBranch: mainBranch,
-		isDark:     isDark,
-		colors:     NewThemeColors(isDark),
+		workDir:     workDir,
+		mainBranch:  mainBranch,
+		isDark:      isDark,
+		colors:      NewThemeColors(isDark),
+		hintFull:    newStatusHint(keyLabel(keymap.ActionSearch) + ":search ]/[:hunk }/{:file c:fold e:expand v:split t:tree m:viewed s:since r:review " + hintClose),
+		hintShort:   newStatusHint(hintClose),
+		hintReview:  newStatusHint("j/k:move a:comment x:delete S:submit r:done"),
+		showTree:    true,
+		collapsed:   make(map[int]bool),
+		expanded:    make(map[diffGapKey]int),
+		contents:    make(map[int][]string),
+		highlighter: newDiffHighlighter(isDark),
+		reviewState: &service.ReviewState{},
 	}
 }
 
@@ -88,24 +132,20 @@ func (m *DiffViewer) Init() tea.Cmd {
 func (m *DiffViewer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.BackgroundColorMsg:
-		m.isDark = msg.IsDark()
+		m.isDark = themeIsDark(msg.IsDark())
 		m.colors = NewThemeColors(m.isDark)
 		m.stylesCached = false // Invalidate style cache on theme change
+		m.highlighter = newDiffHighlighter(m.isDark)
 		setCachedDarkMode(m.isDark)
+		m.rebuildRows()
 		return m, nil
 
 	case tea.KeyMsg:
 		return m.handleKey(msg)
 
 	case tea.MouseClickMsg:
-		if msg.Button == tea.MouseLeft {
-			// Start text selection
-			m.selecting = true
-			m.hasSelection = true
-			m.selectStartY = msg.Y
-			m.selectStartX = msg.X
-			m.selectEndY = msg.Y
-			m.selectEndX = msg.X
+		if msg.Button == tea.MouseLeft && !m.commenting {
+			return m, m.handleClick(msg.X, msg.Y)
 		}
 		return m, nil
 
@@ -113,7 +153,7 @@ func (m *DiffViewer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Extend selection while dragging
 		if m.selecting {
 			m.selectEndY = msg.Y
-			m.selectEndX = msg.X
+			m.selectEndX = max(0, msg.X-m.diffX())
 		}
 		return m, nil
 
@@ -121,7 +161,7 @@ func (m *DiffViewer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.Button == tea.MouseLeft && m.selecting {
 			m.selecting = false
 			m.selectEndY = msg.Y
-			m.selectEndX = msg.X
+			m.selectEndX = max(0, msg.X-m.diffX())
 		}
 		return m, nil
 
@@ -137,27 +177,72 @@ func (m *DiffViewer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		m.rebuildRows()
 		return m, nil
 
 	case diffOutputMsg:
-		m.lines = msg.lines
-		m.cachedDisplayLines = nil // Invalidate display lines cache
+		m.files = msg.files
+		m.blobs = msg.blobs
+		m.tree = buildDiffTree(m.files)
+		if !m.sinceReview {
+			// Comment lines refer to the full diff
+			m.resolveReviewComments()
+		}
+		m.collapsed = make(map[int]bool)
+		for fi := range m.files {
+			if m.isViewed(fi) {
+				m.collapsed[fi] = true
+			}
+		}
+		m.expanded = make(map[diffGapKey]int)
+		m.contents = make(map[int][]string)
+		m.rows = nil
+		m.rebuildRows()
 		m.scrollPos = 0
 		m.horizontalPos = 0
+		m.cursor = 0
 		m.clearSelection()
 		return m, nil
 
+	case diffContentMsg:
+		if msg.err != nil {
+			m.notice = "Failed to load " + m.files[msg.gap.file].path() + ": " + msg.err.Error()
+			return m, nil
+		}
+		m.contents[msg.gap.file] = msg.lines
+		m.expanded[msg.gap] += diffContextExpandStep
+		m.rebuildRows()
+		return m, nil
+
+	case diffReviewSentMsg:
+		m.handleReviewSent(msg)
+		return m, nil
+
 	case error:
 		m.err = msg
 		return m, nil
 	}
 
+	if m.commenting {
+		// Cursor blink and other input messages
+		var cmd tea.Cmd
+		m.commentInput, cmd = m.commentInput.Update(msg)
+		return m, cmd
+	}
 	return m, nil
 }
 
 // diffOutputMsg is sent when diff output is loaded.
 type diffOutputMsg struct {
+	files []*diffFile
+	blobs map[string]string
+}
+
+// diffContentMsg is sent when a file is loaded to expand a context gap.
+type diffContentMsg struct {
+	gap   diffGapKey
 	lines []string
+	err   error
 }
 
 // handleKey handles keyboard input.
@@ -166,8 +251,18 @@ func (m *DiffViewer) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	if m.searchMode {
 		return m.handleSearchKey(msg)
 	}
+	if m.commenting {
+		return m.handleCommentKey(msg)
+	}
+	m.notice = ""
+	key := translateViewerKey(msg)
+	if m.reviewMode {
+		if handled, cmd := m.handleReviewKey(key); handled {
+			return m, cmd
+		}
+	}
 
-	switch msg.String() {
+	switch key {
 	// Copy selection with Ctrl+C
 	case "ctrl+c":
 		if m.hasSelection {
@@ -175,7 +270,7 @@ func (m *DiffViewer) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
-	// Close on q or Ctrl+Shift+D
+	// Close on q or Ctrl+Shift+D (the diff toggle)
 	case "q", "ctrl+shift+d":
 		return m, tea.Quit
 
@@ -224,7 +319,7 @@ func (m *DiffViewer) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "right", "l":
 		if !m.wordWrap {
 			// Limit horizontal scroll to max line width minus screen width
-			maxScroll := m.maxLineWidth() - m.width
+			maxScroll := m.maxLineWidth() - m.diffWidth()
 			if maxScroll < 0 {
 				maxScroll = 0
 			}
@@ -266,6 +361,65 @@ func (m *DiffViewer) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case "ctrl+d":
 		m.scrollDown(m.contentHeight() / 2)
+
+	case "]":
+		m.jumpToRow(diffRowHunk, true)
+
+	case "[":
+		m.jumpToRow(diffRowHunk, false)
+
+	case "}":
+		m.jumpToRow(diffRowFile, true)
+
+	case "{":
+		m.jumpToRow(diffRowFile, false)
+
+	case "c":
+		// Collapse/expand the current file
+		if fi := m.currentFile(); fi >= 0 {
+			m.collapsed[fi] = !m.collapsed[fi]
+			m.rebuildRows()
+			m.scrollToFile(fi)
+		}
+
+	case "C":
+		// Collapse all files, or expand all if all are collapsed
+		fi := m.currentFile()
+		collapse := len(m.collapsed) < len(m.files)
+		for i := range m.files {
+			if collapse {
+				m.collapsed[i] = true
+			} else {
+				delete(m.collapsed, i)
+			}
+		}
+		m.rebuildRows()
+		m.scrollToFile(fi)
+
+	case "e":
+		// Expand the first hidden context gap on screen
+		return m, m.expandVisibleGap()
+
+	case "v":
+		m.splitView = !m.splitView
+		m.horizontalPos = 0
+		m.rebuildRows()
+
+	case "t":
+		m.showTree = !m.showTree
+		m.rebuildRows()
+
+	case "r":
+		m.toggleReviewMode()
+
+	case "m":
+		m.toggleViewed()
+
+	case "M":
+		m.finishReview()
+
+	case "s":
+		return m, m.toggleSinceReview()
 	}
 
 	return m, nil
@@ -342,13 +496,14 @@ func (m *DiffViewer) scrollToEnd() {
 // getDisplayLines returns lines to display, handling word wrap if enabled.
 // Results are cached and invalidated on width/wrap mode change.
 func (m *DiffViewer) getDisplayLines() []string {
-	if !m.wordWrap || m.width <= 0 {
+	width := m.diffWidth()
+	if !m.wordWrap || width <= 0 {
 		return m.lines
 	}
 
 	// Check cache validity
 	if m.cachedDisplayLines != nil &&
-		m.cachedDisplayWidth == m.width &&
+		m.cachedDisplayWidth == width &&
 		m.cachedDisplayWrap == m.wordWrap {
 		return m.cachedDisplayLines
 	}
@@ -356,19 +511,22 @@ func (m *DiffViewer) getDisplayLines() []string {
 	// Word wrap mode: wrap long lines (ANSI-aware)
 	// Pre-allocate with estimated capacity (lines * 1.5 for wrapping)
 	wrapped := make([]string, 0, len(m.lines)*3/2)
-	for _, line := range m.lines {
+	rows := make([]int, 0, len(m.lines)*3/2)
+	for row, line := range m.lines {
 		lineWidth := ansi.StringWidth(line)
-		if lineWidth <= m.width {
+		if lineWidth <= width {
 			wrapped = append(wrapped, line)
+			rows = append(rows, row)
 		} else {
 			// Wrap the line using visual positions
 			pos := 0
 			for pos < lineWidth {
-				end := pos + m.width
+				end := pos + width
 				if end > lineWidth {
 					end = lineWidth
 				}
 				wrapped = append(wrapped, ansi.Cut(line, pos, end))
+				rows = append(rows, row)
 				pos = end
 			}
 		}
@@ -376,12 +534,273 @@ func (m *DiffViewer) getDisplayLines() []string {
 
 	// Cache the result
 	m.cachedDisplayLines = wrapped
-	m.cachedDisplayWidth = m.width
+	m.cachedDisplayRows = rows
+	m.cachedDisplayWidth = width
 	m.cachedDisplayWrap = m.wordWrap
 
 	return wrapped
 }
 
+// rowAtDisplay returns the row shown at a display line index, or -1.
+func (m *DiffViewer) rowAtDisplay(idx int) int {
+	displayLines := m.getDisplayLines()
+	if idx < 0 || idx >= len(displayLines) {
+		return -1
+	}
+	if m.wordWrap && m.cachedDisplayRows != nil {
+		return m.cachedDisplayRows[idx]
+	}
+	return idx
+}
+
+// displayIndexOfRow returns the first display line index of a row.
+func (m *DiffViewer) displayIndexOfRow(row int) int {
+	m.getDisplayLines()
+	if !m.wordWrap || m.cachedDisplayRows == nil {
+		return row
+	}
+	for i, r := range m.cachedDisplayRows {
+		if r >= row {
+			return i
+		}
+	}
+	return len(m.cachedDisplayRows)
+}
+
+// rebuildRows lays out and renders the rows after the diff, layout, width,
+// collapsed files or expanded context changed. The top row stays in view.
+func (m *DiffViewer) rebuildRows() {
+	anchor := -1
+	if top := m.rowAtDisplay(m.scrollPos); top >= 0 {
+		anchor = top
+	}
+	var anchorRow diffRow
+	if anchor >= 0 && anchor < len(m.rows) {
+		anchorRow = m.rows[anchor]
+	}
+	var cursorRow *diffRow
+	if m.cursor < len(m.rows) {
+		cursorRow = &m.rows[m.cursor]
+	}
+
+	m.rows = m.withCommentRows(buildDiffRows(m.files, m.splitView, m.collapsed, m.expanded, m.contents))
+	m.lineNumberWidth = diffLineNumberWidth(m.files, m.contents)
+	width := m.diffWidth()
+	m.lines = make([]string, len(m.rows))
+	for i, row := range m.rows {
+		m.lines[i] = m.renderDiffRow(row, width)
+	}
+	m.cachedDisplayLines = nil // Invalidate display lines cache
+	m.cachedDisplayRows = nil
+
+	if cursorRow != nil {
+		prev := *cursorRow
+		m.cursor = min(m.cursor, max(0, len(m.rows)-1))
+		for i, row := range m.rows {
+			if sameRow(row, prev) {
+				m.cursor = i
+				break
+			}
+		}
+	}
+	if anchor >= 0 {
+		for i, row := range m.rows {
+			if row.file == anchorRow.file && (row.hunk == anchorRow.hunk || anchorRow.kind == diffRowFile) {
+				m.scrollPos = m.displayIndexOfRow(i)
+				break
+			}
+		}
+	}
+	m.clampScroll()
+	if m.searchQuery != "" {
+		m.findMatches()
+	}
+}
+
+// clampScroll keeps the scroll position within the content.
+func (m *DiffViewer) clampScroll() {
+	maxPos := len(m.getDisplayLines()) - m.contentHeight()
+	if m.scrollPos > maxPos {
+		m.scrollPos = maxPos
+	}
+	if m.scrollPos < 0 {
+		m.scrollPos = 0
+	}
+}
+
+// treeWidth returns the width of the file tree sidebar, 0 if hidden.
+func (m *DiffViewer) treeWidth() int {
+	if !m.showTree || len(m.files) == 0 || m.width < diffTreeMinTotalWidth {
+		return 0
+	}
+	return min(max(m.width/4, diffTreeMinWidth), diffTreeMaxWidth)
+}
+
+// diffX returns the screen column where the diff starts (after the tree and its border).
+func (m *DiffViewer) diffX() int {
+	if w := m.treeWidth(); w > 0 {
+		return w + 1
+	}
+	return 0
+}
+
+// diffWidth returns the width of the diff area.
+func (m *DiffViewer) diffWidth() int {
+	return max(0, m.width-m.diffX())
+}
+
+// currentFile returns the file of the top visible row, or -1.
+func (m *DiffViewer) currentFile() int {
+	row := m.rowAtDisplay(m.scrollPos)
+	if row < 0 || row >= len(m.rows) {
+		return -1
+	}
+	return m.rows[row].file
+}
+
+// scrollToRow scrolls so the row is at the top of the screen.
+func (m *DiffViewer) scrollToRow(row int) {
+	m.scrollPos = m.displayIndexOfRow(row)
+	m.clampScroll()
+}
+
+// scrollToFile scrolls to a file's header row.
+func (m *DiffViewer) scrollToFile(fi int) {
+	for i, row := range m.rows {
+		if row.kind == diffRowFile && row.file == fi {
+			m.scrollToRow(i)
+			return
+		}
+	}
+}
+
+// jumpToRow scrolls to the next (or previous) row of the given kind.
+func (m *DiffViewer) jumpToRow(kind diffRowKind, forward bool) {
+	top := m.rowAtDisplay(m.scrollPos)
+	if top < 0 {
+		return
+	}
+	if forward {
+		for i := top + 1; i < len(m.rows); i++ {
+			if m.rows[i].kind == kind {
+				m.scrollToRow(i)
+				return
+			}
+		}
+		return
+	}
+	for i := top - 1; i >= 0; i-- {
+		if m.rows[i].kind == kind {
+			m.scrollToRow(i)
+			return
+		}
+	}
+}
+
+// handleClick handles a left click: file tree rows jump to their file, file
+// headers toggle collapsing, gap rows expand context, other rows start a selection.
+func (m *DiffViewer) handleClick(x, y int) tea.Cmd {
+	if tw := m.treeWidth(); tw > 0 && x <= tw {
+		if x < tw {
+			idx := m.treeOffset() + y
+			if idx >= 0 && idx < len(m.tree) && m.tree[idx].file >= 0 {
+				m.scrollToFile(m.tree[idx].file)
+			}
+		}
+		return nil
+	}
+
+	if row := m.rowAtDisplay(m.scrollPos + y); row >= 0 && y < m.contentHeight() {
+		if m.reviewMode {
+			m.cursor = row
+			return nil
+		}
+		switch m.rows[row].kind { //nolint:exhaustive // Other rows start a text selection
+		case diffRowFile:
+			fi := m.rows[row].file
+			m.collapsed[fi] = !m.collapsed[fi]
+			m.rebuildRows()
+			m.scrollToFile(fi)
+			return nil
+		case diffRowGap:
+			return m.expandGap(diffGapKey{m.rows[row].file, m.rows[row].hunk})
+		}
+	}
+
+	// Start text selection (diff-relative coordinates)
+	m.selecting = true
+	m.hasSelection = true
+	m.selectStartY = y
+	m.selectStartX = x - m.diffX()
+	m.selectEndY = y
+	m.selectEndX = x - m.diffX()
+	return nil
+}
+
+// expandVisibleGap expands the first context gap on screen.
+func (m *DiffViewer) expandVisibleGap() tea.Cmd {
+	for i := m.scrollPos; i < m.scrollPos+m.contentHeight(); i++ {
+		row := m.rowAtDisplay(i)
+		if row < 0 {
+			break
+		}
+		if r := m.rows[row]; r.kind == diffRowGap {
+			return m.expandGap(diffGapKey{r.file, r.hunk})
+		}
+	}
+	m.notice = "No hidden context on screen"
+	return nil
+}
+
+// expandGap reveals more unchanged lines of a gap, loading the file first if needed.
+func (m *DiffViewer) expandGap(key diffGapKey) tea.Cmd {
+	if _, ok := m.contents[key.file]; ok {
+		m.expanded[key] += diffContextExpandStep
+		m.rebuildRows()
+		return nil
+	}
+	path := m.files[key.file].path()
+	workDir := m.workDir
+	return func() tea.Msg {
+		// The diff compares main...HEAD, so the new side is the committed HEAD version
+		cmd := exec.Command("git", "show", "HEAD:"+path) //nolint:gosec // G204: path comes from git diff
+		cmd.Dir = workDir
+		output, err := cmd.Output()
+		if err != nil {
+			return diffContentMsg{gap: key, err: err}
+		}
+		lines := strings.Split(strings.TrimSuffix(string(output), "\n"), "\n")
+		return diffContentMsg{gap: key, lines: lines}
+	}
+}
+
+// treeOffset returns the first visible file tree row, keeping the current file in view.
+func (m *DiffViewer) treeOffset() int {
+	height := m.contentHeight()
+	if len(m.tree) <= height {
+		return 0
+	}
+	current := m.currentFile()
+	idx := 0
+	for i, entry := range m.tree {
+		if entry.file == current {
+			idx = i
+			break
+		}
+	}
+	return min(max(0, idx-height/2), len(m.tree)-height)
+}
+
+// renderTreeLine renders row y of the file tree sidebar.
+func (m *DiffViewer) renderTreeLine(y, width int) string {
+	idx := m.treeOffset() + y
+	if idx >= len(m.tree) {
+		return getPadding(width)
+	}
+	entry := m.tree[idx]
+	return m.renderTreeEntry(entry, width, entry.file >= 0 && entry.file == m.currentFile())
+}
+
 // View renders the diff viewer.
 func (m *DiffViewer) View() tea.View {
 	if m.err != nil {
@@ -398,6 +817,13 @@ func (m *DiffViewer) View() tea.View {
 	contentHeight := m.contentHeight()
 	endPos := m.scrollPos + contentHeight
 
+	width := m.diffWidth()
+	treeWidth := m.treeWidth()
+	var treeBorder string
+	if treeWidth > 0 {
+		treeBorder = lipgloss.NewStyle().Foreground(m.colors.BorderDim).Render("│")
+	}
+
 	// Pre-allocate builder: ~(width + newline) * contentHeight + status bar
 	var sb strings.Builder
 	sb.Grow((m.width + 1) * (contentHeight + 1))
@@ -410,17 +836,17 @@ func (m *DiffViewer) View() tea.View {
 	// Update style cache if needed (only on theme change)
 	if !m.stylesCached {
 		m.styleHighlight = lipgloss.NewStyle().
-			Background(lipgloss.Color("25")).
-			Foreground(lipgloss.Color("255"))
+			Background(themeColor("selection", lipgloss.Color("25"))).
+			Foreground(themeColor("text_bright", lipgloss.Color("255")))
 		m.styleStatus = lipgloss.NewStyle().
 			Background(c.StatusBar).
 			Foreground(c.StatusBarText)
 		m.styleMatchCurrent = lipgloss.NewStyle().
-			Background(lipgloss.Color("208")). // Orange for current match
-			Foreground(lipgloss.Color("0"))    // Black text
+			Background(themeColor("search_current", lipgloss.Color("208"))). // Orange for current match
+			Foreground(lipgloss.Color("0"))                                  // Black text
 		m.styleMatchOther = lipgloss.NewStyle().
-			Background(lipgloss.Color("226")). // Yellow for other matches
-			Foreground(lipgloss.Color("0"))    // Black text
+			Background(themeColor("search_match", lipgloss.Color("226"))). // Yellow for other matches
+			Foreground(lipgloss.Color("0"))                                // Black text
 		m.stylesCached = true
 	}
 
@@ -442,9 +868,9 @@ func (m *DiffViewer) View() tea.View {
 		}
 
 		// Truncate to screen width (ANSI-aware)
-		if lineWidth > m.width {
-			line = ansi.Cut(line, 0, m.width)
-			lineWidth = m.width
+		if lineWidth > width {
+			line = ansi.Cut(line, 0, width)
+			lineWidth = width
 		}
 
 		// Apply search highlighting before padding
@@ -455,8 +881,13 @@ func (m *DiffViewer) View() tea.View {
 		}
 
 		// Pad to full width (accounting for visual width)
-		if lineWidth < m.width {
-			line += getPadding(m.width - lineWidth)
+		if lineWidth < width {
+			line += getPadding(width - lineWidth)
+		}
+
+		// Review cursor marker in the first column
+		if m.reviewMode && m.rowAtDisplay(i) == m.cursor && width > 0 {
+			line = lipgloss.NewStyle().Foreground(m.colors.Accent).Bold(true).Render("▶") + ansi.Cut(line, 1, width)
 		}
 
 		// Apply selection highlighting if this line is in selection
@@ -464,13 +895,21 @@ func (m *DiffViewer) View() tea.View {
 			line = m.applySelectionToLine(line, screenY, m.styleHighlight)
 		}
 
+		if treeWidth > 0 {
+			sb.WriteString(m.renderTreeLine(screenY, treeWidth))
+			sb.WriteString(treeBorder)
+		}
 		sb.WriteString(line)
 		sb.WriteString("\n")
 	}
 
 	// Pad remaining lines (use cached padding for common widths)
-	emptyLine := getPadding(m.width)
+	emptyLine := getPadding(width)
 	for i := endPos - m.scrollPos; i < contentHeight; i++ {
+		if treeWidth > 0 {
+			sb.WriteString(m.renderTreeLine(i, treeWidth))
+			sb.WriteString(treeBorder)
+		}
 		sb.WriteString(emptyLine)
 		sb.WriteString("\n")
 	}
@@ -487,11 +926,34 @@ func (m *DiffViewer) View() tea.View {
 		return v
 	}
 
+	// Comment input mode: show the comment input instead of status
+	if m.commenting {
+		prompt := " Comment on " + m.commentAnchor.Location() + ": "
+		bar := prompt + m.commentInput.View()
+		if w := ansi.StringWidth(bar); w < m.width {
+			bar += getPadding(m.width - w)
+		}
+		sb.WriteString(m.styleStatus.Render(ansi.Truncate(bar, m.width, "")))
+		v := tea.NewView(sb.String())
+		v.AltScreen = true
+		v.MouseMode = tea.MouseModeAllMotion
+		return v
+	}
+
 	var status string
 	status = " [DIFF " + m.mainBranch + "...HEAD]"
+	if m.sinceReview {
+		status = " [SINCE REVIEW " + shortHash(m.reviewState.Snapshot) + "..HEAD]"
+	}
+	if m.splitView {
+		status += " [SPLIT]"
+	}
 	if m.wordWrap {
 		status += " [WRAP]"
 	}
+	if m.reviewMode {
+		status += " [REVIEW " + strconv.Itoa(len(m.reviewState.Drafts())) + " drafts]"
+	}
 	// Show search info
 	if m.searchQuery != "" {
 		if len(m.searchMatches) > 0 {
@@ -502,6 +964,14 @@ func (m *DiffViewer) View() tea.View {
 	}
 	status += " "
 
+	if m.notice != "" {
+		status += m.notice + " "
+	} else if fi := m.currentFile(); fi >= 0 {
+		status += "File " + strconv.Itoa(fi+1) + "/" + strconv.Itoa(len(m.files)) + " "
+		if m.review != nil && len(m.blobs) > 0 {
+			status += "Viewed " + strconv.Itoa(len(m.blobs)-m.reviewState.Unreviewed(m.blobs)) + "/" + strconv.Itoa(len(m.blobs)) + " "
+		}
+	}
 	if len(displayLines) > 0 {
 		status += "Lines " + strconv.Itoa(m.scrollPos+1) + "-" + strconv.Itoa(endPos) + " of " + strconv.Itoa(len(displayLines)) + " "
 	} else {
@@ -510,11 +980,15 @@ func (m *DiffViewer) View() tea.View {
 
 	// Keybindings hint (use pre-computed widths to avoid ansi.StringWidth on each render)
 	statusWidth := ansi.StringWidth(status)
-	hint := diffViewerHintFull
-	padding := m.width - statusWidth - diffViewerHintFullWidth
+	full := m.hintFull
+	if m.reviewMode {
+		full = m.hintReview
+	}
+	hint := full.text
+	padding := m.width - statusWidth - full.width
 	if padding < 0 {
-		hint = diffViewerHintShort
-		padding = m.width - statusWidth - diffViewerHintShortWidth
+		hint = m.hintShort.text
+		padding = m.width - statusWidth - m.hintShort.width
 		if padding < 0 {
 			padding = 0
 		}
@@ -738,13 +1212,13 @@ func (m *DiffViewer) getSelectionXRange(screenY int) (int, int) {
 	switch screenY {
 	case minY:
 		// First row: from startX to end of line
-		return startX, m.width
+		return startX, m.diffWidth()
 	case maxY:
 		// Last row: from start to endX
 		return 0, endX
 	default:
 		// Middle rows: full line
-		return 0, m.width
+		return 0, m.diffWidth()
 	}
 }
 
@@ -787,6 +1261,7 @@ func (m *DiffViewer) copySelection() {
 	}
 
 	displayLines := m.getDisplayLines()
+	width := m.diffWidth()
 	minY, maxY, _, _ := m.getSelectionRange()
 
 	var selectedLines []string
@@ -811,22 +1286,22 @@ func (m *DiffViewer) copySelection() {
 		}
 
 		// Truncate to screen width
-		if lineWidth > m.width {
-			line = ansi.Cut(line, 0, m.width)
-			lineWidth = m.width
+		if lineWidth > width {
+			line = ansi.Cut(line, 0, width)
+			lineWidth = width
 		}
 
 		// Pad for consistent width
-		if lineWidth < m.width {
-			line += getPadding(m.width - lineWidth)
+		if lineWidth < width {
+			line += getPadding(width - lineWidth)
 		}
 
 		startX, endX := m.getSelectionXRange(screenY)
 		if startX < 0 || startX >= endX {
 			continue
 		}
-		if endX > m.width {
-			endX = m.width
+		if endX > width {
+			endX = width
 		}
 
 		// Extract selected portion
@@ -843,33 +1318,43 @@ func (m *DiffViewer) copySelection() {
 
 // loadDiffOutput loads git diff output.
 func (m *DiffViewer) loadDiffOutput() tea.Cmd {
+	workDir, mainBranch := m.workDir, m.mainBranch
+	// git diff main...HEAD shows changes on the current branch since it diverged from main
+	args := []string{"diff", "--no-color", "--no-ext-diff", mainBranch + "...HEAD"}
+	if m.sinceReview {
+		args = []string{"diff", "--no-color", "--no-ext-diff", m.snapshotRef(), "HEAD"}
+	}
+	track := m.review != nil
 	return func() tea.Msg {
-		// git diff main...HEAD shows changes on the current branch since it diverged from main
-		cmd := exec.Command("git", "diff", "--color=always", m.mainBranch+"...HEAD") //nolint:gosec // G204: mainBranch comes from git
-		cmd.Dir = m.workDir
+		cmd := exec.Command("git", args...) //nolint:gosec // G204: refs come from git and the review state
+		cmd.Dir = workDir
 		output, err := cmd.CombinedOutput()
 		if err != nil {
 			// Check if it's just an empty diff (which is not an error)
 			if len(output) == 0 {
-				return diffOutputMsg{lines: []string{}}
+				return diffOutputMsg{}
 			}
 			return fmt.Errorf("git diff failed: %w\nOutput: %s", err, string(output))
 		}
 
-		// Split output into lines
-		lines := strings.Split(string(output), "\n")
-		// Remove last empty line if present
-		if len(lines) > 0 && lines[len(lines)-1] == "" {
-			lines = lines[:len(lines)-1]
+		msg := diffOutputMsg{files: parseUnifiedDiff(string(output))}
+		if track {
+			// Viewed marks always compare against the full diff's blobs
+			if msg.blobs, err = git.New().ChangedBlobs(workDir, mainBranch, "HEAD"); err != nil {
+				logging.Debug("diff viewer: failed to list changed blobs: %v", err)
+			}
 		}
-
-		return diffOutputMsg{lines: lines}
+		return msg
 	}
 }
 
 // RunDiffViewer runs the diff viewer for the given working directory.
-func RunDiffViewer(workDir, mainBranch string) error {
+// With a review target, lines can be commented on and reviews sent to the task's agent.
+func RunDiffViewer(workDir, mainBranch string, review *DiffReviewTarget) error {
 	m := NewDiffViewer(workDir, mainBranch)
+	if review != nil {
+		m.setReviewTarget(review)
+	}
 	p := tea.NewProgram(m)
 	_, err := p.Run()
 	return err
@@ -0,0 +1,43 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/dongho-jung/paw/internal/keymap"
+)
+
+func TestDiffViewerKeysFollowKeymap(t *testing.T) {
+	km, _ := keymap.New(map[string]string{"close": "Q", "search": "?"})
+	SetKeymap(km)
+	t.Cleanup(func() { SetKeymap(keymap.Default()) })
+
+	m := NewDiffViewer("", "main")
+	if m.hintShort.text != "Q:close" {
+		t.Errorf("short hint = %q, want Q:close", m.hintShort.text)
+	}
+	if !strings.HasPrefix(m.hintFull.text, "?:search") {
+		t.Errorf("full hint = %q, want the remapped search key", m.hintFull.text)
+	}
+	if w := ansi.StringWidth(m.hintFull.text); w != m.hintFull.width {
+		t.Errorf("hintFull.width = %d, want %d", m.hintFull.width, w)
+	}
+
+	if _, cmd := m.handleKey(keyPress("q")); cmd != nil {
+		t.Error("q should no longer close the viewer")
+	}
+	if _, cmd := m.handleKey(keyPress("Q")); cmd == nil {
+		t.Error("Q should close the viewer")
+	}
+
+	m.handleKey(keyPress("/"))
+	if m.searchMode {
+		t.Error("/ should no longer start a search")
+	}
+	m.handleKey(keyPress("?"))
+	if !m.searchMode {
+		t.Error("? should start a search")
+	}
+}
@@ -100,7 +100,7 @@ func (m *EndTaskUI) Init() tea.Cmd {
 func (m *EndTaskUI) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.BackgroundColorMsg:
-		m.isDark = msg.IsDark()
+		m.isDark = themeIsDark(msg.IsDark())
 		m.colors = NewThemeColors(m.isDark)
 		m.stylesCached = false // Invalidate style cache on theme change
 		setCachedDarkMode(m.isDark)
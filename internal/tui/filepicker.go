@@ -1,15 +1,21 @@
 package tui
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/v2/textinput"
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/sahilm/fuzzy"
+
+	"github.com/dongho-jung/paw/internal/keymap"
 )
 
 // FilePickerAction represents the selected action.
@@ -23,25 +29,41 @@ const (
 
 // FileEntry represents a file or directory.
 type FileEntry struct {
-	Name  string
-	Path  string
-	IsDir bool
+	Name   string
+	Path   string
+	IsDir  bool
+	Line   int  // Definition line of a symbol (symbol search only)
+	Recent bool // Recently referenced file (listed first in the root directory)
+}
+
+// reference returns the reference inserted for the entry.
+func (e FileEntry) reference() FileReference {
+	return FileReference{Path: e.Path, StartLine: e.Line, EndLine: e.Line}
 }
 
 // FilePicker is a fuzzy-searchable file picker with directory navigation.
+// Typing searches all files of the project (gitignore-aware), "#" searches
+// symbols, Tab marks several files, and the preview selects line ranges.
 type FilePicker struct {
 	input            textinput.Model
+	helpList         string // Help line of the file list, with the keymap's keys
+	helpPreview      string // Help line while selecting preview lines
 	inputOffset      int
 	inputOffsetRight int
-	rootDir          string        // Root directory (project dir)
-	currentDir       string        // Current directory being browsed
-	entries          []FileEntry   // Files/dirs in current directory
-	searchEntries    []FileEntry   // All files including subdirectories (for search)
-	filtered         []int         // Indices into entries/searchEntries for filtered results
-	useSearchEntries bool          // Whether filtered indices refer to searchEntries
-	cursor           int           // Current selection
+	rootDir          string          // Root directory (project dir)
+	currentDir       string          // Current directory being browsed
+	entries          []FileEntry     // Files/dirs in current directory
+	searchEntries    []FileEntry     // All files including subdirectories (for search)
+	symbols          []FileEntry     // Symbol definitions (indexed on the first "#" query)
+	symbolsLoaded    bool            // Whether symbols were indexed
+	filtered         []int           // Indices into entries/searchEntries/symbols for filtered results
+	useSearchEntries bool            // Whether filtered indices refer to searchEntries
+	useSymbols       bool            // Whether filtered indices refer to symbols
+	cursor           int             // Current selection
+	recentRank       map[string]int  // Recently referenced file path → recency (0 = most recent)
+	marked           []FileReference // References marked with Tab, in order
 	action           FilePickerAction
-	selected         string        // Selected file path
+	selected         []FileReference // Chosen references
 	isDark           bool
 	colors           ThemeColors
 	width            int
@@ -51,10 +73,18 @@ type FilePicker struct {
 	previewPath    string   // Path of currently previewed file
 	previewContent []string // Cached preview lines
 	previewOffset  int      // Preview scroll offset
+	previewLine    int      // Symbol line the preview was opened at
+	previewText    bool     // Whether the preview shows file text (not a notice)
+	highlighter    *diffHighlighter
+
+	// Line range selection in the preview (→ on a file)
+	previewFocus bool
+	lineCursor   int // 0-based line under the cursor
+	lineAnchor   int // 0-based first line of the range, -1 if none
 
 	// Layout cache (for mouse click handling)
-	listStartY  int // Y position where file list starts
-	listEndY    int // Y position where file list ends
+	listStartY   int // Y position where file list starts
+	listEndY     int // Y position where file list ends
 	listStartIdx int // First visible item index in filtered
 
 	// Style cache (reused across renders)
@@ -71,12 +101,13 @@ type FilePicker struct {
 }
 
 // NewFilePicker creates a new file picker starting at the given directory.
-func NewFilePicker(startDir string) *FilePicker {
+// recent lists recently referenced files relative to startDir, most recent first.
+func NewFilePicker(startDir string, recent []string) *FilePicker {
 	isDark := DetectDarkMode()
 
 	ti := textinput.New()
 	ti.Prompt = ""
-	ti.Placeholder = "Type to search..."
+	ti.Placeholder = "Type to search files, # for symbols..."
 	ti.Focus()
 	ti.CharLimit = 100
 	ti.SetWidth(50)
@@ -91,6 +122,20 @@ func NewFilePicker(startDir string) *FilePicker {
 		colors:     NewThemeColors(isDark),
 		width:      70,
 		height:     20,
+		recentRank: make(map[string]int, len(recent)),
+		lineAnchor: -1,
+	}
+	up, down := inputKeyLabel(keymap.ActionUp, "⌃P"), inputKeyLabel(keymap.ActionDown, "⌃N")
+	fp.helpList = fmt.Sprintf("%s/%s: Select  Tab: Mark  %s: Lines  Enter: Attach  Esc: Cancel",
+		up, down, inputKeyLabel(keymap.ActionRight, "⌃L"))
+	fp.helpPreview = fmt.Sprintf("%s/%s: Line  Space: Range  Tab: Mark  Enter: Attach  %s: Back",
+		up, down, inputKeyLabel(keymap.ActionLeft, "⌃H"))
+	fp.highlighter = newDiffHighlighter(isDark)
+	for i, rel := range recent {
+		path := filepath.Join(startDir, rel)
+		if _, ok := fp.recentRank[path]; !ok {
+			fp.recentRank[path] = i
+		}
 	}
 
 	fp.loadDirectory()
@@ -134,8 +179,9 @@ func (m *FilePicker) loadDirectory() {
 	sort.Slice(dirs, func(i, j int) bool { return dirs[i].Name < dirs[j].Name })
 	sort.Slice(files, func(i, j int) bool { return files[i].Name < files[j].Name })
 
-	// Dirs first, then files
-	m.entries = append(dirs, files...)
+	// Recently referenced files first (project root only), then dirs, then files
+	m.entries = append(m.recentEntries(), dirs...)
+	m.entries = append(m.entries, files...)
 
 	// Initialize filtered to all
 	m.filtered = make([]int, len(m.entries))
@@ -144,75 +190,57 @@ func (m *FilePicker) loadDirectory() {
 	}
 }
 
-// collectRecursiveFiles collects all files from current directory and subdirectories.
-func (m *FilePicker) collectRecursiveFiles() {
-	if len(m.searchEntries) > 0 {
-		return // Already collected
-	}
-
-	var currentFiles, subFiles []FileEntry
-
-	// First, add current directory files (non-directories)
-	for _, e := range m.entries {
-		if !e.IsDir {
-			currentFiles = append(currentFiles, e)
-		}
+// recentEntries returns the recently referenced files that still exist, shown
+// at the top of the project root.
+func (m *FilePicker) recentEntries() []FileEntry {
+	if m.currentDir != m.rootDir || len(m.recentRank) == 0 {
+		return nil
 	}
-
-	// Then, walk subdirectories
-	err := filepath.WalkDir(m.currentDir, func(path string, d os.DirEntry, err error) error {
-		if err != nil {
-			return nil // Skip errors
-		}
-
-		// Skip the root directory itself
-		if path == m.currentDir {
-			return nil
-		}
-
-		name := d.Name()
-
-		// Skip hidden files and directories
-		if strings.HasPrefix(name, ".") {
-			if d.IsDir() {
-				return filepath.SkipDir
-			}
-			return nil
-		}
-
-		// Skip directories in results (we only want files)
-		if d.IsDir() {
-			return nil
+	entries := make([]FileEntry, 0, len(m.recentRank))
+	for path := range m.recentRank {
+		if info, err := os.Stat(path); err != nil || info.IsDir() {
+			continue
 		}
-
-		// Get relative path from current directory
-		relPath, err := filepath.Rel(m.currentDir, path)
+		rel, err := filepath.Rel(m.rootDir, path)
 		if err != nil {
-			return nil
+			continue
 		}
+		entries = append(entries, FileEntry{Name: rel, Path: path, Recent: true})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return m.recentRank[entries[i].Path] < m.recentRank[entries[j].Path]
+	})
+	return entries
+}
 
-		// Skip files in current directory (already added)
-		if !strings.Contains(relPath, string(filepath.Separator)) {
-			return nil
-		}
+// collectRecursiveFiles collects all files from current directory and subdirectories.
+func (m *FilePicker) collectRecursiveFiles() {
+	if m.searchEntries != nil {
+		return // Already collected
+	}
 
-		subFiles = append(subFiles, FileEntry{
-			Name:  relPath, // Show relative path as name
-			Path:  path,
-			IsDir: false,
+	files := listProjectFiles(m.currentDir)
+	m.searchEntries = make([]FileEntry, 0, len(files))
+	for _, rel := range files {
+		m.searchEntries = append(m.searchEntries, FileEntry{
+			Name: rel, // Show relative path as name
+			Path: filepath.Join(m.currentDir, rel),
 		})
+	}
+}
 
-		return nil
-	})
-	if err != nil {
+// collectProjectSymbols indexes the symbols of the project once.
+func (m *FilePicker) collectProjectSymbols() {
+	if m.symbolsLoaded {
 		return
 	}
-
-	// Sort subFiles by name
-	sort.Slice(subFiles, func(i, j int) bool { return subFiles[i].Name < subFiles[j].Name })
-
-	// Current directory files first, then subdirectory files
-	m.searchEntries = append(currentFiles, subFiles...)
+	// Symbols always cover the whole project
+	var files []FileEntry
+	for _, rel := range listProjectFiles(m.rootDir) {
+		files = append(files, FileEntry{Name: rel, Path: filepath.Join(m.rootDir, rel)})
+	}
+	m.symbols = collectSymbols(files)
+	m.symbolsLoaded = true
 }
 
 // Init initializes the file picker.
@@ -237,14 +265,23 @@ func (m *FilePicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case tea.BackgroundColorMsg:
-		m.isDark = msg.IsDark()
+		m.isDark = themeIsDark(msg.IsDark())
 		m.colors = NewThemeColors(m.isDark)
+		m.highlighter = newDiffHighlighter(m.isDark)
 		m.stylesCached = false
 		setCachedDarkMode(m.isDark)
 		return m, nil
 
 	case tea.KeyMsg:
-		switch msg.String() {
+		if m.previewFocus {
+			return m.updatePreviewKeys(msg)
+		}
+		// Letters type into the query, so only other keys follow the keymap
+		if !typesText(msg) && keyMatches(msg, keymap.ActionClose) {
+			m.action = FilePickerCancel
+			return m, tea.Quit
+		}
+		switch translateInputKey(msg, keymap.ScopeNav, keymap.ScopeViewer) {
 		case "ctrl+c", "esc":
 			m.action = FilePickerCancel
 			return m, tea.Quit
@@ -260,10 +297,24 @@ func (m *FilePicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.loadDirectory()
 					return m, nil
 				}
-				// Select file
-				m.selected = entry.Path
-				m.action = FilePickerSelect
-				return m, tea.Quit
+				// Select file (with the marked ones)
+				return m, m.finish(entry.reference())
+			}
+			if len(m.marked) > 0 {
+				return m, m.finish()
+			}
+			return m, nil
+
+		case "tab":
+			// Mark the file for attaching several at once
+			if len(m.filtered) > 0 && m.cursor < len(m.filtered) {
+				entry := m.getActiveEntries()[m.filtered[m.cursor]]
+				if !entry.IsDir {
+					m.toggleMark(entry.reference())
+					if m.cursor < len(m.filtered)-1 {
+						m.cursor++
+					}
+				}
 			}
 			return m, nil
 
@@ -290,7 +341,7 @@ func (m *FilePicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 
 		case "right", "ctrl+l":
-			// Enter selected directory
+			// Enter selected directory, or select lines in the file preview
 			if len(m.filtered) > 0 && m.cursor < len(m.filtered) {
 				activeEntries := m.getActiveEntries()
 				entry := activeEntries[m.filtered[m.cursor]]
@@ -298,6 +349,8 @@ func (m *FilePicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.currentDir = entry.Path
 					m.input.SetValue("")
 					m.loadDirectory()
+				} else {
+					m.focusPreview()
 				}
 			}
 			return m, nil
@@ -362,9 +415,7 @@ func (m *FilePicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 							m.input.SetValue("")
 							m.loadDirectory()
 						} else {
-							m.selected = entry.Path
-							m.action = FilePickerSelect
-							return m, tea.Quit
+							return m, m.finish(entry.reference())
 						}
 					} else {
 						m.cursor = clickedIdx
@@ -387,9 +438,107 @@ func (m *FilePicker) syncInputOffset() {
 	syncTextInputOffset([]rune(m.input.Value()), m.input.Position(), m.input.Width(), &m.inputOffset, &m.inputOffsetRight)
 }
 
+// toggleMark marks or unmarks a reference for attaching.
+func (m *FilePicker) toggleMark(ref FileReference) {
+	if idx := slices.Index(m.marked, ref); idx >= 0 {
+		m.marked = slices.Delete(m.marked, idx, idx+1)
+		return
+	}
+	m.marked = append(m.marked, ref)
+}
+
+// isMarked reports whether a file has a marked reference (whole or lines).
+func (m *FilePicker) isMarked(path string) bool {
+	for _, ref := range m.marked {
+		if ref.Path == path {
+			return true
+		}
+	}
+	return false
+}
+
+// finish selects the marked references plus refs and closes the picker.
+func (m *FilePicker) finish(refs ...FileReference) tea.Cmd {
+	m.selected = slices.Clone(m.marked)
+	for _, ref := range refs {
+		if !slices.Contains(m.selected, ref) {
+			m.selected = append(m.selected, ref)
+		}
+	}
+	m.action = FilePickerSelect
+	return tea.Quit
+}
+
+// focusPreview moves the keyboard to the preview of the selected file to
+// select a line range.
+func (m *FilePicker) focusPreview() {
+	m.loadPreview()
+	if !m.previewText {
+		return
+	}
+	m.previewFocus = true
+	m.lineAnchor = -1
+	if m.lineCursor < m.previewOffset {
+		m.lineCursor = m.previewOffset
+	}
+}
+
+// rangeReference returns the reference of the selected preview lines.
+func (m *FilePicker) rangeReference() FileReference {
+	start, end := m.lineCursor, m.lineCursor
+	if m.lineAnchor >= 0 {
+		start, end = min(m.lineAnchor, m.lineCursor), max(m.lineAnchor, m.lineCursor)
+	}
+	return FileReference{Path: m.previewPath, StartLine: start + 1, EndLine: end + 1}
+}
+
+// updatePreviewKeys handles keys while selecting lines in the preview.
+func (m *FilePicker) updatePreviewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if keyMatches(msg, keymap.ActionClose) {
+		m.action = FilePickerCancel
+		return m, tea.Quit
+	}
+	switch translateViewerKey(msg) {
+	case "ctrl+c":
+		m.action = FilePickerCancel
+		return m, tea.Quit
+	case "esc", "left", "ctrl+h":
+		m.previewFocus = false
+		m.lineAnchor = -1
+	case "up", "k", "ctrl+p":
+		m.lineCursor--
+	case "down", "j", "ctrl+n":
+		m.lineCursor++
+	case "pgup":
+		m.lineCursor -= 10
+	case "pgdown":
+		m.lineCursor += 10
+	case "space":
+		// Start a range at the cursor, or clear it
+		if m.lineAnchor >= 0 {
+			m.lineAnchor = -1
+		} else {
+			m.lineAnchor = m.lineCursor
+		}
+	case "tab":
+		m.toggleMark(m.rangeReference())
+		m.previewFocus = false
+		m.lineAnchor = -1
+	case "enter":
+		return m, m.finish(m.rangeReference())
+	}
+	m.lineCursor = max(0, min(m.lineCursor, len(m.previewContent)-1))
+	return m, nil
+}
+
 // updateFiltered filters entries based on input.
 func (m *FilePicker) updateFiltered() {
 	query := m.input.Value()
+	if symbolQuery, ok := strings.CutPrefix(query, "#"); ok {
+		m.updateFilteredSymbols(symbolQuery)
+		return
+	}
+	m.useSymbols = false
 	if query == "" {
 		// No query: show current directory entries only
 		m.useSearchEntries = false
@@ -420,14 +569,47 @@ func (m *FilePicker) updateFiltered() {
 	for i, match := range matches {
 		m.filtered[i] = match.Index
 	}
+	rankRecent(m.searchEntries, m.filtered, m.recentRank)
+
+	if m.cursor >= len(m.filtered) {
+		m.cursor = 0
+	}
+}
+
+// updateFilteredSymbols filters the project symbols ("#" queries).
+func (m *FilePicker) updateFilteredSymbols(query string) {
+	m.collectProjectSymbols()
+	m.useSymbols = true
+	m.useSearchEntries = false
+
+	if query == "" {
+		m.filtered = make([]int, len(m.symbols))
+		for i := range m.symbols {
+			m.filtered[i] = i
+		}
+	} else {
+		searchables := make([]string, 0, len(m.symbols))
+		for _, e := range m.symbols {
+			searchables = append(searchables, e.Name)
+		}
+		matches := fuzzy.Find(query, searchables)
+		m.filtered = make([]int, len(matches))
+		for i, match := range matches {
+			m.filtered[i] = match.Index
+		}
+	}
+	rankRecent(m.symbols, m.filtered, m.recentRank)
 
 	if m.cursor >= len(m.filtered) {
 		m.cursor = 0
 	}
 }
 
-// getActiveEntries returns the entries slice currently being used (entries or searchEntries).
+// getActiveEntries returns the entries slice currently being used (entries, searchEntries or symbols).
 func (m *FilePicker) getActiveEntries() []FileEntry {
+	if m.useSymbols {
+		return m.symbols
+	}
 	if m.useSearchEntries {
 		return m.searchEntries
 	}
@@ -456,6 +638,7 @@ func (m *FilePicker) loadPreview() {
 	if len(m.filtered) == 0 || m.cursor >= len(m.filtered) {
 		m.previewPath = ""
 		m.previewContent = nil
+		m.previewText = false
 		return
 	}
 
@@ -464,21 +647,30 @@ func (m *FilePicker) loadPreview() {
 	if entry.IsDir {
 		m.previewPath = ""
 		m.previewContent = nil
+		m.previewText = false
 		return
 	}
 
 	// Skip if already loaded
+	if m.previewPath == entry.Path && m.previewLine == entry.Line {
+		return
+	}
+
+	// Symbols open the preview at their definition
+	m.previewLine = entry.Line
+	m.lineCursor = max(0, entry.Line-1)
+	m.previewOffset = max(0, m.lineCursor-2)
 	if m.previewPath == entry.Path {
 		return
 	}
 
 	m.previewPath = entry.Path
 	m.previewContent = nil
-	m.previewOffset = 0 // Reset scroll on file change
+	m.previewText = false
 
 	// Check file size first (skip large files)
 	info, err := os.Stat(entry.Path)
-	if err != nil || info.Size() > 100*1024 { // Skip files > 100KB
+	if err != nil || info.Size() > filePickerPreviewLimit {
 		m.previewContent = []string{"(File too large to preview)"}
 		return
 	}
@@ -496,9 +688,13 @@ func (m *FilePicker) loadPreview() {
 		return
 	}
 
-	// Split into lines
-	lines := strings.Split(string(data), "\n")
+	// Split into lines (tabs expanded for the preview width)
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.ReplaceAll(line, "\t", "    ")
+	}
 	m.previewContent = lines
+	m.previewText = true
 }
 
 // isBinaryContent checks if content appears to be binary.
@@ -556,6 +752,9 @@ func (m *FilePicker) View() tea.View {
 	sb.WriteString(m.styleTitle.Render("Select File"))
 	sb.WriteString("  ")
 	sb.WriteString(m.stylePath.Render(m.relativePath()))
+	if len(m.marked) > 0 {
+		sb.WriteString(m.styleSelected.Render(fmt.Sprintf("  ● %d marked", len(m.marked))))
+	}
 	sb.WriteString("\n\n")
 	line += 2
 
@@ -625,6 +824,12 @@ func (m *FilePicker) View() tea.View {
 			if entry.IsDir {
 				name += "/"
 			}
+			switch {
+			case m.isMarked(entry.Path) && !entry.IsDir:
+				name = "● " + name
+			case entry.Recent:
+				name = "★ " + name
+			}
 
 			// Truncate name if too wide (rune-aware), account for scrollbar
 			maxNameWidth := m.width - 4 - scrollbarWidth
@@ -675,6 +880,14 @@ func (m *FilePicker) View() tea.View {
 
 	// Preview section with scroll offset
 	if len(m.previewContent) > 0 {
+		// Keep the line cursor visible while selecting lines
+		if m.previewFocus {
+			if m.lineCursor < m.previewOffset {
+				m.previewOffset = m.lineCursor
+			} else if m.lineCursor >= m.previewOffset+previewHeight {
+				m.previewOffset = m.lineCursor - previewHeight + 1
+			}
+		}
 		// Ensure previewOffset is valid
 		maxOffset := max(0, len(m.previewContent)-previewHeight)
 		if m.previewOffset > maxOffset {
@@ -687,10 +900,7 @@ func (m *FilePicker) View() tea.View {
 				sb.WriteString("\n")
 				continue
 			}
-			previewLine := m.previewContent[lineIdx]
-			// Truncate line if too wide (rune-aware)
-			previewLine = truncateRuneString(previewLine, m.width-1)
-			sb.WriteString(m.stylePreview.Render(previewLine))
+			sb.WriteString(m.renderPreviewLine(lineIdx))
 			sb.WriteString("\n")
 		}
 	} else {
@@ -699,7 +909,11 @@ func (m *FilePicker) View() tea.View {
 	}
 
 	// Help
-	sb.WriteString(m.styleHelp.Render("↑/↓: Select  ←/→: Navigate  Enter: Open/Select  Esc: Cancel"))
+	help := m.helpList
+	if m.previewFocus {
+		help = m.helpPreview
+	}
+	sb.WriteString(m.styleHelp.Render(ansi.Truncate(help, max(1, m.width-1), "…")))
 
 	v := tea.NewView(sb.String())
 	v.AltScreen = true
@@ -714,19 +928,49 @@ func (m *FilePicker) View() tea.View {
 	return v
 }
 
-// Result returns the action and selected file path.
-func (m *FilePicker) Result() (FilePickerAction, string) {
+// renderPreviewLine renders a preview line with its line number, syntax
+// colors and the line range selection.
+func (m *FilePicker) renderPreviewLine(lineIdx int) string {
+	text := m.previewContent[lineIdx]
+	if !m.previewText {
+		// Notice such as "(Binary file)"
+		return m.stylePreview.Render(truncateRuneString(text, m.width-1))
+	}
+
+	gutterWidth := len(strconv.Itoa(len(m.previewContent)))
+	gutter := fmt.Sprintf("%*d ", gutterWidth, lineIdx+1)
+	text = truncateRuneString(text, m.width-1-len(gutter))
+
+	inRange := false
+	if m.previewFocus {
+		ref := m.rangeReference()
+		inRange = lineIdx+1 >= ref.StartLine && lineIdx+1 <= ref.EndLine
+	}
+	if lineIdx == m.lineCursor && m.previewFocus {
+		gutter = fmt.Sprintf("%*d>", gutterWidth, lineIdx+1)
+	}
+	if inRange {
+		gutter = m.styleSelected.Render(gutter)
+	} else {
+		gutter = m.styleDim.Render(gutter)
+	}
+	return gutter + m.highlighter.render(m.previewPath, &diffLine{kind: diffLineContext, text: text})
+}
+
+// Result returns the action and the selected file references.
+func (m *FilePicker) Result() (FilePickerAction, []FileReference) {
 	return m.action, m.selected
 }
 
-// RunFilePicker runs the file picker and returns the selected file path.
-func RunFilePicker(startDir string) (FilePickerAction, string, error) {
-	m := NewFilePicker(startDir)
+// RunFilePicker runs the file picker and returns the selected file references.
+// recent lists recently referenced files relative to startDir, ranked first.
+func RunFilePicker(startDir string, recent []string) (FilePickerAction, []FileReference, error) {
+	m := NewFilePicker(startDir, recent)
 	p := tea.NewProgram(m)
 
 	finalModel, err := p.Run()
 	if err != nil {
-		return FilePickerCancel, "", err
+		return FilePickerCancel, nil, err
 	}
 
 	picker := finalModel.(*FilePicker)
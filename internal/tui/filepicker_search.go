@@ -0,0 +1,139 @@
+package tui
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/dongho-jung/paw/internal/git"
+)
+
+// File picker search limits (symbols are indexed on the first "#" query).
+const (
+	filePickerPreviewLimit = 100 * 1024 // Larger files are not previewed or indexed
+	filePickerSymbolFiles  = 5000       // Files scanned for symbols at most
+	filePickerSymbolLimit  = 20000      // Symbols kept at most
+)
+
+// FileReference is a file, or a line range of it, chosen in the file picker.
+type FileReference struct {
+	Path      string
+	StartLine int // First line (1-based), 0 for the whole file
+	EndLine   int // Last line, equal to StartLine for a single line
+}
+
+// String formats the reference as it is inserted into the task text:
+// "path", "path:12" or "path:10-40".
+func (r FileReference) String() string {
+	switch {
+	case r.StartLine <= 0:
+		return r.Path
+	case r.EndLine <= r.StartLine:
+		return fmt.Sprintf("%s:%d", r.Path, r.StartLine)
+	}
+	return fmt.Sprintf("%s:%d-%d", r.Path, r.StartLine, r.EndLine)
+}
+
+// symbolPattern matches definition lines of common languages (Go, Python,
+// JS/TS, Rust, Ruby, Swift, Kotlin, ...) and captures the defined name.
+var symbolPattern = regexp.MustCompile(`^\s*(?:export\s+)?(?:default\s+)?(?:pub(?:\([^)]*\))?\s+)?(?:async\s+)?(?:func|function|def|class|type|interface|struct|enum|trait|fn|module|protocol)\s+(?:\([^)]*\)\s*)?([A-Za-z_$][\w$]*)`)
+
+// symbolExtensions lists the file types scanned for symbols.
+var symbolExtensions = map[string]bool{
+	".go": true, ".py": true, ".js": true, ".jsx": true, ".ts": true, ".tsx": true,
+	".mjs": true, ".rs": true, ".rb": true, ".swift": true, ".kt": true, ".java": true,
+	".scala": true, ".php": true, ".ex": true, ".exs": true,
+}
+
+// listProjectFiles returns the files under dir, relative to it. In a git
+// repository the list comes from git so ignored files are left out; other
+// directories are walked, skipping hidden files and directories.
+func listProjectFiles(dir string) []string {
+	if files, err := git.New().ListFiles(dir); err == nil && len(files) > 0 {
+		return files
+	}
+
+	var files []string
+	_ = filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || path == dir {
+			return nil // Skip errors
+		}
+		if strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if rel, err := filepath.Rel(dir, path); err == nil {
+			files = append(files, rel)
+		}
+		return nil
+	})
+	sort.Strings(files)
+	return files
+}
+
+// collectSymbols indexes the definitions in the searchable files. Entries are
+// named "Symbol  path:line" so the path can be searched too.
+func collectSymbols(files []FileEntry) []FileEntry {
+	var symbols []FileEntry
+	scanned := 0
+	for _, file := range files {
+		if !symbolExtensions[filepath.Ext(file.Path)] {
+			continue
+		}
+		if scanned >= filePickerSymbolFiles || len(symbols) >= filePickerSymbolLimit {
+			break
+		}
+		scanned++
+
+		info, err := os.Stat(file.Path)
+		if err != nil || info.Size() > filePickerPreviewLimit {
+			continue
+		}
+		data, err := os.ReadFile(file.Path) //nolint:gosec // G304: file.Path is from the project file list
+		if err != nil || isBinaryContent(data) {
+			continue
+		}
+
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		scanner.Buffer(make([]byte, 0, 64*1024), filePickerPreviewLimit)
+		for line := 1; scanner.Scan(); line++ {
+			match := symbolPattern.FindSubmatch(scanner.Bytes())
+			if match == nil {
+				continue
+			}
+			symbols = append(symbols, FileEntry{
+				Name: fmt.Sprintf("%s  %s:%d", match[1], file.Name, line),
+				Path: file.Path,
+				Line: line,
+			})
+		}
+	}
+	return symbols
+}
+
+// rankRecent moves matches of recently referenced files to the front, most
+// recent first, keeping the order of the other matches.
+func rankRecent(entries []FileEntry, matches []int, recentRank map[string]int) {
+	if len(recentRank) == 0 {
+		return
+	}
+	rank := func(idx int) int {
+		if r, ok := recentRank[entries[idx].Path]; ok {
+			return r
+		}
+		return len(recentRank)
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return rank(matches[i]) < rank(matches[j])
+	})
+}
@@ -0,0 +1,170 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/dongho-jung/paw/internal/keymap"
+)
+
+func writePickerFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func typeInto(m *FilePicker, s string) {
+	for _, r := range s {
+		m.Update(keyPress(string(r)))
+	}
+}
+
+func TestFileReferenceString(t *testing.T) {
+	tests := []struct {
+		ref  FileReference
+		want string
+	}{
+		{FileReference{Path: "a.go"}, "a.go"},
+		{FileReference{Path: "a.go", StartLine: 12, EndLine: 12}, "a.go:12"},
+		{FileReference{Path: "a.go", StartLine: 10, EndLine: 40}, "a.go:10-40"},
+	}
+	for _, tt := range tests {
+		if got := tt.ref.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestFilePickerSearchRanksRecent(t *testing.T) {
+	dir := writePickerFiles(t, map[string]string{
+		"alpha.go":          "package a\n",
+		"sub/alpha_test.go": "package a\n",
+		".hidden/alpha.go":  "package a\n",
+	})
+	m := NewFilePicker(dir, []string{"sub/alpha_test.go"})
+
+	// The project root lists recent files first
+	if len(m.entries) == 0 || !m.entries[0].Recent || m.entries[0].Name != "sub/alpha_test.go" {
+		t.Fatalf("root entries = %+v, want the recent file first", m.entries)
+	}
+
+	typeInto(m, "alpha")
+	var names []string
+	for _, idx := range m.filtered {
+		names = append(names, m.searchEntries[idx].Name)
+	}
+	if want := []string{"sub/alpha_test.go", "alpha.go"}; !slices.Equal(names, want) {
+		t.Errorf("matches = %v, want %v", names, want)
+	}
+}
+
+func TestFilePickerMarkAndLineRange(t *testing.T) {
+	dir := writePickerFiles(t, map[string]string{
+		"a.txt": "one\n",
+		"b.txt": "1\n2\n3\n4\n5\n",
+	})
+	m := NewFilePicker(dir, nil)
+
+	m.Update(keyPress("tab")) // Mark a.txt, move to b.txt
+	m.Update(keyPress("right"))
+	if !m.previewFocus {
+		t.Fatal("→ on a file should focus the preview")
+	}
+	m.Update(keyPress("down"))
+	m.Update(keyPress("space"))
+	m.Update(keyPress("down"))
+	m.Update(keyPress("down"))
+	m.View()
+	if _, cmd := m.Update(keyPress("enter")); cmd == nil {
+		t.Fatal("enter should close the picker")
+	}
+
+	action, refs := m.Result()
+	var got []string
+	for _, ref := range refs {
+		rel, _ := filepath.Rel(dir, ref.Path)
+		got = append(got, FileReference{Path: rel, StartLine: ref.StartLine, EndLine: ref.EndLine}.String())
+	}
+	if want := []string{"a.txt", "b.txt:2-4"}; action != FilePickerSelect || !slices.Equal(got, want) {
+		t.Errorf("Result() = %v, %v; want %v", action, got, want)
+	}
+}
+
+func TestFilePickerSymbols(t *testing.T) {
+	dir := writePickerFiles(t, map[string]string{
+		"greet.go": "package greet\n\nfunc (g *Greeter) SayHello() string {\n\treturn \"hi\"\n}\n",
+		"app.py":   "class HelloApp:\n    pass\n",
+		"notes.md": "func SayHello is documented here\n",
+	})
+	m := NewFilePicker(dir, nil)
+
+	typeInto(m, "#SayHello")
+	if !m.useSymbols || len(m.filtered) != 1 {
+		t.Fatalf("symbol matches = %d, want 1", len(m.filtered))
+	}
+	m.Update(keyPress("enter"))
+	_, refs := m.Result()
+	if len(refs) != 1 || filepath.Base(refs[0].Path) != "greet.go" || refs[0].StartLine != 3 {
+		t.Errorf("Result() = %+v, want greet.go:3", refs)
+	}
+}
+
+func TestFilePickerKeysFollowKeymap(t *testing.T) {
+	km, err := keymap.New(map[string]string{"up": "k,ctrl+w", "down": "j,ctrl+x", "close": "Q"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetKeymap(km)
+	t.Cleanup(func() { SetKeymap(keymap.Default()) })
+
+	dir := writePickerFiles(t, map[string]string{"a.txt": "1\n2\n", "b.txt": "1\n"})
+	m := NewFilePicker(dir, nil)
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+
+	if view := ansi.Strip(fmt.Sprint(m.View().Layer)); !strings.Contains(view, "⌃W/⌃X: Select") {
+		t.Errorf("help should show the remapped keys that don't type text:\n%s", view)
+	}
+	m.Update(keyPress("ctrl+x"))
+	if m.cursor != 1 {
+		t.Errorf("cursor = %d, want ctrl+x to move down", m.cursor)
+	}
+	m.Update(keyPress("ctrl+w"))
+	if m.cursor != 0 {
+		t.Errorf("cursor = %d, want ctrl+w to move up", m.cursor)
+	}
+
+	// Letters bound to navigation still type into the query
+	typeInto(m, "j")
+	if m.input.Value() != "j" {
+		t.Errorf("query = %q, want j to be typed", m.input.Value())
+	}
+	m.input.SetValue("")
+	m.loadDirectory()
+
+	m.Update(keyPress("right"))
+	if !m.previewFocus {
+		t.Fatal("→ on a file should focus the preview")
+	}
+	if _, cmd := m.Update(keyPress("q")); cmd != nil {
+		t.Error("q should no longer close the picker")
+	}
+	if _, cmd := m.Update(keyPress("Q")); cmd == nil {
+		t.Error("Q should close the picker from the preview")
+	}
+}
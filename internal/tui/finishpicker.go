@@ -6,6 +6,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/charmbracelet/lipgloss/v2"
 
+	"github.com/dongho-jung/paw/internal/keymap"
 	"github.com/dongho-jung/paw/internal/logging"
 )
 
@@ -14,14 +15,15 @@ type FinishAction string
 
 // Finish action options.
 const (
-	FinishActionCancel     FinishAction = "cancel"
-	FinishActionMergePush  FinishAction = "merge-push"
-	FinishActionMerge      FinishAction = "merge"
-	FinishActionPR         FinishAction = "pr"
-	FinishActionKeep       FinishAction = "keep"
-	FinishActionDone       FinishAction = "done"
-	FinishActionDrop       FinishAction = "drop"
-	FinishActionCreateMain FinishAction = "create-main" // Create main branch and merge
+	FinishActionCancel       FinishAction = "cancel"
+	FinishActionMergePush    FinishAction = "merge-push"
+	FinishActionMerge        FinishAction = "merge"
+	FinishActionPR           FinishAction = "pr"
+	FinishActionKeep         FinishAction = "keep"
+	FinishActionDone         FinishAction = "done"
+	FinishActionDrop         FinishAction = "drop"
+	FinishActionCreateMain   FinishAction = "create-main"   // Create main branch and merge
+	FinishActionPushUpstream FinishAction = "push-upstream" // Push to the existing branch/PR
 )
 
 // FinishOption represents an option in the finish picker.
@@ -72,6 +74,20 @@ func gitOptionsNoRemote() []FinishOption {
 	}
 }
 
+// upstreamOptions returns the options for tasks checked out from an existing branch or PR.
+// pushTarget describes where commits are pushed (e.g. "PR #12 (feature/login)").
+// Merge is not offered: the work belongs to the upstream branch, not main.
+func upstreamOptions(pushTarget string) []FinishOption {
+	name := "Push"
+	if strings.HasPrefix(pushTarget, "PR ") {
+		name = "Push to PR"
+	}
+	return []FinishOption{
+		{Action: FinishActionPushUpstream, Name: name, Description: "Push commits to " + pushTarget + " and keep the task"},
+		{Action: FinishActionDrop, Name: "Drop", Description: "Discard all changes and clean up", Warning: true},
+	}
+}
+
 // doneOptions returns the options when there's nothing to merge (non-git or no commits).
 func doneOptions() []FinishOption {
 	return []FinishOption{
@@ -93,8 +109,9 @@ func noMainOptions() []FinishOption {
 // hasCommits: whether there are commits to merge (only relevant if isGitRepo is true)
 // hasRemote: whether the repository has a remote origin
 // hasMainBranch: whether the main branch exists (only relevant if isGitRepo is true)
-func NewFinishPicker(isGitRepo, hasCommits, hasRemote, hasMainBranch bool) *FinishPicker {
-	logging.Debug("-> NewFinishPicker(isGitRepo=%v, hasCommits=%v, hasRemote=%v, hasMainBranch=%v)", isGitRepo, hasCommits, hasRemote, hasMainBranch)
+// pushTarget: existing branch/PR the task was checked out from (empty for regular tasks)
+func NewFinishPicker(isGitRepo, hasCommits, hasRemote, hasMainBranch bool, pushTarget string) *FinishPicker {
+	logging.Debug("-> NewFinishPicker(isGitRepo=%v, hasCommits=%v, hasRemote=%v, hasMainBranch=%v, pushTarget=%q)", isGitRepo, hasCommits, hasRemote, hasMainBranch, pushTarget)
 	defer logging.Debug("<- NewFinishPicker")
 
 	// Detect dark mode BEFORE bubbletea starts
@@ -102,7 +119,9 @@ func NewFinishPicker(isGitRepo, hasCommits, hasRemote, hasMainBranch bool) *Fini
 
 	var options []FinishOption
 	if isGitRepo && hasCommits {
-		if !hasMainBranch {
+		if pushTarget != "" {
+			options = upstreamOptions(pushTarget)
+		} else if !hasMainBranch {
 			// No main branch: offer to create it
 			options = noMainOptions()
 		} else if hasRemote {
@@ -133,7 +152,7 @@ func (m *FinishPicker) Init() tea.Cmd {
 func (m *FinishPicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.BackgroundColorMsg:
-		m.isDark = msg.IsDark()
+		m.isDark = themeIsDark(msg.IsDark())
 		m.colors = NewThemeColors(m.isDark)
 		m.stylesCached = false // Invalidate style cache on theme change
 		setCachedDarkMode(m.isDark)
@@ -142,12 +161,12 @@ func (m *FinishPicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		// Handle confirmation mode
 		if m.confirming {
-			switch msg.String() {
+			switch toggleKey(msg, keymap.ActionFinishTask) {
 			case "y", "Y":
 				// Direct yes selection
 				m.selected = FinishActionDrop
 				return m, tea.Quit
-			case "n", "N", "esc", "ctrl+c", "ctrl+f":
+			case "n", "N", "esc", "ctrl+c":
 				// Direct no selection or cancel
 				m.confirming = false
 				m.confirmCursor = 0
@@ -176,8 +195,8 @@ func (m *FinishPicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		// Normal mode
-		switch msg.String() {
-		case "ctrl+c", "esc", "ctrl+f", "q":
+		switch toggleKey(msg, keymap.ActionFinishTask) {
+		case "ctrl+c", "esc", "q":
 			m.selected = FinishActionCancel
 			return m, tea.Quit
 
@@ -223,7 +242,7 @@ func (m *FinishPicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		case "p", "P":
 			for i, opt := range m.options {
-				if opt.Action == FinishActionPR {
+				if opt.Action == FinishActionPR || opt.Action == FinishActionPushUpstream {
 					m.cursor = i
 					m.selected = opt.Action
 					return m, tea.Quit
@@ -339,7 +358,7 @@ func (m *FinishPicker) View() tea.View {
 	}
 
 	// Help
-	sb.WriteString(m.styleHelp.Render("↑/↓: Navigate  Enter: Select  ⌃F/Esc: Cancel"))
+	sb.WriteString(m.styleHelp.Render("↑/↓: Navigate  Enter: Select  " + escLabel(keymap.ActionFinishTask) + ": Cancel"))
 
 	return tea.NewView(sb.String())
 }
@@ -350,11 +369,11 @@ func (m *FinishPicker) Result() FinishAction {
 }
 
 // RunFinishPicker runs the finish picker and returns the selected action.
-func RunFinishPicker(isGitRepo, hasCommits, hasRemote, hasMainBranch bool) (FinishAction, error) {
-	logging.Debug("-> RunFinishPicker(isGitRepo=%v, hasCommits=%v, hasRemote=%v, hasMainBranch=%v)", isGitRepo, hasCommits, hasRemote, hasMainBranch)
+func RunFinishPicker(isGitRepo, hasCommits, hasRemote, hasMainBranch bool, pushTarget string) (FinishAction, error) {
+	logging.Debug("-> RunFinishPicker(isGitRepo=%v, hasCommits=%v, hasRemote=%v, hasMainBranch=%v, pushTarget=%q)", isGitRepo, hasCommits, hasRemote, hasMainBranch, pushTarget)
 	defer logging.Debug("<- RunFinishPicker")
 
-	m := NewFinishPicker(isGitRepo, hasCommits, hasRemote, hasMainBranch)
+	m := NewFinishPicker(isGitRepo, hasCommits, hasRemote, hasMainBranch, pushTarget)
 	logging.Debug("RunFinishPicker: starting tea.Program")
 	p := tea.NewProgram(m)
 
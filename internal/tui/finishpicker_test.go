@@ -0,0 +1,39 @@
+package tui
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFinishPickerOptions(t *testing.T) {
+	tests := []struct {
+		name       string
+		hasRemote  bool
+		pushTarget string
+		want       []FinishAction
+	}{
+		{"remote", true, "", []FinishAction{FinishActionMergePush, FinishActionMerge, FinishActionPR, FinishActionDrop}},
+		{"no remote", false, "", []FinishAction{FinishActionMerge, FinishActionDrop}},
+		{"branch", true, "origin/feature", []FinishAction{FinishActionPushUpstream, FinishActionDrop}},
+		{"PR", true, "PR #12 (feature/login)", []FinishAction{FinishActionPushUpstream, FinishActionDrop}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewFinishPicker(true, true, tt.hasRemote, true, tt.pushTarget)
+			var got []FinishAction
+			for _, opt := range m.options {
+				got = append(got, opt.Action)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("options = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFinishPickerUpstreamIgnoresMergeKey(t *testing.T) {
+	m := NewFinishPicker(true, true, true, true, "PR #12 (feature/login)")
+	if _, cmd := m.Update(keyPress("m")); cmd != nil || m.selected != FinishActionCancel {
+		t.Errorf("m selected %v, want merge unavailable for a PR task", m.selected)
+	}
+}
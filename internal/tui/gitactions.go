@@ -0,0 +1,971 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/v2/textinput"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+
+	"github.com/dongho-jung/paw/internal/claude"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/embed"
+	"github.com/dongho-jung/paw/internal/git"
+)
+
+// Status bar hints of the interactive modes
+const (
+	gitChangesHint = "space:stage ⏎:hunks a:all d:discard c:commit C:generate A:amend z:stash"
+	gitCommitsHint = "r:reword s:squash"
+	gitStashHint   = "space:apply p:pop d:drop z:stash"
+)
+
+// gitChangeSection groups working tree changes in the changes mode.
+type gitChangeSection int
+
+const (
+	gitSectionStaged gitChangeSection = iota
+	gitSectionUnstaged
+	gitSectionUntracked
+)
+
+// gitChange is a changed file in one section of the changes mode.
+type gitChange struct {
+	section  gitChangeSection
+	status   byte   // Porcelain status letter (M, A, D, R, U, ?)
+	path     string // Path relative to the worktree root
+	origPath string // Source path of a staged rename
+	header   string // Diff header (diff --git ... +++), prepended to hunk patches
+	hunks    []string
+}
+
+// key identifies the change across reloads.
+func (c gitChange) key() string {
+	return strconv.Itoa(int(c.section)) + ":" + c.path
+}
+
+// gitRow is one line of an interactive mode.
+type gitRow struct {
+	text   string
+	change int // Index into changes (-1 if none)
+	hunk   int // Hunk index of a hunk header (-1 for file rows)
+	item   int // Index into commits or stashes (-1 if none)
+}
+
+// selectable reports whether the cursor can stop on the row.
+func (r gitRow) selectable() bool {
+	return r.change >= 0 || r.item >= 0
+}
+
+// gitInputKind identifies what the status bar input is collecting.
+type gitInputKind int
+
+const (
+	gitInputCommit gitInputKind = iota
+	gitInputReword
+	gitInputStash
+)
+
+// gitActionsMsg carries the state shown by the interactive modes.
+type gitActionsMsg struct {
+	mode    gitMode
+	changes []gitChange
+	commits []git.CommitInfo
+	stashes []git.StashEntry
+}
+
+// gitActionDoneMsg is sent when a git action finishes.
+type gitActionDoneMsg struct {
+	notice string
+	err    error
+}
+
+// gitCommitMessageMsg is sent when commit message generation finishes.
+type gitCommitMessageMsg struct {
+	message string
+	err     error
+}
+
+// interactive reports whether the mode works on a cursor instead of raw git output.
+func (g gitMode) interactive() bool {
+	return g == gitModeChanges || g == gitModeCommits || g == gitModeStash
+}
+
+// loadActions loads changes, task commits and stashes of the worktree.
+func (m *GitViewer) loadActions() tea.Cmd {
+	mode, workDir, mainBranch, client := m.mode, m.workDir, m.mainBranch, m.git
+	return func() tea.Msg {
+		changes, err := loadGitChanges(workDir)
+		if err != nil {
+			return err
+		}
+		// Commits and stashes are optional (e.g. the main branch may not resolve)
+		commits, _ := client.GetBranchCommits(workDir, "HEAD", mainBranch, 0)
+		stashes, _ := client.StashList(workDir)
+		return gitActionsMsg{mode: mode, changes: changes, commits: commits, stashes: stashes}
+	}
+}
+
+// loadGitChanges lists staged, unstaged and untracked files with their hunks.
+func loadGitChanges(dir string) ([]gitChange, error) {
+	status, err := gitCommandOutput(dir, "-c", "core.quotePath=false", "status", "--porcelain=v1", "-z", "--untracked-files=all")
+	if err != nil {
+		return nil, err
+	}
+	changes := parsePorcelainStatus(status)
+
+	for _, section := range []gitChangeSection{gitSectionStaged, gitSectionUnstaged} {
+		args := []string{"-c", "core.quotePath=false", "diff", "--no-color", "--no-ext-diff"}
+		if section == gitSectionStaged {
+			args = append(args, "--cached")
+		}
+		diff, err := gitCommandOutput(dir, args...)
+		if err != nil {
+			return nil, err
+		}
+		patches := splitFilePatches(diff)
+		for i := range changes {
+			if changes[i].section != section {
+				continue
+			}
+			if p, ok := patches[changes[i].path]; ok {
+				changes[i].header = p.header
+				changes[i].hunks = p.hunks
+			}
+		}
+	}
+	return changes, nil
+}
+
+// gitCommandOutput runs git in dir and returns its stdout.
+func gitCommandOutput(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return "", fmt.Errorf("git %s failed: %w: %s", args[0], err, exitErr.Stderr)
+		}
+		return "", err
+	}
+	return string(output), nil
+}
+
+// parsePorcelainStatus parses `git status --porcelain=v1 -z` output into
+// staged, unstaged and untracked changes (in that order).
+func parsePorcelainStatus(output string) []gitChange {
+	var staged, unstaged, untracked []gitChange
+	fields := strings.Split(output, "\x00")
+	for i := 0; i < len(fields); i++ {
+		entry := fields[i]
+		if len(entry) < 4 {
+			continue
+		}
+		x, y, path := entry[0], entry[1], entry[3:]
+		var origPath string
+		if x == 'R' || x == 'C' {
+			// With -z the source path follows as its own field
+			if i+1 < len(fields) {
+				origPath = fields[i+1]
+				i++
+			}
+		}
+		switch {
+		case x == '?':
+			untracked = append(untracked, gitChange{section: gitSectionUntracked, status: '?', path: path})
+		case x == '!':
+			// Ignored files are not listed without --ignored
+		case x == 'U' || y == 'U':
+			unstaged = append(unstaged, gitChange{section: gitSectionUnstaged, status: 'U', path: path})
+		default:
+			if x != ' ' {
+				staged = append(staged, gitChange{section: gitSectionStaged, status: x, path: path, origPath: origPath})
+			}
+			if y != ' ' {
+				unstaged = append(unstaged, gitChange{section: gitSectionUnstaged, status: y, path: path})
+			}
+		}
+	}
+	return append(append(staged, unstaged...), untracked...)
+}
+
+// filePatch is the diff of a single file split into its header and hunks.
+type filePatch struct {
+	header string
+	hunks  []string
+}
+
+// splitFilePatches splits a unified diff by file, keyed by the new path.
+// Hunks keep their raw text so they can be fed back to `git apply`.
+func splitFilePatches(diff string) map[string]filePatch {
+	patches := make(map[string]filePatch)
+	var (
+		path   string
+		header []string
+		hunks  [][]string
+		inFile bool
+	)
+	flush := func() {
+		if !inFile {
+			return
+		}
+		p := filePatch{header: strings.Join(header, "")}
+		for _, h := range hunks {
+			p.hunks = append(p.hunks, strings.Join(h, ""))
+		}
+		patches[path] = p
+	}
+
+	for _, line := range strings.SplitAfter(diff, "\n") {
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, "diff --git "):
+			flush()
+			inFile = true
+			header = []string{line}
+			hunks = nil
+			path = ""
+			if idx := strings.LastIndex(line, " b/"); idx >= 0 {
+				path = strings.TrimSuffix(line[idx+3:], "\n")
+			}
+		case !inFile:
+			continue
+		case strings.HasPrefix(line, "@@"):
+			hunks = append(hunks, []string{line})
+		case len(hunks) > 0:
+			hunks[len(hunks)-1] = append(hunks[len(hunks)-1], line)
+		default:
+			header = append(header, line)
+			if strings.HasPrefix(line, "+++ b/") {
+				path = strings.TrimSuffix(line[len("+++ b/"):], "\n")
+			}
+		}
+	}
+	flush()
+	return patches
+}
+
+// handleActionsMsg stores freshly loaded state and rebuilds the rows.
+func (m *GitViewer) handleActionsMsg(msg gitActionsMsg) {
+	if msg.mode != m.mode {
+		return // Stale load from a previous mode
+	}
+	if msg.mode != m.rowsMode {
+		m.rowsMode = msg.mode
+		m.cursor = 0
+		m.scrollPos = 0
+	}
+	m.changes = msg.changes
+	m.commits = msg.commits
+	m.stashes = msg.stashes
+	m.horizontalPos = 0
+	m.clearSelection()
+	m.buildActionRows()
+}
+
+// buildActionRows rebuilds the rows of the current interactive mode.
+func (m *GitViewer) buildActionRows() {
+	headerStyle := lipgloss.NewStyle().Bold(true)
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	addStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+	delStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+
+	var rows []gitRow
+	plain := func(text string) {
+		rows = append(rows, gitRow{text: text, change: -1, hunk: -1, item: -1})
+	}
+
+	switch m.mode {
+	case gitModeChanges:
+		titles := map[gitChangeSection]string{
+			gitSectionStaged:    "Staged changes",
+			gitSectionUnstaged:  "Unstaged changes",
+			gitSectionUntracked: "Untracked files",
+		}
+		for _, section := range []gitChangeSection{gitSectionStaged, gitSectionUnstaged, gitSectionUntracked} {
+			count := 0
+			for _, c := range m.changes {
+				if c.section == section {
+					count++
+				}
+			}
+			if count == 0 {
+				continue
+			}
+			if len(rows) > 0 {
+				plain("")
+			}
+			plain(headerStyle.Render(titles[section] + " (" + strconv.Itoa(count) + ")"))
+			for i, c := range m.changes {
+				if c.section != section {
+					continue
+				}
+				statusStyle := delStyle
+				if section == gitSectionStaged {
+					statusStyle = addStyle
+				}
+				fold := " "
+				if len(c.hunks) > 0 {
+					fold = "▸"
+					if m.expanded[c.key()] {
+						fold = "▾"
+					}
+				}
+				name := c.path
+				if c.origPath != "" {
+					name = c.origPath + " → " + c.path
+				}
+				rows = append(rows, gitRow{text: fold + " " + statusStyle.Render(string(c.status)) + " " + name, change: i, hunk: -1, item: -1})
+				if !m.expanded[c.key()] {
+					continue
+				}
+				for h, hunk := range c.hunks {
+					lines := strings.Split(strings.TrimSuffix(hunk, "\n"), "\n")
+					rows = append(rows, gitRow{text: "    " + dimStyle.Render(lines[0]), change: i, hunk: h, item: -1})
+					for _, line := range lines[1:] {
+						line = strings.ReplaceAll(line, "\t", "    ")
+						switch {
+						case strings.HasPrefix(line, "+"):
+							line = addStyle.Render(line)
+						case strings.HasPrefix(line, "-"):
+							line = delStyle.Render(line)
+						}
+						plain("      " + line)
+					}
+				}
+			}
+		}
+		if len(rows) == 0 {
+			plain(dimStyle.Render("Working tree clean"))
+		}
+
+	case gitModeCommits:
+		if len(m.commits) == 0 {
+			plain(dimStyle.Render("No commits since " + m.mainBranch))
+			break
+		}
+		plain(headerStyle.Render("Task commits (" + m.mainBranch + "..HEAD)"))
+		for i, c := range m.commits {
+			rows = append(rows, gitRow{text: dimStyle.Render(shortHash(c.Hash)) + " " + c.Subject, change: -1, hunk: -1, item: i})
+		}
+
+	case gitModeStash:
+		if len(m.stashes) == 0 {
+			plain(dimStyle.Render("No stashes"))
+			break
+		}
+		for i, s := range m.stashes {
+			rows = append(rows, gitRow{text: dimStyle.Render(stashRef(s)+":") + " " + s.Message, change: -1, hunk: -1, item: i})
+		}
+	}
+
+	m.rows = rows
+	m.clampActionCursor()
+	m.refreshActionLines()
+}
+
+// clampActionCursor moves the cursor to the nearest selectable row.
+func (m *GitViewer) clampActionCursor() {
+	if m.cursor >= len(m.rows) {
+		m.cursor = len(m.rows) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+	for i := m.cursor; i < len(m.rows); i++ {
+		if m.rows[i].selectable() {
+			m.cursor = i
+			return
+		}
+	}
+	for i := m.cursor; i >= 0; i-- {
+		if i < len(m.rows) && m.rows[i].selectable() {
+			m.cursor = i
+			return
+		}
+	}
+}
+
+// refreshActionLines renders the rows with the cursor marker into m.lines.
+func (m *GitViewer) refreshActionLines() {
+	cursorStyle := lipgloss.NewStyle().Bold(true)
+	lines := make([]string, len(m.rows))
+	for i, row := range m.rows {
+		if i == m.cursor && row.selectable() {
+			lines[i] = cursorStyle.Render("▶ ") + row.text
+		} else {
+			lines[i] = "  " + row.text
+		}
+	}
+	m.lines = lines
+	m.cachedDisplayLines = nil
+	if m.searchQuery != "" {
+		m.findMatches()
+	}
+}
+
+// cursorRow returns the row under the cursor if it is selectable.
+func (m *GitViewer) cursorRow() (gitRow, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.rows) || !m.rows[m.cursor].selectable() {
+		return gitRow{}, false
+	}
+	return m.rows[m.cursor], true
+}
+
+// moveActionCursor moves the cursor by delta selectable rows.
+func (m *GitViewer) moveActionCursor(delta int) {
+	step := 1
+	if delta < 0 {
+		step = -1
+		delta = -delta
+	}
+	for ; delta > 0; delta-- {
+		next := m.cursor + step
+		for next >= 0 && next < len(m.rows) && !m.rows[next].selectable() {
+			next += step
+		}
+		if next < 0 || next >= len(m.rows) {
+			break
+		}
+		m.cursor = next
+	}
+	m.refreshActionLines()
+	m.ensureActionCursorVisible()
+}
+
+// ensureActionCursorVisible scrolls so that the cursor row is on screen.
+func (m *GitViewer) ensureActionCursorVisible() {
+	h := m.contentHeight()
+	if m.cursor < m.scrollPos {
+		m.scrollPos = m.cursor
+	} else if m.cursor >= m.scrollPos+h {
+		m.scrollPos = m.cursor - h + 1
+	}
+	// Keep the section header above the first row visible
+	if m.scrollPos == 1 && m.cursor < h {
+		m.scrollPos = 0
+	}
+}
+
+// handleActionKey handles keys of the interactive modes.
+// It reports false for keys that fall through to the common handling.
+func (m *GitViewer) handleActionKey(key string) (bool, tea.Cmd) {
+	switch key {
+	case "down", "j":
+		m.moveActionCursor(1)
+		return true, nil
+	case "up", "k":
+		m.moveActionCursor(-1)
+		return true, nil
+	case "pgdown", "ctrl+f":
+		m.moveActionCursor(m.contentHeight())
+		return true, nil
+	case "pgup", "ctrl+b":
+		m.moveActionCursor(-m.contentHeight())
+		return true, nil
+	case "g":
+		m.moveActionCursor(-len(m.rows))
+		return true, nil
+	case "G":
+		m.moveActionCursor(len(m.rows))
+		return true, nil
+	case "s", "L", "a", "d":
+		// Letter mode keys are taken by actions here; switch with Tab/1-7
+	default:
+		if len(key) != 1 && key != "space" && key != "enter" {
+			return false, nil
+		}
+	}
+
+	switch m.mode {
+	case gitModeChanges:
+		switch key {
+		case "space":
+			return true, m.toggleStage()
+		case "enter":
+			m.toggleHunks()
+			return true, nil
+		case "a":
+			return true, m.toggleStageAll()
+		case "d":
+			m.confirmDiscard()
+			return true, nil
+		case "c":
+			return true, m.openCommitInput("", "")
+		case "C":
+			return true, m.generateCommitMessage()
+		case "A":
+			m.confirmAmend()
+			return true, nil
+		case "z":
+			return true, m.openGitInput(gitInputStash, "", "Stash message (optional)")
+		}
+	case gitModeCommits:
+		switch key {
+		case "r":
+			return true, m.openRewordInput()
+		case "s":
+			m.confirmSquash()
+			return true, nil
+		}
+	case gitModeStash:
+		switch key {
+		case "space", "enter":
+			return true, m.applyStash(false)
+		case "p":
+			return true, m.applyStash(true)
+		case "d":
+			m.confirmDropStash()
+			return true, nil
+		case "z":
+			return true, m.openGitInput(gitInputStash, "", "Stash message (optional)")
+		}
+	}
+	// Swallow letter mode keys so they don't switch modes under the user
+	return key == "s" || key == "L" || key == "a" || key == "d", nil
+}
+
+// runAction runs a git action asynchronously and reports the notice on success.
+// Only one action runs at a time.
+func (m *GitViewer) runAction(notice string, fn func() error) tea.Cmd {
+	if m.busy {
+		m.notice = "Another git action is still running"
+		return nil
+	}
+	m.busy = true
+	return func() tea.Msg {
+		return gitActionDoneMsg{notice: notice, err: fn()}
+	}
+}
+
+// handleActionDone shows the result of a git action and reloads the state.
+func (m *GitViewer) handleActionDone(msg gitActionDoneMsg) tea.Cmd {
+	m.busy = false
+	if msg.err != nil {
+		m.notice = "Error: " + firstLine(msg.err.Error())
+	} else {
+		m.notice = msg.notice
+	}
+	return m.loadActions()
+}
+
+// askConfirm asks for y/N confirmation before running a destructive action.
+func (m *GitViewer) askConfirm(prompt, notice string, fn func() error) {
+	m.confirmPrompt = prompt
+	m.confirmNotice = notice
+	m.confirmAction = fn
+}
+
+// handleConfirmKey runs the pending action on y and cancels it on any other key.
+func (m *GitViewer) handleConfirmKey(key string) tea.Cmd {
+	fn, notice := m.confirmAction, m.confirmNotice
+	m.confirmPrompt = ""
+	m.confirmAction = nil
+	if key != "y" && key != "Y" {
+		m.notice = "Cancelled"
+		return nil
+	}
+	return m.runAction(notice, fn)
+}
+
+// toggleStage stages or unstages the file or hunk under the cursor.
+func (m *GitViewer) toggleStage() tea.Cmd {
+	row, ok := m.cursorRow()
+	if !ok || row.change < 0 {
+		return nil
+	}
+	c := m.changes[row.change]
+	dir, client := m.workDir, m.git
+
+	if row.hunk >= 0 {
+		patch := c.header + c.hunks[row.hunk]
+		if c.section == gitSectionStaged {
+			return m.runAction("Unstaged hunk of "+c.path, func() error {
+				return client.ApplyPatch(dir, patch, true, true)
+			})
+		}
+		return m.runAction("Staged hunk of "+c.path, func() error {
+			return client.ApplyPatch(dir, patch, true, false)
+		})
+	}
+
+	if c.section == gitSectionStaged {
+		return m.runAction("Unstaged "+c.path, func() error {
+			if c.origPath != "" {
+				if err := client.ResetPath(dir, c.origPath); err != nil {
+					return err
+				}
+			}
+			return client.ResetPath(dir, c.path)
+		})
+	}
+	return m.runAction("Staged "+c.path, func() error {
+		return client.Add(dir, c.path)
+	})
+}
+
+// toggleStageAll stages everything, or unstages everything when nothing is left to stage.
+func (m *GitViewer) toggleStageAll() tea.Cmd {
+	dir, client := m.workDir, m.git
+	for _, c := range m.changes {
+		if c.section != gitSectionStaged {
+			return m.runAction("Staged all changes", func() error {
+				return client.AddAll(dir)
+			})
+		}
+	}
+	if len(m.changes) == 0 {
+		m.notice = "Working tree clean"
+		return nil
+	}
+	return m.runAction("Unstaged all changes", func() error {
+		return client.ResetPath(dir, ".")
+	})
+}
+
+// toggleHunks expands or collapses the hunks of the file under the cursor.
+func (m *GitViewer) toggleHunks() {
+	row, ok := m.cursorRow()
+	if !ok || row.change < 0 {
+		return
+	}
+	c := m.changes[row.change]
+	if len(c.hunks) == 0 {
+		m.notice = "No hunks to show for " + c.path
+		return
+	}
+	if m.expanded == nil {
+		m.expanded = make(map[string]bool)
+	}
+	m.expanded[c.key()] = !m.expanded[c.key()]
+	if !m.expanded[c.key()] {
+		// Collapsing from a hunk moves the cursor back to its file
+		for m.cursor > 0 && m.rows[m.cursor].hunk >= 0 {
+			m.cursor--
+		}
+	}
+	m.buildActionRows()
+	m.ensureActionCursorVisible()
+}
+
+// confirmDiscard asks before discarding the unstaged file or hunk under the cursor.
+func (m *GitViewer) confirmDiscard() {
+	row, ok := m.cursorRow()
+	if !ok || row.change < 0 {
+		return
+	}
+	c := m.changes[row.change]
+	dir, client := m.workDir, m.git
+
+	switch {
+	case c.section == gitSectionStaged:
+		m.notice = "Unstage changes before discarding them"
+	case row.hunk >= 0:
+		patch := c.header + c.hunks[row.hunk]
+		m.askConfirm("Discard this hunk of "+c.path+"?", "Discarded hunk of "+c.path, func() error {
+			return client.ApplyPatch(dir, patch, false, true)
+		})
+	case c.section == gitSectionUntracked:
+		m.askConfirm("Delete untracked "+c.path+"?", "Deleted "+c.path, func() error {
+			return client.DiscardPath(dir, c.path, true)
+		})
+	default:
+		m.askConfirm("Discard changes to "+c.path+"?", "Discarded "+c.path, func() error {
+			return client.DiscardPath(dir, c.path, false)
+		})
+	}
+}
+
+// hasStaged reports whether any change is staged.
+func (m *GitViewer) hasStaged() bool {
+	for _, c := range m.changes {
+		if c.section == gitSectionStaged {
+			return true
+		}
+	}
+	return false
+}
+
+// confirmAmend asks before amending the task's last commit with the staged changes.
+func (m *GitViewer) confirmAmend() {
+	if !m.hasStaged() {
+		m.notice = "Nothing staged to amend"
+		return
+	}
+	if len(m.commits) == 0 {
+		m.notice = "HEAD is not a task commit; nothing to amend"
+		return
+	}
+	dir, client := m.workDir, m.git
+	head := m.commits[0]
+	m.askConfirm("Amend "+shortHash(head.Hash)+" with the staged changes?", "Amended "+shortHash(head.Hash), func() error {
+		return client.CommitAmend(dir, "")
+	})
+}
+
+// openCommitInput opens the commit message input, optionally prefilled.
+func (m *GitViewer) openCommitInput(subject, body string) tea.Cmd {
+	if !m.hasStaged() {
+		m.notice = "Nothing staged to commit"
+		return nil
+	}
+	cmd := m.openGitInput(gitInputCommit, subject, "Commit message (Esc to cancel)")
+	m.inputBody = body
+	return cmd
+}
+
+// generateCommitMessage asks Claude for a message for the staged changes,
+// following the commit-message prompt, and opens it in the commit input.
+func (m *GitViewer) generateCommitMessage() tea.Cmd {
+	if !m.hasStaged() {
+		m.notice = "Nothing staged to commit"
+		return nil
+	}
+	if m.busy {
+		m.notice = "Another git action is still running"
+		return nil
+	}
+	m.busy = true
+	m.notice = "Generating commit message…"
+	dir, pawDir := m.workDir, m.pawDir
+	return func() tea.Msg {
+		diff, err := gitCommandOutput(dir, "diff", "--cached", "--no-color", "--no-ext-diff")
+		if err != nil {
+			return gitCommitMessageMsg{err: err}
+		}
+		msg, err := claude.New().GenerateCommitMessage(diff, commitGuidelines(pawDir))
+		return gitCommitMessageMsg{message: msg, err: err}
+	}
+}
+
+// handleCommitMessage opens the generated message in the commit input.
+func (m *GitViewer) handleCommitMessage(msg gitCommitMessageMsg) tea.Cmd {
+	m.busy = false
+	if msg.err != nil {
+		m.notice = "Error: " + firstLine(msg.err.Error())
+		return nil
+	}
+	m.notice = ""
+	subject, body, _ := strings.Cut(msg.message, "\n")
+	return m.openCommitInput(strings.TrimSpace(subject), strings.TrimSpace(body))
+}
+
+// commitGuidelines returns the project's commit-message prompt, or the default one.
+func commitGuidelines(pawDir string) string {
+	if pawDir != "" {
+		path := filepath.Join(pawDir, constants.PromptsDirName, constants.CommitMessagePromptFile)
+		if data, err := os.ReadFile(path); err == nil { //nolint:gosec // G304: path is built from the PAW directory
+			return string(data)
+		}
+	}
+	guidelines, _ := embed.GetCommitMessagePrompt()
+	return guidelines
+}
+
+// openRewordInput opens the input to reword the commit under the cursor.
+func (m *GitViewer) openRewordInput() tea.Cmd {
+	row, ok := m.cursorRow()
+	if !ok || row.item < 0 {
+		return nil
+	}
+	c := m.commits[row.item]
+	m.inputTarget = c.Hash
+	return m.openGitInput(gitInputReword, c.Subject, "New subject (Esc to cancel)")
+}
+
+// confirmSquash asks before folding the commit under the cursor into the previous one.
+func (m *GitViewer) confirmSquash() {
+	row, ok := m.cursorRow()
+	if !ok || row.item < 0 {
+		return
+	}
+	// Commits are newest first; the oldest task commit has nothing to squash into
+	if row.item == len(m.commits)-1 {
+		m.notice = "The first task commit has no task commit to squash into"
+		return
+	}
+	c, prev := m.commits[row.item], m.commits[row.item+1]
+	dir, client := m.workDir, m.git
+	m.askConfirm("Squash "+shortHash(c.Hash)+" into "+shortHash(prev.Hash)+"?", "Squashed "+shortHash(c.Hash), func() error {
+		return client.SquashCommit(dir, c.Hash)
+	})
+}
+
+// stashRef returns the stash@{n} reference of an entry.
+func stashRef(s git.StashEntry) string {
+	return fmt.Sprintf("stash@{%d}", s.Index)
+}
+
+// stashUnambiguous reports whether dropping by message would hit the entry at idx.
+// StashDropByMessage drops the newest stash whose message contains the given one.
+func (m *GitViewer) stashUnambiguous(idx int) bool {
+	for i := 0; i < idx; i++ {
+		if strings.Contains(m.stashes[i].Message, m.stashes[idx].Message) {
+			return false
+		}
+	}
+	return true
+}
+
+// applyStash applies the stash under the cursor; pop also drops it once applied.
+func (m *GitViewer) applyStash(pop bool) tea.Cmd {
+	row, ok := m.cursorRow()
+	if !ok || row.item < 0 {
+		return nil
+	}
+	s := m.stashes[row.item]
+	ref := stashRef(s)
+	dir, client := m.workDir, m.git
+	if !pop {
+		return m.runAction("Applied "+ref, func() error {
+			return client.StashApply(dir, ref)
+		})
+	}
+	if !m.stashUnambiguous(row.item) {
+		m.notice = "A newer stash has the same message; apply and drop it instead"
+		return nil
+	}
+	return m.runAction("Popped "+ref, func() error {
+		// Drop only after a clean apply so a conflict never loses the stash
+		if err := client.StashApply(dir, ref); err != nil {
+			return err
+		}
+		return client.StashDropByMessage(dir, s.Message)
+	})
+}
+
+// confirmDropStash asks before dropping the stash under the cursor.
+func (m *GitViewer) confirmDropStash() {
+	row, ok := m.cursorRow()
+	if !ok || row.item < 0 {
+		return
+	}
+	if !m.stashUnambiguous(row.item) {
+		m.notice = "A newer stash has the same message; drop that one first"
+		return
+	}
+	s := m.stashes[row.item]
+	dir, client := m.workDir, m.git
+	m.askConfirm("Drop "+stashRef(s)+"?", "Dropped "+stashRef(s), func() error {
+		return client.StashDropByMessage(dir, s.Message)
+	})
+}
+
+// openGitInput opens the status bar input.
+func (m *GitViewer) openGitInput(kind gitInputKind, value, placeholder string) tea.Cmd {
+	ti := textinput.New()
+	ti.Prompt = ""
+	ti.Placeholder = placeholder
+	ti.CharLimit = 0
+	ti.SetWidth(max(20, m.width-24))
+	ti.VirtualCursor = true
+	ti.SetValue(value)
+	ti.CursorEnd()
+	cmd := ti.Focus()
+	m.input = ti
+	m.inputKind = kind
+	m.inputBody = ""
+	m.inputting = true
+	return cmd
+}
+
+// inputLabel returns the status bar label of the open input.
+func (m *GitViewer) inputLabel() string {
+	switch m.inputKind {
+	case gitInputReword:
+		return " Reword " + shortHash(m.inputTarget) + ": "
+	case gitInputStash:
+		return " Stash: "
+	default:
+		label := " Commit: "
+		if m.inputBody != "" {
+			label = " Commit (+" + strconv.Itoa(strings.Count(m.inputBody, "\n")+1) + " body lines): "
+		}
+		return label
+	}
+}
+
+// handleInputKey handles keys while the status bar input is open.
+func (m *GitViewer) handleInputKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		m.inputting = false
+		return m, m.submitInput(strings.TrimSpace(m.input.Value()))
+	case "esc":
+		m.inputting = false
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// submitInput runs the action collected by the status bar input.
+func (m *GitViewer) submitInput(value string) tea.Cmd {
+	dir, client := m.workDir, m.git
+	switch m.inputKind {
+	case gitInputStash:
+		return m.runAction("Stashed changes", func() error {
+			return client.StashPush(dir, value)
+		})
+	case gitInputReword:
+		if value == "" {
+			m.notice = "Empty subject; reword cancelled"
+			return nil
+		}
+		hash := m.inputTarget
+		return m.runAction("Reworded "+shortHash(hash), func() error {
+			message, err := client.GetCommitMessage(dir, hash)
+			if err != nil {
+				return err
+			}
+			return client.RewordCommit(dir, hash, replaceSubject(message, value))
+		})
+	default:
+		if value == "" {
+			m.notice = "Empty message; commit cancelled"
+			return nil
+		}
+		message := value
+		if m.inputBody != "" {
+			message += "\n\n" + m.inputBody
+		}
+		return m.runAction("Committed "+value, func() error {
+			return client.Commit(dir, message)
+		})
+	}
+}
+
+// replaceSubject replaces the first line of a commit message, keeping the body.
+func replaceSubject(message, subject string) string {
+	if _, body, ok := strings.Cut(message, "\n"); ok {
+		return subject + "\n" + body
+	}
+	return subject
+}
+
+// actionsHint returns the status bar hint of the current interactive mode.
+func (m *GitViewer) actionsHint() string {
+	switch m.mode {
+	case gitModeChanges:
+		return gitChangesHint
+	case gitModeCommits:
+		return gitCommitsHint
+	default:
+		return gitStashHint
+	}
+}
+
+// firstLine returns the first line of s, used to fit git errors in the status bar.
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+	return line
+}
@@ -0,0 +1,170 @@
+package tui
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// initTestRepo creates a repository with one committed file.
+func initTestRepo(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"config", "user.name", "Test User"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "core.hooksPath", "/dev/null"},
+	} {
+		if out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	writeTestFile(t, dir, "file.txt", "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n")
+	if out, err := exec.Command("git", "-C", dir, "add", "-A").CombinedOutput(); err != nil {
+		t.Fatalf("git add: %v\n%s", err, out)
+	}
+	if out, err := exec.Command("git", "-C", dir, "commit", "-qm", "Initial commit").CombinedOutput(); err != nil {
+		t.Fatalf("git commit: %v\n%s", err, out)
+	}
+	return dir
+}
+
+func writeTestFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+}
+
+func TestParsePorcelainStatus(t *testing.T) {
+	output := "MM both.go\x00R  new.go\x00old.go\x00 D gone.go\x00UU conflict.go\x00?? notes.txt\x00"
+	changes := parsePorcelainStatus(output)
+
+	want := []struct {
+		section gitChangeSection
+		status  byte
+		path    string
+	}{
+		{gitSectionStaged, 'M', "both.go"},
+		{gitSectionStaged, 'R', "new.go"},
+		{gitSectionUnstaged, 'M', "both.go"},
+		{gitSectionUnstaged, 'D', "gone.go"},
+		{gitSectionUnstaged, 'U', "conflict.go"},
+		{gitSectionUntracked, '?', "notes.txt"},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("got %d changes, want %d: %+v", len(changes), len(want), changes)
+	}
+	for i, w := range want {
+		c := changes[i]
+		if c.section != w.section || c.status != w.status || c.path != w.path {
+			t.Errorf("changes[%d] = %+v, want %+v", i, c, w)
+		}
+	}
+	if changes[1].origPath != "old.go" {
+		t.Errorf("rename origPath = %q, want old.go", changes[1].origPath)
+	}
+}
+
+func TestSplitFilePatches(t *testing.T) {
+	diff := "diff --git a/a.go b/a.go\nindex 1..2 100644\n--- a/a.go\n+++ b/a.go\n" +
+		"@@ -1,2 +1,2 @@\n-x\n+y\n z\n@@ -9 +9 @@\n-p\n+q\n" +
+		"diff --git a/gone.go b/gone.go\ndeleted file mode 100644\n--- a/gone.go\n+++ /dev/null\n@@ -1 +0,0 @@\n-bye\n"
+	patches := splitFilePatches(diff)
+
+	a := patches["a.go"]
+	if len(a.hunks) != 2 || !strings.HasPrefix(a.hunks[1], "@@ -9 +9 @@\n") {
+		t.Fatalf("a.go hunks = %q", a.hunks)
+	}
+	if !strings.HasSuffix(a.header, "+++ b/a.go\n") {
+		t.Errorf("a.go header = %q", a.header)
+	}
+	if len(patches["gone.go"].hunks) != 1 {
+		t.Errorf("gone.go patch = %+v", patches["gone.go"])
+	}
+}
+
+func TestGitViewerStageHunkAndCommit(t *testing.T) {
+	dir := initTestRepo(t)
+	// Two hunks far apart in one file
+	writeTestFile(t, dir, "file.txt", "one\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\ntwelve\n")
+
+	m := NewGitViewer(dir, "main", "")
+	m.width, m.height = 100, 20
+	m.mode = gitModeChanges
+	m.Update(m.loadGitOutput()())
+
+	if len(m.changes) != 1 || len(m.changes[0].hunks) != 2 {
+		t.Fatalf("changes = %+v, want one file with two hunks", m.changes)
+	}
+
+	// Expand the file and stage its first hunk
+	m.handleActionKey("enter")
+	m.handleActionKey("j")
+	if row, ok := m.cursorRow(); !ok || row.hunk != 0 {
+		t.Fatalf("cursor row = %+v, want the first hunk", row)
+	}
+	_, cmd := m.handleActionKey("space")
+	m.Update(cmd())
+	m.Update(m.loadGitOutput()())
+
+	staged, _ := gitCommandOutput(dir, "diff", "--cached")
+	unstaged, _ := gitCommandOutput(dir, "diff")
+	if !strings.Contains(staged, "+one") || strings.Contains(staged, "+twelve") {
+		t.Errorf("staged diff = %q, want only the first hunk", staged)
+	}
+	if !strings.Contains(unstaged, "+twelve") {
+		t.Errorf("unstaged diff = %q, want the second hunk", unstaged)
+	}
+
+	// Commit the staged hunk through the message input
+	m.handleActionKey("c")
+	if !m.inputting {
+		t.Fatal("commit input should open with staged changes")
+	}
+	m.input.SetValue("Rename first line")
+	_, cmd = m.handleInputKey(keyPress("enter"))
+	m.Update(cmd())
+	if subject, _ := gitCommandOutput(dir, "log", "-1", "--format=%s"); strings.TrimSpace(subject) != "Rename first line" {
+		t.Errorf("HEAD subject = %q", subject)
+	}
+}
+
+func TestGitViewerDiscardNeedsConfirm(t *testing.T) {
+	dir := initTestRepo(t)
+	writeTestFile(t, dir, "scratch.txt", "tmp\n")
+
+	m := NewGitViewer(dir, "main", "")
+	m.width, m.height = 100, 20
+	m.mode = gitModeChanges
+	m.Update(m.loadGitOutput()())
+
+	m.handleActionKey("d")
+	if m.confirmPrompt == "" {
+		t.Fatal("discard should ask for confirmation")
+	}
+	if cmd := m.handleConfirmKey("n"); cmd != nil {
+		t.Fatal("declining should not run the action")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "scratch.txt")); err != nil {
+		t.Fatal("file should survive a declined discard")
+	}
+
+	m.handleActionKey("d")
+	m.Update(m.handleConfirmKey("y")())
+	if _, err := os.Stat(filepath.Join(dir, "scratch.txt")); !os.IsNotExist(err) {
+		t.Error("untracked file should be deleted after confirming")
+	}
+}
+
+func TestReplaceSubject(t *testing.T) {
+	if got := replaceSubject("old\n\nbody", "new"); got != "new\n\nbody" {
+		t.Errorf("replaceSubject() = %q", got)
+	}
+	if got := replaceSubject("old", "new"); got != "new" {
+		t.Errorf("replaceSubject() = %q", got)
+	}
+}
@@ -8,9 +8,13 @@ import (
 	"strings"
 
 	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/v2/textinput"
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/charmbracelet/lipgloss/v2"
 	"github.com/charmbracelet/x/ansi"
+
+	"github.com/dongho-jung/paw/internal/git"
+	"github.com/dongho-jung/paw/internal/keymap"
 )
 
 // gitMode represents different git command modes
@@ -21,20 +25,16 @@ const (
 	gitModeLog
 	gitModeAll
 	gitModeDiff
-)
-
-// Pre-computed status bar hints and their widths (avoids ansi.StringWidth on each render)
-const (
-	gitViewerHintFull       = "Tab/1-4:mode /:search n/N:match q:close"
-	gitViewerHintShort      = "⌃G/q:close"
-	gitViewerHintFullWidth  = 41 // len("Tab/1-4:mode /:search n/N:match q:close")
-	gitViewerHintShortWidth = 10 // ansi.StringWidth("⌃G/q:close") - ⌃ is 1 width
+	gitModeChanges // Interactive: stage, discard, commit
+	gitModeCommits // Interactive: reword and squash task commits
+	gitModeStash   // Interactive: stash management
 )
 
 // GitViewer provides an interactive git viewer with mode switching and vim-like navigation.
 type GitViewer struct {
 	workDir       string
 	mainBranch    string
+	pawDir        string // For the project's commit-message prompt
 	mode          gitMode
 	lines         []string
 	scrollPos     int
@@ -46,6 +46,10 @@ type GitViewer struct {
 	isDark        bool
 	colors        ThemeColors
 
+	// Status bar hints, rendered from the keymap once (avoids ansi.StringWidth on each render)
+	hintFull  statusHint
+	hintShort statusHint
+
 	// Mouse text selection state
 	selecting    bool
 	hasSelection bool
@@ -74,19 +78,43 @@ type GitViewer struct {
 	cachedDisplayLines []string
 	cachedDisplayWidth int
 	cachedDisplayWrap  bool
+
+	// Interactive modes (changes, commits, stash)
+	git           git.Client
+	rows          []gitRow
+	rowsMode      gitMode // Mode the rows were built for
+	cursor        int     // Row index under the cursor
+	changes       []gitChange
+	commits       []git.CommitInfo // Task commits, newest first
+	stashes       []git.StashEntry
+	expanded      map[string]bool // gitChange.key() → hunks shown
+	notice        string          // One-off status bar message
+	busy          bool            // A git action or message generation is running
+	inputting     bool
+	input         textinput.Model
+	inputKind     gitInputKind
+	inputBody     string // Commit body kept from a generated message
+	inputTarget   string // Commit being reworded
+	confirmPrompt string
+	confirmNotice string
+	confirmAction func() error
 }
 
 // NewGitViewer creates a new git viewer for the given working directory.
-func NewGitViewer(workDir, mainBranch string) *GitViewer {
+func NewGitViewer(workDir, mainBranch, pawDir string) *GitViewer {
 	// Detect dark mode BEFORE bubbletea starts
 	isDark := DetectDarkMode()
 
 	return &GitViewer{
 		workDir:    workDir,
 		mainBranch: mainBranch,
+		pawDir:     pawDir,
 		mode:       gitModeStatus, // Start in status mode by default
+		git:        git.New(),
 		isDark:     isDark,
 		colors:     NewThemeColors(isDark),
+		hintFull:   newStatusHint("Tab/1-7:mode /:search n/N:match " + closeHint(keymap.ActionClose)),
+		hintShort:  newStatusHint(closeHint(keymap.ActionToggleGit, keymap.ActionClose)),
 	}
 }
 
@@ -99,7 +127,7 @@ func (m *GitViewer) Init() tea.Cmd {
 func (m *GitViewer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.BackgroundColorMsg:
-		m.isDark = msg.IsDark()
+		m.isDark = themeIsDark(msg.IsDark())
 		m.colors = NewThemeColors(m.isDark)
 		m.stylesCached = false // Invalidate style cache on theme change
 		setCachedDarkMode(m.isDark)
@@ -110,6 +138,13 @@ func (m *GitViewer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tea.MouseClickMsg:
 		if msg.Button == tea.MouseLeft {
+			// Clicking a row in an interactive mode moves the cursor there
+			if m.mode.interactive() {
+				if idx := m.scrollPos + msg.Y; idx < len(m.rows) && m.rows[idx].selectable() {
+					m.cursor = idx
+					m.refreshActionLines()
+				}
+			}
 			// Start text selection
 			m.selecting = true
 			m.hasSelection = true
@@ -158,11 +193,27 @@ func (m *GitViewer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.clearSelection()
 		return m, nil
 
+	case gitActionsMsg:
+		m.handleActionsMsg(msg)
+		return m, nil
+
+	case gitActionDoneMsg:
+		return m, m.handleActionDone(msg)
+
+	case gitCommitMessageMsg:
+		return m, m.handleCommitMessage(msg)
+
 	case error:
 		m.err = msg
 		return m, nil
 	}
 
+	if m.inputting {
+		// Cursor blink and other input messages
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		return m, cmd
+	}
 	return m, nil
 }
 
@@ -177,8 +228,25 @@ func (m *GitViewer) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	if m.searchMode {
 		return m.handleSearchKey(msg)
 	}
+	if m.inputting {
+		return m.handleInputKey(msg)
+	}
+	if m.confirmPrompt != "" {
+		return m, m.handleConfirmKey(msg.String())
+	}
+	m.notice = ""
 
-	switch msg.String() {
+	if keyMatches(msg, keymap.ActionToggleGit) {
+		return m, tea.Quit
+	}
+	key := translateViewerKey(msg)
+	if m.mode.interactive() {
+		if handled, cmd := m.handleActionKey(key); handled {
+			return m, cmd
+		}
+	}
+
+	switch key {
 	// Copy selection with Ctrl+C
 	case "ctrl+c":
 		if m.hasSelection {
@@ -187,7 +255,7 @@ func (m *GitViewer) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	// Close on q or Ctrl+G
-	case "q", "ctrl+g", "ctrl+shift+g":
+	case "q", "ctrl+shift+g":
 		return m, tea.Quit
 
 	case "esc":
@@ -319,6 +387,15 @@ func (m *GitViewer) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m, m.loadGitOutput()
 		}
 
+	case "5", "6", "7":
+		// Quick switch to the interactive modes
+		mode := gitModeChanges + gitMode(msg.String()[0]-'5')
+		if m.mode != mode {
+			m.mode = mode
+			m.clearSearch()
+			return m, m.loadGitOutput()
+		}
+
 	case "w":
 		// Toggle word wrap
 		m.wordWrap = !m.wordWrap
@@ -344,7 +421,7 @@ func (m *GitViewer) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.scrollDown(m.contentHeight() / 2)
 
 	case "tab":
-		// Cycle through modes: status -> log -> all -> diff -> status
+		// Cycle through modes: status -> log -> all -> diff -> changes -> commits -> stash -> status
 		switch m.mode {
 		case gitModeStatus:
 			m.mode = gitModeLog
@@ -353,6 +430,12 @@ func (m *GitViewer) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		case gitModeAll:
 			m.mode = gitModeDiff
 		case gitModeDiff:
+			m.mode = gitModeChanges
+		case gitModeChanges:
+			m.mode = gitModeCommits
+		case gitModeCommits:
+			m.mode = gitModeStash
+		case gitModeStash:
 			m.mode = gitModeStatus
 		}
 		m.clearSearch() // Clear search on mode change
@@ -433,7 +516,8 @@ func (m *GitViewer) scrollToEnd() {
 // getDisplayLines returns lines to display, handling word wrap if enabled.
 // Results are cached and invalidated on width/wrap mode change.
 func (m *GitViewer) getDisplayLines() []string {
-	if !m.wordWrap || m.width <= 0 {
+	// Interactive modes map lines 1:1 to rows, so they never wrap
+	if !m.wordWrap || m.width <= 0 || m.mode.interactive() {
 		return m.lines
 	}
 
@@ -501,17 +585,17 @@ func (m *GitViewer) View() tea.View {
 	// Update style cache if needed (only on theme change)
 	if !m.stylesCached {
 		m.styleHighlight = lipgloss.NewStyle().
-			Background(lipgloss.Color("25")).
-			Foreground(lipgloss.Color("255"))
+			Background(themeColor("selection", lipgloss.Color("25"))).
+			Foreground(themeColor("text_bright", lipgloss.Color("255")))
 		m.styleStatus = lipgloss.NewStyle().
 			Background(c.StatusBar).
 			Foreground(c.StatusBarText)
 		m.styleMatchCurrent = lipgloss.NewStyle().
-			Background(lipgloss.Color("208")). // Orange for current match
-			Foreground(lipgloss.Color("0"))    // Black text
+			Background(themeColor("search_current", lipgloss.Color("208"))). // Orange for current match
+			Foreground(lipgloss.Color("0"))                                  // Black text
 		m.styleMatchOther = lipgloss.NewStyle().
-			Background(lipgloss.Color("226")). // Yellow for other matches
-			Foreground(lipgloss.Color("0"))    // Black text
+			Background(themeColor("search_match", lipgloss.Color("226"))). // Yellow for other matches
+			Foreground(lipgloss.Color("0"))                                // Black text
 		m.stylesCached = true
 	}
 
@@ -578,6 +662,19 @@ func (m *GitViewer) View() tea.View {
 		return v
 	}
 
+	// Status bar input (commit message, reword, stash message)
+	if m.inputting {
+		bar := m.inputLabel() + m.input.View()
+		if w := ansi.StringWidth(bar); w < m.width {
+			bar += getPadding(m.width - w)
+		}
+		sb.WriteString(m.styleStatus.Render(ansi.Truncate(bar, m.width, "")))
+		v := tea.NewView(sb.String())
+		v.AltScreen = true
+		v.MouseMode = tea.MouseModeAllMotion
+		return v
+	}
+
 	// Mode indicator
 	var modeStr string
 	switch m.mode {
@@ -589,6 +686,12 @@ func (m *GitViewer) View() tea.View {
 		modeStr = "[3:LOG --all]"
 	case gitModeDiff:
 		modeStr = "[4:DIFF " + m.mainBranch + "...HEAD]"
+	case gitModeChanges:
+		modeStr = "[5:CHANGES]"
+	case gitModeCommits:
+		modeStr = "[6:COMMITS " + m.mainBranch + "..HEAD]"
+	case gitModeStash:
+		modeStr = "[7:STASH]"
 	}
 
 	var status string
@@ -605,20 +708,32 @@ func (m *GitViewer) View() tea.View {
 		}
 	}
 	status += " "
+	if m.confirmPrompt != "" {
+		status += m.confirmPrompt + " (y/N) "
+	} else if m.notice != "" {
+		status += m.notice + " "
+	}
 
-	if len(displayLines) > 0 {
+	switch {
+	case m.mode.interactive():
+		// The cursor marks the position in interactive modes
+	case len(displayLines) > 0:
 		status += "Lines " + strconv.Itoa(m.scrollPos+1) + "-" + strconv.Itoa(endPos) + " of " + strconv.Itoa(len(displayLines)) + " "
-	} else {
+	default:
 		status += "(empty) "
 	}
 
 	// Keybindings hint (use pre-computed widths to avoid ansi.StringWidth on each render)
 	statusWidth := ansi.StringWidth(status)
-	hint := gitViewerHintFull
-	padding := m.width - statusWidth - gitViewerHintFullWidth
+	hint := m.hintFull.text
+	padding := m.width - statusWidth - m.hintFull.width
+	if m.mode.interactive() {
+		hint = m.actionsHint()
+		padding = m.width - statusWidth - ansi.StringWidth(hint)
+	}
 	if padding < 0 {
-		hint = gitViewerHintShort
-		padding = m.width - statusWidth - gitViewerHintShortWidth
+		hint = m.hintShort.text
+		padding = m.width - statusWidth - m.hintShort.width
 		if padding < 0 {
 			padding = 0
 		}
@@ -947,6 +1062,9 @@ func (m *GitViewer) copySelection() {
 
 // loadGitOutput loads git output based on the current mode.
 func (m *GitViewer) loadGitOutput() tea.Cmd {
+	if m.mode.interactive() {
+		return m.loadActions()
+	}
 	return func() tea.Msg {
 		var cmd *exec.Cmd
 
@@ -983,8 +1101,9 @@ func (m *GitViewer) loadGitOutput() tea.Cmd {
 }
 
 // RunGitViewer runs the git viewer for the given working directory.
-func RunGitViewer(workDir, mainBranch string) error {
-	m := NewGitViewer(workDir, mainBranch)
+// pawDir locates the project's commit-message prompt for generated messages.
+func RunGitViewer(workDir, mainBranch, pawDir string) error {
+	m := NewGitViewer(workDir, mainBranch, pawDir)
 	p := tea.NewProgram(m)
 	_, err := p.Run()
 	return err
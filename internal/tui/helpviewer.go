@@ -9,14 +9,8 @@ import (
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/charmbracelet/lipgloss/v2"
 	"github.com/charmbracelet/x/ansi"
-)
 
-// Pre-computed status bar hints and their widths (avoids ansi.StringWidth on each render)
-const (
-	helpViewerHintFull       = "↑↓j/k:scroll g/G:top/end ⌃/:close"
-	helpViewerHintShort      = "⌃/:close"
-	helpViewerHintFullWidth  = 33 // ansi.StringWidth - ⌃ char is 1 width
-	helpViewerHintShortWidth = 8  // ansi.StringWidth("⌃/:close")
+	"github.com/dongho-jung/paw/internal/keymap"
 )
 
 // HelpViewer provides an interactive help viewer with vim-like navigation.
@@ -29,6 +23,10 @@ type HelpViewer struct {
 	isDark        bool
 	colors        ThemeColors
 
+	// Status bar hints, rendered from the keymap once (avoids ansi.StringWidth on each render)
+	hintFull  statusHint
+	hintShort statusHint
+
 	// Mouse text selection state
 	selecting    bool
 	hasSelection bool
@@ -44,8 +42,9 @@ type HelpViewer struct {
 }
 
 // NewHelpViewer creates a new help viewer with the given content.
+// Key placeholders in the content are rendered from the effective keymap.
 func NewHelpViewer(content string) *HelpViewer {
-	lines := strings.Split(content, "\n")
+	lines := strings.Split(activeKeymap().RenderHelp(content), "\n")
 	// Remove last empty line if present
 	if len(lines) > 0 && lines[len(lines)-1] == "" {
 		lines = lines[:len(lines)-1]
@@ -54,10 +53,13 @@ func NewHelpViewer(content string) *HelpViewer {
 	// Detect dark mode BEFORE bubbletea starts
 	isDark := DetectDarkMode()
 
+	hintClose := closeHint(keymap.ActionToggleHelp)
 	return &HelpViewer{
-		lines:  lines,
-		isDark: isDark,
-		colors: NewThemeColors(isDark),
+		lines:     lines,
+		isDark:    isDark,
+		colors:    NewThemeColors(isDark),
+		hintFull:  newStatusHint("↑↓j/k:scroll g/G:top/end " + hintClose),
+		hintShort: newStatusHint(hintClose),
 	}
 }
 
@@ -70,7 +72,7 @@ func (m *HelpViewer) Init() tea.Cmd {
 func (m *HelpViewer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.BackgroundColorMsg:
-		m.isDark = msg.IsDark()
+		m.isDark = themeIsDark(msg.IsDark())
 		m.colors = NewThemeColors(m.isDark)
 		m.stylesCached = false // Invalidate style cache on theme change
 		setCachedDarkMode(m.isDark)
@@ -127,7 +129,11 @@ func (m *HelpViewer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // handleKey handles keyboard input.
 func (m *HelpViewer) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
+	if keyMatches(msg, keymap.ActionToggleHelp) {
+		return m, tea.Quit
+	}
+
+	switch translateViewerKey(msg) {
 	// Copy selection with Ctrl+C
 	case "ctrl+c":
 		if m.hasSelection {
@@ -135,8 +141,8 @@ func (m *HelpViewer) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
-	// Close on q, Esc, or Ctrl+/ (which is ctrl+_)
-	case "q", "esc", "ctrl+_", "ctrl+/", "ctrl+shift+/":
+	// Close on q or Esc (the toggle-help key is handled above)
+	case "q", "esc":
 		return m, tea.Quit
 
 	case "down", "j":
@@ -282,11 +288,11 @@ func (m *HelpViewer) View() tea.View {
 	}
 
 	// Keybindings hint (use pre-computed widths to avoid ansi.StringWidth on each render)
-	hint := helpViewerHintFull
-	padding := m.width - len(status) - helpViewerHintFullWidth
+	hint := m.hintFull.text
+	padding := m.width - len(status) - m.hintFull.width
 	if padding < 0 {
-		hint = helpViewerHintShort
-		padding = m.width - len(status) - helpViewerHintShortWidth
+		hint = m.hintShort.text
+		padding = m.width - len(status) - m.hintShort.width
 		if padding < 0 {
 			padding = 0
 		}
@@ -0,0 +1,404 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/v2/textinput"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+
+	"github.com/dongho-jung/paw/internal/keymap"
+	"github.com/dongho-jung/paw/internal/service"
+)
+
+// inboxRefreshInterval is how often the inbox list is reloaded, so entries
+// of agents that resumed disappear.
+const inboxRefreshInterval = 2 * time.Second
+
+type inboxRefreshMsg struct{}
+
+// Inbox lists the questions agents are waiting on and collects answers.
+// Selecting an entry steps through its questions; choosing an option or
+// typing an answer moves to the next one.
+type Inbox struct {
+	load    func() []service.InboxEntry
+	entries []service.InboxEntry
+	cursor  int
+
+	// Answer mode (entry != nil)
+	entry     *service.InboxEntry
+	question  int
+	optCursor int // len(options) is the "type an answer" row
+	checked   map[int]bool
+	answers   []service.InboxAnswer
+	input     textinput.Model
+
+	done   bool
+	now    func() time.Time
+	isDark bool
+	colors ThemeColors
+	width  int
+	height int
+
+	// Style cache (reused across renders)
+	styleTitle    lipgloss.Style
+	styleHeader   lipgloss.Style
+	styleItem     lipgloss.Style
+	styleSelected lipgloss.Style
+	styleHelp     lipgloss.Style
+	styleDim      lipgloss.Style
+	stylesCached  bool
+}
+
+// NewInbox creates an inbox listing the entries returned by load.
+func NewInbox(load func() []service.InboxEntry) *Inbox {
+	isDark := DetectDarkMode()
+
+	ti := textinput.New()
+	ti.Prompt = ""
+	ti.Placeholder = "Type an answer"
+	ti.CharLimit = 0
+	ti.SetWidth(50)
+
+	return &Inbox{
+		load:    load,
+		entries: load(),
+		input:   ti,
+		now:     time.Now,
+		isDark:  isDark,
+		colors:  NewThemeColors(isDark),
+		width:   80,
+		height:  24,
+	}
+}
+
+// Init initializes the inbox.
+func (m *Inbox) Init() tea.Cmd {
+	if _, ok := cachedDarkModeValue(); ok {
+		return inboxRefresh()
+	}
+	return tea.Batch(tea.RequestBackgroundColor, inboxRefresh())
+}
+
+func inboxRefresh() tea.Cmd {
+	return tea.Tick(inboxRefreshInterval, func(time.Time) tea.Msg { return inboxRefreshMsg{} })
+}
+
+// Update handles messages.
+func (m *Inbox) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.input.SetWidth(max(20, m.width-12))
+		return m, nil
+	case tea.BackgroundColorMsg:
+		m.isDark = themeIsDark(msg.IsDark())
+		m.colors = NewThemeColors(m.isDark)
+		m.stylesCached = false
+		setCachedDarkMode(m.isDark)
+		return m, nil
+	case inboxRefreshMsg:
+		// Keep the entry being answered in place
+		if m.entry == nil {
+			m.entries = m.load()
+			m.cursor = max(0, min(m.cursor, len(m.entries)-1))
+		}
+		return m, inboxRefresh()
+	case tea.KeyMsg:
+		if m.entry != nil {
+			return m.updateAnswer(msg)
+		}
+		return m.updateList(msg)
+	}
+
+	if m.entry != nil && m.input.Focused() {
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m *Inbox) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch toggleKey(msg, keymap.ActionInbox) {
+	case "ctrl+c", "esc", "q":
+		return m, tea.Quit
+	case "up", "k", "ctrl+p":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j", "ctrl+n":
+		if m.cursor < len(m.entries)-1 {
+			m.cursor++
+		}
+	case "enter", "space", " ":
+		if m.cursor < len(m.entries) {
+			m.entry = &m.entries[m.cursor]
+			m.answers = nil
+			m.startQuestion(0)
+		}
+	}
+	return m, nil
+}
+
+func (m *Inbox) updateAnswer(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	q := m.entry.Questions[m.question]
+	typeRow := len(q.Options)
+
+	switch key := msg.String(); key {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc":
+		// Back to the list, dropping answers given so far
+		m.entry = nil
+		m.input.Blur()
+		return m, nil
+	case "up", "ctrl+p":
+		if m.optCursor > 0 {
+			m.optCursor--
+		}
+		return m, m.syncInputFocus(typeRow)
+	case "down", "ctrl+n", "tab":
+		if m.optCursor < typeRow {
+			m.optCursor++
+		}
+		return m, m.syncInputFocus(typeRow)
+	case "enter":
+		if m.optCursor == typeRow {
+			text := strings.TrimSpace(m.input.Value())
+			if text == "" {
+				return m, nil
+			}
+			return m, m.answer(service.InboxAnswer{Text: text})
+		}
+		if !q.MultiSelect {
+			return m, m.answer(service.InboxAnswer{Options: []int{m.optCursor}})
+		}
+		if len(m.checked) == 0 {
+			m.checked[m.optCursor] = true
+		}
+		return m, m.answer(service.InboxAnswer{Options: sortedChecked(m.checked)})
+	default:
+		if m.optCursor == typeRow {
+			var cmd tea.Cmd
+			m.input, cmd = m.input.Update(msg)
+			return m, cmd
+		}
+		if (key == "space" || key == " ") && q.MultiSelect {
+			m.checked[m.optCursor] = !m.checked[m.optCursor]
+			return m, nil
+		}
+		if len(key) == 1 && key[0] >= '1' && key[0] <= '9' {
+			idx := int(key[0] - '1')
+			if idx >= len(q.Options) {
+				return m, nil
+			}
+			m.optCursor = idx
+			if q.MultiSelect {
+				m.checked[idx] = !m.checked[idx]
+				return m, nil
+			}
+			return m, m.answer(service.InboxAnswer{Options: []int{idx}})
+		}
+	}
+	return m, nil
+}
+
+// syncInputFocus focuses the text input while the "type an answer" row is selected.
+func (m *Inbox) syncInputFocus(typeRow int) tea.Cmd {
+	if m.optCursor == typeRow {
+		return m.input.Focus()
+	}
+	m.input.Blur()
+	return nil
+}
+
+func (m *Inbox) startQuestion(i int) {
+	m.question = i
+	m.optCursor = 0
+	m.checked = make(map[int]bool)
+	m.input.Reset()
+	m.input.Blur()
+}
+
+// answer records the answer to the current question and moves on.
+func (m *Inbox) answer(a service.InboxAnswer) tea.Cmd {
+	m.answers = append(m.answers, a)
+	if m.question+1 < len(m.entry.Questions) {
+		m.startQuestion(m.question + 1)
+		return nil
+	}
+	m.done = true
+	return tea.Quit
+}
+
+func sortedChecked(checked map[int]bool) []int {
+	var options []int
+	for i, ok := range checked {
+		if ok {
+			options = append(options, i)
+		}
+	}
+	sort.Ints(options)
+	return options
+}
+
+// inboxAge renders how long an agent has been waiting.
+func inboxAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
+
+// View renders the inbox.
+func (m *Inbox) View() tea.View {
+	c := m.colors
+	if !m.stylesCached {
+		m.styleTitle = lipgloss.NewStyle().Bold(true).Foreground(c.Accent)
+		m.styleHeader = lipgloss.NewStyle().Bold(true).Foreground(c.TextNormal)
+		m.styleItem = lipgloss.NewStyle().Foreground(c.TextNormal).PaddingLeft(2)
+		m.styleSelected = lipgloss.NewStyle().Foreground(c.Accent).Bold(true)
+		m.styleHelp = lipgloss.NewStyle().Foreground(c.TextDim).MarginTop(1)
+		m.styleDim = lipgloss.NewStyle().Foreground(c.TextDim)
+		m.stylesCached = true
+	}
+
+	var content string
+	if m.entry != nil {
+		content = m.viewAnswer()
+	} else {
+		content = m.viewList()
+	}
+
+	v := tea.NewView(content)
+	v.AltScreen = true
+	return v
+}
+
+func (m *Inbox) viewList() string {
+	var sb strings.Builder
+	sb.WriteString(m.styleTitle.Render(fmt.Sprintf("Inbox (%d waiting)", len(m.entries))))
+	sb.WriteString("\n\n")
+
+	if len(m.entries) == 0 {
+		sb.WriteString(m.styleDim.Render("  No agent is waiting for an answer"))
+		sb.WriteString("\n")
+	}
+
+	// Each entry takes two lines
+	listHeight := max(1, (m.height-5)/2)
+	start := 0
+	if m.cursor >= listHeight {
+		start = m.cursor - listHeight + 1
+	}
+	end := min(start+listHeight, len(m.entries))
+	textWidth := max(20, m.width-6)
+
+	for i := start; i < end; i++ {
+		e := m.entries[i]
+		meta := m.styleDim.Render(fmt.Sprintf("  %s · %s", e.Session, inboxAge(m.now().Sub(e.AskedAt))))
+		if i == m.cursor {
+			sb.WriteString(m.styleSelected.Render("> "+e.Task) + meta)
+		} else {
+			sb.WriteString(m.styleItem.Render(e.Task) + meta)
+		}
+		sb.WriteString("\n")
+
+		question := ""
+		if len(e.Questions) > 0 {
+			question = e.Questions[0].Question
+		}
+		if more := len(e.Questions) - 1; more > 0 {
+			question += fmt.Sprintf(" (+%d more)", more)
+		}
+		sb.WriteString(m.styleDim.Render("    " + truncateWithEllipsis(question, textWidth)))
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(m.styleHelp.Render("↑/↓: Navigate  Enter: Answer  " + escLabel(keymap.ActionInbox) + ": Close"))
+	return sb.String()
+}
+
+func (m *Inbox) viewAnswer() string {
+	q := m.entry.Questions[m.question]
+	textWidth := max(20, m.width-4)
+
+	var sb strings.Builder
+	sb.WriteString(m.styleTitle.Render(m.entry.Task))
+	sb.WriteString(m.styleDim.Render("  " + m.entry.Session))
+	sb.WriteString("\n\n")
+
+	progress := fmt.Sprintf("Question %d/%d", m.question+1, len(m.entry.Questions))
+	if q.Header != "" {
+		progress += " · " + q.Header
+	}
+	sb.WriteString(m.styleDim.Render(progress))
+	sb.WriteString("\n")
+	sb.WriteString(m.styleHeader.Width(textWidth).Render(q.Question))
+	sb.WriteString("\n\n")
+
+	for i, opt := range q.Options {
+		label := fmt.Sprintf("%d. %s", i+1, opt.Label)
+		if q.MultiSelect {
+			box := "[ ] "
+			if m.checked[i] {
+				box = "[x] "
+			}
+			label = box + label
+		}
+		if i == m.optCursor {
+			sb.WriteString(m.styleSelected.Render("> " + label))
+		} else {
+			sb.WriteString(m.styleItem.Render(label))
+		}
+		if opt.Description != "" {
+			sb.WriteString(m.styleDim.Render("  " + truncateWithEllipsis(opt.Description, max(10, textWidth-lipgloss.Width(label)-4))))
+		}
+		sb.WriteString("\n")
+	}
+
+	prefix := "  ✎ "
+	if m.optCursor == len(q.Options) {
+		prefix = m.styleSelected.Render("> ✎ ")
+	}
+	sb.WriteString(prefix + m.input.View())
+	sb.WriteString("\n")
+
+	help := "↑/↓: Navigate  1-9: Choose  Enter: Answer  Esc: Back"
+	if q.MultiSelect {
+		help = "↑/↓: Navigate  Space/1-9: Toggle  Enter: Answer  Esc: Back"
+	}
+	sb.WriteString(m.styleHelp.Render(help))
+	return sb.String()
+}
+
+// Result returns the answered entry and its answers, or nil if the inbox was
+// closed without answering.
+func (m *Inbox) Result() (*service.InboxEntry, []service.InboxAnswer) {
+	if !m.done {
+		return nil, nil
+	}
+	return m.entry, m.answers
+}
+
+// RunInbox runs the inbox and returns the answered entry and its answers.
+func RunInbox(load func() []service.InboxEntry) (*service.InboxEntry, []service.InboxAnswer, error) {
+	m := NewInbox(load)
+	finalModel, err := tea.NewProgram(m).Run()
+	if err != nil {
+		return nil, nil, err
+	}
+	entry, answers := finalModel.(*Inbox).Result()
+	return entry, answers, nil
+}
@@ -0,0 +1,80 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/dongho-jung/paw/internal/service"
+)
+
+func inboxEntries() []service.InboxEntry {
+	return []service.InboxEntry{{
+		Session:  "api",
+		WindowID: "@2",
+		Task:     "add-cache",
+		AskedAt:  time.Now().Add(-5 * time.Minute),
+		Questions: []service.InboxQuestion{
+			{Header: "Store", Question: "Which cache?", Options: []service.InboxOption{{Label: "Redis"}, {Label: "Memory"}}},
+			{Question: "Which regions?", MultiSelect: true, Options: []service.InboxOption{{Label: "us"}, {Label: "eu"}, {Label: "ap"}}},
+			{Question: "Anything else?", Options: []service.InboxOption{{Label: "No"}}},
+		},
+	}}
+}
+
+func TestInboxAnswerFlow(t *testing.T) {
+	m := NewInbox(inboxEntries)
+
+	view := ansi.Strip(fmt.Sprint(m.View().Layer))
+	if !strings.Contains(view, "add-cache  api · 5m ago") || !strings.Contains(view, "Which cache? (+2 more)") {
+		t.Errorf("list view:\n%s", view)
+	}
+
+	m.Update(keyPress("enter"))
+	m.Update(keyPress("2")) // Memory
+
+	// Multi-select: toggle us and ap
+	m.Update(keyPress("1"))
+	m.Update(keyPress("down"))
+	m.Update(keyPress("down"))
+	m.Update(keyPress("space"))
+	m.Update(keyPress("enter"))
+
+	// Typed answer on the free-text row
+	m.Update(keyPress("down"))
+	for _, r := range "ship it" {
+		m.Update(keyPress(string(r)))
+	}
+	if view := ansi.Strip(fmt.Sprint(m.View().Layer)); !strings.Contains(view, "Question 3/3") {
+		t.Errorf("answer view:\n%s", view)
+	}
+	_, cmd := m.Update(keyPress("enter"))
+	if cmd == nil {
+		t.Fatal("answering the last question should quit")
+	}
+
+	entry, answers := m.Result()
+	if entry == nil || entry.Task != "add-cache" || len(answers) != 3 {
+		t.Fatalf("Result() = %+v, %+v", entry, answers)
+	}
+	if fmt.Sprint(answers[0].Options) != "[1]" || fmt.Sprint(answers[1].Options) != "[0 2]" || answers[2].Text != "ship it" {
+		t.Errorf("answers = %+v", answers)
+	}
+}
+
+func TestInboxBackAndCancel(t *testing.T) {
+	m := NewInbox(inboxEntries)
+	m.Update(keyPress("enter"))
+	m.Update(keyPress("1"))
+	m.Update(keyPress("esc"))
+	if m.entry != nil {
+		t.Fatal("esc should go back to the list")
+	}
+	m.Update(keyPress("esc"))
+	if entry, answers := m.Result(); entry != nil || answers != nil {
+		t.Errorf("Result() after cancel = %+v, %+v", entry, answers)
+	}
+}
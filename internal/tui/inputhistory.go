@@ -9,6 +9,8 @@ import (
 	"github.com/charmbracelet/lipgloss/v2"
 	rw "github.com/mattn/go-runewidth"
 	"github.com/sahilm/fuzzy"
+
+	"github.com/dongho-jung/paw/internal/keymap"
 )
 
 // InputHistoryAction represents the selected action.
@@ -97,15 +99,15 @@ func (m *InputHistoryPicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.input.SetWidth(inputWidth)
 		}
 	case tea.BackgroundColorMsg:
-		m.isDark = msg.IsDark()
+		m.isDark = themeIsDark(msg.IsDark())
 		m.colors = NewThemeColors(m.isDark)
 		m.stylesCached = false // Invalidate style cache on theme change
 		setCachedDarkMode(m.isDark)
 		return m, nil
 
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c", "esc", "ctrl+r":
+		switch toggleKey(msg, keymap.ActionHistorySearch) {
+		case "ctrl+c", "esc":
 			m.action = InputHistoryCancel
 			return m, tea.Quit
 
@@ -329,7 +331,7 @@ func (m *InputHistoryPicker) View() tea.View {
 
 	// Help
 	sb.WriteString("\n")
-	sb.WriteString(m.styleHelp.Render("↑/↓: Navigate  Enter: Select  Esc/⌃R: Cancel"))
+	sb.WriteString(m.styleHelp.Render("↑/↓: Navigate  Enter: Select  " + escLabel(keymap.ActionHistorySearch) + ": Cancel"))
 
 	v := tea.NewView(sb.String())
 	v.AltScreen = true
@@ -2,9 +2,9 @@
 package tui
 
 import (
-	"image/color"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/atotto/clipboard"
 	"github.com/charmbracelet/lipgloss/v2"
@@ -12,12 +12,13 @@ import (
 	"github.com/mattn/go-runewidth"
 
 	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/logging"
 	"github.com/dongho-jung/paw/internal/service"
 )
 
 const (
 	kanbanMinColumnWidth = 15
-	kanbanColumnGap      = 6
+	kanbanColumnBorder   = 2 // Left and right border of each column
 	kanbanHeaderLines    = 2
 	kanbanTaskIndent     = 2
 )
@@ -40,7 +41,8 @@ var hintPatterns = []string{
 }
 
 // KanbanView renders a Kanban-style task board.
-// The board has 3 columns: Working, Waiting, Done.
+// Columns are configurable (kanban_columns in the global config); the default
+// board is Backlog, Blocked, Working, Needs Input, In Review and Done.
 type KanbanView struct {
 	width   int
 	height  int
@@ -48,25 +50,27 @@ type KanbanView struct {
 	service *service.TaskDiscoveryService
 
 	// Cached task data (refreshed on tick, not on every render)
-	// 3 columns: Working, Waiting, Done (Warning merged into Waiting)
-	working        []*service.DiscoveredTask
-	waiting        []*service.DiscoveredTask
-	done           []*service.DiscoveredTask
-	taskCountCache int // Cached total task count, updated on Refresh()
+	// Each column keeps its discovered tasks, visible (filtered) tasks and selection.
+	columns        []*kanbanColumn // Configured columns
+	visible        []*kanbanColumn // Displayed columns (empty columns are hidden when space is short)
+	taskCountCache int             // Cached total task count, updated on Refresh()
+
+	// Active (persisted) filter bar state
+	filter     KanbanFilter
+	filterPath string // Per-user filter file ("" disables persistence)
 
 	// Scroll state
 	scrollOffset int
 	focused      bool
-	focusedCol   int // -1 = none, 0-2 = specific column
+	focusedCol   int // -1 = none, otherwise index into visible columns
 
-	// Task selection state (per column)
-	// -1 = no task selected, 0+ = index of selected task
-	selectedTaskIdx [3]int
+	// Tasks marked for a bulk action (key: session/window ID)
+	marked map[string]bool
 
 	// Text selection state (column-aware)
 	selecting     bool
 	hasSelection  bool // True if a selection was made (persists until ClearSelection)
-	selectColumn  int  // Column being selected (0-2), -1 if none
+	selectColumn  int  // Column being selected, -1 if none
 	selectStartX  int  // Start X position (relative to column)
 	selectStartY  int  // Start row (relative to kanban top)
 	selectEndX    int  // End X position (relative to column)
@@ -86,6 +90,7 @@ type KanbanView struct {
 	lastRenderScroll    int
 	lastRenderTaskCount int
 	lastRenderSelection bool
+	lastRenderColumns   int
 
 	// Style cache (invalidated on theme change)
 	stylesCached      bool
@@ -93,7 +98,9 @@ type KanbanView struct {
 	styleTaskName     lipgloss.Style
 	styleSelectedTask lipgloss.Style
 	styleAction       lipgloss.Style
+	styleCard         lipgloss.Style
 	styleHighlight    lipgloss.Style
+	styleLane         lipgloss.Style
 
 	// Separator cache (invalidated on width change)
 	cachedSeparator      string
@@ -102,12 +109,17 @@ type KanbanView struct {
 
 // NewKanbanView creates a new Kanban view.
 func NewKanbanView(isDark bool) *KanbanView {
+	filterPath := kanbanFilterPath()
+	columns := loadKanbanColumns()
 	return &KanbanView{
-		isDark:          isDark,
-		service:         service.NewTaskDiscoveryService(),
-		focusedCol:      -1,                 // No column focused initially
-		selectColumn:    -1,                 // No column selected initially
-		selectedTaskIdx: [3]int{-1, -1, -1}, // No task selected in any column
+		isDark:       isDark,
+		filterPath:   filterPath,
+		filter:       loadKanbanFilter(filterPath),
+		service:      service.NewTaskDiscoveryService(),
+		columns:      columns,
+		visible:      columns,
+		focusedCol:   -1, // No column focused initially
+		selectColumn: -1, // No column selected initially
 	}
 }
 
@@ -123,13 +135,68 @@ func (k *KanbanView) SetDarkMode(isDark bool) {
 func (k *KanbanView) SetSize(width, height int) {
 	k.width = width
 	k.height = height
+	k.updateVisibleColumns()
+}
+
+// fitsColumns reports whether n columns fit the board width.
+func (k *KanbanView) fitsColumns(n int) bool {
+	return n > 0 && k.width >= n*(kanbanMinColumnWidth+kanbanColumnBorder)
+}
+
+// updateVisibleColumns picks the displayed columns. When the board is too narrow
+// for all configured columns, empty columns are hidden (extra columns such as
+// Backlog or In Review before Working, Needs Input and Done).
+func (k *KanbanView) updateVisibleColumns() {
+	var focused *kanbanColumn
+	if k.focusedCol >= 0 && k.focusedCol < len(k.visible) {
+		focused = k.visible[k.focusedCol]
+	}
+
+	visible := append([]*kanbanColumn(nil), k.columns...)
+	for _, core := range []bool{false, true} {
+		for i := len(visible) - 1; i >= 0 && !k.fitsColumns(len(visible)); i-- {
+			if len(visible[i].tasks) == 0 && visible[i].isCore() == core {
+				visible = append(visible[:i], visible[i+1:]...)
+			}
+		}
+	}
+	k.visible = visible
+
+	// Keep the focus on the same column
+	k.focusedCol = -1
+	for i, col := range visible {
+		if col == focused {
+			k.focusedCol = i
+		}
+	}
+	k.invalidateCache()
+}
+
+// column returns the visible column at index col, or nil.
+func (k *KanbanView) column(col int) *kanbanColumn {
+	if col < 0 || col >= len(k.visible) {
+		return nil
+	}
+	return k.visible[col]
+}
+
+// ColumnCount returns the number of displayed columns.
+func (k *KanbanView) ColumnCount() int {
+	return len(k.visible)
+}
+
+// ColumnHasStatus reports whether a displayed column shows tasks of the given status.
+func (k *KanbanView) ColumnHasStatus(col int, status service.DiscoveredStatus) bool {
+	c := k.column(col)
+	return c != nil && c.hasStatus(status)
 }
 
 func (k *KanbanView) baseColumnWidth() int {
-	if k.width < kanbanMinColumnWidth*3+kanbanColumnGap {
+	n := len(k.visible)
+	if !k.fitsColumns(n) {
 		return 0
 	}
-	return (k.width - kanbanColumnGap) / 3
+	return (k.width - n*kanbanColumnBorder) / n
 }
 
 func (k *KanbanView) columnContentWidth() int {
@@ -171,10 +238,65 @@ func (k *KanbanView) taskAreaHeight() int {
 // Refresh updates the cached task data by discovering all tasks.
 // This should be called periodically (e.g., on tick) rather than on every render.
 func (k *KanbanView) Refresh() {
-	k.working, k.waiting, k.done = k.service.DiscoverAll()
+	k.setTasks(k.service.DiscoverAll())
+}
+
+// setTasks distributes discovered tasks into columns by status.
+// Tasks whose status has no configured column are not shown.
+func (k *KanbanView) setTasks(tasks []*service.DiscoveredTask) {
+	for _, col := range k.columns {
+		col.all = col.all[:0]
+	}
+	for _, task := range tasks {
+		for _, col := range k.columns {
+			if col.hasStatus(task.Status) {
+				col.all = append(col.all, task)
+				break
+			}
+		}
+	}
+	k.pruneMarks()
+	k.applyFilter()
+}
+
+// applyFilter rebuilds the visible column tasks from the discovered tasks.
+func (k *KanbanView) applyFilter() {
+	now := time.Now()
+	count := 0
+	for _, col := range k.columns {
+		col.tasks = k.filter.Apply(col.all, now)
+		count += len(col.tasks)
+	}
 	// Update cached task count to avoid recalculating in isCacheValid/updateCacheState
-	k.taskCountCache = len(k.working) + len(k.waiting) + len(k.done)
-	k.invalidateCache() // Task data changed, need to re-render
+	k.taskCountCache = count
+	k.updateVisibleColumns() // Also invalidates the render cache
+}
+
+// Filter returns the active filter.
+func (k *KanbanView) Filter() KanbanFilter {
+	return k.filter
+}
+
+// SetFilter applies a filter to the board and persists it for the user.
+func (k *KanbanView) SetFilter(f KanbanFilter) {
+	k.filter = f
+	k.scrollOffset = 0
+	k.applyFilter()
+	if err := saveKanbanFilter(k.filterPath, f); err != nil {
+		logging.Warn("Failed to save kanban filter: %v", err)
+	}
+}
+
+// laneHeader returns the swimlane header shown before tasks[idx], or "" if none.
+// Swimlanes group each column by project when the filter enables grouping.
+func (k *KanbanView) laneHeader(tasks []*service.DiscoveredTask, idx int) string {
+	if !k.filter.Group {
+		return ""
+	}
+	if idx > 0 && tasks[idx-1].Session == tasks[idx].Session {
+		return ""
+	}
+	return "▸ " + tasks[idx].Session
 }
 
 // invalidateCache marks the render cache as invalid.
@@ -196,7 +318,8 @@ func (k *KanbanView) isCacheValid() bool {
 		k.focusedCol != k.lastRenderFocusCol ||
 		k.scrollOffset != k.lastRenderScroll ||
 		k.taskCountCache != k.lastRenderTaskCount ||
-		k.hasSelection != k.lastRenderSelection {
+		k.hasSelection != k.lastRenderSelection ||
+		len(k.visible) != k.lastRenderColumns {
 		return false
 	}
 	return true
@@ -214,6 +337,7 @@ func (k *KanbanView) updateCacheState() {
 	// Use cached taskCountCache instead of recalculating
 	k.lastRenderTaskCount = k.taskCountCache
 	k.lastRenderSelection = k.hasSelection
+	k.lastRenderColumns = len(k.visible)
 }
 
 // Render renders the Kanban board using cached task data.
@@ -224,37 +348,34 @@ func (k *KanbanView) Render() string {
 		return k.cachedRender
 	}
 
-	// Use cached task data (populated by Refresh())
-	// 3 columns: Working, Waiting, Done (Warning merged into Waiting)
-	working, waiting, done := k.working, k.waiting, k.done
-
 	// Update style cache if needed (only on theme change)
 	if !k.stylesCached {
 		lightDark := lipgloss.LightDark(k.isDark)
-		normalColor := lightDark(lipgloss.Color("236"), lipgloss.Color("252"))
-		dimColor := lightDark(lipgloss.Color("245"), lipgloss.Color("243"))
+		normalColor := themeColor("text", lightDark(lipgloss.Color("236"), lipgloss.Color("252")))
+		dimColor := themeColor("text_dim", lightDark(lipgloss.Color("245"), lipgloss.Color("243")))
+		accentColor := themeColor("accent", lipgloss.Color("39"))
+		invertedColor := themeColor("text_inverted", lipgloss.Color("231"))
 
 		k.styleHeader = lipgloss.NewStyle().Bold(true).Foreground(normalColor)
 		k.styleTaskName = lipgloss.NewStyle().Foreground(normalColor)
 		k.styleSelectedTask = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("231")).
-			Background(lipgloss.Color("39")).
+			Foreground(invertedColor).
+			Background(accentColor).
 			Bold(true)
 		k.styleAction = lipgloss.NewStyle().Foreground(dimColor).Italic(true)
+		k.styleCard = lipgloss.NewStyle().Foreground(dimColor)
 		k.styleHighlight = lipgloss.NewStyle().
-			Background(lipgloss.Color("39")).
-			Foreground(lipgloss.Color("231"))
+			Background(accentColor).
+			Foreground(invertedColor)
+		k.styleLane = lipgloss.NewStyle().Bold(true).Foreground(dimColor)
 		k.stylesCached = true
 	}
 
-	// Use cached styles
-	lightDark := lipgloss.LightDark(k.isDark)
-
-	// Calculate column width (3 columns with gaps)
+	// Calculate column width (displayed columns with borders)
 	// Minimum width per column
-	// columnGap accounts for borders only (padding is included in lipgloss Width):
+	// kanbanColumnBorder accounts for borders only (padding is included in lipgloss Width):
 	// - In lipgloss v2, Width(X) sets content+padding width, then border is added
-	// - Borders: 3 columns × 2 chars = 6
+	// - Borders: 2 chars per column
 	// Note: Scrollbar (2 chars) is added separately after columns, not included here
 	columnWidth := k.baseColumnWidth()
 	if columnWidth == 0 {
@@ -262,31 +383,16 @@ func (k *KanbanView) Render() string {
 	}
 
 	// Build each column
-	// Colors are chosen to have good contrast on both light and dark backgrounds
-	// Using bold text with medium-saturation colors for visibility
-	workingColor := lightDark(lipgloss.Color("28"), lipgloss.Color("34"))   // Dark green / Bright green
-	waitingColor := lightDark(lipgloss.Color("130"), lipgloss.Color("178")) // Dark orange / Gold
-	doneColor := lightDark(lipgloss.Color("240"), lipgloss.Color("250"))    // Dark gray / Light gray
-
-	columns := []struct {
-		emoji string
-		title string
-		tasks []*service.DiscoveredTask
-		color color.Color
-	}{
-		{constants.EmojiWorking, "Working", working, workingColor},
-		{constants.EmojiWaiting, "Waiting", waiting, waitingColor},
-		{constants.EmojiDone, "Done", done, doneColor},
-	}
-
-	columnViews := make([]string, 0, 3) // Pre-allocate for 3 columns
-	maxHeight := k.height - 2           // Reserve space for border only (no title)
-
-	for colIdx, col := range columns {
+	filtered := !k.filter.IsZero()
+
+	columnViews := make([]string, 0, len(k.visible))
+	maxHeight := k.height - 2 // Reserve space for border only (no title)
+
+	for colIdx, col := range k.visible {
 		// Determine border color for this column (use foreground from cached action style for dim)
 		borderColor := k.styleAction.GetForeground()
 		if k.focused && k.focusedCol == colIdx {
-			borderColor = lipgloss.Color("39")
+			borderColor = k.styleHighlight.GetBackground()
 		}
 		panelStyle := lipgloss.NewStyle().
 			BorderStyle(lipgloss.RoundedBorder()).
@@ -295,8 +401,12 @@ func (k *KanbanView) Render() string {
 		var content strings.Builder
 
 		// Column header (use string concatenation to avoid fmt.Sprintf, use cached style)
-		colHeaderStyle := k.styleHeader.Foreground(col.color)
-		header := col.emoji + " " + col.title + " (" + strconv.Itoa(len(col.tasks)) + ")"
+		colHeaderStyle := k.styleHeader.Foreground(kanbanStatusColor(col.statuses[0], k.isDark))
+		count := strconv.Itoa(len(col.tasks))
+		if filtered && len(col.tasks) != len(col.all) {
+			count += "/" + strconv.Itoa(len(col.all))
+		}
+		header := col.emoji + " " + col.title + " (" + count + ")"
 		content.WriteString(colHeaderStyle.Render(header))
 		content.WriteString("\n")
 		content.WriteString(k.getSeparator(columnWidth))
@@ -315,6 +425,7 @@ func (k *KanbanView) Render() string {
 		actionLinesPerTask := calculateActionLinesPerTask(contentHeight, len(col.tasks))
 
 		linesToSkip := k.scrollOffset
+		now := time.Now()
 
 		for taskIdx, task := range col.tasks {
 			if linesUsed >= maxHeight {
@@ -322,29 +433,45 @@ func (k *KanbanView) Render() string {
 			}
 
 			// Full task display name: session/taskName (using camelCase for task name)
-			camelTaskName := constants.ToCamelCase(task.Name)
-			fullName := task.Session + "/" + camelTaskName
+			// Backlog drafts have no task name yet and show their title as is
+			fullName := task.Session + "/" + constants.ToCamelCase(task.Name)
+			if task.BacklogID != "" {
+				fullName = task.Session + "/" + task.Name
+			}
 
 			// Determine if this task is selected
-			isSelected := k.focused && k.focusedCol == colIdx && k.selectedTaskIdx[colIdx] == taskIdx
+			isSelected := k.focused && k.focusedCol == colIdx && col.selected == taskIdx
 
 			// Build the display name (no metadata on name line anymore)
+			// Columns with several statuses mark tasks not matching the column emoji
 			displayName := fullName
-			if task.Status == service.DiscoveredWaiting &&
-				(task.StatusEmoji == constants.EmojiReview || task.StatusEmoji == constants.EmojiWarning) {
+			if k.marked[kanbanMarkKey(task)] {
+				displayName = "● " + displayName
+			}
+			if len(col.statuses) > 1 && task.StatusEmoji != "" && task.StatusEmoji != col.emoji {
 				displayName = task.StatusEmoji + " " + displayName
 			}
+			// Reviewed tasks show how many changed files still need a look
+			if badge := unreviewedBadge(task.Unreviewed); badge != "" && availableWidth > 2*lipgloss.Width(badge) {
+				displayName = truncateWithEllipsis(displayName, availableWidth-lipgloss.Width(badge)) + badge
+			}
 			displayName = truncateWithEllipsis(displayName, availableWidth)
 
 			// Build task lines for scrolling (name + detail lines, use cached styles)
 			// Pre-allocate with estimated capacity (1 name line + actionLinesPerTask detail lines)
-			taskLines := make([]string, 0, 1+actionLinesPerTask)
+			taskLines := make([]string, 0, 2+actionLinesPerTask)
+			if lane := k.laneHeader(col.tasks, taskIdx); lane != "" {
+				taskLines = append(taskLines, k.styleLane.Render(truncateWithEllipsis(lane, availableWidth)))
+			}
 			if isSelected {
 				taskLines = append(taskLines, k.styleSelectedTask.Render(displayName))
 			} else {
 				taskLines = append(taskLines, k.styleTaskName.Render(displayName))
 			}
 
+			for _, line := range buildCardLines(task, availableWidth, now) {
+				taskLines = append(taskLines, k.styleCard.Render(kanbanIndentStr+line))
+			}
 			detailLines := buildTaskDetailLines(task, actionLinesPerTask, availableWidth)
 			for _, line := range detailLines {
 				taskLines = append(taskLines, k.styleAction.Render(kanbanIndentStr+line))
@@ -405,12 +532,12 @@ func (k *KanbanView) Render() string {
 
 // HasTasks returns true if there are any cached tasks to display.
 func (k *KanbanView) HasTasks() bool {
-	return len(k.working)+len(k.waiting)+len(k.done) > 0
+	return k.TaskCount() > 0
 }
 
 // TaskCount returns the total number of cached tasks.
 func (k *KanbanView) TaskCount() int {
-	return len(k.working) + len(k.waiting) + len(k.done)
+	return k.taskCountCache
 }
 
 // SetFocused sets the focus state of the kanban view.
@@ -429,9 +556,9 @@ func (k *KanbanView) IsFocused() bool {
 	return k.focused
 }
 
-// SetFocusedColumn sets which column is focused (0-2), or -1 for none.
+// SetFocusedColumn sets which column is focused, or -1 for none.
 func (k *KanbanView) SetFocusedColumn(col int) {
-	if col >= -1 && col < 3 {
+	if col >= -1 && col < len(k.visible) {
 		k.focusedCol = col
 	}
 }
@@ -443,35 +570,30 @@ func (k *KanbanView) FocusedColumn() int {
 
 // ColumnTaskCount returns the number of tasks in a specific column.
 func (k *KanbanView) ColumnTaskCount(col int) int {
-	switch col {
-	case 0:
-		return len(k.working)
-	case 1:
-		return len(k.waiting)
-	case 2:
-		return len(k.done)
-	default:
-		return 0
+	if c := k.column(col); c != nil {
+		return len(c.tasks)
 	}
+	return 0
 }
 
 // SelectedTaskIndex returns the selected task index for a column (-1 if none).
 func (k *KanbanView) SelectedTaskIndex(col int) int {
-	if col < 0 || col > 2 {
-		return -1
+	if c := k.column(col); c != nil {
+		return c.selected
 	}
-	return k.selectedTaskIdx[col]
+	return -1
 }
 
 // SetSelectedTaskIndex sets the selected task index for a column.
 func (k *KanbanView) SetSelectedTaskIndex(col, idx int) {
-	if col < 0 || col > 2 {
+	c := k.column(col)
+	if c == nil {
 		return
 	}
-	taskCount := k.ColumnTaskCount(col)
+	taskCount := len(c.tasks)
 	if taskCount == 0 {
-		if k.selectedTaskIdx[col] != -1 {
-			k.selectedTaskIdx[col] = -1
+		if c.selected != -1 {
+			c.selected = -1
 			k.invalidateCache()
 		}
 		return
@@ -482,54 +604,48 @@ func (k *KanbanView) SetSelectedTaskIndex(col, idx int) {
 	} else if idx >= taskCount {
 		idx = taskCount - 1
 	}
-	if k.selectedTaskIdx[col] != idx {
-		k.selectedTaskIdx[col] = idx
+	if c.selected != idx {
+		c.selected = idx
 		k.invalidateCache()
 	}
 }
 
 // SelectPreviousTask moves selection up in the focused column.
 func (k *KanbanView) SelectPreviousTask() {
-	if k.focusedCol < 0 || k.focusedCol > 2 {
+	c := k.column(k.focusedCol)
+	if c == nil || len(c.tasks) == 0 {
 		return
 	}
-	taskCount := k.ColumnTaskCount(k.focusedCol)
-	if taskCount == 0 {
-		return
-	}
-	current := k.selectedTaskIdx[k.focusedCol]
+	current := c.selected
 	var next int
 	if current <= 0 {
 		// Wrap to last task
-		next = taskCount - 1
+		next = len(c.tasks) - 1
 	} else {
 		next = current - 1
 	}
 	if next != current {
-		k.selectedTaskIdx[k.focusedCol] = next
+		c.selected = next
 		k.invalidateCache()
 	}
 }
 
 // SelectNextTask moves selection down in the focused column.
 func (k *KanbanView) SelectNextTask() {
-	if k.focusedCol < 0 || k.focusedCol > 2 {
-		return
-	}
-	taskCount := k.ColumnTaskCount(k.focusedCol)
-	if taskCount == 0 {
+	c := k.column(k.focusedCol)
+	if c == nil || len(c.tasks) == 0 {
 		return
 	}
-	current := k.selectedTaskIdx[k.focusedCol]
+	current := c.selected
 	var next int
-	if current < 0 || current >= taskCount-1 {
+	if current < 0 || current >= len(c.tasks)-1 {
 		// Wrap to first task
 		next = 0
 	} else {
 		next = current + 1
 	}
 	if next != current {
-		k.selectedTaskIdx[k.focusedCol] = next
+		c.selected = next
 		k.invalidateCache()
 	}
 }
@@ -537,41 +653,36 @@ func (k *KanbanView) SelectNextTask() {
 // InitializeColumnSelection initializes selection when a column gains focus.
 // If the column has tasks and no selection, selects the first task.
 func (k *KanbanView) InitializeColumnSelection(col int) {
-	if col < 0 || col > 2 {
+	c := k.column(col)
+	if c != nil && len(c.tasks) > 0 && c.selected < 0 {
+		c.selected = 0
+		k.invalidateCache()
+	}
+}
+
+// SelectTask focuses a column and selects the given task in it.
+func (k *KanbanView) SelectTask(col int, task *service.DiscoveredTask) {
+	c := k.column(col)
+	if c == nil {
 		return
 	}
-	taskCount := k.ColumnTaskCount(col)
-	if taskCount > 0 && k.selectedTaskIdx[col] < 0 {
-		k.selectedTaskIdx[col] = 0
-		k.invalidateCache()
+	for i, t := range c.tasks {
+		if t == task {
+			k.SetFocusedColumn(col)
+			k.SetSelectedTaskIndex(col, i)
+			return
+		}
 	}
 }
 
 // GetSelectedTask returns the currently selected task in the focused column.
 // Returns nil if no column is focused or no task is selected.
 func (k *KanbanView) GetSelectedTask() *service.DiscoveredTask {
-	if k.focusedCol < 0 || k.focusedCol > 2 {
-		return nil
-	}
-	idx := k.selectedTaskIdx[k.focusedCol]
-	if idx < 0 {
-		return nil
-	}
-
-	var tasks []*service.DiscoveredTask
-	switch k.focusedCol {
-	case 0:
-		tasks = k.working
-	case 1:
-		tasks = k.waiting
-	case 2:
-		tasks = k.done
-	}
-
-	if idx >= len(tasks) {
+	c := k.column(k.focusedCol)
+	if c == nil || c.selected < 0 || c.selected >= len(c.tasks) {
 		return nil
 	}
-	return tasks[idx]
+	return c.tasks[c.selected]
 }
 
 // ColumnWidth returns the width of each column (including border and padding).
@@ -626,14 +737,19 @@ func (k *KanbanView) maxTaskLinesInAnyColumn() int {
 		return 0
 	}
 	availableWidth := k.columnContentWidth()
-	columns := [][]*service.DiscoveredTask{k.working, k.waiting, k.done}
+	now := time.Now()
 	maxLines := 0
-	for _, tasks := range columns {
+	for _, col := range k.visible {
+		tasks := col.tasks
 		lines := 0
 		actionLinesPerTask := calculateActionLinesPerTask(contentHeight, len(tasks))
-		for _, task := range tasks {
+		for i, task := range tasks {
+			cardLines := buildCardLines(task, availableWidth, now)
 			detailLines := buildTaskDetailLines(task, actionLinesPerTask, availableWidth)
-			lines += 1 + len(detailLines)
+			lines += 1 + len(cardLines) + len(detailLines)
+			if k.laneHeader(tasks, i) != "" {
+				lines++
+			}
 		}
 		if lines > maxLines {
 			maxLines = lines
@@ -663,7 +779,7 @@ func (k *KanbanView) renderScrollbar(visibleHeight int) string {
 }
 
 // StartSelection starts text selection at the given position within a column.
-// col is the column index (0-3), x is the position within the column, y is the row.
+// col is the displayed column index, x is the position within the column, y is the row.
 func (k *KanbanView) StartSelection(col, x, y int) {
 	k.selecting = true
 	k.hasSelection = true
@@ -786,7 +902,7 @@ func (k *KanbanView) CopySelection() error {
 		return nil
 	}
 
-	if k.selectColumn < 0 || k.selectColumn > 2 {
+	if k.column(k.selectColumn) == nil {
 		return nil // No valid column selected
 	}
 
@@ -866,7 +982,7 @@ func displayColToByteOffset(s string, displayCol int) int {
 // applySelectionHighlight applies selection highlight to the rendered board.
 // Selection is column-aware: only highlights within the selected column's boundaries.
 func (k *KanbanView) applySelectionHighlight(board string) string {
-	if k.selectColumn < 0 || k.selectColumn > 2 {
+	if k.column(k.selectColumn) == nil {
 		return board // No valid column selected
 	}
 
@@ -943,13 +1059,91 @@ func (k *KanbanView) cacheTextForCopy(board string) {
 	}
 }
 
+// kanbanMarkKey identifies a task across refreshes.
+func kanbanMarkKey(task *service.DiscoveredTask) string {
+	return task.Session + "/" + task.WindowID
+}
+
+// ToggleMark marks or unmarks a task for a bulk action and reports whether
+// it is marked now. Backlog drafts cannot be marked.
+func (k *KanbanView) ToggleMark(task *service.DiscoveredTask) bool {
+	if task == nil || task.BacklogID != "" {
+		return false
+	}
+	if k.marked == nil {
+		k.marked = make(map[string]bool)
+	}
+	key := kanbanMarkKey(task)
+	if k.marked[key] {
+		delete(k.marked, key)
+	} else {
+		k.marked[key] = true
+	}
+	k.invalidateCache()
+	return k.marked[key]
+}
+
+// MarkedTasks returns the marked tasks in board order, including marked
+// tasks hidden by the filter.
+func (k *KanbanView) MarkedTasks() []*service.DiscoveredTask {
+	var tasks []*service.DiscoveredTask
+	for _, col := range k.columns {
+		for _, task := range col.all {
+			if k.marked[kanbanMarkKey(task)] {
+				tasks = append(tasks, task)
+			}
+		}
+	}
+	return tasks
+}
+
+// AllTasks returns the discovered tasks in board order, including tasks
+// hidden by the filter.
+func (k *KanbanView) AllTasks() []*service.DiscoveredTask {
+	var tasks []*service.DiscoveredTask
+	for _, col := range k.columns {
+		tasks = append(tasks, col.all...)
+	}
+	return tasks
+}
+
+// MarkedCount returns the number of marked tasks.
+func (k *KanbanView) MarkedCount() int {
+	return len(k.marked)
+}
+
+// ClearMarks unmarks all tasks.
+func (k *KanbanView) ClearMarks() {
+	if len(k.marked) > 0 {
+		k.marked = nil
+		k.invalidateCache()
+	}
+}
+
+// pruneMarks drops the marks of tasks that are gone from the board.
+func (k *KanbanView) pruneMarks() {
+	if len(k.marked) == 0 {
+		return
+	}
+	present := make(map[string]bool, len(k.marked))
+	for _, col := range k.columns {
+		for _, task := range col.all {
+			if key := kanbanMarkKey(task); k.marked[key] && task.BacklogID == "" {
+				present[key] = true
+			}
+		}
+	}
+	k.marked = present
+}
+
 // NextNonEmptyColumn finds the next column with tasks, starting from currentCol+1.
 // Wraps around to column 0 if no column is found after currentCol.
 // Returns -1 if all columns are empty.
 func (k *KanbanView) NextNonEmptyColumn(currentCol int) int {
+	n := len(k.visible)
 	// Try columns after currentCol first
-	for i := 1; i <= 3; i++ {
-		col := (currentCol + i) % 3
+	for i := 1; i <= n; i++ {
+		col := ((currentCol+i)%n + n) % n
 		if k.ColumnTaskCount(col) > 0 {
 			return col
 		}
@@ -958,12 +1152,13 @@ func (k *KanbanView) NextNonEmptyColumn(currentCol int) int {
 }
 
 // PrevNonEmptyColumn finds the previous column with tasks, starting from currentCol-1.
-// Wraps around to column 2 if no column is found before currentCol.
+// Wraps around to the last column if no column is found before currentCol.
 // Returns -1 if all columns are empty.
 func (k *KanbanView) PrevNonEmptyColumn(currentCol int) int {
+	n := len(k.visible)
 	// Try columns before currentCol first
-	for i := 1; i <= 3; i++ {
-		col := (currentCol - i + 3) % 3
+	for i := 1; i <= n; i++ {
+		col := ((currentCol-i)%n + n) % n
 		if k.ColumnTaskCount(col) > 0 {
 			return col
 		}
@@ -971,10 +1166,10 @@ func (k *KanbanView) PrevNonEmptyColumn(currentCol int) int {
 	return -1 // All columns empty
 }
 
-// FirstNonEmptyColumn returns the first column (0-2) that has tasks.
+// FirstNonEmptyColumn returns the first column that has tasks.
 // Returns -1 if all columns are empty.
 func (k *KanbanView) FirstNonEmptyColumn() int {
-	for col := 0; col < 3; col++ {
+	for col := range k.visible {
 		if k.ColumnTaskCount(col) > 0 {
 			return col
 		}
@@ -983,27 +1178,18 @@ func (k *KanbanView) FirstNonEmptyColumn() int {
 }
 
 // GetTaskAtPosition returns the task at the given column and row position.
-// col is the column index (0-2: working, waiting, done).
+// col is the displayed column index.
 // row is the Y position relative to the kanban area (0-indexed).
 // Returns nil if no task is found at that position.
 func (k *KanbanView) GetTaskAtPosition(col, row int) *service.DiscoveredTask {
-	if col < 0 || col > 2 {
+	c := k.column(col)
+	if c == nil {
 		return nil
 	}
 
 	contentHeight := k.taskAreaHeight()
 	availableWidth := k.columnContentWidth()
-
-	// Get the task list for the column
-	var tasks []*service.DiscoveredTask
-	switch col {
-	case 0:
-		tasks = k.working
-	case 1:
-		tasks = k.waiting
-	case 2:
-		tasks = k.done
-	}
+	tasks := c.tasks
 
 	if len(tasks) == 0 {
 		return nil
@@ -1026,12 +1212,22 @@ func (k *KanbanView) GetTaskAtPosition(col, row int) *service.DiscoveredTask {
 	}
 
 	// Find which task is at the adjusted row
-	// Each task takes 1 line (name) + N detail lines
+	// Each task takes 1 line (name) + card lines + N detail lines
 	actionLinesPerTask := calculateActionLinesPerTask(contentHeight, len(tasks))
+	now := time.Now()
 	currentLine := 0
-	for _, task := range tasks {
+	for i, task := range tasks {
+		// Swimlane headers take a line but belong to no task
+		if k.laneHeader(tasks, i) != "" {
+			if adjustedRow == currentLine {
+				return nil
+			}
+			currentLine++
+		}
+
+		cardLines := buildCardLines(task, availableWidth, now)
 		detailLines := buildTaskDetailLines(task, actionLinesPerTask, availableWidth)
-		taskLines := 1 + len(detailLines)
+		taskLines := 1 + len(cardLines) + len(detailLines)
 
 		if adjustedRow >= currentLine && adjustedRow < currentLine+taskLines {
 			return task
@@ -1042,6 +1238,106 @@ func (k *KanbanView) GetTaskAtPosition(col, row int) *service.DiscoveredTask {
 	return nil
 }
 
+// unreviewedBadge returns the card suffix for files not yet viewed in the diff
+// viewer, e.g. " ±3".
+func unreviewedBadge(count int) string {
+	if count <= 0 {
+		return ""
+	}
+	return " ±" + strconv.Itoa(count)
+}
+
+// kanbanCardBadgeLines caps how many lines the card badges may wrap to.
+const kanbanCardBadgeLines = 2
+
+// buildCardLines returns the card lines shown under a task name: badges for
+// the diff against main (+added -removed, files), commits ahead (↑) and main
+// commits since branching (↓), verification and hook results, the PR, the
+// model and the time since the last agent activity, then the subject of the
+// last task commit.
+func buildCardLines(task *service.DiscoveredTask, availableWidth int, now time.Time) []string {
+	width := availableWidth - kanbanTaskIndent
+	if width <= 0 {
+		return nil
+	}
+	d := task.Details
+
+	var badges []string
+	if d.Diff.Files > 0 {
+		files := strconv.Itoa(d.Diff.Files) + " files"
+		if d.Diff.Files == 1 {
+			files = "1 file"
+		}
+		badges = append(badges, "+"+strconv.Itoa(d.Diff.Added)+" -"+strconv.Itoa(d.Diff.Removed), files)
+	}
+	var commits []string
+	if d.Ahead > 0 {
+		commits = append(commits, "↑"+strconv.Itoa(d.Ahead))
+	}
+	if d.Behind > 0 {
+		commits = append(commits, "↓"+strconv.Itoa(d.Behind))
+	}
+	if len(commits) > 0 {
+		badges = append(badges, strings.Join(commits, " "))
+	}
+	switch d.Verify {
+	case service.VerifyPassed:
+		badges = append(badges, "✓ verify")
+	case service.VerifyFailed:
+		badges = append(badges, "✗ verify")
+	}
+	if d.Hook != nil {
+		mark := "✓ "
+		if d.Hook.Status != "success" {
+			mark = "✗ "
+		}
+		badges = append(badges, mark+d.Hook.Name)
+	}
+	if d.PRNumber > 0 {
+		pr := "#" + strconv.Itoa(d.PRNumber)
+		if d.PRState != "" {
+			pr += " " + d.PRState
+		}
+		badges = append(badges, pr)
+	}
+	if task.Model != "" {
+		badges = append(badges, task.Model)
+	}
+	if !task.LastActivity.IsZero() {
+		badges = append(badges, inboxAge(now.Sub(task.LastActivity)))
+	}
+
+	lines := packCardBadges(badges, width, kanbanCardBadgeLines)
+	if d.LastCommit != "" {
+		lines = append(lines, truncateWithEllipsis("› "+d.LastCommit, width))
+	}
+	return lines
+}
+
+// packCardBadges joins badges with " · " into at most maxLines lines of width.
+// Badges are never split; badges that do not fit are dropped.
+func packCardBadges(badges []string, width, maxLines int) []string {
+	var lines []string
+	current := ""
+	for _, badge := range badges {
+		switch {
+		case current == "":
+			current = truncateWithEllipsis(badge, width)
+		case ansi.StringWidth(current+" · "+badge) <= width:
+			current += " · " + badge
+		case len(lines)+1 < maxLines:
+			lines = append(lines, current)
+			current = truncateWithEllipsis(badge, width)
+		default:
+			return append(lines, current)
+		}
+	}
+	if current != "" {
+		lines = append(lines, current)
+	}
+	return lines
+}
+
 // buildMetadataString builds a display string from duration and tokens.
 // Returns a string like "1m 36s · ↓ 5.9k" or just one of them if the other is empty.
 func buildMetadataString(duration, tokens string) string {
@@ -0,0 +1,473 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/v2/textinput"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/dongho-jung/paw/internal/claude"
+	"github.com/dongho-jung/paw/internal/keymap"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/tmux"
+)
+
+// KanbanAction is an action that can be run on a kanban card.
+// Values double as the action argument of "paw internal task-action".
+type KanbanAction string
+
+// Kanban card actions.
+const (
+	KanbanActionJump   KanbanAction = "jump"
+	KanbanActionFinish KanbanAction = "finish"
+	KanbanActionCancel KanbanAction = "cancel"
+	KanbanActionSync   KanbanAction = "sync"
+	KanbanActionDiff   KanbanAction = "diff"
+	KanbanActionReply  KanbanAction = "reply"
+
+	// Backlog card actions (drafted tasks have no window yet)
+	KanbanActionStart   KanbanAction = "start"
+	KanbanActionDiscard KanbanAction = "discard"
+)
+
+// kanbanStatusDuration is how long an action result stays in the header.
+const kanbanStatusDuration = 3 * time.Second
+
+// kanbanActionItem is an entry of the card context menu.
+type kanbanActionItem struct {
+	action  KanbanAction
+	key     string        // Default key (handlers match keys translated through the keymap)
+	binding keymap.Action // Remappable action of the key
+	label   string
+}
+
+// kanbanActionItems lists card actions in context menu order.
+var kanbanActionItems = []kanbanActionItem{
+	{KanbanActionJump, "enter", keymap.ActionKanbanJump, "Jump to window"},
+	{KanbanActionFinish, "f", keymap.ActionKanbanFinish, "Finish..."},
+	{KanbanActionReply, "r", keymap.ActionKanbanReply, "Quick reply"},
+	{KanbanActionSync, "s", keymap.ActionKanbanSync, "Sync with main"},
+	{KanbanActionDiff, "d", keymap.ActionKanbanDiff, "Show diff"},
+	{KanbanActionCancel, "x", keymap.ActionKanbanCancel, "Cancel task"},
+}
+
+// keyLabel returns the label of the item's first effective key.
+func (item kanbanActionItem) keyLabel() string {
+	if key := activeKeymap().Key(item.binding); key != "" {
+		return keymap.KeyLabel(key)
+	}
+	return ""
+}
+
+// kanbanActionForKey returns the card action bound to a translated key
+// (see translateKey).
+func kanbanActionForKey(key string) (KanbanAction, bool) {
+	for _, item := range kanbanActionItems {
+		if item.key == key {
+			return item.action, true
+		}
+	}
+	return "", false
+}
+
+// kanbanMenu is the right-click context menu of a kanban card.
+type kanbanMenu struct {
+	task     *service.DiscoveredTask
+	x, y     int // Screen position of the top-left corner
+	selected int
+}
+
+// kanbanMenuInnerWidth is the width of a menu row without border.
+func kanbanMenuInnerWidth() int {
+	width := 0
+	for _, item := range kanbanActionItems {
+		width = max(width, len(item.label)+ansi.StringWidth(item.keyLabel())+4)
+	}
+	return width
+}
+
+// kanbanMenuSize returns the rendered menu size including border.
+func kanbanMenuSize() (int, int) {
+	return kanbanMenuInnerWidth() + 2, len(kanbanActionItems) + 2
+}
+
+// itemAt returns the menu item index at a screen position, or -1.
+func (km *kanbanMenu) itemAt(x, y int) int {
+	width, _ := kanbanMenuSize()
+	if x <= km.x || x >= km.x+width-1 {
+		return -1
+	}
+	idx := y - km.y - 1
+	if idx < 0 || idx >= len(kanbanActionItems) {
+		return -1
+	}
+	return idx
+}
+
+// kanbanActionMsg is the result of running a kanban card action.
+type kanbanActionMsg struct {
+	action KanbanAction
+	task   string
+	err    error
+}
+
+// runKanbanAction starts a card action on a task.
+// Cancel and reply first ask for confirmation or input in the header line.
+func (m *TaskInput) runKanbanAction(action KanbanAction, task *service.DiscoveredTask) tea.Cmd {
+	if task == nil {
+		return nil
+	}
+	m.kanbanMenu = nil
+
+	// Backlog drafts can only be started (jump) or discarded (cancel)
+	if task.BacklogID != "" {
+		switch action { //nolint:exhaustive // Other actions need a running task
+		case KanbanActionJump, KanbanActionStart:
+			return runTaskAction(KanbanActionStart, task)
+		case KanbanActionCancel, KanbanActionDiscard:
+			m.kanbanConfirmTask = task
+			return nil
+		}
+		return m.setKanbanStatus(task.Name + " is not started yet (Enter or drag to Working to start)")
+	}
+
+	switch action {
+	case KanbanActionJump:
+		return jumpToTask(task)
+	case KanbanActionCancel:
+		m.kanbanConfirmTask = task
+		return nil
+	case KanbanActionReply:
+		return m.openKanbanReply(task)
+	case KanbanActionFinish, KanbanActionSync, KanbanActionDiff:
+		return runTaskAction(action, task)
+	}
+	return nil
+}
+
+// runTaskAction runs a card action through "paw internal task-action".
+// Popups are shown on this session's client, so tasks of other projects work too.
+func runTaskAction(action KanbanAction, task *service.DiscoveredTask) tea.Cmd {
+	return func() tea.Msg {
+		pawBin, err := os.Executable()
+		if err != nil {
+			pawBin = "paw"
+		}
+		target := task.WindowID
+		if task.BacklogID != "" {
+			target = task.BacklogID
+		}
+		args := []string{"internal", "task-action", string(action), task.Session, target}
+		if SessionName != "" {
+			args = append(args, "--from", SessionName)
+		}
+		cmd := exec.Command(pawBin, args...) //nolint:gosec // G204: pawBin is the running executable
+		if output, err := cmd.CombinedOutput(); err != nil {
+			if msg := strings.TrimSpace(string(output)); msg != "" {
+				err = fmt.Errorf("%s", lastLine(msg))
+			}
+			logging.Warn("kanban %s on %s failed: %v", action, task.Name, err)
+			return kanbanActionMsg{action: action, task: task.Name, err: err}
+		}
+		return kanbanActionMsg{action: action, task: task.Name}
+	}
+}
+
+// runBulkAction opens the bulk action popup for the marked tasks.
+func runBulkAction(tasks []*service.DiscoveredTask) tea.Cmd {
+	return func() tea.Msg {
+		pawBin, err := os.Executable()
+		if err != nil {
+			pawBin = "paw"
+		}
+		popupSession := SessionName
+		if popupSession == "" {
+			popupSession = tasks[0].Session
+		}
+		args := []string{"internal", "toggle-bulk", popupSession}
+		for _, task := range tasks {
+			args = append(args, task.Session+":"+task.WindowID)
+		}
+		cmd := exec.Command(pawBin, args...) //nolint:gosec // G204: pawBin is the running executable
+		if output, err := cmd.CombinedOutput(); err != nil {
+			if msg := strings.TrimSpace(string(output)); msg != "" {
+				err = fmt.Errorf("%s", lastLine(msg))
+			}
+			logging.Warn("kanban bulk action on %d tasks failed: %v", len(tasks), err)
+			return kanbanActionMsg{action: "bulk", task: fmt.Sprintf("%d tasks", len(tasks)), err: err}
+		}
+		return nil
+	}
+}
+
+// sendKanbanReply sends text to a task's agent pane.
+func sendKanbanReply(task *service.DiscoveredTask, text string) tea.Cmd {
+	return func() tea.Msg {
+		tm := tmux.New(task.Session)
+		err := claude.New().SendInputWithRetry(tm, task.WindowID+".0", text, 5)
+		if err != nil {
+			logging.Warn("kanban reply to %s failed: %v", task.Name, err)
+		}
+		return kanbanActionMsg{action: KanbanActionReply, task: task.Name, err: err}
+	}
+}
+
+// lastLine returns the last line of multi-line command output.
+func lastLine(s string) string {
+	if idx := strings.LastIndex(s, "\n"); idx >= 0 {
+		return s[idx+1:]
+	}
+	return s
+}
+
+// openKanbanReply shows the quick reply input for a task.
+func (m *TaskInput) openKanbanReply(task *service.DiscoveredTask) tea.Cmd {
+	ti := textinput.New()
+	ti.Prompt = ""
+	ti.Placeholder = "Type a reply and press Enter"
+	ti.CharLimit = 0
+	ti.SetWidth(max(20, m.width/2))
+	ti.VirtualCursor = true // The real cursor belongs to the task textarea
+	cmd := ti.Focus()
+	m.kanbanReplyInput = ti
+	m.kanbanReplyTask = task
+	return cmd
+}
+
+// openKanbanFilter shows the filter bar prefilled with the active filter.
+func (m *TaskInput) openKanbanFilter() tea.Cmd {
+	ti := textinput.New()
+	ti.Prompt = ""
+	ti.Placeholder = "search project:<name> status:<s> model:<m> age:<2h sort:<age|activity|tokens> group:project"
+	ti.CharLimit = 0
+	ti.SetWidth(max(20, m.width/2))
+	ti.VirtualCursor = true // The real cursor belongs to the task textarea
+	ti.SetValue(m.kanban.Filter().String())
+	ti.CursorEnd()
+	cmd := ti.Focus()
+	m.kanbanFilterInput = ti
+	m.kanbanFilterEditing = true
+	m.kanbanFilterErr = ""
+	return cmd
+}
+
+// setKanbanStatus shows a transient action result in the header.
+func (m *TaskInput) setKanbanStatus(status string) tea.Cmd {
+	m.kanbanStatus = status
+	m.kanbanStatusUntil = time.Now().Add(kanbanStatusDuration)
+	return tea.Tick(kanbanStatusDuration, func(_ time.Time) tea.Msg {
+		return templateTipClearMsg{}
+	})
+}
+
+// handleKanbanActionResult reports a finished card action.
+func (m *TaskInput) handleKanbanActionResult(msg kanbanActionMsg) tea.Cmd {
+	if msg.err != nil {
+		return m.setKanbanStatus(fmt.Sprintf("✗ %s %s: %v", msg.action, msg.task, msg.err))
+	}
+	switch msg.action { //nolint:exhaustive // Popup actions report nothing on success
+	case KanbanActionReply:
+		return m.setKanbanStatus("✓ Reply sent to " + msg.task)
+	case KanbanActionCancel:
+		return m.setKanbanStatus("✓ Cancelling " + msg.task)
+	case KanbanActionSync:
+		return m.setKanbanStatus("✓ Syncing " + msg.task)
+	case KanbanActionStart:
+		return m.setKanbanStatus("✓ Starting " + msg.task)
+	case KanbanActionDiscard:
+		return m.setKanbanStatus("✓ Discarded " + msg.task)
+	}
+	return nil
+}
+
+// updateKanbanOverlay handles keys while the filter bar, context menu, cancel
+// confirmation, or quick reply input is open. Returns false if no overlay is active.
+func (m *TaskInput) updateKanbanOverlay(msg tea.KeyMsg) (bool, tea.Cmd) {
+	keyStr := msg.String()
+
+	if m.kanbanFilterEditing {
+		switch keyStr {
+		case "esc":
+			m.kanbanFilterEditing = false
+			return true, nil
+		case "enter":
+			filter, err := ParseKanbanFilter(m.kanbanFilterInput.Value())
+			if err != nil {
+				m.kanbanFilterErr = err.Error()
+				return true, nil
+			}
+			m.kanbanFilterEditing = false
+			m.kanban.SetFilter(filter)
+			return true, nil
+		}
+		m.kanbanFilterErr = ""
+		var cmd tea.Cmd
+		m.kanbanFilterInput, cmd = m.kanbanFilterInput.Update(msg)
+		return true, cmd
+	}
+
+	if task := m.kanbanReplyTask; task != nil {
+		switch keyStr {
+		case "esc":
+			m.kanbanReplyTask = nil
+			return true, nil
+		case "enter":
+			text := strings.TrimSpace(m.kanbanReplyInput.Value())
+			m.kanbanReplyTask = nil
+			if text == "" {
+				return true, nil
+			}
+			return true, sendKanbanReply(task, text)
+		}
+		var cmd tea.Cmd
+		m.kanbanReplyInput, cmd = m.kanbanReplyInput.Update(msg)
+		return true, cmd
+	}
+
+	if task := m.kanbanConfirmTask; task != nil {
+		m.kanbanConfirmTask = nil
+		if keyStr == "y" || keyStr == "x" {
+			if task.BacklogID != "" {
+				return true, runTaskAction(KanbanActionDiscard, task)
+			}
+			return true, runTaskAction(KanbanActionCancel, task)
+		}
+		return true, nil
+	}
+
+	if menu := m.kanbanMenu; menu != nil {
+		switch keyStr = translateKey(msg, keymap.ScopeNav, keymap.ScopeKanban); keyStr {
+		case "up", "k":
+			menu.selected = (menu.selected - 1 + len(kanbanActionItems)) % len(kanbanActionItems)
+			return true, nil
+		case "down", "j":
+			menu.selected = (menu.selected + 1) % len(kanbanActionItems)
+			return true, nil
+		case "enter":
+			return true, m.runKanbanAction(kanbanActionItems[menu.selected].action, menu.task)
+		}
+		if action, ok := kanbanActionForKey(keyStr); ok {
+			return true, m.runKanbanAction(action, menu.task)
+		}
+		// Any other key closes the menu
+		m.kanbanMenu = nil
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// openKanbanMenu selects the card under the mouse and opens its context menu.
+func (m *TaskInput) openKanbanMenu(x, y int) {
+	col := m.detectKanbanColumn(x)
+	task := m.kanban.GetTaskAtPosition(col, m.getKanbanRelativeY(y))
+	if task == nil {
+		m.kanbanMenu = nil
+		return
+	}
+	m.applyOptionInputValues()
+	m.switchFocusToKanbanColumn(col)
+	m.kanban.SelectTask(col, task)
+
+	width, height := kanbanMenuSize()
+	m.kanbanMenu = &kanbanMenu{
+		task: task,
+		x:    max(0, min(x, m.width-width)),
+		y:    max(0, min(y, m.height-height)),
+	}
+}
+
+// handleKanbanMenuClick runs the menu item under the mouse, or closes the menu.
+func (m *TaskInput) handleKanbanMenuClick(x, y int) tea.Cmd {
+	menu := m.kanbanMenu
+	m.kanbanMenu = nil
+	if idx := menu.itemAt(x, y); idx >= 0 {
+		return m.runKanbanAction(kanbanActionItems[idx].action, menu.task)
+	}
+	return nil
+}
+
+// renderKanbanMenu renders the context menu box.
+func (m *TaskInput) renderKanbanMenu() string {
+	lightDark := lipgloss.LightDark(m.isDark)
+	accent := themeColor("accent", lightDark(lipgloss.Color("25"), lipgloss.Color("39")))
+	dim := themeColor("text_dim", lightDark(lipgloss.Color("245"), lipgloss.Color("240")))
+	innerWidth := kanbanMenuInnerWidth()
+
+	lines := make([]string, 0, len(kanbanActionItems))
+	for i, item := range kanbanActionItems {
+		key := item.keyLabel()
+		gap := innerWidth - len(item.label) - ansi.StringWidth(key) - 2
+		row := " " + item.label + strings.Repeat(" ", gap) + lipgloss.NewStyle().Foreground(dim).Render(key) + " "
+		if i == m.kanbanMenu.selected {
+			row = lipgloss.NewStyle().Reverse(true).Render(" " + item.label + strings.Repeat(" ", gap) + key + " ")
+		}
+		lines = append(lines, row)
+	}
+
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(accent).
+		Render(strings.Join(lines, "\n"))
+}
+
+// overlayAt draws box over content with its top-left corner at (x, y).
+func overlayAt(content, box string, x, y int) string {
+	lines := strings.Split(content, "\n")
+	for i, boxLine := range strings.Split(box, "\n") {
+		row := y + i
+		for row >= len(lines) {
+			lines = append(lines, "")
+		}
+		line := lines[row]
+		left := ansi.Truncate(line, x, "")
+		if pad := x - ansi.StringWidth(left); pad > 0 {
+			left += strings.Repeat(" ", pad)
+		}
+		right := ansi.TruncateLeft(line, x+ansi.StringWidth(boxLine), "")
+		lines[row] = left + "\x1b[0m" + boxLine + "\x1b[0m" + right
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderKanbanHeader renders the header line while a kanban overlay or status is active.
+// Returns false if the normal header should be shown.
+func (m *TaskInput) renderKanbanHeader() (string, bool) {
+	if m.kanbanFilterEditing {
+		prefix := m.viewStyleCancelHint.Render("Filter: ")
+		hint := m.viewStyleHelp.Render("  Enter: Apply  |  Esc: Close")
+		if m.kanbanFilterErr != "" {
+			hint = m.viewStyleWarning.Render("  " + m.kanbanFilterErr)
+		}
+		return prefix + m.kanbanFilterInput.View() + hint, true
+	}
+	if task := m.kanbanReplyTask; task != nil {
+		prefix := m.viewStyleCancelHint.Render("Reply to " + task.Name + ": ")
+		hint := m.viewStyleHelp.Render("  Enter: Send  |  Esc: Close")
+		return prefix + m.kanbanReplyInput.View() + hint, true
+	}
+	if task := m.kanbanConfirmTask; task != nil {
+		verb := "Cancel "
+		if task.BacklogID != "" {
+			verb = "Discard "
+		}
+		return m.viewStyleCancelHint.Render(verb + task.Name + "? (y/n)"), true
+	}
+	if m.kanbanStatus != "" && time.Now().Before(m.kanbanStatusUntil) {
+		return m.viewStyleCancelHint.Render(m.kanbanStatus), true
+	}
+	if n := m.kanban.MarkedCount(); n > 0 && m.focusPanel == FocusPanelKanban {
+		hint := fmt.Sprintf("  %s: Bulk action  |  %s: Unmark",
+			keymap.KeyLabel(activeKeymap().Key(keymap.ActionKanbanBulk)),
+			keymap.KeyLabel(activeKeymap().Key(keymap.ActionKanbanMark)))
+		return m.viewStyleCancelHint.Render(fmt.Sprintf("● %d marked", n)) + m.viewStyleHelp.Render(hint), true
+	}
+	return "", false
+}
@@ -0,0 +1,196 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/dongho-jung/paw/internal/service"
+)
+
+func keyPress(s string) tea.KeyPressMsg {
+	switch s {
+	case "enter":
+		return tea.KeyPressMsg{Code: tea.KeyEnter}
+	case "esc":
+		return tea.KeyPressMsg{Code: tea.KeyEscape}
+	case "down":
+		return tea.KeyPressMsg{Code: tea.KeyDown}
+	case "right":
+		return tea.KeyPressMsg{Code: tea.KeyRight}
+	case "tab":
+		return tea.KeyPressMsg{Code: tea.KeyTab}
+	case "space":
+		return tea.KeyPressMsg{Code: tea.KeySpace, Text: " "}
+	}
+	if letter, ok := strings.CutPrefix(s, "ctrl+"); ok {
+		return tea.KeyPressMsg{Code: []rune(letter)[0], Mod: tea.ModCtrl}
+	}
+	r := []rune(s)[0]
+	return tea.KeyPressMsg{Code: r, Text: s}
+}
+
+func TestKanbanActionForKey(t *testing.T) {
+	tests := []struct {
+		key    string
+		action KanbanAction
+		ok     bool
+	}{
+		{"enter", KanbanActionJump, true},
+		{"f", KanbanActionFinish, true},
+		{"r", KanbanActionReply, true},
+		{"s", KanbanActionSync, true},
+		{"d", KanbanActionDiff, true},
+		{"x", KanbanActionCancel, true},
+		{"q", "", false},
+	}
+	for _, tt := range tests {
+		action, ok := kanbanActionForKey(tt.key)
+		if action != tt.action || ok != tt.ok {
+			t.Errorf("kanbanActionForKey(%q) = %q, %v; want %q, %v", tt.key, action, ok, tt.action, tt.ok)
+		}
+	}
+}
+
+func TestKanbanMenuItemAt(t *testing.T) {
+	menu := &kanbanMenu{x: 10, y: 5}
+	width, height := kanbanMenuSize()
+
+	if got := menu.itemAt(11, 6); got != 0 {
+		t.Errorf("first item: got %d, want 0", got)
+	}
+	if got := menu.itemAt(11, 5+len(kanbanActionItems)); got != len(kanbanActionItems)-1 {
+		t.Errorf("last item: got %d, want %d", got, len(kanbanActionItems)-1)
+	}
+	// Borders and outside positions are not items
+	for _, pos := range [][2]int{{10, 6}, {10 + width - 1, 6}, {11, 5}, {11, 5 + height - 1}, {0, 0}} {
+		if got := menu.itemAt(pos[0], pos[1]); got != -1 {
+			t.Errorf("itemAt(%d, %d) = %d, want -1", pos[0], pos[1], got)
+		}
+	}
+}
+
+func TestOverlayAt(t *testing.T) {
+	content := "aaaaaaaa\nbbbbbbbb\ncccccccc"
+	got := ansi.Strip(overlayAt(content, "XX\nYY", 3, 1))
+	want := "aaaaaaaa\nbbbXXbbb\ncccYYccc"
+	if got != want {
+		t.Errorf("overlayAt() = %q, want %q", got, want)
+	}
+
+	// Boxes past the end of short content are padded
+	got = ansi.Strip(overlayAt("ab", "Z", 4, 1))
+	if got != "ab\n    Z" {
+		t.Errorf("overlayAt() padding = %q", got)
+	}
+}
+
+func TestKanbanOverlayKeys(t *testing.T) {
+	task := &service.DiscoveredTask{Name: "fix-login", Session: "proj", WindowID: "@3"}
+
+	t.Run("no overlay", func(t *testing.T) {
+		m := NewTaskInputWithOptions(nil, true)
+		if handled, _ := m.updateKanbanOverlay(keyPress("x")); handled {
+			t.Error("key handled without an open overlay")
+		}
+	})
+
+	t.Run("cancel asks for confirmation", func(t *testing.T) {
+		m := NewTaskInputWithOptions(nil, true)
+		if cmd := m.runKanbanAction(KanbanActionCancel, task); cmd != nil {
+			t.Error("cancel should not run before confirmation")
+		}
+		if m.kanbanConfirmTask != task {
+			t.Fatal("confirmation not pending")
+		}
+		header, ok := m.renderKanbanHeader()
+		if !ok || !strings.Contains(header, "Cancel fix-login?") {
+			t.Errorf("header = %q", header)
+		}
+		handled, cmd := m.updateKanbanOverlay(keyPress("n"))
+		if !handled || cmd != nil || m.kanbanConfirmTask != nil {
+			t.Error("other keys should dismiss the confirmation")
+		}
+	})
+
+	t.Run("backlog card", func(t *testing.T) {
+		m := NewTaskInputWithOptions(nil, true)
+		draft := &service.DiscoveredTask{Name: "Add dark mode", Session: "proj", Status: service.DiscoveredBacklog, BacklogID: "abc"}
+		if m.runKanbanAction(KanbanActionReply, draft); m.kanbanReplyTask != nil {
+			t.Error("reply should not open for a backlog card")
+		}
+		m.runKanbanAction(KanbanActionCancel, draft)
+		header, ok := m.renderKanbanHeader()
+		if !ok || !strings.Contains(header, "Discard Add dark mode?") {
+			t.Errorf("header = %q", header)
+		}
+	})
+
+	t.Run("menu navigation", func(t *testing.T) {
+		m := NewTaskInputWithOptions(nil, true)
+		m.kanbanMenu = &kanbanMenu{task: task}
+		m.updateKanbanOverlay(keyPress("down"))
+		if m.kanbanMenu.selected != 1 {
+			t.Errorf("selected = %d, want 1", m.kanbanMenu.selected)
+		}
+		// Shortcut keys work inside the menu
+		m.updateKanbanOverlay(keyPress("x"))
+		if m.kanbanMenu != nil || m.kanbanConfirmTask != task {
+			t.Error("menu shortcut should close the menu and start the action")
+		}
+	})
+
+	t.Run("reply input", func(t *testing.T) {
+		m := NewTaskInputWithOptions(nil, true)
+		m.runKanbanAction(KanbanActionReply, task)
+		if m.kanbanReplyTask != task {
+			t.Fatal("reply input not open")
+		}
+		m.updateKanbanOverlay(keyPress("h"))
+		m.updateKanbanOverlay(keyPress("i"))
+		if got := m.kanbanReplyInput.Value(); got != "hi" {
+			t.Errorf("reply value = %q, want %q", got, "hi")
+		}
+		if _, cmd := m.updateKanbanOverlay(keyPress("enter")); cmd == nil {
+			t.Error("enter should send the reply")
+		}
+		if m.kanbanReplyTask != nil {
+			t.Error("reply input should close after sending")
+		}
+	})
+}
+
+func TestKanbanMarks(t *testing.T) {
+	k := NewKanbanView(true)
+	k.filterPath = ""
+	k.columns, _ = parseKanbanColumns(defaultKanbanColumns)
+	k.SetSize(200, 30)
+	draft := &service.DiscoveredTask{Name: "draft", Session: "proj", Status: service.DiscoveredBacklog, BacklogID: "x"}
+	a := &service.DiscoveredTask{Name: "a", Session: "proj", WindowID: "@1", Status: service.DiscoveredWorking}
+	b := &service.DiscoveredTask{Name: "b", Session: "proj", WindowID: "@2", Status: service.DiscoveredDone}
+	k.setTasks([]*service.DiscoveredTask{draft, a, b})
+
+	if k.ToggleMark(draft) {
+		t.Error("backlog drafts should not be markable")
+	}
+	k.ToggleMark(b)
+	k.ToggleMark(a)
+	marked := k.MarkedTasks()
+	if len(marked) != 2 || marked[0] != a || marked[1] != b {
+		t.Fatalf("MarkedTasks() = %v, want board order [a b]", marked)
+	}
+	if !strings.Contains(ansi.Strip(k.Render()), "● proj/a") {
+		t.Error("marked cards should show a mark")
+	}
+
+	// Marks follow tasks across refreshes and drop finished ones
+	k.setTasks([]*service.DiscoveredTask{draft, {Name: "a", Session: "proj", WindowID: "@1", Status: service.DiscoveredWaiting}})
+	if k.MarkedCount() != 1 || len(k.MarkedTasks()) != 1 {
+		t.Errorf("MarkedCount() = %d after b was removed, want 1", k.MarkedCount())
+	}
+	if k.ToggleMark(k.MarkedTasks()[0]) || k.MarkedCount() != 0 {
+		t.Error("toggling a marked task should unmark it")
+	}
+}
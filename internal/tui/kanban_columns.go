@@ -0,0 +1,141 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/service"
+)
+
+// defaultKanbanColumns is the column layout used when kanban_columns is not configured.
+const defaultKanbanColumns = "backlog, blocked, working, waiting+warning, review, done"
+
+// Column emojis for statuses without a task window emoji.
+const (
+	kanbanEmojiBacklog = "📝"
+	kanbanEmojiBlocked = "⏳"
+)
+
+// kanbanStatusInfo is the default title and emoji of a column led by a status.
+var kanbanStatusInfo = map[service.DiscoveredStatus]struct{ title, emoji string }{
+	service.DiscoveredBacklog: {"Backlog", kanbanEmojiBacklog},
+	service.DiscoveredBlocked: {"Blocked", kanbanEmojiBlocked},
+	service.DiscoveredWorking: {"Working", constants.EmojiWorking},
+	service.DiscoveredWaiting: {"Needs Input", constants.EmojiWaiting},
+	service.DiscoveredWarning: {"Warning", constants.EmojiWarning},
+	service.DiscoveredReview:  {"In Review", constants.EmojiReview},
+	service.DiscoveredDone:    {"Done", constants.EmojiDone},
+}
+
+// kanbanColumn is a board column showing the tasks of one or more statuses.
+type kanbanColumn struct {
+	title    string
+	emoji    string
+	statuses []service.DiscoveredStatus
+	all      []*service.DiscoveredTask // Discovered tasks (unfiltered)
+	tasks    []*service.DiscoveredTask // Visible tasks (filtered and sorted)
+	selected int                       // Selected task index, -1 if none
+}
+
+// hasStatus reports whether the column shows tasks of the given status.
+func (c *kanbanColumn) hasStatus(status service.DiscoveredStatus) bool {
+	for _, s := range c.statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// isCore reports whether the column shows running tasks (working, waiting or done).
+// Empty non-core columns are hidden first when the board is too narrow.
+func (c *kanbanColumn) isCore() bool {
+	return c.hasStatus(service.DiscoveredWorking) || c.hasStatus(service.DiscoveredWaiting) || c.hasStatus(service.DiscoveredDone)
+}
+
+// parseKanbanColumns parses a kanban_columns value, e.g.
+// "backlog, working, Needs Input=waiting+warning, done".
+// Columns are comma-separated; "+" shows several statuses in one column and
+// an optional "Title=" prefix overrides the column title.
+func parseKanbanColumns(spec string) ([]*kanbanColumn, error) {
+	seen := make(map[service.DiscoveredStatus]bool)
+	var columns []*kanbanColumn
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		title, statusList, hasTitle := strings.Cut(item, "=")
+		if !hasTitle {
+			statusList = item
+		}
+
+		col := &kanbanColumn{selected: -1}
+		for _, name := range strings.Split(statusList, "+") {
+			status := service.DiscoveredStatus(strings.ToLower(strings.TrimSpace(name)))
+			if _, ok := kanbanStatusInfo[status]; !ok {
+				return nil, fmt.Errorf("unknown kanban status %q", name)
+			}
+			if seen[status] {
+				return nil, fmt.Errorf("kanban status %q is in more than one column", status)
+			}
+			seen[status] = true
+			col.statuses = append(col.statuses, status)
+		}
+
+		info := kanbanStatusInfo[col.statuses[0]]
+		col.title, col.emoji = info.title, info.emoji
+		if title = strings.TrimSpace(title); hasTitle && title != "" {
+			col.title = title
+		}
+		columns = append(columns, col)
+	}
+	if len(columns) == 0 {
+		return nil, errors.New("no kanban columns configured")
+	}
+	return columns, nil
+}
+
+// loadKanbanColumns returns the columns configured in the global config.
+// Invalid configurations fall back to the default columns.
+func loadKanbanColumns() []*kanbanColumn {
+	spec := defaultKanbanColumns
+	if dir := config.GlobalPawDir(); dir != "" {
+		if cfg, err := config.Load(dir); err == nil && strings.TrimSpace(cfg.KanbanColumns) != "" {
+			spec = cfg.KanbanColumns
+		}
+	}
+	columns, err := parseKanbanColumns(spec)
+	if err != nil {
+		logging.Warn("Invalid kanban_columns %q: %v; using defaults", spec, err)
+		columns, _ = parseKanbanColumns(defaultKanbanColumns)
+	}
+	return columns
+}
+
+// kanbanStatusColor returns the header color of a column led by a status.
+// The built-in colors have good contrast on both light and dark backgrounds.
+func kanbanStatusColor(status service.DiscoveredStatus, isDark bool) color.Color {
+	colors := NewThemeColors(isDark)
+	switch status {
+	case service.DiscoveredBacklog:
+		return colors.StatusBacklog
+	case service.DiscoveredBlocked:
+		return colors.StatusBlocked
+	case service.DiscoveredWorking:
+		return colors.StatusWorking
+	case service.DiscoveredWaiting:
+		return colors.StatusWaiting
+	case service.DiscoveredWarning:
+		return colors.StatusWarning
+	case service.DiscoveredReview:
+		return colors.StatusReview
+	case service.DiscoveredDone:
+	}
+	return colors.StatusDone
+}
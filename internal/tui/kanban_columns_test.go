@@ -0,0 +1,83 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dongho-jung/paw/internal/service"
+)
+
+func TestParseKanbanColumns(t *testing.T) {
+	columns, err := parseKanbanColumns(defaultKanbanColumns)
+	if err != nil {
+		t.Fatalf("parseKanbanColumns(default) error = %v", err)
+	}
+	var titles []string
+	for _, col := range columns {
+		titles = append(titles, col.title)
+	}
+	want := []string{"Backlog", "Blocked", "Working", "Needs Input", "In Review", "Done"}
+	if len(titles) != len(want) {
+		t.Fatalf("titles = %v, want %v", titles, want)
+	}
+	for i := range want {
+		if titles[i] != want[i] {
+			t.Errorf("titles = %v, want %v", titles, want)
+			break
+		}
+	}
+	if !columns[3].hasStatus(service.DiscoveredWarning) {
+		t.Error("Needs Input column should include warning tasks")
+	}
+
+	columns, err = parseKanbanColumns(" Todo = Backlog , working+blocked, done ")
+	if err != nil {
+		t.Fatalf("parseKanbanColumns() error = %v", err)
+	}
+	if len(columns) != 3 || columns[0].title != "Todo" || columns[1].title != "Working" {
+		t.Errorf("unexpected columns: %+v", columns)
+	}
+	if !columns[1].hasStatus(service.DiscoveredBlocked) || columns[1].hasStatus(service.DiscoveredDone) {
+		t.Errorf("working column statuses = %v", columns[1].statuses)
+	}
+
+	for _, spec := range []string{"", " , ", "working, bogus", "working, working+done", "Todo="} {
+		if _, err := parseKanbanColumns(spec); err == nil {
+			t.Errorf("parseKanbanColumns(%q) expected error", spec)
+		}
+	}
+}
+
+func TestKanbanColumnsDistributeAndHide(t *testing.T) {
+	k := NewKanbanView(true)
+	k.filterPath = ""
+	k.columns, _ = parseKanbanColumns(defaultKanbanColumns)
+	k.SetSize(200, 30)
+	k.setTasks([]*service.DiscoveredTask{
+		{Name: "draft", Status: service.DiscoveredBacklog, BacklogID: "x"},
+		{Name: "a", Status: service.DiscoveredWorking},
+		{Name: "b", Status: service.DiscoveredWarning},
+		{Name: "c", Status: service.DiscoveredReview},
+	})
+
+	if k.ColumnCount() != 6 {
+		t.Fatalf("ColumnCount() = %d, want 6 on a wide board", k.ColumnCount())
+	}
+	if k.ColumnTaskCount(3) != 1 || k.TaskCount() != 4 {
+		t.Errorf("Needs Input count = %d, total = %d", k.ColumnTaskCount(3), k.TaskCount())
+	}
+
+	// Narrow boards hide empty non-core columns first, then empty core columns
+	k.SetSize(4*(kanbanMinColumnWidth+kanbanColumnBorder), 30)
+	var titles []string
+	for _, col := range k.visible {
+		titles = append(titles, col.title)
+	}
+	want := "Backlog,Working,Needs Input,In Review"
+	if got := strings.Join(titles, ","); got != want {
+		t.Errorf("visible columns = %s, want %s", got, want)
+	}
+	if !k.ColumnHasStatus(1, service.DiscoveredWorking) || k.ColumnHasStatus(0, service.DiscoveredWorking) {
+		t.Error("ColumnHasStatus should follow the visible columns")
+	}
+}
@@ -0,0 +1,287 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sahilm/fuzzy"
+
+	"github.com/dongho-jung/paw/internal/config"
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/service"
+)
+
+// KanbanSort is the card order within kanban columns.
+type KanbanSort string
+
+// Kanban sort orders.
+const (
+	KanbanSortAge      KanbanSort = "age"      // Oldest first (default)
+	KanbanSortActivity KanbanSort = "activity" // Most recently active first
+	KanbanSortTokens   KanbanSort = "tokens"   // Highest token usage first
+)
+
+// kanbanSorts lists sort orders in cycle order.
+var kanbanSorts = []KanbanSort{KanbanSortAge, KanbanSortActivity, KanbanSortTokens}
+
+// KanbanFilter narrows and orders the tasks shown on the kanban board.
+// It is written as a filter bar expression, e.g.
+// "login project:api status:waiting model:opus age:<2h sort:tokens group:project".
+type KanbanFilter struct {
+	Query   string     `json:"query,omitempty"`   // Fuzzy match on task name and content
+	Session string     `json:"session,omitempty"` // Project/session substring
+	Status  string     `json:"status,omitempty"`  // A service.DiscoveredStatus
+	Model   string     `json:"model,omitempty"`   // Model substring
+	Age     string     `json:"age,omitempty"`     // "<2h" (newer than) or ">1d" (older than)
+	Sort    KanbanSort `json:"sort,omitempty"`
+	Group   bool       `json:"group,omitempty"` // Swimlanes grouped by project
+}
+
+// ParseKanbanFilter parses a filter bar expression.
+// "key:value" terms set fields; remaining words form the fuzzy query.
+func ParseKanbanFilter(expr string) (KanbanFilter, error) {
+	var f KanbanFilter
+	var query []string
+	for _, term := range strings.Fields(expr) {
+		key, value, ok := strings.Cut(term, ":")
+		if !ok || value == "" {
+			query = append(query, term)
+			continue
+		}
+		switch strings.ToLower(key) {
+		case "project", "session", "p":
+			f.Session = value
+		case "status", "s":
+			status, err := parseKanbanStatus(value)
+			if err != nil {
+				return f, err
+			}
+			f.Status = status
+		case "model", "m":
+			f.Model = value
+		case "age", "a":
+			if _, _, err := parseKanbanAge(value); err != nil {
+				return f, err
+			}
+			f.Age = value
+		case "sort":
+			sortBy := KanbanSort(strings.ToLower(value))
+			if !isKanbanSort(sortBy) {
+				return f, fmt.Errorf("unknown sort %q (use age, activity or tokens)", value)
+			}
+			f.Sort = sortBy
+		case "group", "lanes":
+			switch strings.ToLower(value) {
+			case "project", "on":
+				f.Group = true
+			case "none", "off":
+				f.Group = false
+			default:
+				return f, fmt.Errorf("unknown group %q (use project or none)", value)
+			}
+		default:
+			query = append(query, term)
+		}
+	}
+	f.Query = strings.Join(query, " ")
+	return f, nil
+}
+
+// String renders the filter as a filter bar expression.
+func (f KanbanFilter) String() string {
+	var terms []string
+	if f.Query != "" {
+		terms = append(terms, f.Query)
+	}
+	if f.Session != "" {
+		terms = append(terms, "project:"+f.Session)
+	}
+	if f.Status != "" {
+		terms = append(terms, "status:"+f.Status)
+	}
+	if f.Model != "" {
+		terms = append(terms, "model:"+f.Model)
+	}
+	if f.Age != "" {
+		terms = append(terms, "age:"+f.Age)
+	}
+	if f.Sort != "" && f.Sort != KanbanSortAge {
+		terms = append(terms, "sort:"+string(f.Sort))
+	}
+	if f.Group {
+		terms = append(terms, "group:project")
+	}
+	return strings.Join(terms, " ")
+}
+
+// IsZero reports whether the filter shows all tasks in the default order.
+func (f KanbanFilter) IsZero() bool {
+	return f.String() == ""
+}
+
+// Matches reports whether a task passes the filter.
+func (f KanbanFilter) Matches(task *service.DiscoveredTask, now time.Time) bool {
+	if f.Session != "" && !containsLower(task.Session, strings.ToLower(f.Session)) {
+		return false
+	}
+	if f.Status != "" && string(task.Status) != f.Status {
+		return false
+	}
+	if f.Model != "" && !containsLower(task.Model, strings.ToLower(f.Model)) {
+		return false
+	}
+	if f.Age != "" {
+		if newer, limit, err := parseKanbanAge(f.Age); err == nil {
+			age := now.Sub(task.CreatedAt)
+			if (newer && age > limit) || (!newer && age < limit) {
+				return false
+			}
+		}
+	}
+	if f.Query != "" {
+		target := task.Name + " " + task.Content
+		for _, word := range strings.Fields(f.Query) {
+			if len(fuzzy.Find(word, []string{target})) == 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Apply filters and sorts a column's tasks. The input slice is not modified.
+func (f KanbanFilter) Apply(tasks []*service.DiscoveredTask, now time.Time) []*service.DiscoveredTask {
+	result := make([]*service.DiscoveredTask, 0, len(tasks))
+	for _, task := range tasks {
+		if f.Matches(task, now) {
+			result = append(result, task)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if f.Group && a.Session != b.Session {
+			return a.Session < b.Session
+		}
+		switch f.Sort {
+		case KanbanSortActivity:
+			return a.LastActivity.After(b.LastActivity)
+		case KanbanSortTokens:
+			return service.ParseTokenCount(a.Tokens) > service.ParseTokenCount(b.Tokens)
+		case KanbanSortAge:
+		}
+		return a.CreatedAt.Before(b.CreatedAt)
+	})
+	return result
+}
+
+// NextSort returns the filter with the next sort order.
+func (f KanbanFilter) NextSort() KanbanFilter {
+	current := f.Sort
+	if current == "" {
+		current = KanbanSortAge
+	}
+	for i, s := range kanbanSorts {
+		if s == current {
+			f.Sort = kanbanSorts[(i+1)%len(kanbanSorts)]
+			return f
+		}
+	}
+	f.Sort = KanbanSortAge
+	return f
+}
+
+func isKanbanSort(s KanbanSort) bool {
+	for _, known := range kanbanSorts {
+		if s == known {
+			return true
+		}
+	}
+	return false
+}
+
+// parseKanbanStatus resolves a status name or prefix (e.g. "wait").
+func parseKanbanStatus(value string) (string, error) {
+	value = strings.ToLower(value)
+	names := make([]string, 0, len(service.DiscoveredStatuses))
+	for _, status := range service.DiscoveredStatuses {
+		if strings.HasPrefix(string(status), value) {
+			return string(status), nil
+		}
+		names = append(names, string(status))
+	}
+	return "", fmt.Errorf("unknown status %q (use %s)", value, strings.Join(names, ", "))
+}
+
+// parseKanbanAge parses "<2h" (newer than) or ">1d" (older than).
+// A bare duration means newer than. Durations accept a "d" (days) suffix.
+func parseKanbanAge(value string) (bool, time.Duration, error) {
+	newer := true
+	switch {
+	case strings.HasPrefix(value, "<"):
+		value = value[1:]
+	case strings.HasPrefix(value, ">"):
+		newer = false
+		value = value[1:]
+	}
+
+	var d time.Duration
+	var err error
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		var n int
+		n, err = strconv.Atoi(days)
+		d = time.Duration(n) * 24 * time.Hour
+	} else {
+		d, err = time.ParseDuration(value)
+	}
+	if err != nil || d <= 0 {
+		return false, 0, fmt.Errorf("invalid age %q (e.g. <2h, >1d)", value)
+	}
+	return newer, d, nil
+}
+
+// kanbanFilterPath returns the per-user filter file ($HOME/.config/paw/kanban-filter.json).
+func kanbanFilterPath() string {
+	dir := config.GlobalPawDir()
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, constants.KanbanFilterFileName)
+}
+
+// loadKanbanFilter reads a saved filter. Missing or invalid files yield the zero filter.
+func loadKanbanFilter(path string) KanbanFilter {
+	var f KanbanFilter
+	if path == "" {
+		return f
+	}
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is the fixed filter file
+	if err != nil {
+		return f
+	}
+	if err := json.Unmarshal(data, &f); err != nil {
+		return KanbanFilter{}
+	}
+	return f
+}
+
+// saveKanbanFilter persists a filter.
+func saveKanbanFilter(path string, f KanbanFilter) error {
+	if path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil { //nolint:gosec // G301: standard config directory permissions
+		return err
+	}
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644) //nolint:gosec // G306: filter settings are not sensitive
+}
@@ -0,0 +1,179 @@
+package tui
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/service"
+)
+
+func TestParseKanbanFilter(t *testing.T) {
+	f, err := ParseKanbanFilter("login redirect project:api status:wait model:opus age:<2h sort:tokens group:project")
+	if err != nil {
+		t.Fatalf("ParseKanbanFilter() error = %v", err)
+	}
+	want := KanbanFilter{
+		Query:   "login redirect",
+		Session: "api",
+		Status:  "waiting",
+		Model:   "opus",
+		Age:     "<2h",
+		Sort:    KanbanSortTokens,
+		Group:   true,
+	}
+	if f != want {
+		t.Errorf("ParseKanbanFilter() = %+v, want %+v", f, want)
+	}
+
+	// String renders an expression that parses back to the same filter
+	again, err := ParseKanbanFilter(f.String())
+	if err != nil || again != f {
+		t.Errorf("round trip = %+v, %v; want %+v", again, err, f)
+	}
+
+	for _, expr := range []string{"status:bogus", "age:soon", "sort:name", "group:model"} {
+		if _, err := ParseKanbanFilter(expr); err == nil {
+			t.Errorf("ParseKanbanFilter(%q) expected error", expr)
+		}
+	}
+
+	if f, _ := ParseKanbanFilter("  "); !f.IsZero() {
+		t.Errorf("empty expression should give zero filter, got %+v", f)
+	}
+}
+
+func TestKanbanFilterMatches(t *testing.T) {
+	now := time.Now()
+	task := &service.DiscoveredTask{
+		Name:      "fix-login",
+		Session:   "api-server",
+		Status:    service.DiscoveredWaiting,
+		Model:     "opus",
+		Content:   "Redirect to dashboard after OAuth",
+		CreatedAt: now.Add(-3 * time.Hour),
+	}
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{"", true},
+		{"fxlogin", true}, // fuzzy name
+		{"oauth", true},   // content
+		{"payment", false},
+		{"project:API", true},
+		{"project:web", false},
+		{"status:waiting", true},
+		{"status:done", false},
+		{"status:block", false},
+		{"model:op", true},
+		{"model:haiku", false},
+		{"age:<1d", true},
+		{"age:<2h", false},
+		{"age:>1h", true},
+		{"age:>1d", false},
+	}
+	for _, tt := range tests {
+		f, err := ParseKanbanFilter(tt.expr)
+		if err != nil {
+			t.Fatalf("ParseKanbanFilter(%q) error = %v", tt.expr, err)
+		}
+		if got := f.Matches(task, now); got != tt.want {
+			t.Errorf("Matches(%q) = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestKanbanFilterApplySortAndGroup(t *testing.T) {
+	now := time.Now()
+	a := &service.DiscoveredTask{Name: "a", Session: "web", CreatedAt: now.Add(-3 * time.Hour), LastActivity: now.Add(-time.Hour), Tokens: "↓ 2k"}
+	b := &service.DiscoveredTask{Name: "b", Session: "api", CreatedAt: now.Add(-2 * time.Hour), LastActivity: now, Tokens: "↓ 900"}
+	c := &service.DiscoveredTask{Name: "c", Session: "web", CreatedAt: now.Add(-time.Hour), LastActivity: now.Add(-2 * time.Hour), Tokens: "↓ 10k"}
+	tasks := []*service.DiscoveredTask{c, a, b}
+
+	names := func(ts []*service.DiscoveredTask) string {
+		s := ""
+		for _, t := range ts {
+			s += t.Name
+		}
+		return s
+	}
+
+	tests := []struct {
+		filter KanbanFilter
+		want   string
+	}{
+		{KanbanFilter{}, "abc"},
+		{KanbanFilter{Sort: KanbanSortActivity}, "bac"},
+		{KanbanFilter{Sort: KanbanSortTokens}, "cab"},
+		{KanbanFilter{Group: true}, "bac"},
+		{KanbanFilter{Group: true, Sort: KanbanSortTokens}, "bca"},
+	}
+	for _, tt := range tests {
+		if got := names(tt.filter.Apply(tasks, now)); got != tt.want {
+			t.Errorf("Apply(%+v) = %s, want %s", tt.filter, got, tt.want)
+		}
+	}
+	if names(tasks) != "cab" {
+		t.Error("Apply must not reorder its input")
+	}
+}
+
+func TestKanbanFilterNextSort(t *testing.T) {
+	f := KanbanFilter{}
+	for _, want := range []KanbanSort{KanbanSortActivity, KanbanSortTokens, KanbanSortAge} {
+		f = f.NextSort()
+		if f.Sort != want {
+			t.Errorf("NextSort() = %q, want %q", f.Sort, want)
+		}
+	}
+}
+
+func TestKanbanFilterPersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "paw", "kanban-filter.json")
+	if f := loadKanbanFilter(path); !f.IsZero() {
+		t.Errorf("missing file should load zero filter, got %+v", f)
+	}
+
+	want := KanbanFilter{Query: "login", Status: "done", Sort: KanbanSortActivity, Group: true}
+	if err := saveKanbanFilter(path, want); err != nil {
+		t.Fatalf("saveKanbanFilter() error = %v", err)
+	}
+	if got := loadKanbanFilter(path); got != want {
+		t.Errorf("loadKanbanFilter() = %+v, want %+v", got, want)
+	}
+}
+
+func TestKanbanSwimlanes(t *testing.T) {
+	k := NewKanbanView(true)
+	k.filterPath = ""
+	k.columns, _ = parseKanbanColumns("waiting")
+	k.SetSize(120, 30)
+	k.setTasks([]*service.DiscoveredTask{
+		{Name: "a", Session: "web", Status: service.DiscoveredWaiting},
+		{Name: "b", Session: "api", Status: service.DiscoveredWaiting},
+		{Name: "c", Session: "web", Status: service.DiscoveredWaiting},
+	})
+	k.SetFilter(KanbanFilter{Group: true})
+
+	tasks := k.visible[0].tasks
+	headers := 0
+	for i := range tasks {
+		if k.laneHeader(tasks, i) != "" {
+			headers++
+		}
+	}
+	if headers != 2 {
+		t.Errorf("lane headers = %d, want 2", headers)
+	}
+
+	// Row 3 is the first lane header ("api"), row 4 its task
+	taskStartRow := 1 + kanbanHeaderLines
+	if task := k.GetTaskAtPosition(0, taskStartRow); task != nil {
+		t.Errorf("lane header row returned task %s", task.Name)
+	}
+	if task := k.GetTaskAtPosition(0, taskStartRow+1); task == nil || task.Name != "b" {
+		t.Errorf("first task under lane = %v, want b", task)
+	}
+}
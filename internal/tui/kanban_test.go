@@ -2,7 +2,12 @@
 package tui
 
 import (
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/dongho-jung/paw/internal/git"
+	"github.com/dongho-jung/paw/internal/service"
 )
 
 func TestCalculateActionLinesPerTask(t *testing.T) {
@@ -302,3 +307,50 @@ func BenchmarkNormalizePreviewLine(b *testing.B) {
 		}
 	}
 }
+
+func TestUnreviewedBadge(t *testing.T) {
+	if got := unreviewedBadge(0); got != "" {
+		t.Errorf("unreviewedBadge(0) = %q, want empty", got)
+	}
+	if got := unreviewedBadge(3); got != " ±3" {
+		t.Errorf("unreviewedBadge(3) = %q, want %q", got, " ±3")
+	}
+}
+
+func TestBuildCardLines(t *testing.T) {
+	now := time.Now()
+	task := &service.DiscoveredTask{
+		Name:         "my-task",
+		Model:        "opus",
+		LastActivity: now.Add(-3 * time.Minute),
+		Details: service.CardDetails{
+			Diff:       git.DiffStat{Files: 3, Added: 120, Removed: 8},
+			Ahead:      2,
+			Behind:     5,
+			LastCommit: "Add the card details",
+			Verify:     service.VerifyPassed,
+			Hook:       &service.HookMetadata{Name: "pre-merge", Status: "failed"},
+			PRNumber:   42,
+			PRState:    "open",
+		},
+	}
+
+	lines := buildCardLines(task, 80, now)
+	want := []string{
+		"+120 -8 · 3 files · ↑2 ↓5 · ✓ verify · ✗ pre-merge · #42 open · opus · 3m ago",
+		"› Add the card details",
+	}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("buildCardLines() = %q, want %q", lines, want)
+	}
+
+	// Narrow cards wrap badges to two lines and drop the rest
+	narrow := buildCardLines(task, 24, now)
+	if len(narrow) != 3 || narrow[0] != "+120 -8 · 3 files" || narrow[1] != "↑2 ↓5 · ✓ verify" {
+		t.Errorf("narrow buildCardLines() = %q", narrow)
+	}
+
+	if lines := buildCardLines(&service.DiscoveredTask{Name: "bare"}, 80, now); len(lines) != 0 {
+		t.Errorf("bare task lines = %q, want none", lines)
+	}
+}
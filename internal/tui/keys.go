@@ -0,0 +1,134 @@
+// Package tui provides terminal user interface components for PAW.
+package tui
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/dongho-jung/paw/internal/keymap"
+	"github.com/dongho-jung/paw/internal/logging"
+)
+
+var (
+	keysOnce sync.Once
+	keys     *keymap.Keymap
+)
+
+// activeKeymap returns the effective keymap. It is loaded on first use from
+// the global config and the project config of $PAW_DIR.
+func activeKeymap() *keymap.Keymap {
+	keysOnce.Do(func() {
+		if keys != nil {
+			return
+		}
+		var errs []error
+		keys, errs = keymap.Load(os.Getenv("PAW_DIR"))
+		for _, err := range errs {
+			logging.Warn("keybindings: %v", err)
+		}
+	})
+	return keys
+}
+
+// SetKeymap sets the effective keymap instead of loading it from config.
+func SetKeymap(k *keymap.Keymap) {
+	keysOnce.Do(func() {})
+	keys = k
+}
+
+// translateKey maps a key press to the default key of the action it is bound
+// to in the given scopes, so handlers can keep matching default keys.
+func translateKey(msg tea.KeyMsg, scopes ...keymap.Scope) string {
+	return activeKeymap().Translate(msg.String(), scopes...)
+}
+
+// translateViewerKey translates a key press in a viewer.
+func translateViewerKey(msg tea.KeyMsg) string {
+	return translateKey(msg, keymap.ScopeNav, keymap.ScopeViewer)
+}
+
+// typesText reports whether a key press types a character into a text input.
+func typesText(msg tea.KeyMsg) bool {
+	return utf8.RuneCountInString(msg.String()) == 1
+}
+
+// translateInputKey translates a key press in a view with a focused text
+// input. Keys that type text are left alone so they reach the input.
+func translateInputKey(msg tea.KeyMsg, scopes ...keymap.Scope) string {
+	if typesText(msg) {
+		return msg.String()
+	}
+	return translateKey(msg, scopes...)
+}
+
+// inputKeyLabel returns the label of the first key of an action that does
+// not type text, e.g. "↑", or fallback if every key types text.
+func inputKeyLabel(action keymap.Action, fallback string) string {
+	for _, key := range activeKeymap().Keys(action) {
+		if utf8.RuneCountInString(key) != 1 {
+			return keymap.KeyLabel(key)
+		}
+	}
+	return fallback
+}
+
+// keyMatches reports whether a key press is bound to an action.
+func keyMatches(msg tea.KeyMsg, action keymap.Action) bool {
+	key, err := keymap.ParseKey(msg.String())
+	if err != nil {
+		return false
+	}
+	return slices.Contains(activeKeymap().Keys(action), key)
+}
+
+// keyLabel returns the key label of an action for hints, e.g. "⌃G".
+func keyLabel(action keymap.Action) string {
+	return activeKeymap().Label(action)
+}
+
+// closeHint returns a "⌃G/q:close" hint from the keys of the given close actions.
+func closeHint(actions ...keymap.Action) string {
+	var labels []string
+	for _, action := range actions {
+		if label := keyLabel(action); label != "" {
+			labels = append(labels, label)
+		}
+	}
+	if len(labels) == 0 {
+		labels = append(labels, "Esc")
+	}
+	return strings.Join(labels, "/") + ":close"
+}
+
+// statusHint is a status bar hint with its display width computed once.
+type statusHint struct {
+	text  string
+	width int
+}
+
+func newStatusHint(text string) statusHint {
+	return statusHint{text: text, width: ansi.StringWidth(text)}
+}
+
+// toggleKey returns "esc" if a key press is bound to the action that opened a
+// picker, so pressing it again closes the picker; otherwise the key itself.
+func toggleKey(msg tea.KeyMsg, action keymap.Action) string {
+	if keyMatches(msg, action) {
+		return "esc"
+	}
+	return msg.String()
+}
+
+// escLabel returns the close label of a picker opened by an action, e.g. "Esc/⌃P".
+func escLabel(action keymap.Action) string {
+	if label := keyLabel(action); label != "" {
+		return "Esc/" + label
+	}
+	return "Esc"
+}
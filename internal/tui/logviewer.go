@@ -14,6 +14,9 @@ import (
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/charmbracelet/lipgloss/v2"
 	"github.com/charmbracelet/x/ansi"
+
+	"github.com/dongho-jung/paw/internal/keymap"
+	"github.com/dongho-jung/paw/internal/logging"
 )
 
 // logLevelTags stores pre-computed log level tags to avoid fmt.Sprintf per line.
@@ -22,14 +25,6 @@ var logLevelTags = [6]string{"[L0]", "[L1]", "[L2]", "[L3]", "[L4]", "[L5]"}
 // logLevelFilters stores pre-computed filter indicators for status bar.
 var logLevelFilters = [6]string{"", " [L1+]", " [L2+]", " [L3+]", " [L4+]", " [L5]"}
 
-// Pre-computed status bar hints and their widths (avoids ansi.StringWidth on each render)
-const (
-	logViewerHintFull       = "/:search n/N:match Tab:level w:wrap ⌃O/q:close"
-	logViewerHintShort      = "⌃O/q:close"
-	logViewerHintFullWidth  = 46 // ansi.StringWidth - ⌃ chars are 1 width each
-	logViewerHintShortWidth = 10 // ansi.StringWidth("⌃O/q:close")
-)
-
 // LogViewer provides an interactive log viewer with vim-like navigation.
 type LogViewer struct {
 	logFile              string
@@ -38,7 +33,8 @@ type LogViewer struct {
 	horizontalPos        int
 	tailMode             bool
 	wordWrap             bool
-	minLevel             int // 0-5: minimum level to display (0=all, 1=L1+, ..., 5=L5 only)
+	minLevel             int  // 0-5: minimum level to display (0=all, 1=L1+, ..., 5=L5 only)
+	timeline             bool // Show timer durations instead of log lines
 	width                int
 	height               int
 	lastModTime          time.Time
@@ -48,6 +44,10 @@ type LogViewer struct {
 	isDark               bool
 	colors               ThemeColors
 
+	// Status bar hints, rendered from the keymap once (avoids ansi.StringWidth on each render)
+	hintFull  statusHint
+	hintShort statusHint
+
 	// Mouse text selection state
 	selecting    bool
 	hasSelection bool
@@ -64,9 +64,18 @@ type LogViewer struct {
 	searchMatches    []int  // display line indices containing matches
 	currentMatchIdx  int    // current match index for n/N navigation
 
+	// Structured filter state (task:, script:, caller:, level:, since:, until:)
+	filterMode  bool           // whether filter input is active
+	filterInput string         // input buffer while typing
+	filterQuery string         // current filter query (confirmed)
+	filterErr   string         // parse error of the filter being typed
+	filter      logging.Filter // parsed filterQuery
+
 	// Display lines cache (invalidated on lines/minLevel/wordWrap/width change)
 	cachedDisplayLines  []string
 	cacheMinLevel       int
+	cacheFilterQuery    string
+	cacheTimeline       bool
 	cacheWordWrap       bool
 	cacheWidth          int
 	cacheLinesLen       int
@@ -114,12 +123,15 @@ func NewLogViewer(logFile string) *LogViewer {
 	// Detect dark mode BEFORE bubbletea starts
 	isDark := DetectDarkMode()
 
+	hintClose := closeHint(keymap.ActionToggleLogs, keymap.ActionClose)
 	return &LogViewer{
-		logFile:  logFile,
-		tailMode: true,
-		minLevel: minLevel,
-		isDark:   isDark,
-		colors:   NewThemeColors(isDark),
+		logFile:   logFile,
+		tailMode:  true,
+		minLevel:  minLevel,
+		isDark:    isDark,
+		colors:    NewThemeColors(isDark),
+		hintFull:  newStatusHint("/:search n/N:match f:filter T:timeline Tab:level w:wrap " + hintClose),
+		hintShort: newStatusHint(hintClose),
 	}
 }
 
@@ -132,7 +144,7 @@ func (m *LogViewer) Init() tea.Cmd {
 func (m *LogViewer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.BackgroundColorMsg:
-		m.isDark = msg.IsDark()
+		m.isDark = themeIsDark(msg.IsDark())
 		m.colors = NewThemeColors(m.isDark)
 		m.stylesCached = false // Invalidate style cache on theme change
 		setCachedDarkMode(m.isDark)
@@ -253,8 +265,14 @@ func (m *LogViewer) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	if m.searchMode {
 		return m.handleSearchKey(msg)
 	}
+	if m.filterMode {
+		return m.handleFilterKey(msg)
+	}
+	if keyMatches(msg, keymap.ActionToggleLogs) {
+		return m, tea.Quit
+	}
 
-	switch msg.String() {
+	switch translateViewerKey(msg) {
 	// Copy selection with Ctrl+C
 	case "ctrl+c":
 		if m.hasSelection {
@@ -262,7 +280,20 @@ func (m *LogViewer) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
-	case "q", "ctrl+o", "ctrl+l", "ctrl+shift+l", "ctrl+shift+o":
+	// Structured filter
+	case "f":
+		m.filterMode = true
+		m.filterInput = m.filterQuery
+		m.filterErr = ""
+		return m, nil
+
+	// Timeline of timer durations
+	case "T":
+		m.timeline = !m.timeline
+		m.refilter()
+		return m, nil
+
+	case "q", "ctrl+l", "ctrl+shift+l", "ctrl+shift+o":
 		return m, tea.Quit
 
 	case "esc":
@@ -402,6 +433,56 @@ func (m *LogViewer) handleSearchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	}
 }
 
+// handleFilterKey handles keyboard input in filter mode.
+func (m *LogViewer) handleFilterKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		filter, err := logging.ParseFilterQuery(m.filterInput, time.Now())
+		if err != nil {
+			m.filterErr = err.Error()
+			return m, nil
+		}
+		m.filterMode = false
+		m.filterQuery = strings.TrimSpace(m.filterInput)
+		m.filter = filter
+		m.refilter()
+		return m, nil
+
+	case "esc":
+		m.filterMode = false
+		return m, nil
+
+	case "backspace":
+		if len(m.filterInput) > 0 {
+			m.filterInput = m.filterInput[:len(m.filterInput)-1]
+		}
+		m.filterErr = ""
+		return m, nil
+
+	case "space":
+		m.filterInput += " "
+		return m, nil
+
+	default:
+		if len(msg.String()) == 1 {
+			m.filterInput += msg.String()
+			m.filterErr = ""
+		}
+		return m, nil
+	}
+}
+
+// refilter re-applies filters after the filter or view mode changed.
+func (m *LogViewer) refilter() {
+	m.scrollPos = 0
+	if m.searchQuery != "" {
+		m.findMatches()
+	}
+	if m.tailMode {
+		m.scrollToEnd()
+	}
+}
+
 // scrollUp scrolls up by n lines.
 func (m *LogViewer) scrollUp(n int) {
 	m.scrollPos -= n
@@ -452,8 +533,14 @@ func getLogLevel(line string) int {
 	return -1
 }
 
-// getFilteredLines returns lines filtered by minimum log level.
+// getFilteredLines returns lines filtered by minimum log level and the structured filter.
 func (m *LogViewer) getFilteredLines() []string {
+	if m.timeline {
+		return m.timelineLines()
+	}
+	if !m.filter.IsZero() {
+		return m.getStructuredLines()
+	}
 	if m.minLevel <= 0 {
 		return m.lines
 	}
@@ -470,6 +557,39 @@ func (m *LogViewer) getFilteredLines() []string {
 	return filtered
 }
 
+// getStructuredLines returns lines whose parsed entry passes the level and
+// structured filters. Continuation lines follow the entry they belong to.
+func (m *LogViewer) getStructuredLines() []string {
+	filtered := make([]string, 0, len(m.lines))
+	keep := true
+	for _, line := range m.lines {
+		e, ok := logging.ParseEntry(line)
+		if ok {
+			keep = (e.Level == logging.LevelUnknown || int(e.Level) >= m.minLevel) && m.filter.Match(e)
+		}
+		if keep {
+			filtered = append(filtered, line)
+		}
+	}
+	return filtered
+}
+
+// timelineLines renders timer spans of the entries passing the structured filter.
+// The level filter is ignored since timers log at L1.
+func (m *LogViewer) timelineLines() []string {
+	var entries []logging.Entry
+	for _, line := range m.lines {
+		if e, ok := logging.ParseEntry(line); ok && m.filter.Match(e) {
+			entries = append(entries, e)
+		}
+	}
+	lines := logging.FormatTimeline(logging.BuildTimeline(entries))
+	if len(lines) == 0 {
+		return []string{"No timer entries (timers are logged with PAW_DEBUG=1)"}
+	}
+	return lines
+}
+
 // colorizeLogLine applies log level coloring to a line based on its level.
 // The level tag (e.g., [L2]) is colorized with the appropriate color.
 func (m *LogViewer) colorizeLogLine(line string) string {
@@ -502,6 +622,8 @@ func (m *LogViewer) getDisplayLines() []string {
 	// Check cache validity
 	if m.cachedDisplayLines != nil &&
 		m.cacheMinLevel == m.minLevel &&
+		m.cacheFilterQuery == m.filterQuery &&
+		m.cacheTimeline == m.timeline &&
 		m.cacheWordWrap == m.wordWrap &&
 		m.cacheWidth == m.width &&
 		m.cacheLinesLen == len(m.lines) {
@@ -537,6 +659,8 @@ func (m *LogViewer) getDisplayLines() []string {
 	// Update cache
 	m.cachedDisplayLines = result
 	m.cacheMinLevel = m.minLevel
+	m.cacheFilterQuery = m.filterQuery
+	m.cacheTimeline = m.timeline
 	m.cacheWordWrap = m.wordWrap
 	m.cacheWidth = m.width
 	m.cacheLinesLen = len(m.lines)
@@ -664,10 +788,34 @@ func (m *LogViewer) View() tea.View {
 		return v
 	}
 
+	// Filter input mode: show filter bar instead of status
+	if m.filterMode {
+		bar := " Filter: " + m.filterInput + "█"
+		if m.filterErr != "" {
+			bar += "  " + m.filterErr
+		} else if m.filterInput == "" {
+			bar += "  level: task: script: caller: since: until:"
+		}
+		if w := ansi.StringWidth(bar); w < m.width {
+			bar += getPadding(m.width - w)
+		}
+		sb.WriteString(m.styleSearch.Render(ansi.Truncate(bar, m.width, "")))
+		v := tea.NewView(sb.String())
+		v.AltScreen = true
+		v.MouseMode = tea.MouseModeAllMotion
+		return v
+	}
+
 	var status string
 	if m.tailMode {
 		status = " [TAIL]"
 	}
+	if m.timeline {
+		status += " [TIMELINE]"
+	}
+	if m.filterQuery != "" {
+		status += " [" + m.filterQuery + "]"
+	}
 	if m.wordWrap {
 		status += " [WRAP]"
 	}
@@ -695,11 +843,12 @@ func (m *LogViewer) View() tea.View {
 	}
 
 	// Keybindings hint (use pre-computed widths to avoid ansi.StringWidth on each render)
-	hint := logViewerHintFull
-	padding := m.width - len(status) - logViewerHintFullWidth
+	statusWidth := ansi.StringWidth(status)
+	hint := m.hintFull.text
+	padding := m.width - statusWidth - m.hintFull.width
 	if padding < 0 {
-		hint = logViewerHintShort
-		padding = m.width - len(status) - logViewerHintShortWidth
+		hint = m.hintShort.text
+		padding = m.width - statusWidth - m.hintShort.width
 		if padding < 0 {
 			padding = 0
 		}
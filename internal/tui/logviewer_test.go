@@ -0,0 +1,78 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/dongho-jung/paw/internal/keymap"
+)
+
+func TestLogViewerKeysFollowKeymap(t *testing.T) {
+	km, _ := keymap.New(map[string]string{"toggle-logs": "C-x", "close": "Q"})
+	SetKeymap(km)
+	t.Cleanup(func() { SetKeymap(keymap.Default()) })
+
+	m := NewLogViewer("")
+	if m.hintShort.text != "⌃X/Q:close" {
+		t.Errorf("short hint = %q, want ⌃X/Q:close", m.hintShort.text)
+	}
+	if w := ansi.StringWidth(m.hintFull.text); w != m.hintFull.width {
+		t.Errorf("hintFull.width = %d, want %d", m.hintFull.width, w)
+	}
+
+	if _, cmd := m.handleKey(keyPress("q")); cmd != nil {
+		t.Error("q should no longer close the viewer")
+	}
+	for _, key := range []string{"Q", "ctrl+x"} {
+		if _, cmd := m.handleKey(keyPress(key)); cmd == nil {
+			t.Errorf("%s should close the viewer", key)
+		}
+	}
+}
+
+func TestLogViewerStructuredFilter(t *testing.T) {
+	m := NewLogViewer("")
+	m.minLevel = 0
+	m.lines = []string{
+		"[25-01-02 15:04:05.0] [L2] [new-task:add-api] [run] creating worktree",
+		"[25-01-02 15:04:06.0] [L2] [end-task:fix-login] [merge] merging",
+		"  continuation of the merge message",
+		"[25-01-02 15:04:07.0] [L1] [new-task:add-api] [setup] worktree setup started",
+		"[25-01-02 15:04:09.5] [L1] [new-task:add-api] [setup] worktree setup completed in 2.5s",
+	}
+
+	m.handleKey(keyPress("f"))
+	for _, r := range "script:end-task" {
+		m.handleKey(keyPress(string(r)))
+	}
+	m.handleKey(keyPress("enter"))
+	got := m.getDisplayLines()
+	if len(got) != 2 || !strings.Contains(got[0], "merging") || !strings.Contains(got[1], "continuation") {
+		t.Fatalf("filtered lines = %q", got)
+	}
+
+	// Invalid queries keep the input open
+	m.handleKey(keyPress("f"))
+	if m.filterInput != "script:end-task" {
+		t.Errorf("filter input = %q, want the current query", m.filterInput)
+	}
+	m.filterInput = ""
+	m.handleKey(keyPress("x"))
+	m.handleKey(keyPress("enter"))
+	if !m.filterMode || m.filterErr == "" {
+		t.Error("invalid filter should keep the input open with an error")
+	}
+	m.handleKey(keyPress("esc"))
+
+	// The timeline pairs timer entries of the filtered task
+	m.filterQuery = "task:add-api"
+	m.filter.Script = ""
+	m.filter.Task = "add-api"
+	m.handleKey(keyPress("T"))
+	got = m.getDisplayLines()
+	if len(got) != 1 || !strings.Contains(got[0], "worktree setup") || !strings.Contains(got[0], "2.5s") {
+		t.Errorf("timeline = %q", got)
+	}
+}
@@ -0,0 +1,396 @@
+package tui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/dongho-jung/paw/internal/keymap"
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/tmux"
+)
+
+const (
+	// overviewRefreshInterval is how often the tiles are recaptured.
+	overviewRefreshInterval = time.Second
+	// overviewMinTileHeight is the smallest tile (border, title and 3 lines).
+	overviewMinTileHeight = 6
+	// overviewMinTileWidth keeps tiles readable on narrow screens.
+	overviewMinTileWidth = 30
+)
+
+// overviewSource provides the tasks shown in the overview and their pane contents.
+type overviewSource interface {
+	Tasks() []*service.DiscoveredTask
+	Capture(task *service.DiscoveredTask) []string
+}
+
+// tmuxOverviewSource discovers tasks across sessions and captures their agent panes.
+type tmuxOverviewSource struct {
+	discovery *service.TaskDiscoveryService
+}
+
+func (s *tmuxOverviewSource) Tasks() []*service.DiscoveredTask {
+	return s.discovery.DiscoverAll()
+}
+
+func (s *tmuxOverviewSource) Capture(task *service.DiscoveredTask) []string {
+	content, err := tmux.New(task.Session).CapturePane(task.WindowID+".0", 0)
+	if err != nil {
+		return nil
+	}
+	lines := strings.Split(strings.TrimRight(content, "\n"), "\n")
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+type overviewTickMsg struct{}
+
+type overviewFrameMsg struct {
+	tasks    []*service.DiscoveredTask
+	captures map[string][]string
+}
+
+type overviewJumpMsg struct {
+	task string
+	err  error
+}
+
+// Overview tiles live, read-only mirrors of the agent panes of running tasks.
+type Overview struct {
+	session string
+	source  overviewSource
+	jump    func(*service.DiscoveredTask) error
+
+	tasks      []*service.DiscoveredTask
+	captures   map[string][]string // Task key → last captured pane lines
+	marked     map[string]bool
+	allScopes  bool // Show tasks of all sessions instead of this one
+	markedOnly bool
+	cursor     int
+	zoomed     bool
+	status     string
+	loaded     bool
+
+	isDark bool
+	colors ThemeColors
+	width  int
+	height int
+
+	// Style cache (reused across renders)
+	styleTitle   lipgloss.Style
+	styleTile    lipgloss.Style
+	styleFocused lipgloss.Style
+	styleHelp    lipgloss.Style
+	styleDim     lipgloss.Style
+	stylesCached bool
+}
+
+func newOverview(session string, source overviewSource, jump func(*service.DiscoveredTask) error) *Overview {
+	isDark := DetectDarkMode()
+	return &Overview{
+		session:  session,
+		source:   source,
+		jump:     jump,
+		captures: make(map[string][]string),
+		marked:   make(map[string]bool),
+		isDark:   isDark,
+		colors:   NewThemeColors(isDark),
+		width:    120,
+		height:   40,
+	}
+}
+
+func overviewKey(task *service.DiscoveredTask) string {
+	return task.Session + "/" + task.WindowID
+}
+
+// Init starts the first capture.
+func (m *Overview) Init() tea.Cmd {
+	if _, ok := cachedDarkModeValue(); ok {
+		return m.refresh()
+	}
+	return tea.Batch(tea.RequestBackgroundColor, m.refresh())
+}
+
+// refresh captures all running task panes in the background.
+func (m *Overview) refresh() tea.Cmd {
+	source := m.source
+	return func() tea.Msg {
+		var tasks []*service.DiscoveredTask
+		captures := make(map[string][]string)
+		for _, task := range source.Tasks() {
+			if task.WindowID == "" || task.Status == service.DiscoveredDone {
+				continue
+			}
+			tasks = append(tasks, task)
+			captures[overviewKey(task)] = source.Capture(task)
+		}
+		return overviewFrameMsg{tasks: tasks, captures: captures}
+	}
+}
+
+// Update handles messages.
+func (m *Overview) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+	case tea.BackgroundColorMsg:
+		m.isDark = themeIsDark(msg.IsDark())
+		m.colors = NewThemeColors(m.isDark)
+		m.stylesCached = false
+		setCachedDarkMode(m.isDark)
+		return m, nil
+	case overviewFrameMsg:
+		current := m.current()
+		m.tasks = msg.tasks
+		m.captures = msg.captures
+		m.loaded = true
+		m.keepCursorOn(current)
+		return m, tea.Tick(overviewRefreshInterval, func(time.Time) tea.Msg { return overviewTickMsg{} })
+	case overviewTickMsg:
+		return m, m.refresh()
+	case overviewJumpMsg:
+		if msg.err != nil {
+			m.status = fmt.Sprintf("Failed to jump to %s: %v", msg.task, msg.err)
+		}
+		return m, nil
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+	return m, nil
+}
+
+func (m *Overview) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	visible := m.visibleTasks()
+	cols, _ := m.grid(len(visible))
+	m.status = ""
+
+	switch toggleKey(msg, keymap.ActionOverview) {
+	case "ctrl+c", "q":
+		return m, tea.Quit
+	case "esc":
+		if m.zoomed {
+			m.zoomed = false
+			return m, nil
+		}
+		return m, tea.Quit
+	case "left", "h":
+		m.moveCursor(-1, len(visible))
+	case "right", "l", "tab":
+		m.moveCursor(1, len(visible))
+	case "up", "k":
+		m.moveCursor(-cols, len(visible))
+	case "down", "j":
+		m.moveCursor(cols, len(visible))
+	case "z":
+		m.zoomed = !m.zoomed && len(visible) > 0
+	case "space", " ":
+		if task := m.current(); task != nil {
+			key := overviewKey(task)
+			m.marked[key] = !m.marked[key]
+			if !m.marked[key] {
+				delete(m.marked, key)
+			}
+		}
+	case "f":
+		if !m.markedOnly && len(m.marked) == 0 {
+			m.status = "Mark tiles with Space first"
+			return m, nil
+		}
+		current := m.current()
+		m.markedOnly = !m.markedOnly
+		m.keepCursorOn(current)
+	case "a":
+		current := m.current()
+		m.allScopes = !m.allScopes
+		m.keepCursorOn(current)
+	case "enter":
+		if task := m.current(); task != nil && m.jump != nil {
+			jump := m.jump
+			return m, func() tea.Msg {
+				return overviewJumpMsg{task: task.Name, err: jump(task)}
+			}
+		}
+	}
+	return m, nil
+}
+
+func (m *Overview) moveCursor(delta, n int) {
+	if n == 0 {
+		return
+	}
+	m.cursor = max(0, min(n-1, m.cursor+delta))
+}
+
+// visibleTasks returns the tasks in scope: this session or all, marked only or all.
+func (m *Overview) visibleTasks() []*service.DiscoveredTask {
+	var tasks []*service.DiscoveredTask
+	for _, task := range m.tasks {
+		if !m.allScopes && task.Session != m.session {
+			continue
+		}
+		if m.markedOnly && !m.marked[overviewKey(task)] {
+			continue
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks
+}
+
+func (m *Overview) current() *service.DiscoveredTask {
+	visible := m.visibleTasks()
+	if m.cursor < len(visible) {
+		return visible[m.cursor]
+	}
+	return nil
+}
+
+// keepCursorOn moves the cursor to a task after the task list changed.
+func (m *Overview) keepCursorOn(task *service.DiscoveredTask) {
+	visible := m.visibleTasks()
+	if task != nil {
+		for i, t := range visible {
+			if overviewKey(t) == overviewKey(task) {
+				m.cursor = i
+				return
+			}
+		}
+	}
+	m.cursor = max(0, min(m.cursor, len(visible)-1))
+	if len(visible) == 0 {
+		m.zoomed = false
+	}
+}
+
+// grid returns the columns and rows used to tile n panes.
+func (m *Overview) grid(n int) (int, int) {
+	if n <= 1 {
+		return 1, 1
+	}
+	cols := int(math.Ceil(math.Sqrt(float64(n))))
+	cols = max(1, min(cols, m.width/overviewMinTileWidth))
+	rows := (n + cols - 1) / cols
+	maxRows := max(1, (m.height-2)/overviewMinTileHeight)
+	return cols, min(rows, maxRows)
+}
+
+// View renders the tiles.
+func (m *Overview) View() tea.View {
+	c := m.colors
+	if !m.stylesCached {
+		m.styleTitle = lipgloss.NewStyle().Bold(true).Foreground(c.Accent)
+		m.styleTile = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(c.Border)
+		m.styleFocused = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(c.BorderFocused)
+		m.styleHelp = lipgloss.NewStyle().Foreground(c.TextDim)
+		m.styleDim = lipgloss.NewStyle().Foreground(c.TextDim)
+		m.stylesCached = true
+	}
+
+	visible := m.visibleTasks()
+	scope := "this session"
+	if m.allScopes {
+		scope = "all sessions"
+	}
+	if m.markedOnly {
+		scope += ", marked"
+	}
+	header := m.styleTitle.Render("Overview") + m.styleDim.Render(fmt.Sprintf("  %d tasks · %s", len(visible), scope))
+
+	gridHeight := max(overviewMinTileHeight, m.height-2)
+	var body string
+	switch {
+	case !m.loaded:
+		body = m.styleDim.Render("  Capturing agent panes...")
+	case len(visible) == 0:
+		body = m.styleDim.Render("  No running tasks (press a to show all sessions)")
+	case m.zoomed:
+		task := visible[m.cursor]
+		body = m.renderTile(task, m.width, gridHeight, true)
+	default:
+		body = m.renderGrid(visible, gridHeight)
+	}
+
+	help := "←↓↑→: Move  z: Zoom  Enter: Jump  Space: Mark  f: Marked only  a: All sessions  q: Close"
+	if m.status != "" {
+		help = m.status
+	}
+
+	v := tea.NewView(lipgloss.JoinVertical(lipgloss.Left, header, body, m.styleHelp.Render(help)))
+	v.AltScreen = true
+	return v
+}
+
+func (m *Overview) renderGrid(tasks []*service.DiscoveredTask, gridHeight int) string {
+	cols, rows := m.grid(len(tasks))
+	perPage := cols * rows
+	start := (m.cursor / perPage) * perPage
+	end := min(start+perPage, len(tasks))
+
+	tileWidth := m.width / cols
+	tileHeight := gridHeight / rows
+
+	var lines []string
+	for row := start; row < end; row += cols {
+		var tiles []string
+		for i := row; i < min(row+cols, end); i++ {
+			tiles = append(tiles, m.renderTile(tasks[i], tileWidth, tileHeight, i == m.cursor))
+		}
+		lines = append(lines, lipgloss.JoinHorizontal(lipgloss.Top, tiles...))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderTile renders a bordered tile with the task title and the bottom of its pane.
+func (m *Overview) renderTile(task *service.DiscoveredTask, width, height int, focused bool) string {
+	innerWidth := max(1, width-2)
+	innerHeight := max(1, height-2)
+
+	title := task.StatusEmoji + " " + task.Name
+	if task.Session != m.session {
+		title += m.styleDim.Render(" · " + task.Session)
+	}
+	if m.marked[overviewKey(task)] {
+		title = "● " + title
+	}
+	if focused {
+		title = m.styleTitle.Render(ansi.Strip(title))
+	}
+
+	content := m.captures[overviewKey(task)]
+	if n := innerHeight - 1; len(content) > n {
+		content = content[len(content)-n:]
+	}
+
+	lines := make([]string, 0, innerHeight)
+	lines = append(lines, ansi.Truncate(title, innerWidth, "…"))
+	for _, line := range content {
+		lines = append(lines, ansi.Truncate(line, innerWidth, ""))
+	}
+	for len(lines) < innerHeight {
+		lines = append(lines, "")
+	}
+
+	style := m.styleTile
+	if focused {
+		style = m.styleFocused
+	}
+	return style.Width(width).Height(height).MaxHeight(height).Render(strings.Join(lines, "\n"))
+}
+
+// RunOverview runs the overview of the running tasks, starting with the
+// tasks of session. jump moves the client to a task's window.
+func RunOverview(session string, jump func(*service.DiscoveredTask) error) error {
+	source := &tmuxOverviewSource{discovery: service.NewTaskDiscoveryService()}
+	_, err := tea.NewProgram(newOverview(session, source, jump)).Run()
+	return err
+}
@@ -0,0 +1,105 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/dongho-jung/paw/internal/service"
+)
+
+type fakeOverviewSource struct {
+	tasks []*service.DiscoveredTask
+}
+
+func (s *fakeOverviewSource) Tasks() []*service.DiscoveredTask { return s.tasks }
+
+func (s *fakeOverviewSource) Capture(task *service.DiscoveredTask) []string {
+	return []string{"older output of " + task.Name, "latest output of " + task.Name}
+}
+
+func overviewTestModel(t *testing.T) *Overview {
+	t.Helper()
+	source := &fakeOverviewSource{tasks: []*service.DiscoveredTask{
+		{Name: "alpha", Session: "app", WindowID: "@1", StatusEmoji: "🤖", Status: service.DiscoveredWorking},
+		{Name: "beta", Session: "app", WindowID: "@2", StatusEmoji: "💬", Status: service.DiscoveredWaiting},
+		{Name: "gamma", Session: "app", WindowID: "@3", StatusEmoji: "🤖", Status: service.DiscoveredWorking},
+		{Name: "finished", Session: "app", WindowID: "@4", StatusEmoji: "✅", Status: service.DiscoveredDone},
+		{Name: "delta", Session: "api", WindowID: "@1", StatusEmoji: "🤖", Status: service.DiscoveredWorking},
+		{Name: "draft", Session: "app", BacklogID: "b1", Status: service.DiscoveredBacklog},
+	}}
+
+	m := newOverview("app", source, nil)
+	m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	m.Update(m.refresh()())
+	return m
+}
+
+func overviewView(m *Overview) string {
+	return ansi.Strip(fmt.Sprint(m.View().Layer))
+}
+
+func TestOverviewTiles(t *testing.T) {
+	m := overviewTestModel(t)
+
+	view := overviewView(m)
+	for _, want := range []string{"3 tasks · this session", "alpha", "beta", "gamma", "latest output of gamma"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view is missing %q:\n%s", want, view)
+		}
+	}
+	for _, unwanted := range []string{"finished", "draft", "delta"} {
+		if strings.Contains(view, unwanted) {
+			t.Errorf("view should not show %q:\n%s", unwanted, view)
+		}
+	}
+	for _, line := range strings.Split(view, "\n") {
+		if w := lipgloss.Width(line); w > 100 {
+			t.Fatalf("line is %d cells wide, want <= 100: %q", w, line)
+		}
+	}
+
+	m.Update(keyPress("a"))
+	if view := overviewView(m); !strings.Contains(view, "4 tasks · all sessions") || !strings.Contains(view, "delta · api") {
+		t.Errorf("all sessions view:\n%s", view)
+	}
+}
+
+func TestOverviewZoomMarkAndJump(t *testing.T) {
+	m := overviewTestModel(t)
+
+	m.Update(keyPress("l"))
+	m.Update(keyPress("z"))
+	view := overviewView(m)
+	if !strings.Contains(view, "beta") || strings.Contains(view, "alpha") {
+		t.Errorf("zoomed view should only show beta:\n%s", view)
+	}
+	m.Update(keyPress("esc"))
+	if m.zoomed {
+		t.Fatal("esc should leave zoom")
+	}
+
+	m.Update(keyPress("space"))
+	m.Update(keyPress("f"))
+	if view := overviewView(m); !strings.Contains(view, "1 tasks · this session, marked") || strings.Contains(view, "gamma") {
+		t.Errorf("marked only view:\n%s", view)
+	}
+
+	var jumped *service.DiscoveredTask
+	m.jump = func(task *service.DiscoveredTask) error {
+		jumped = task
+		return nil
+	}
+	_, cmd := m.Update(keyPress("enter"))
+	if cmd == nil {
+		t.Fatal("enter should jump")
+	}
+	cmd()
+	if jumped == nil || jumped.Name != "beta" {
+		t.Errorf("jumped to %+v, want beta", jumped)
+	}
+}
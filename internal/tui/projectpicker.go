@@ -1,12 +1,17 @@
 package tui
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/v2/textinput"
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/charmbracelet/lipgloss/v2"
 	"github.com/sahilm/fuzzy"
+
+	"github.com/dongho-jung/paw/internal/keymap"
 )
 
 // ProjectPickerAction represents the selected action.
@@ -18,10 +23,53 @@ const (
 	ProjectPickerSelect
 )
 
-// ProjectPickerItem represents a PAW project session.
+// ProjectPickerItem represents a PAW project, running or not.
 type ProjectPickerItem struct {
 	Name       string // Session name (project name)
-	SocketPath string // Tmux socket path
+	SocketPath string // Tmux socket path (running projects only)
+	Dir        string // Project directory (registered projects only)
+	Running    bool   // Whether the project's session is running
+	Pinned     bool
+	Tasks      int // Tasks in the workspace
+}
+
+// label renders the project name with its pin marker.
+func (p ProjectPickerItem) label() string {
+	label := p.Name
+	if p.Pinned {
+		label = "★ " + label
+	}
+	return label
+}
+
+// details renders the state, task count and directory of a project.
+func (p ProjectPickerItem) details() string {
+	state := "stopped"
+	if p.Running {
+		state = "running"
+	}
+	parts := []string{state}
+	switch p.Tasks {
+	case 0:
+	case 1:
+		parts = append(parts, "1 task")
+	default:
+		parts = append(parts, fmt.Sprintf("%d tasks", p.Tasks))
+	}
+	if p.Dir != "" {
+		parts = append(parts, shortenHome(p.Dir))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// shortenHome replaces the home directory prefix of a path with "~".
+func shortenHome(path string) string {
+	if home, err := os.UserHomeDir(); err == nil && home != "" {
+		if rest, ok := strings.CutPrefix(path, home); ok && (rest == "" || strings.HasPrefix(rest, string(filepath.Separator))) {
+			return "~" + rest
+		}
+	}
+	return path
 }
 
 // ProjectPicker is a fuzzy-searchable project picker.
@@ -104,15 +152,15 @@ func (m *ProjectPicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.input.SetWidth(inputWidth)
 		}
 	case tea.BackgroundColorMsg:
-		m.isDark = msg.IsDark()
+		m.isDark = themeIsDark(msg.IsDark())
 		m.colors = NewThemeColors(m.isDark)
 		m.stylesCached = false // Invalidate style cache on theme change
 		setCachedDarkMode(m.isDark)
 		return m, nil
 
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c", "esc", "ctrl+j":
+		switch toggleKey(msg, keymap.ActionProjectPicker) {
+		case "ctrl+c", "esc":
 			m.action = ProjectPickerCancel
 			return m, tea.Quit
 
@@ -180,10 +228,10 @@ func (m *ProjectPicker) updateFiltered() {
 		return
 	}
 
-	// Create searchable strings (project names)
+	// Create searchable strings (project names and directories)
 	searchables := make([]string, 0, len(m.projects))
 	for _, p := range m.projects {
-		searchables = append(searchables, p.Name)
+		searchables = append(searchables, p.Name+" "+p.Dir)
 	}
 
 	// Fuzzy search
@@ -258,7 +306,7 @@ func (m *ProjectPicker) View() tea.View {
 	// Filtered projects
 	if len(m.filtered) == 0 {
 		if len(m.projects) == 0 {
-			sb.WriteString(m.styleDim.Render("  No other projects running"))
+			sb.WriteString(m.styleDim.Render("  No other projects"))
 		} else {
 			sb.WriteString(m.styleDim.Render("  No matching projects"))
 		}
@@ -275,10 +323,11 @@ func (m *ProjectPicker) View() tea.View {
 			idx := m.filtered[i]
 			project := m.projects[idx]
 
+			details := m.styleDim.Render("  " + project.details())
 			if i == m.cursor {
-				sb.WriteString(m.styleSelected.Render("> " + project.Name))
+				sb.WriteString(m.styleSelected.Render("> "+project.label()) + details)
 			} else {
-				sb.WriteString(m.styleItem.Render(project.Name))
+				sb.WriteString(m.styleItem.Render(project.label()) + details)
 			}
 			sb.WriteString("\n")
 		}
@@ -293,7 +342,7 @@ func (m *ProjectPicker) View() tea.View {
 	}
 
 	// Help (MarginTop adds spacing)
-	sb.WriteString(m.styleHelp.Render("↑/↓: Navigate  Enter/Space: Switch  Esc/⌃J: Cancel"))
+	sb.WriteString(m.styleHelp.Render("↑/↓: Navigate  Enter/Space: Switch (starts stopped projects)  " + escLabel(keymap.ActionProjectPicker) + ": Cancel"))
 
 	v := tea.NewView(sb.String())
 	v.AltScreen = true
@@ -6,6 +6,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/charmbracelet/lipgloss/v2"
 
+	"github.com/dongho-jung/paw/internal/keymap"
 	"github.com/dongho-jung/paw/internal/logging"
 )
 
@@ -83,15 +84,15 @@ func (m *PromptPicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.height = msg.Height
 
 	case tea.BackgroundColorMsg:
-		m.isDark = msg.IsDark()
+		m.isDark = themeIsDark(msg.IsDark())
 		m.colors = NewThemeColors(m.isDark)
 		m.stylesCached = false // Invalidate style cache on theme change
 		setCachedDarkMode(m.isDark)
 		return m, nil
 
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c", "esc", "ctrl+y", "q":
+		switch toggleKey(msg, keymap.ActionPromptPicker) {
+		case "ctrl+c", "esc", "q":
 			m.action = PromptPickerCancel
 			return m, tea.Quit
 
@@ -205,7 +206,7 @@ func (m *PromptPicker) View() tea.View {
 
 	// Help
 	sb.WriteString("\n")
-	sb.WriteString(m.styleHelp.Render("↑/↓: Navigate  Enter: Edit  Esc/⌃Y: Close"))
+	sb.WriteString(m.styleHelp.Render("↑/↓: Navigate  Enter: Edit  " + escLabel(keymap.ActionPromptPicker) + ": Close"))
 
 	v := tea.NewView(sb.String())
 	v.AltScreen = true
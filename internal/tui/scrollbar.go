@@ -23,8 +23,8 @@ func getScrollbarStyles(isDark bool) (track, thumb lipgloss.Style) {
 
 	if !scrollbarStyles[idx].init {
 		lightDark := lipgloss.LightDark(isDark)
-		trackColor := lightDark(lipgloss.Color("250"), lipgloss.Color("238"))
-		thumbColor := lightDark(lipgloss.Color("245"), lipgloss.Color("245"))
+		trackColor := themeColor("border_dim", lightDark(lipgloss.Color("250"), lipgloss.Color("238")))
+		thumbColor := themeColor("text_dim", lightDark(lipgloss.Color("245"), lipgloss.Color("245")))
 
 		scrollbarStyles[idx].track = lipgloss.NewStyle().Foreground(trackColor)
 		scrollbarStyles[idx].thumb = lipgloss.NewStyle().Foreground(thumbColor)
@@ -35,12 +35,12 @@ var spinnerStyles struct {
 // getSpinnerStyles returns cached spinner styles.
 func getSpinnerStyles() (errorStyle, successStyle, spinnerStyle, boxStyle lipgloss.Style) {
 	if !spinnerStyles.init {
-		spinnerStyles.error = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
-		spinnerStyles.success = lipgloss.NewStyle().Foreground(lipgloss.Color("40"))
-		spinnerStyles.spinner = lipgloss.NewStyle().Foreground(lipgloss.Color("39"))
+		spinnerStyles.error = lipgloss.NewStyle().Foreground(themeColor("error", lipgloss.Color("196")))
+		spinnerStyles.success = lipgloss.NewStyle().Foreground(themeColor("success", lipgloss.Color("40")))
+		spinnerStyles.spinner = lipgloss.NewStyle().Foreground(themeColor("accent", lipgloss.Color("39")))
 		spinnerStyles.box = lipgloss.NewStyle().
 			BorderStyle(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("39")).
+			BorderForeground(themeColor("border_focused", lipgloss.Color("39"))).
 			Padding(1, 3)
 		spinnerStyles.init = true
 	}
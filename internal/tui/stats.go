@@ -0,0 +1,323 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/dongho-jung/paw/internal/keymap"
+	"github.com/dongho-jung/paw/internal/service"
+)
+
+const (
+	// statsMaxDays caps the days listed under "Tasks per day".
+	statsMaxDays = 31
+	// statsBarWidth is the width of the longest tasks-per-day bar.
+	statsBarWidth = 20
+)
+
+// StatsRange is a period the statistics can cover.
+type StatsRange struct {
+	Label string
+	Span  time.Duration // 0 covers all history
+}
+
+// StatsRanges are the periods the dashboard cycles through.
+var StatsRanges = []StatsRange{
+	{"last 7 days", 7 * 24 * time.Hour},
+	{"last 30 days", 30 * 24 * time.Hour},
+	{"last 90 days", 90 * 24 * time.Hour},
+	{"all time", 0},
+}
+
+// statsSection is a titled block of the statistics report.
+type statsSection struct {
+	title string
+	lines []string
+}
+
+func formatStatsRate(rate float64) string {
+	return fmt.Sprintf("%.0f%%", rate*100)
+}
+
+// formatStatsDuration renders a median or total, "-" when there is none.
+func formatStatsDuration(d time.Duration) string {
+	if d <= 0 {
+		return "-"
+	}
+	return formatTimelineDuration(d)
+}
+
+// statsSections lays out the report: summary, tasks per day and models.
+func statsSections(stats service.Stats) []statsSection {
+	finished := stats.TaskCount - stats.Open
+	row := func(label, value string) string { return fmt.Sprintf("%-16s%s", label, value) }
+	summary := statsSection{title: "Summary", lines: []string{
+		row("Tasks", fmt.Sprintf("%d started · %d completed · %d dropped · %d cancelled · %d open",
+			stats.TaskCount, stats.Completed, stats.Dropped, stats.Cancelled, stats.Open)),
+		row("Completion", fmt.Sprintf("%s of %d finished tasks", formatStatsRate(stats.CompletionRate), finished)),
+		row("Time to done", "median "+formatStatsDuration(stats.MedianTimeToDone)),
+		row("Waiting on you", fmt.Sprintf("%s in total · median %s per task",
+			formatStatsDuration(stats.TotalWaiting), formatStatsDuration(stats.MedianWaiting))),
+		row("Merge conflicts", fmt.Sprintf("%d encountered · %d auto-resolved", stats.MergeConflicts, stats.ConflictsResolved)),
+	}}
+
+	days := stats.PerDay
+	if len(days) > statsMaxDays {
+		days = days[len(days)-statsMaxDays:]
+	}
+	most := 0
+	for _, d := range days {
+		most = max(most, d.Started)
+	}
+	perDay := statsSection{title: "Tasks per day"}
+	for _, d := range days {
+		label := d.Date
+		if day, err := time.ParseInLocation("2006-01-02", d.Date, time.Local); err == nil {
+			label = day.Format("Mon 01-02")
+		}
+		bar := ""
+		if most > 0 {
+			bar = strings.Repeat("█", (d.Started*statsBarWidth+most-1)/most)
+		}
+		perDay.lines = append(perDay.lines, fmt.Sprintf("%s  %-*s %2d started  %2d completed",
+			label, statsBarWidth, bar, d.Started, d.Completed))
+	}
+	if len(perDay.lines) == 0 {
+		perDay.lines = []string{"No tasks"}
+	}
+
+	models := statsSection{title: "Models (finished tasks)"}
+	if len(stats.Models) > 0 {
+		models.lines = append(models.lines, fmt.Sprintf("%-10s %8s %8s %15s %15s", "MODEL", "FINISHED", "SUCCESS", "MEDIAN TO DONE", "MEDIAN WORKING"))
+	}
+	for _, m := range stats.Models {
+		models.lines = append(models.lines, fmt.Sprintf("%-10s %8d %8s %15s %15s",
+			m.Model, m.Finished, formatStatsRate(m.SuccessRate),
+			formatStatsDuration(m.MedianTimeToDone), formatStatsDuration(m.MedianWorking)))
+	}
+	if len(stats.Models) == 0 {
+		models.lines = []string{"No finished tasks"}
+	}
+
+	return []statsSection{summary, perDay, models}
+}
+
+// FormatStats renders the statistics report as text lines.
+func FormatStats(stats service.Stats) []string {
+	var lines []string
+	for i, section := range statsSections(stats) {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, section.title)
+		for _, line := range section.lines {
+			lines = append(lines, "  "+line)
+		}
+	}
+	return lines
+}
+
+type statsLoadedMsg struct {
+	stats service.Stats
+	err   error
+}
+
+// StatsView is a dashboard of the task statistics, switching between
+// periods and projects.
+type StatsView struct {
+	load     func(project string, since, now time.Time) (service.Stats, error)
+	projects []string // Project names; "" is all projects
+
+	project  int
+	period   int
+	stats    service.Stats
+	err      error
+	loaded   bool
+	offset   int
+	contents []string
+
+	isDark bool
+	colors ThemeColors
+	width  int
+	height int
+
+	// Style cache (reused across renders)
+	styleTitle   lipgloss.Style
+	styleSection lipgloss.Style
+	styleHelp    lipgloss.Style
+	styleDim     lipgloss.Style
+	stylesCached bool
+}
+
+func newStatsView(projects []string, project string, load func(project string, since, now time.Time) (service.Stats, error)) *StatsView {
+	isDark := DetectDarkMode()
+	m := &StatsView{
+		load:     load,
+		projects: append([]string{""}, projects...),
+		period:   1,
+		isDark:   isDark,
+		colors:   NewThemeColors(isDark),
+		width:    100,
+		height:   30,
+	}
+	for i, p := range m.projects {
+		if p == project {
+			m.project = i
+		}
+	}
+	return m
+}
+
+// Init loads the statistics.
+func (m *StatsView) Init() tea.Cmd {
+	if _, ok := cachedDarkModeValue(); ok {
+		return m.refresh()
+	}
+	return tea.Batch(tea.RequestBackgroundColor, m.refresh())
+}
+
+// refresh computes the statistics of the current period and project in the background.
+func (m *StatsView) refresh() tea.Cmd {
+	load, project, span := m.load, m.projects[m.project], StatsRanges[m.period].Span
+	return func() tea.Msg {
+		now := time.Now()
+		var since time.Time
+		if span > 0 {
+			since = now.Add(-span)
+		}
+		stats, err := load(project, since, now)
+		return statsLoadedMsg{stats: stats, err: err}
+	}
+}
+
+// Update handles messages.
+func (m *StatsView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.clampOffset()
+		return m, nil
+	case tea.BackgroundColorMsg:
+		m.isDark = themeIsDark(msg.IsDark())
+		m.colors = NewThemeColors(m.isDark)
+		m.stylesCached = false
+		setCachedDarkMode(m.isDark)
+		return m, nil
+	case statsLoadedMsg:
+		m.stats, m.err = msg.stats, msg.err
+		m.loaded = true
+		m.stylesCached = false // Rebuild the styled contents
+		return m, nil
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+	return m, nil
+}
+
+func (m *StatsView) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if keyMatches(msg, keymap.ActionClose) {
+		return m, tea.Quit
+	}
+	// Period and project keys belong to the dashboard, so they are matched before the keymap
+	switch msg.String() {
+	case "ctrl+c", "esc":
+		return m, tea.Quit
+	case "tab", "r":
+		m.period = (m.period + 1) % len(StatsRanges)
+		m.offset = 0
+		return m, m.refresh()
+	case "p":
+		m.project = (m.project + 1) % len(m.projects)
+		m.offset = 0
+		return m, m.refresh()
+	}
+
+	switch translateViewerKey(msg) {
+	case "up":
+		m.offset--
+	case "down":
+		m.offset++
+	case "pgup":
+		m.offset -= m.pageSize()
+	case "pgdown":
+		m.offset += m.pageSize()
+	}
+	m.clampOffset()
+	return m, nil
+}
+
+// pageSize is the number of report lines between the header and the help.
+func (m *StatsView) pageSize() int {
+	return max(1, m.height-3)
+}
+
+func (m *StatsView) clampOffset() {
+	m.offset = max(0, min(m.offset, len(m.contents)-m.pageSize()))
+}
+
+// View renders the dashboard.
+func (m *StatsView) View() tea.View {
+	c := m.colors
+	if !m.stylesCached {
+		m.styleTitle = lipgloss.NewStyle().Bold(true).Foreground(c.Accent)
+		m.styleSection = lipgloss.NewStyle().Bold(true).Foreground(c.TextBright)
+		m.styleHelp = lipgloss.NewStyle().Foreground(c.TextDim)
+		m.styleDim = lipgloss.NewStyle().Foreground(c.TextDim)
+		m.contents = nil
+		if m.loaded && m.err == nil {
+			for i, section := range statsSections(m.stats) {
+				if i > 0 {
+					m.contents = append(m.contents, "")
+				}
+				m.contents = append(m.contents, m.styleSection.Render(section.title))
+				for _, line := range section.lines {
+					m.contents = append(m.contents, "  "+line)
+				}
+			}
+		}
+		m.clampOffset()
+		m.stylesCached = true
+	}
+
+	project := m.projects[m.project]
+	if project == "" {
+		project = "all projects"
+	}
+	header := m.styleTitle.Render("Statistics") + m.styleDim.Render("  "+StatsRanges[m.period].Label+" · "+project)
+
+	var body []string
+	switch {
+	case !m.loaded:
+		body = []string{m.styleDim.Render("  Reading task history...")}
+	case m.err != nil:
+		body = []string{m.styleDim.Render(fmt.Sprintf("  Failed to compute statistics: %v", m.err))}
+	case m.stats.TaskCount == 0:
+		body = []string{m.styleDim.Render("  No tasks in this period (press r for a longer one)")}
+	default:
+		end := min(len(m.contents), m.offset+m.pageSize())
+		for _, line := range m.contents[m.offset:end] {
+			body = append(body, ansi.Truncate(line, m.width, "…"))
+		}
+	}
+
+	help := "r:period p:project ↑↓:scroll " + closeHint(keymap.ActionClose)
+	lines := append([]string{header}, body...)
+	lines = append(lines, m.styleHelp.Render(help))
+
+	v := tea.NewView(strings.Join(lines, "\n"))
+	v.AltScreen = true
+	return v
+}
+
+// RunStats runs the statistics dashboard. projects are the project names to
+// cycle through next to all projects, starting with project ("" for all).
+func RunStats(projects []string, project string, load func(project string, since, now time.Time) (service.Stats, error)) error {
+	_, err := tea.NewProgram(newStatsView(projects, project, load)).Run()
+	return err
+}
@@ -0,0 +1,124 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/dongho-jung/paw/internal/keymap"
+	"github.com/dongho-jung/paw/internal/service"
+)
+
+func statsTestData() service.Stats {
+	return service.Stats{
+		TaskCount: 4, Completed: 2, Dropped: 1, Open: 1,
+		CompletionRate:   2.0 / 3,
+		MedianTimeToDone: 90 * time.Minute,
+		TotalWaiting:     45 * time.Minute,
+		MergeConflicts:   2, ConflictsResolved: 1,
+		PerDay: []service.DayStats{
+			{Date: "2026-03-02", Started: 1, Completed: 1},
+			{Date: "2026-03-03", Started: 3, Completed: 1},
+		},
+		Models: []service.ModelStats{
+			{Model: "opus", Finished: 2, Completed: 2, SuccessRate: 1, MedianTimeToDone: time.Hour, MedianWorking: 40 * time.Minute},
+			{Model: "haiku", Finished: 1},
+		},
+	}
+}
+
+func TestFormatStats(t *testing.T) {
+	text := strings.Join(FormatStats(statsTestData()), "\n")
+	for _, want := range []string{
+		"4 started · 2 completed · 1 dropped · 0 cancelled · 1 open",
+		"67% of 3 finished tasks",
+		"median 1h30m",
+		"45m in total · median - per task",
+		"2 encountered · 1 auto-resolved",
+		"Mon 03-02",
+		"Tue 03-03  " + strings.Repeat("█", statsBarWidth) + "  3 started   1 completed",
+		"opus              2     100%",
+		"haiku             1       0%",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("report missing %q:\n%s", want, text)
+		}
+	}
+
+	empty := strings.Join(FormatStats(service.Stats{}), "\n")
+	if !strings.Contains(empty, "No tasks") || !strings.Contains(empty, "No finished tasks") {
+		t.Errorf("empty report = %q", empty)
+	}
+}
+
+func TestStatsViewKeys(t *testing.T) {
+	type call struct {
+		project string
+		all     bool
+	}
+	var calls []call
+	load := func(project string, since, _ time.Time) (service.Stats, error) {
+		calls = append(calls, call{project, since.IsZero()})
+		return statsTestData(), nil
+	}
+
+	m := newStatsView([]string{"api", "web"}, "web", load)
+	m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	m.Update(m.refresh()())
+
+	view := ansi.Strip(fmt.Sprint(m.View().Layer))
+	if !strings.Contains(view, "last 30 days · web") || !strings.Contains(view, "Tasks per day") {
+		t.Errorf("view = %q", view)
+	}
+
+	// p cycles projects: web → all projects
+	_, cmd := m.Update(keyPress("p"))
+	m.Update(cmd())
+	if !strings.Contains(ansi.Strip(fmt.Sprint(m.View().Layer)), "all projects") {
+		t.Error("p should switch to all projects")
+	}
+
+	// r cycles periods up to all time
+	for range 2 {
+		_, cmd = m.Update(keyPress("r"))
+		m.Update(cmd())
+	}
+	if !strings.Contains(ansi.Strip(fmt.Sprint(m.View().Layer)), "all time") {
+		t.Error("r should reach all time")
+	}
+
+	want := []call{{"web", false}, {"", false}, {"", false}, {"", true}}
+	if fmt.Sprint(calls) != fmt.Sprint(want) {
+		t.Errorf("loads = %v, want %v", calls, want)
+	}
+}
+
+func TestStatsViewKeysFollowKeymap(t *testing.T) {
+	km, _ := keymap.New(map[string]string{"close": "Q", "down": "J"})
+	SetKeymap(km)
+	t.Cleanup(func() { SetKeymap(keymap.Default()) })
+
+	m := newStatsView([]string{"api"}, "api", func(string, time.Time, time.Time) (service.Stats, error) {
+		return statsTestData(), nil
+	})
+	m.Update(tea.WindowSizeMsg{Width: 100, Height: 10})
+	m.Update(m.refresh()())
+
+	if view := ansi.Strip(fmt.Sprint(m.View().Layer)); !strings.Contains(view, "Q:close") {
+		t.Errorf("help should show the remapped close key:\n%s", view)
+	}
+	m.Update(keyPress("J"))
+	if m.offset != 1 {
+		t.Errorf("offset = %d, want J to scroll down", m.offset)
+	}
+	if _, cmd := m.Update(keyPress("q")); cmd != nil {
+		t.Error("q should no longer close the dashboard")
+	}
+	if _, cmd := m.Update(keyPress("Q")); cmd == nil {
+		t.Error("Q should close the dashboard")
+	}
+}
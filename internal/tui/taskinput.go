@@ -5,6 +5,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/v2/textinput"
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/charmbracelet/lipgloss/v2"
 
@@ -31,13 +32,15 @@ type OptField int
 const (
 	OptFieldModel OptField = iota
 	OptFieldBranchName
+	OptFieldSource
+	OptFieldTarget
 )
 
 // optFieldCount returns the number of option fields based on git mode.
-// In non-git mode, the Branch field is hidden.
+// In non-git mode, the Branch, From and Target fields are hidden.
 func optFieldCount(isGitRepo bool) int {
 	if isGitRepo {
-		return 2 // Model + Branch
+		return 4 // Model + Branch + From + Target
 	}
 	return 1 // Model only
 }
@@ -61,6 +64,7 @@ type TaskInput struct {
 	submitted            bool
 	cancelled            bool
 	requestTaskNamePopup bool
+	saveToBacklog        bool
 	width                int
 	height               int
 	options              *config.TaskOptions
@@ -77,10 +81,12 @@ type TaskInput struct {
 	optionsPanelWidth int // Options panel display width (dynamic for alignment)
 
 	// Inline options editing
-	focusPanel FocusPanel
-	optField   OptField
-	modelIdx   int
-	branchName string // Custom branch name input (empty = auto)
+	focusPanel   FocusPanel
+	optField     OptField
+	modelIdx     int
+	branchName   string // Custom branch name input (empty = auto)
+	sourceRef    string // Existing branch/PR to check out (empty = new branch from the target)
+	targetBranch string // Branch to merge into and open PRs against (empty = project default)
 
 	mouseSelecting  bool
 	selectAnchorRow int
@@ -88,7 +94,7 @@ type TaskInput struct {
 
 	// Kanban mouse selection (column-aware)
 	kanbanSelecting  bool
-	kanbanSelectCol  int // Column being selected (0-3)
+	kanbanSelectCol  int // Column being selected
 	kanbanSelectX    int // X position relative to column
 	kanbanSelectY    int // Y position relative to kanban area
 	kanbanClickStart struct {
@@ -121,6 +127,27 @@ type TaskInput struct {
 	// Cross-project jump target (set when user requests to jump to external project)
 	jumpTarget *JumpTarget
 
+	// Kanban card actions: context menu, cancel confirmation, quick reply and result status
+	kanbanMenu        *kanbanMenu
+	kanbanConfirmTask *service.DiscoveredTask
+	kanbanReplyTask   *service.DiscoveredTask
+	kanbanReplyInput  textinput.Model
+	kanbanStatus      string
+	kanbanStatusUntil time.Time
+
+	// Inline completion popup (@ files, # tasks, / templates) and its cached candidates
+	completion              *taskCompletion
+	completionDismissed     *completionPos // Trigger whose popup was closed with Esc
+	completionFiles         []completionItem
+	completionFilesAt       time.Time
+	completionHistory       []completionItem
+	completionHistoryLoaded bool
+
+	// Kanban filter bar (opened with "/" on the board)
+	kanbanFilterEditing bool
+	kanbanFilterInput   textinput.Model
+	kanbanFilterErr     string
+
 	// Style cache for options panel (invalidated on theme change)
 	optStylesCached       bool
 	optStyleTitle         lipgloss.Style
@@ -140,8 +167,10 @@ type TaskInput struct {
 	viewStyleCancelHint  lipgloss.Style
 
 	// Pre-rendered help text and width (cached to avoid lipgloss.Width on each render)
-	viewHelpRendered string
-	viewHelpWidth    int
+	viewHelpRendered       string
+	viewHelpWidth          int
+	viewKanbanHelpRendered string
+	viewKanbanHelpWidth    int
 }
 
 // tickMsg is used for periodic Kanban refresh.
@@ -172,6 +201,7 @@ type TaskInputResult struct {
 	Cancelled            bool
 	JumpTarget           *JumpTarget // Non-nil if user requested to jump to an external project task
 	RequestTaskNamePopup bool        // True if user pressed Alt+Enter with empty content
+	SaveToBacklog        bool        // True if user pressed Alt+S to draft the task without starting it
 }
 
 // NewTaskInputWithOptions creates a new task input model with active task list and git mode flag.
@@ -225,6 +255,8 @@ func NewTaskInputWithOptions(activeTasks []string, isGitRepo bool) *TaskInput {
 		optField:          OptFieldModel,
 		modelIdx:          modelIdx,
 		branchName:        opts.BranchName,
+		sourceRef:         opts.SourceRef,
+		targetBranch:      opts.TargetBranch,
 		kanban:            NewKanbanView(isDark),
 		currentTip:        GetTip(),
 		lastTipRefresh:    time.Now(),
@@ -235,8 +267,8 @@ func applyTaskInputTextareaTheme(ta *textarea.Model, isDark bool) {
 	ta.Styles = textarea.DefaultStyles(isDark)
 	// Accent color: darker blue for light bg (good contrast), bright cyan for dark bg
 	lightDark := lipgloss.LightDark(isDark)
-	accentColor := lightDark(lipgloss.Color("25"), lipgloss.Color("39"))
-	dimColor := lightDark(lipgloss.Color("250"), lipgloss.Color("240"))
+	accentColor := themeColor("border_focused", lightDark(lipgloss.Color("25"), lipgloss.Color("39")))
+	dimColor := themeColor("border", lightDark(lipgloss.Color("250"), lipgloss.Color("240")))
 	ta.Styles.Focused.Base = lipgloss.NewStyle().
 		BorderStyle(lipgloss.RoundedBorder()).
 		BorderForeground(accentColor).
@@ -423,8 +455,11 @@ func (m *TaskInput) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Same-project jump completed - nothing to do on success
 		return m, nil
 
+	case kanbanActionMsg:
+		return m, m.handleKanbanActionResult(msg)
+
 	case tea.BackgroundColorMsg:
-		isDark := msg.IsDark()
+		isDark := themeIsDark(msg.IsDark())
 		setCachedDarkMode(isDark)
 		m.applyTheme(isDark)
 		return m, nil
@@ -506,8 +541,18 @@ func (m *TaskInput) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Track keystroke time to skip expensive I/O during typing
 		m.lastKeystroke = time.Now()
 
+		// Kanban card overlays (context menu, cancel confirmation, quick reply) take all keys
+		if handled, overlayCmd := m.updateKanbanOverlay(msg); handled {
+			return m, overlayCmd
+		}
+
 		keyStr := msg.String()
 
+		// The completion popup takes its keys before the global ones (Esc closes it)
+		if m.focusPanel == FocusPanelLeft && m.completionOpen() && m.updateCompletionKeys(keyStr) {
+			return m, nil
+		}
+
 		// Handle Ctrl+C or Cmd+C for copying selection (textarea or kanban)
 		// Cmd+C works on terminals that support the Kitty keyboard protocol
 		key := msg.Key()
@@ -553,6 +598,15 @@ func (m *TaskInput) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.requestTaskNamePopup = true
 			return m, tea.Quit
 
+		// Save to backlog: Alt+S (drafted, started later from the kanban)
+		case "alt+s":
+			m.applyOptionInputValues()
+			if strings.TrimSpace(m.textarea.Value()) != "" {
+				m.saveToBacklog = true
+				return m, tea.Quit
+			}
+			return m, nil
+
 		case "tab":
 			if m.focusPanel == FocusPanelLeft {
 				if m.jumpToNextTemplatePlaceholder() {
@@ -590,13 +644,13 @@ func (m *TaskInput) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 
 		// Toggle panel backward: Alt+Shift+Tab (cycle backward through panels)
-		// Cycle order: Left → Kanban(col 2) → Kanban(col 1) → Kanban(col 0) → Right → Left
+		// Cycle order: Left → Kanban(last col) → ... → Kanban(col 0) → Right → Left
 		case "alt+shift+tab":
 			m.applyOptionInputValues()
 			switch m.focusPanel {
 			case FocusPanelLeft:
-				// Move to last Kanban column (Done = column 2)
-				m.switchFocusToKanbanColumn(2)
+				// Move to last Kanban column (Done by default)
+				m.switchFocusToKanbanColumn(m.kanban.ColumnCount() - 1)
 			case FocusPanelRight:
 				m.switchFocusTo(FocusPanelLeft)
 			case FocusPanelKanban:
@@ -624,6 +678,31 @@ func (m *TaskInput) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Left panel (textarea) - handle mouse clicks below
 
 	case tea.MouseClickMsg:
+		// An open context menu consumes the click (runs the item or closes the menu)
+		if m.kanbanMenu != nil {
+			return m, m.handleKanbanMenuClick(msg.X, msg.Y)
+		}
+
+		// A click on a completion item inserts it; other clicks close the popup
+		if m.focusPanel == FocusPanelLeft && m.completionOpen() {
+			if idx := m.completionItemAt(msg.X, msg.Y); idx >= 0 && msg.Button == tea.MouseLeft {
+				m.completion.selected = idx
+				m.acceptCompletion()
+				return m, nil
+			}
+			m.completion = nil
+		}
+
+		// Right-click on a kanban card opens its action menu
+		if msg.Button == tea.MouseRight {
+			if m.detectClickedPanel(msg.X, msg.Y) == FocusPanelKanban {
+				m.textarea.ClearSelection()
+				m.kanban.ClearSelection()
+				m.openKanbanMenu(msg.X, msg.Y)
+			}
+			return m, nil
+		}
+
 		if msg.Button == tea.MouseLeft {
 			// Determine which panel was clicked and switch focus
 			clickedPanel := m.detectClickedPanel(msg.X, msg.Y)
@@ -698,6 +777,12 @@ func (m *TaskInput) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.kanbanSelecting = false
 				m.kanban.EndSelection()
 
+				// Dragging a backlog card onto the Working column starts it
+				if task := m.kanbanDroppedBacklogTask(msg.X, msg.Y); task != nil {
+					m.kanban.ClearSelection()
+					return m, m.runKanbanAction(KanbanActionStart, task)
+				}
+
 				// Check if this was a single click (minimal movement from click start)
 				// If so, jump to the clicked task instead of selecting text
 				dx := msg.X - m.kanbanClickStart.x
@@ -727,6 +812,12 @@ func (m *TaskInput) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Update textarea height dynamically based on content
 		m.updateTextareaHeight()
+
+		// Open or refine the completion popup for the word being typed
+		switch msg.(type) {
+		case tea.KeyMsg, tea.PasteMsg:
+			m.updateCompletion()
+		}
 		// Note: persistTemplateDraft() is called on tick (every 1s) for performance
 		// instead of on every keystroke to avoid disk I/O stuttering
 	}
@@ -739,16 +830,18 @@ func (m *TaskInput) View() tea.View {
 	// Update view style cache if needed (only on theme change)
 	if !m.viewStylesCached {
 		lightDark := lipgloss.LightDark(m.isDark)
-		dimColor := lightDark(lipgloss.Color("245"), lipgloss.Color("240"))
+		dimColor := themeColor("text_dim", lightDark(lipgloss.Color("245"), lipgloss.Color("240")))
 		m.viewStyleHelp = lipgloss.NewStyle().Foreground(dimColor)
-		m.viewStyleVersion = lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Bold(true)
-		m.viewStyleWarning = lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true)
+		m.viewStyleVersion = lipgloss.NewStyle().Foreground(themeColor("accent", lipgloss.Color("39"))).Bold(true)
+		m.viewStyleWarning = lipgloss.NewStyle().Foreground(themeColor("error", lipgloss.Color("196"))).Bold(true)
 		m.viewStyleTemplateTip = lipgloss.NewStyle().
-			Foreground(lightDark(lipgloss.Color("24"), lipgloss.Color("214"))).Bold(true)
-		m.viewStyleCancelHint = lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true)
+			Foreground(themeColor("accent_secondary", lightDark(lipgloss.Color("24"), lipgloss.Color("214")))).Bold(true)
+		m.viewStyleCancelHint = lipgloss.NewStyle().Foreground(themeColor("warning", lipgloss.Color("214"))).Bold(true)
 		// Pre-render help text and cache width (avoids lipgloss.Width on each render)
-		m.viewHelpRendered = m.viewStyleHelp.Render("Alt+Enter: Submit  |  Esc×2: Cancel")
+		m.viewHelpRendered = m.viewStyleHelp.Render("Alt+Enter: Submit  |  Alt+S: Backlog  |  Esc×2: Cancel")
 		m.viewHelpWidth = lipgloss.Width(m.viewHelpRendered)
+		m.viewKanbanHelpRendered = m.viewStyleHelp.Render("↵ Jump  f Finish  r Reply  s Sync  d Diff  x Cancel  / Filter  o Sort  g Group")
+		m.viewKanbanHelpWidth = lipgloss.Width(m.viewKanbanHelpRendered)
 		m.viewStylesCached = true
 	}
 
@@ -801,8 +894,10 @@ func (m *TaskInput) View() tea.View {
 	leftContent := versionText + projectText + tipText
 	leftWidth := lipgloss.Width(leftContent)
 
-	// Show cancel pending hint if waiting for second press, otherwise show normal help text
-	if m.isCancelPending() {
+	// Show kanban overlay/status, cancel pending hint, or normal help text
+	if header, ok := m.renderKanbanHeader(); ok {
+		sb.WriteString(header)
+	} else if m.isCancelPending() {
 		// Display the appropriate key based on what was pressed
 		keyName := "Esc"
 		if m.cancelKey == "ctrl+c" {
@@ -819,12 +914,22 @@ func (m *TaskInput) View() tea.View {
 		sb.WriteString(cancelHint)
 	} else {
 		// Add version+tip on left, help text on right (use pre-rendered cached values)
+		// The kanban panel shows its card action keys instead of the submit help
+		helpRendered, helpWidth := m.viewHelpRendered, m.viewHelpWidth
+		if m.focusPanel == FocusPanelKanban {
+			helpRendered, helpWidth = m.viewKanbanHelpRendered, m.viewKanbanHelpWidth
+			leftContent = versionText + projectText
+			if filter := m.kanban.Filter(); !filter.IsZero() {
+				leftContent += m.viewStyleTemplateTip.Render("  Filter: " + filter.String())
+			}
+			leftWidth = lipgloss.Width(leftContent)
+		}
 		sb.WriteString(leftContent)
-		gap := m.width - leftWidth - m.viewHelpWidth
+		gap := m.width - leftWidth - helpWidth
 		if gap > 0 {
 			sb.WriteString(getPadding(gap))
 		}
-		sb.WriteString(m.viewHelpRendered)
+		sb.WriteString(helpRendered)
 	}
 	sb.WriteString("\n")
 
@@ -839,7 +944,17 @@ func (m *TaskInput) View() tea.View {
 		}
 	}
 
-	v := tea.NewView(sb.String())
+	content := sb.String()
+	if m.kanbanMenu != nil {
+		content = overlayAt(content, m.renderKanbanMenu(), m.kanbanMenu.x, m.kanbanMenu.y)
+	}
+	if m.focusPanel == FocusPanelLeft && m.completionOpen() {
+		if box, x, y := m.completionBox(); box != "" {
+			content = overlayAt(content, box, x, y)
+		}
+	}
+
+	v := tea.NewView(content)
 	v.AltScreen = true
 	v.ReportFocus = true
 	// Use AllMotion for better tmux mouse passthrough compatibility
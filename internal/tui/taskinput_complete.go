@@ -0,0 +1,452 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sahilm/fuzzy"
+
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/service"
+)
+
+// Inline completion popup limits.
+const (
+	completionMaxRows    = 8                // Items visible in the popup at once
+	completionMaxWidth   = 60               // Popup row width without border
+	completionHistoryMax = 200              // Historical tasks offered at most
+	completionSummaryMax = 80               // Task summary length in inserted references
+	completionFilesTTL   = 30 * time.Second // Project files are listed again after this
+)
+
+// Completion triggers, typed at the start of a word.
+const (
+	completionFile     = '@' // Files and directories of the project
+	completionTask     = '#' // Active and historical tasks
+	completionTemplate = '/' // Task templates
+)
+
+// completionItem is a candidate of the inline completion popup.
+type completionItem struct {
+	label  string // Text the query is matched against
+	detail string // Dim text shown after the label
+	insert string // Text that replaces the trigger and query
+}
+
+// completionPos is the position of a completion trigger in the textarea.
+type completionPos struct {
+	row, col int
+}
+
+// taskCompletion is the inline completion state of the word being typed.
+type taskCompletion struct {
+	trigger rune
+	completionPos
+	query    string
+	all      []completionItem
+	labels   []string
+	items    []completionItem // Candidates matching the query, best first
+	selected int
+	offset   int // First visible item
+}
+
+// completionToken returns the trigger and query of the word before the
+// cursor. Triggers only count at the start of a word, so paths such as
+// "src/app" or "a#b" don't open the popup.
+func completionToken(before string) (trigger rune, query string, ok bool) {
+	runes := []rune(before)
+	start := len(runes)
+	for start > 0 && !unicode.IsSpace(runes[start-1]) {
+		start--
+	}
+	if start == len(runes) {
+		return 0, "", false
+	}
+	switch runes[start] {
+	case completionFile, completionTask, completionTemplate:
+		return runes[start], string(runes[start+1:]), true
+	}
+	return 0, "", false
+}
+
+// filter matches the candidates against the query, keeping the candidate
+// order for an empty query.
+func (c *taskCompletion) filter(query string) {
+	if c.items != nil && query == c.query {
+		return
+	}
+	c.query = query
+	c.selected = 0
+	c.offset = 0
+	if query == "" {
+		c.items = c.all
+		return
+	}
+	matches := fuzzy.Find(query, c.labels)
+	c.items = make([]completionItem, len(matches))
+	for i, match := range matches {
+		c.items[i] = c.all[match.Index]
+	}
+}
+
+// move moves the selection by delta, wrapping around and keeping the
+// selected item visible.
+func (c *taskCompletion) move(delta int) {
+	n := len(c.items)
+	c.selected = ((c.selected+delta)%n + n) % n
+	if c.selected < c.offset {
+		c.offset = c.selected
+	} else if c.selected >= c.offset+completionMaxRows {
+		c.offset = c.selected - completionMaxRows + 1
+	}
+}
+
+// completionOpen reports whether the completion popup is shown.
+func (m *TaskInput) completionOpen() bool {
+	return m.completion != nil && len(m.completion.items) > 0
+}
+
+// updateCompletion opens, filters or closes the completion popup for the
+// word before the cursor. It runs after each edit of the textarea.
+func (m *TaskInput) updateCompletion() {
+	if m.focusPanel != FocusPanelLeft || m.textarea.HasSelection() {
+		m.completion = nil
+		return
+	}
+	trigger, query, ok := completionToken(m.textarea.TextBeforeCursor())
+	if !ok {
+		m.completion = nil
+		m.completionDismissed = nil
+		return
+	}
+	row, cursorCol := m.textarea.CursorPosition()
+	col := cursorCol - len([]rune(query)) - 1
+	if d := m.completionDismissed; d != nil && *d == (completionPos{row, col}) {
+		return // Closed with Esc; stays closed until the word changes
+	}
+	m.completionDismissed = nil
+
+	c := m.completion
+	if c == nil || c.trigger != trigger || c.row != row || c.col != col {
+		c = &taskCompletion{trigger: trigger, completionPos: completionPos{row, col}, all: m.completionCandidates(trigger)}
+		c.labels = make([]string, len(c.all))
+		for i, item := range c.all {
+			c.labels[i] = item.label
+		}
+	}
+	c.filter(query)
+	m.completion = c
+}
+
+// dismissCompletion closes the popup until the cursor leaves the word.
+func (m *TaskInput) dismissCompletion() {
+	if c := m.completion; c != nil {
+		m.completionDismissed = &c.completionPos
+	}
+	m.completion = nil
+}
+
+// acceptCompletion replaces the trigger and query with the selected item.
+func (m *TaskInput) acceptCompletion() {
+	c := m.completion
+	m.completion = nil
+	if c == nil || c.selected >= len(c.items) {
+		return
+	}
+	item := c.items[c.selected]
+	m.textarea.ReplaceBeforeCursor(len([]rune(c.query))+1, item.insert)
+
+	// Templates continue at their first placeholder, like the template picker
+	if c.trigger == completionTemplate && strings.Contains(item.insert, templatePlaceholderToken) {
+		m.moveCursorTo(c.row, c.col)
+		m.templateTipUntil = time.Now().Add(templateTipDuration)
+		m.jumpToNextTemplatePlaceholder()
+	}
+	if c.trigger == completionFile && !strings.HasSuffix(item.insert, "/") {
+		_ = service.NewRecentFiles(m.pawDirPath()).Record(strings.TrimSpace(item.insert))
+	}
+	m.updateTextareaHeight()
+}
+
+// updateCompletionKeys handles the popup keys. Other keys fall through to
+// the textarea and refine the query.
+func (m *TaskInput) updateCompletionKeys(keyStr string) bool {
+	switch keyStr {
+	case "up", "ctrl+p":
+		m.completion.move(-1)
+	case "down", "ctrl+n":
+		m.completion.move(1)
+	case "enter", "tab":
+		m.acceptCompletion()
+	case "esc":
+		m.dismissCompletion()
+	default:
+		return false
+	}
+	return true
+}
+
+// completionCandidates lists the candidates of a trigger.
+func (m *TaskInput) completionCandidates(trigger rune) []completionItem {
+	switch trigger {
+	case completionFile:
+		return m.fileCompletions()
+	case completionTask:
+		return m.taskCompletions()
+	case completionTemplate:
+		return m.templateCompletions()
+	}
+	return nil
+}
+
+// completionProjectDir returns the directory file references are relative to.
+func completionProjectDir() string {
+	if dir := os.Getenv("PROJECT_DIR"); dir != "" {
+		return dir
+	}
+	dir, _ := os.Getwd()
+	return dir
+}
+
+// fileCompletions lists recently referenced files first, then the project
+// directories and files. The list is cached briefly since listing a large
+// repository takes a while.
+func (m *TaskInput) fileCompletions() []completionItem {
+	if m.completionFiles == nil || time.Since(m.completionFilesAt) > completionFilesTTL {
+		files := listProjectFiles(completionProjectDir())
+		dirs := make(map[string]bool)
+		for _, file := range files {
+			for dir := filepath.Dir(file); dir != "."; dir = filepath.Dir(dir) {
+				dirs[dir] = true
+			}
+		}
+		sortedDirs := make([]string, 0, len(dirs))
+		for dir := range dirs {
+			sortedDirs = append(sortedDirs, dir)
+		}
+		sort.Strings(sortedDirs)
+
+		items := make([]completionItem, 0, len(sortedDirs)+len(files))
+		for _, dir := range sortedDirs {
+			items = append(items, completionItem{label: dir + "/", detail: "dir", insert: dir + "/"})
+		}
+		for _, file := range files {
+			items = append(items, completionItem{label: file, insert: file + " "})
+		}
+		m.completionFiles = items
+		m.completionFilesAt = time.Now()
+	}
+
+	recent, _ := service.NewRecentFiles(m.pawDirPath()).Recent()
+	if len(recent) == 0 {
+		return m.completionFiles
+	}
+	rank := make(map[string]int, len(recent))
+	for i, path := range recent {
+		rank[path] = i
+	}
+	items := make([]completionItem, 0, len(m.completionFiles))
+	var recentItems []completionItem
+	for _, item := range m.completionFiles {
+		if _, ok := rank[item.label]; ok {
+			item.detail = "recent"
+			recentItems = append(recentItems, item)
+			continue
+		}
+		items = append(items, item)
+	}
+	sort.SliceStable(recentItems, func(i, j int) bool {
+		return rank[recentItems[i].label] < rank[recentItems[j].label]
+	})
+	return slices.Concat(recentItems, items)
+}
+
+// taskCompletions lists the tasks on the board, then finished tasks from the
+// history, newest first. Tasks are inserted as "branch (summary)".
+func (m *TaskInput) taskCompletions() []completionItem {
+	var items []completionItem
+	seen := make(map[string]bool)
+	add := func(name, status, content string) {
+		if name == "" || seen[name] {
+			return
+		}
+		seen[name] = true
+		summary := taskSummary(content)
+		insert := name
+		if summary != "" {
+			insert += " (" + summary + ")"
+		}
+		items = append(items, completionItem{
+			label:  name,
+			detail: strings.TrimSpace(status + "  " + summary),
+			insert: insert + " ",
+		})
+	}
+
+	for _, task := range m.kanban.AllTasks() {
+		add(task.Name, string(task.Status), task.Content)
+	}
+	for _, name := range m.activeTasks {
+		add(name, "active", "")
+	}
+	for _, item := range m.historyCompletions() {
+		if !seen[item.label] {
+			seen[item.label] = true
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// historyCompletions lists finished tasks from the history. History files
+// don't change while the input is open, so they are read once.
+func (m *TaskInput) historyCompletions() []completionItem {
+	if m.completionHistoryLoaded {
+		return m.completionHistory
+	}
+	m.completionHistoryLoaded = true
+
+	pawDir := m.pawDirPath()
+	if pawDir == "" {
+		return nil
+	}
+	history := service.NewHistoryService(filepath.Join(pawDir, constants.HistoryDirName))
+	files, err := history.ListHistoryFiles()
+	if err != nil {
+		return nil
+	}
+	// History files start with their timestamp, so names sort by age
+	sort.Sort(sort.Reverse(sort.StringSlice(files)))
+
+	seen := make(map[string]bool)
+	for _, file := range files {
+		if len(m.completionHistory) >= completionHistoryMax {
+			break
+		}
+		name := service.ExtractTaskName(file)
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		status := "done"
+		if service.IsCancelled(file) {
+			status = "cancelled"
+		}
+		content, _ := history.LoadTaskContent(file)
+		summary := taskSummary(content)
+		insert := name
+		if summary != "" {
+			insert += " (" + summary + ")"
+		}
+		m.completionHistory = append(m.completionHistory, completionItem{
+			label:  name,
+			detail: strings.TrimSpace(status + "  " + summary),
+			insert: insert + " ",
+		})
+	}
+	return m.completionHistory
+}
+
+// taskSummary returns the first non-empty line of a task, shortened.
+func taskSummary(content string) string {
+	for line := range strings.SplitSeq(content, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return ansi.Truncate(line, completionSummaryMax, "…")
+		}
+	}
+	return ""
+}
+
+// templateCompletions lists the saved task templates. Templates are inserted
+// with their placeholders, like the template picker does.
+func (m *TaskInput) templateCompletions() []completionItem {
+	pawDir := m.pawDirPath()
+	if pawDir == "" {
+		return nil
+	}
+	templates, err := service.NewTemplateService(pawDir).LoadTemplates()
+	if err != nil {
+		return nil
+	}
+	items := make([]completionItem, 0, len(templates))
+	for _, tmpl := range templates {
+		items = append(items, completionItem{
+			label:  tmpl.Name,
+			detail: taskSummary(tmpl.Content),
+			insert: strings.ReplaceAll(tmpl.Content, templatePlaceholderInput, templatePlaceholderToken),
+		})
+	}
+	return items
+}
+
+// completionBox renders the popup and returns its screen position: below the
+// trigger, or above it when there is no room below. The position follows the
+// textarea cursor, so soft-wrapped lines and scrolling are accounted for.
+func (m *TaskInput) completionBox() (string, int, int) {
+	c := m.completion
+	cursor := m.textarea.Cursor()
+	if cursor == nil {
+		return "", 0, 0
+	}
+
+	innerWidth := 0
+	end := min(c.offset+completionMaxRows, len(c.items))
+	for _, item := range c.items[c.offset:end] {
+		innerWidth = max(innerWidth, ansi.StringWidth(item.label)+ansi.StringWidth(item.detail)+4)
+	}
+	innerWidth = max(1, min(innerWidth, completionMaxWidth, m.width-2))
+
+	lightDark := lipgloss.LightDark(m.isDark)
+	accent := themeColor("accent", lightDark(lipgloss.Color("25"), lipgloss.Color("39")))
+	dim := themeColor("text_dim", lightDark(lipgloss.Color("245"), lipgloss.Color("240")))
+	dimStyle := lipgloss.NewStyle().Foreground(dim)
+
+	lines := make([]string, 0, end-c.offset)
+	for i := c.offset; i < end; i++ {
+		item := c.items[i]
+		label := ansi.Truncate(item.label, innerWidth-2, "…")
+		detail := ""
+		if room := innerWidth - ansi.StringWidth(label) - 4; room > 0 && item.detail != "" {
+			detail = ansi.Truncate(item.detail, room, "…")
+		}
+		gap := strings.Repeat(" ", max(0, innerWidth-ansi.StringWidth(label)-ansi.StringWidth(detail)-2))
+		if i == c.selected {
+			lines = append(lines, lipgloss.NewStyle().Reverse(true).Render(" "+label+gap+detail+" "))
+			continue
+		}
+		lines = append(lines, " "+label+gap+dimStyle.Render(detail)+" ")
+	}
+	box := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(accent).
+		Render(strings.Join(lines, "\n"))
+
+	// The cursor is relative to the textarea: +2 for the help line and the
+	// top border, +1 for the left border (as in View)
+	cursorX, cursorY := cursor.X+1, cursor.Y+2
+	boxWidth, boxHeight := innerWidth+2, len(lines)+2
+	x := cursorX - ansi.StringWidth(string(c.trigger)+c.query)
+	x = max(0, min(x, m.width-boxWidth))
+	y := cursorY + 1
+	if y+boxHeight > m.height && cursorY-boxHeight >= 0 {
+		y = cursorY - boxHeight
+	}
+	return box, x, y
+}
+
+// completionItemAt returns the popup item at a screen position, or -1.
+func (m *TaskInput) completionItemAt(x, y int) int {
+	box, bx, by := m.completionBox()
+	lines := strings.Split(box, "\n")
+	if x <= bx || x >= bx+ansi.StringWidth(lines[0])-1 || y <= by || y >= by+len(lines)-1 {
+		return -1
+	}
+	return m.completion.offset + y - by - 1
+}
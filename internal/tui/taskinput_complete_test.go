@@ -0,0 +1,174 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/service"
+)
+
+func newCompletionInput(t *testing.T) (*TaskInput, string) {
+	t.Helper()
+	projectDir := writePickerFiles(t, map[string]string{
+		"alpha.go":          "package a\n",
+		"sub/beta.go":       "package b\n",
+		"sub/beta_test.go":  "package b\n",
+		".hidden/alpha.txt": "hidden\n",
+	})
+	pawDir := t.TempDir()
+	t.Setenv("PROJECT_DIR", projectDir)
+	t.Setenv("PAW_DIR", pawDir)
+
+	m := NewTaskInputWithOptions(nil, true)
+	m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	return m, pawDir
+}
+
+func typeIntoInput(m *TaskInput, s string) {
+	for _, r := range s {
+		if r == ' ' {
+			m.Update(keyPress("space"))
+			continue
+		}
+		m.Update(keyPress(string(r)))
+	}
+}
+
+func TestCompletionToken(t *testing.T) {
+	tests := []struct {
+		before  string
+		trigger rune
+		query   string
+		ok      bool
+	}{
+		{"see @", '@', "", true},
+		{"see @sub/be", '@', "sub/be", true},
+		{"#fix", '#', "fix", true},
+		{"use /bug", '/', "bug", true},
+		{"src/app", 0, "", false},
+		{"issue a#b", 0, "", false},
+		{"@done ", 0, "", false},
+		{"", 0, "", false},
+	}
+	for _, tt := range tests {
+		trigger, query, ok := completionToken(tt.before)
+		if trigger != tt.trigger || query != tt.query || ok != tt.ok {
+			t.Errorf("completionToken(%q) = %q, %q, %v, want %q, %q, %v",
+				tt.before, trigger, query, ok, tt.trigger, tt.query, tt.ok)
+		}
+	}
+}
+
+func TestTaskInputFileCompletion(t *testing.T) {
+	m, pawDir := newCompletionInput(t)
+
+	typeIntoInput(m, "see @beta.g")
+	if !m.completionOpen() {
+		t.Fatal("completion popup not open after @")
+	}
+	for _, item := range m.completion.items {
+		if strings.HasPrefix(item.label, ".hidden") {
+			t.Errorf("hidden file %q offered", item.label)
+		}
+	}
+	view := ansi.Strip(fmt.Sprint(m.View().Layer))
+	if !strings.Contains(view, "sub/beta.go") {
+		t.Errorf("popup not rendered:\n%s", view)
+	}
+
+	m.Update(keyPress("tab"))
+	if got := m.textarea.Value(); got != "see sub/beta.go " {
+		t.Errorf("value = %q, want %q", got, "see sub/beta.go ")
+	}
+	if m.completionOpen() {
+		t.Error("popup still open after inserting")
+	}
+	recent, _ := service.NewRecentFiles(pawDir).Recent()
+	if len(recent) == 0 || recent[0] != "sub/beta.go" {
+		t.Errorf("recent = %v, want sub/beta.go recorded", recent)
+	}
+
+	// Recent files are offered first
+	typeIntoInput(m, "@")
+	if !m.completionOpen() || m.completion.items[0].label != "sub/beta.go" {
+		t.Errorf("first item = %+v, want the recent file", m.completion.items)
+	}
+}
+
+func TestTaskInputCompletionEscDismisses(t *testing.T) {
+	m, _ := newCompletionInput(t)
+
+	typeIntoInput(m, "@al")
+	m.Update(keyPress("esc"))
+	if m.completionOpen() {
+		t.Fatal("popup open after Esc")
+	}
+	if m.isCancelPending() {
+		t.Error("Esc closing the popup started the cancel confirmation")
+	}
+
+	// The popup stays closed while the same word is typed
+	typeIntoInput(m, "p")
+	if m.completionOpen() {
+		t.Error("popup reopened for the dismissed word")
+	}
+	typeIntoInput(m, " @")
+	if !m.completionOpen() {
+		t.Error("popup not reopened for a new word")
+	}
+}
+
+func TestTaskInputTaskAndTemplateCompletion(t *testing.T) {
+	m, pawDir := newCompletionInput(t)
+
+	historyDir := filepath.Join(pawDir, constants.HistoryDirName)
+	if err := os.MkdirAll(historyDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	history := "Fix the login redirect\nafter logout\n---summary---\ndone\n---capture---\npane\n"
+	if err := os.WriteFile(filepath.Join(historyDir, "260101_120000_fix-login"), []byte(history), 0644); err != nil {
+		t.Fatal(err)
+	}
+	templates := []service.TemplateEntry{{Name: "bugfix", Content: "Fix ??? and add a test"}}
+	if err := service.NewTemplateService(pawDir).SaveTemplates(templates); err != nil {
+		t.Fatal(err)
+	}
+
+	typeIntoInput(m, "after #login")
+	m.Update(keyPress("enter"))
+	if got, want := m.textarea.Value(), "after fix-login (Fix the login redirect) "; got != want {
+		t.Errorf("value = %q, want %q", got, want)
+	}
+
+	m.textarea.SetValue("")
+	typeIntoInput(m, "/bug")
+	m.Update(keyPress("enter"))
+	if got, want := m.textarea.Value(), "Fix  and add a test"; got != want {
+		t.Errorf("value = %q, want %q", got, want)
+	}
+	if _, col := m.textarea.CursorPosition(); col != 4 {
+		t.Errorf("cursor column = %d, want 4 (the template placeholder)", col)
+	}
+}
+
+func TestTaskInputCompletionMouse(t *testing.T) {
+	m, _ := newCompletionInput(t)
+
+	typeIntoInput(m, "@sub/")
+	if !m.completionOpen() || len(m.completion.items) < 2 {
+		t.Fatalf("items = %+v, want several matches", m.completion)
+	}
+	want := m.completion.items[1].insert
+	_, x, y := m.completionBox()
+	m.Update(tea.MouseClickMsg{X: x + 2, Y: y + 2, Button: tea.MouseLeft})
+	if got := m.textarea.Value(); got != want {
+		t.Errorf("value = %q, want %q", got, want)
+	}
+}
@@ -70,6 +70,7 @@ func (m *TaskInput) Result() TaskInputResult {
 		Cancelled:            m.cancelled,
 		JumpTarget:           m.jumpTarget,
 		RequestTaskNamePopup: m.requestTaskNamePopup,
+		SaveToBacklog:        m.saveToBacklog,
 	}
 }
 
@@ -109,7 +110,8 @@ func (m *TaskInput) checkHistorySelection() {
 }
 
 // checkYaziSelection checks for a yazi file selection file.
-// If found, it appends the selected file path to the current content and deletes the file.
+// If found, it appends the selected file references (space-separated, e.g.
+// "a.go b.go:10-40") to the current content and deletes the file.
 func (m *TaskInput) checkYaziSelection() {
 	pawDir := m.pawDirPath()
 	if pawDir == "" {
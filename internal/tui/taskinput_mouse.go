@@ -5,6 +5,9 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/mattn/go-runewidth"
+
+	"github.com/dongho-jung/paw/internal/keymap"
+	"github.com/dongho-jung/paw/internal/service"
 )
 
 // handleTextareaMouse handles mouse click in the textarea area.
@@ -156,7 +159,7 @@ func (m *TaskInput) detectClickedPanel(x, y int) FocusPanel {
 }
 
 // detectKanbanColumn determines which Kanban column was clicked based on X position.
-// Returns column index (0-3) or -1 if outside column area.
+// Returns the displayed column index or -1 if outside column area.
 func (m *TaskInput) detectKanbanColumn(x int) int {
 	colWidth := m.kanban.ColumnWidth()
 	if colWidth <= 0 {
@@ -166,12 +169,30 @@ func (m *TaskInput) detectKanbanColumn(x int) int {
 	// Kanban columns start at X=0
 	// Each column takes colWidth pixels
 	col := x / colWidth
-	if col >= 0 && col < 4 {
+	if col >= 0 && col < m.kanban.ColumnCount() {
 		return col
 	}
 	return -1
 }
 
+// kanbanDroppedBacklogTask returns the backlog task dragged from the click start
+// position and dropped at (x, y) on a column showing working tasks, or nil.
+func (m *TaskInput) kanbanDroppedBacklogTask(x, y int) *service.DiscoveredTask {
+	if m.detectClickedPanel(x, y) != FocusPanelKanban {
+		return nil
+	}
+	fromCol := m.detectKanbanColumn(m.kanbanClickStart.x)
+	toCol := m.detectKanbanColumn(x)
+	if fromCol < 0 || fromCol == toCol || !m.kanban.ColumnHasStatus(toCol, service.DiscoveredWorking) {
+		return nil
+	}
+	task := m.kanban.GetTaskAtPosition(fromCol, m.getKanbanRelativeY(m.kanbanClickStart.y))
+	if task == nil || task.BacklogID == "" {
+		return nil
+	}
+	return task
+}
+
 // getKanbanRelativeY converts absolute Y coordinate to kanban-relative row.
 func (m *TaskInput) getKanbanRelativeY(y int) int {
 	// Kanban starts after: help text (1) + textarea (dynamic height + 2 border)
@@ -185,9 +206,9 @@ func (m *TaskInput) getKanbanRelativeY(y int) int {
 }
 
 // getKanbanRelativeX converts absolute X coordinate to column-relative position.
-// col is the column index (0-3). Returns X position relative to the column's start.
+// col is the displayed column index. Returns X position relative to the column's start.
 func (m *TaskInput) getKanbanRelativeX(x, col int) int {
-	if col < 0 || col > 3 {
+	if col < 0 || col >= m.kanban.ColumnCount() {
 		return 0
 	}
 	colWidth := m.kanban.ColumnWidth()
@@ -247,7 +268,7 @@ func (m *TaskInput) switchFocusToKanbanColumn(col int) {
 
 // updateKanbanPanel handles key events when the kanban panel is focused.
 func (m *TaskInput) updateKanbanPanel(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	keyStr := msg.String()
+	keyStr := translateKey(msg, keymap.ScopeNav, keymap.ScopeKanban)
 
 	switch keyStr {
 	// Up/Down: select task within the focused column
@@ -279,12 +300,40 @@ func (m *TaskInput) updateKanbanPanel(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "pgdown", "ctrl+d":
 		m.kanban.ScrollDown(5)
 		return m, nil
-	// Enter/Space: jump to selected task
-	case "enter", " ":
-		if task := m.kanban.GetSelectedTask(); task != nil {
-			return m, jumpToTask(task)
+	// Filter bar, sort order and project swimlanes
+	case "/":
+		return m, m.openKanbanFilter()
+	case "o":
+		m.kanban.SetFilter(m.kanban.Filter().NextSort())
+		return m, m.setKanbanStatus("Sort: " + string(m.kanban.Filter().Sort))
+	case "g":
+		filter := m.kanban.Filter()
+		filter.Group = !filter.Group
+		m.kanban.SetFilter(filter)
+		return m, nil
+	// Marks for bulk actions
+	case "m":
+		task := m.kanban.GetSelectedTask()
+		if task == nil {
+			return m, nil
+		}
+		if task.BacklogID != "" {
+			return m, m.setKanbanStatus(task.Name + " is not started yet and cannot be marked")
 		}
+		m.kanban.ToggleMark(task)
 		return m, nil
+	case "b":
+		tasks := m.kanban.MarkedTasks()
+		if len(tasks) == 0 {
+			return m, m.setKanbanStatus("Mark tasks with " + keymap.KeyLabel(activeKeymap().Key(keymap.ActionKanbanMark)) + " first")
+		}
+		m.kanban.ClearMarks()
+		return m, runBulkAction(tasks)
+	}
+
+	// Card actions on the selected task (Enter/Space: jump, f: finish, r: reply, ...)
+	if action, ok := kanbanActionForKey(keyStr); ok {
+		return m, m.runKanbanAction(action, m.kanban.GetSelectedTask())
 	}
 
 	return m, nil
@@ -14,15 +14,20 @@ import (
 const (
 	optionLabelModel  = "Model:      " // 12 chars, left-aligned
 	optionLabelBranch = "Branch:     " // 12 chars, left-aligned
+	optionLabelSource = "From:       " // 12 chars, left-aligned
+	optionLabelTarget = "Target:     " // 12 chars, left-aligned
 )
 
+// maxSourceRefLen limits the From field (long enough for PR URLs).
+const maxSourceRefLen = 200
+
 // updateOptionsPanel handles key events when the options panel is focused.
 func (m *TaskInput) updateOptionsPanel(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	keyStr := msg.String()
 	fieldCount := optFieldCount(m.isGitRepo)
 
-	// Handle text input for branch name field (only in git mode)
-	if m.isGitRepo && m.optField == OptFieldBranchName {
+	// Handle text input for branch name, source and target fields (only in git mode)
+	if m.isGitRepo && (m.optField == OptFieldBranchName || m.optField == OptFieldSource || m.optField == OptFieldTarget) {
 		switch keyStr {
 		case "tab", "down":
 			m.applyOptionInputValues()
@@ -32,32 +37,14 @@ func (m *TaskInput) updateOptionsPanel(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.applyOptionInputValues()
 			m.optField = OptField((int(m.optField) - 1 + fieldCount) % fieldCount)
 			return m, nil
-		case "backspace":
-			if len(m.branchName) > 0 {
-				m.branchName = m.branchName[:len(m.branchName)-1]
-			}
-			return m, nil
-		case "delete", "ctrl+u":
-			m.branchName = ""
-			return m, nil
+		}
+		switch m.optField {
+		case OptFieldSource:
+			m.sourceRef = editOptionText(m.sourceRef, msg, maxSourceRefLen, isSourceRefRune, false)
+		case OptFieldTarget:
+			m.targetBranch = editOptionText(m.targetBranch, msg, maxSourceRefLen, isTargetBranchRune, false)
 		default:
-			// Accept printable characters for branch name
-			// Only allow valid branch name characters: a-z, 0-9, -, _
-			key := msg.Key()
-			if len(keyStr) == 1 && key.Mod == 0 {
-				r := rune(keyStr[0])
-				if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' || r == '_' {
-					// Convert to lowercase for branch names
-					if r >= 'A' && r <= 'Z' {
-						r += 32
-					}
-					if len(m.branchName) >= 32 {
-						return m, nil
-					}
-					m.branchName += string(r)
-					return m, nil
-				}
-			}
+			m.branchName = editOptionText(m.branchName, msg, 32, isBranchNameRune, true)
 		}
 		return m, nil
 	}
@@ -85,6 +72,51 @@ func (m *TaskInput) updateOptionsPanel(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// isBranchNameRune reports whether r is allowed in a custom branch name: a-z, 0-9, -, _.
+// Uppercase letters are accepted and lowercased on input.
+func isBranchNameRune(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' || r == '_'
+}
+
+// isSourceRefRune reports whether r is allowed in a branch/PR reference or PR URL.
+func isSourceRefRune(r rune) bool {
+	return isBranchNameRune(r) || strings.ContainsRune("/.#:", r)
+}
+
+// isTargetBranchRune reports whether r is allowed in a target branch name (e.g. release/1.2).
+func isTargetBranchRune(r rune) bool {
+	return isBranchNameRune(r) || r == '/' || r == '.'
+}
+
+// editOptionText applies a key press to a single-line option text field.
+// If lowercase is set, letters are converted to lowercase (branch names).
+func editOptionText(value string, msg tea.KeyMsg, maxLen int, allowed func(rune) bool, lowercase bool) string {
+	keyStr := msg.String()
+	switch keyStr {
+	case "backspace":
+		if len(value) > 0 {
+			return value[:len(value)-1]
+		}
+		return value
+	case "delete", "ctrl+u":
+		return ""
+	}
+
+	// Accept printable characters
+	key := msg.Key()
+	if len(keyStr) == 1 && key.Mod == 0 {
+		r := rune(keyStr[0])
+		if !allowed(r) || len(value) >= maxLen {
+			return value
+		}
+		if lowercase && r >= 'A' && r <= 'Z' {
+			r += 32
+		}
+		return value + string(r)
+	}
+	return value
+}
+
 // handleOptionLeft handles left arrow key in options panel.
 func (m *TaskInput) handleOptionLeft() {
 	if m.optField == OptFieldModel {
@@ -112,6 +144,8 @@ func (m *TaskInput) applyOptionInputValues() {
 		return
 	}
 	m.options.BranchName = strings.TrimSpace(m.branchName)
+	m.options.SourceRef = strings.TrimSpace(m.sourceRef)
+	m.options.TargetBranch = strings.TrimSpace(m.targetBranch)
 }
 
 // renderOptionsPanel renders the options panel for the right side.
@@ -123,9 +157,9 @@ func (m *TaskInput) renderOptionsPanel() string {
 	if !m.optStylesCached {
 		// Adaptive colors for light/dark terminal themes
 		lightDark := lipgloss.LightDark(m.isDark)
-		normalColor := lightDark(lipgloss.Color("236"), lipgloss.Color("252"))
-		dimColor := lightDark(lipgloss.Color("245"), lipgloss.Color("243"))
-		accentColor := lightDark(lipgloss.Color("25"), lipgloss.Color("39"))
+		normalColor := themeColor("text", lightDark(lipgloss.Color("236"), lipgloss.Color("252")))
+		dimColor := themeColor("text_dim", lightDark(lipgloss.Color("245"), lipgloss.Color("243")))
+		accentColor := themeColor("accent", lightDark(lipgloss.Color("25"), lipgloss.Color("39")))
 
 		m.optStyleTitle = lipgloss.NewStyle().Bold(true).Foreground(accentColor)
 		m.optStyleTitleDim = lipgloss.NewStyle().Bold(true).Foreground(dimColor)
@@ -161,9 +195,12 @@ func (m *TaskInput) renderOptionsPanel() string {
 		lines = append(lines, padToWidth(m.optStyleTitleDim.Render("Options"), innerWidth))
 	}
 
-	// Empty line (from MarginBottom effect)
+	// Empty line (from MarginBottom effect), dropped when the textarea is too short
+	// to fit it alongside all fields
 	emptyLine := getPadding(innerWidth)
-	lines = append(lines, emptyLine)
+	if 2+optFieldCount(m.isGitRepo) <= m.textareaHeight {
+		lines = append(lines, emptyLine)
+	}
 
 	// Model field (use cached styles)
 	{
@@ -218,6 +255,9 @@ func (m *TaskInput) renderOptionsPanel() string {
 
 		branchLine := label + branchStyle.Render(branchValue)
 		lines = append(lines, padToWidth(branchLine, innerWidth))
+
+		lines = append(lines, padToWidth(m.renderSourceOption(isFocused, innerWidth), innerWidth))
+		lines = append(lines, padToWidth(m.renderTargetOption(isFocused, innerWidth), innerWidth))
 	}
 
 	// Fill remaining height with empty lines (reuse cached padding)
@@ -233,3 +273,54 @@ func (m *TaskInput) renderOptionsPanel() string {
 
 	return panelStyle.Render(strings.Join(lines, "\n"))
 }
+
+// renderSourceOption renders the From field (existing branch or PR to check out).
+func (m *TaskInput) renderSourceOption(isFocused bool, innerWidth int) string {
+	isSelected := isFocused && m.optField == OptFieldSource
+	label := m.optStyleLabel.Render(optionLabelSource)
+	if isSelected {
+		label = m.optStyleSelectedLabel.Render(optionLabelSource)
+	}
+
+	value := m.sourceRef
+	style := m.optStyleValue
+	if value == "" {
+		value = "target (branch, #PR)"
+		style = m.optStyleDim
+	}
+	if isSelected {
+		style = m.optStyleSelectedValue
+	}
+
+	availableWidth := innerWidth - len(optionLabelSource)
+	if availableWidth > 0 && lipgloss.Width(value) > availableWidth {
+		// Keep the end of long refs visible (PR numbers, branch leaf names)
+		value = "…" + value[len(value)-availableWidth+1:]
+	}
+	return label + style.Render(value)
+}
+
+// renderTargetOption renders the Target field (branch to merge into and open PRs against).
+func (m *TaskInput) renderTargetOption(isFocused bool, innerWidth int) string {
+	isSelected := isFocused && m.optField == OptFieldTarget
+	label := m.optStyleLabel.Render(optionLabelTarget)
+	if isSelected {
+		label = m.optStyleSelectedLabel.Render(optionLabelTarget)
+	}
+
+	value := m.targetBranch
+	style := m.optStyleValue
+	if value == "" {
+		value = "default"
+		style = m.optStyleDim
+	}
+	if isSelected {
+		style = m.optStyleSelectedValue
+	}
+
+	availableWidth := innerWidth - len(optionLabelTarget)
+	if availableWidth > 0 && lipgloss.Width(value) > availableWidth {
+		value = "…" + value[len(value)-availableWidth+1:]
+	}
+	return label + style.Render(value)
+}
@@ -115,7 +115,7 @@ func (m *TaskNameInput) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.input.SetWidth(inputWidth)
 		}
 	case tea.BackgroundColorMsg:
-		m.isDark = msg.IsDark()
+		m.isDark = themeIsDark(msg.IsDark())
 		m.colors = NewThemeColors(m.isDark)
 		m.stylesCached = false // Invalidate style cache on theme change
 		setCachedDarkMode(m.isDark)
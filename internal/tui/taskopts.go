@@ -80,7 +80,7 @@ func (m *TaskOptsUI) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.height = msg.Height
 		return m, nil
 	case tea.BackgroundColorMsg:
-		m.isDark = msg.IsDark()
+		m.isDark = themeIsDark(msg.IsDark())
 		setCachedDarkMode(m.isDark)
 		return m, nil
 
@@ -141,11 +141,11 @@ func (m *TaskOptsUI) View() tea.View {
 	// Light theme: use darker colors for visibility on light backgrounds
 	// Dark theme: use lighter colors for visibility on dark backgrounds
 	lightDark := lipgloss.LightDark(m.isDark)
-	normalColor := lightDark(lipgloss.Color("236"), lipgloss.Color("252"))
+	normalColor := themeColor("text", lightDark(lipgloss.Color("236"), lipgloss.Color("252")))
 	// Dim color: medium contrast for non-selected items (readable on various backgrounds)
-	dimColor := lightDark(lipgloss.Color("245"), lipgloss.Color("243"))
+	dimColor := themeColor("text_dim", lightDark(lipgloss.Color("245"), lipgloss.Color("243")))
 	// Accent color: darker blue for light bg, bright cyan for dark bg
-	accentColor := lightDark(lipgloss.Color("25"), lipgloss.Color("39"))
+	accentColor := themeColor("accent", lightDark(lipgloss.Color("25"), lipgloss.Color("39")))
 
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
@@ -70,7 +70,7 @@ func (m *TaskViewer) Init() tea.Cmd {
 func (m *TaskViewer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.BackgroundColorMsg:
-		m.isDark = msg.IsDark()
+		m.isDark = themeIsDark(msg.IsDark())
 		m.colors = NewThemeColors(m.isDark)
 		m.stylesCached = false // Invalidate style cache on theme change
 		setCachedDarkMode(m.isDark)
@@ -127,7 +127,7 @@ func (m *TaskViewer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // handleKey handles keyboard input.
 func (m *TaskViewer) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
+	switch translateViewerKey(msg) {
 	// Copy selection with Ctrl+C
 	case "ctrl+c":
 		if m.hasSelection {
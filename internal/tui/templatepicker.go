@@ -9,6 +9,7 @@ import (
 	"github.com/charmbracelet/lipgloss/v2"
 	"github.com/sahilm/fuzzy"
 
+	"github.com/dongho-jung/paw/internal/keymap"
 	"github.com/dongho-jung/paw/internal/logging"
 	"github.com/dongho-jung/paw/internal/service"
 )
@@ -130,7 +131,7 @@ func (m *TemplatePicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case tea.BackgroundColorMsg:
-		m.isDark = msg.IsDark()
+		m.isDark = themeIsDark(msg.IsDark())
 		m.colors = NewThemeColors(m.isDark)
 		m.stylesCached = false // Invalidate style cache on theme change
 		setCachedDarkMode(m.isDark)
@@ -145,8 +146,8 @@ func (m *TemplatePicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.handleNamePromptKey(msg)
 		}
 
-		switch msg.String() {
-		case "ctrl+c", "esc", "ctrl+t":
+		switch toggleKey(msg, keymap.ActionTemplatePicker) {
+		case "ctrl+c", "esc":
 			m.action = TemplatePickerCancel
 			return m, tea.Quit
 
@@ -215,8 +216,8 @@ func (m *TemplatePicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m *TemplatePicker) handleNamePromptKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "ctrl+c", "esc", "ctrl+t":
+	switch toggleKey(msg, keymap.ActionTemplatePicker) {
+	case "ctrl+c", "esc":
 		m.endNamePrompt()
 		return m, nil
 	case "enter":
@@ -235,8 +236,8 @@ func (m *TemplatePicker) handleNamePromptKey(msg tea.KeyMsg) (tea.Model, tea.Cmd
 }
 
 func (m *TemplatePicker) handleDeleteConfirmKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "ctrl+c", "esc", "ctrl+t", "n":
+	switch toggleKey(msg, keymap.ActionTemplatePicker) {
+	case "ctrl+c", "esc", "n":
 		m.endDeleteConfirm()
 		return m, nil
 	case "enter", "y":
@@ -512,7 +513,7 @@ func (m *TemplatePicker) View() tea.View {
 	}
 
 	sb.WriteString("\n")
-	sb.WriteString(m.styleHelp.Render("↑/↓: Navigate  Enter: Apply  Ctrl+N: New  Ctrl+U: Update  Ctrl+D: Delete  Esc/⌃T: Cancel"))
+	sb.WriteString(m.styleHelp.Render("↑/↓: Navigate  Enter: Apply  Ctrl+N: New  Ctrl+U: Update  Ctrl+D: Delete  " + escLabel(keymap.ActionTemplatePicker) + ": Cancel"))
 
 	v := tea.NewView(sb.String())
 	v.AltScreen = true
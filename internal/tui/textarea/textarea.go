@@ -710,6 +710,21 @@ func (m Model) CursorPosition() (row, col int) {
 	return m.row, m.col
 }
 
+// TextBeforeCursor returns the text of the cursor line up to the cursor.
+func (m Model) TextBeforeCursor() string {
+	return string(m.value[m.row][:m.col])
+}
+
+// ReplaceBeforeCursor replaces the n runes before the cursor on the cursor
+// line with s, leaving the cursor after the inserted text.
+func (m *Model) ReplaceBeforeCursor(n int, s string) {
+	m.ClearSelection()
+	n = clamp(n, 0, m.col)
+	m.value[m.row] = append(m.value[m.row][:m.col-n], m.value[m.row][m.col:]...)
+	m.SetCursorColumn(m.col - n)
+	m.InsertString(s)
+}
+
 // SetSelection sets the selection range.
 func (m *Model) SetSelection(startRow, startCol, endRow, endCol int) {
 	start := m.clampSelectionPos(cursorPos{row: startRow, col: startCol})
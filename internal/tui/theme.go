@@ -12,6 +12,8 @@ import (
 
 	"github.com/charmbracelet/lipgloss/v2"
 	"github.com/dongho-jung/paw/internal/constants"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/theme"
 )
 
 // paddingCache stores pre-computed padding strings to avoid repeated allocations.
@@ -94,7 +96,8 @@ func isCurrentMatchLine(searchMatches []int, currentMatchIdx, idx int) bool {
 }
 
 // ThemeColors provides a consistent color palette for TUI components.
-// Colors are calculated based on whether the terminal is in dark or light mode.
+// Colors are calculated based on whether the terminal is in dark or light mode,
+// with the colors of the configured theme applied on top.
 type ThemeColors struct {
 	isDark bool
 
@@ -130,10 +133,131 @@ type ThemeColors struct {
 	LogWarn  color.Color // L3 - Warning level
 	LogError color.Color // L4 - Error level
 	LogFatal color.Color // L5 - Fatal level (most critical)
+
+	// Kanban status colors (column headers)
+	StatusBacklog color.Color
+	StatusBlocked color.Color
+	StatusWorking color.Color
+	StatusWaiting color.Color
+	StatusWarning color.Color
+	StatusReview  color.Color
+	StatusDone    color.Color
+
+	// Diff colors
+	DiffAdd            color.Color // Added line sign
+	DiffDelete         color.Color // Deleted line sign
+	DiffAddBg          color.Color // Added line background
+	DiffDeleteBg       color.Color // Deleted line background
+	DiffAddEmphasis    color.Color // Changed words in an added line
+	DiffDeleteEmphasis color.Color // Changed words in a deleted line
+}
+
+var (
+	themeOnce sync.Once
+	themeCur  *theme.Theme
+)
+
+// activeTheme returns the configured theme. It is loaded on first use from
+// the global config and the project config of $PAW_DIR.
+func activeTheme() *theme.Theme {
+	themeOnce.Do(func() {
+		if themeCur != nil {
+			return
+		}
+		var err error
+		themeCur, err = theme.Load(os.Getenv("PAW_DIR"))
+		if err != nil {
+			logging.Warn("theme: %v", err)
+		}
+	})
+	return themeCur
 }
 
-// NewThemeColors creates a new color palette based on dark mode setting.
+// SetTheme sets the active theme instead of loading it from config.
+func SetTheme(t *theme.Theme) {
+	themeOnce.Do(func() {})
+	themeCur = t
+}
+
+// themeIsDark resolves a detected terminal background against the active theme's mode.
+func themeIsDark(detected bool) bool {
+	return activeTheme().IsDark(detected)
+}
+
+// NewThemeColors creates a new color palette based on dark mode setting and
+// the active theme.
 func NewThemeColors(isDark bool) ThemeColors {
+	return themeColorsFor(activeTheme(), isDark)
+}
+
+// themeColorsFor returns the palette of a theme for a detected terminal background.
+func themeColorsFor(t *theme.Theme, detectedDark bool) ThemeColors {
+	tc := basePalette(t.IsDark(detectedDark))
+	fields := tc.fields()
+	for key, value := range t.TUI {
+		if field, ok := fields[key]; ok {
+			*field = lipgloss.Color(value)
+		}
+	}
+	return tc
+}
+
+// themeColor returns the active theme's color for a key, or fallback if the
+// theme leaves it out. It keeps components with their own tuned defaults
+// themeable.
+func themeColor(key string, fallback color.Color) color.Color {
+	if value, ok := activeTheme().TUI[key]; ok {
+		return lipgloss.Color(value)
+	}
+	return fallback
+}
+
+// fields maps theme color keys (see theme.TUIKeys) to palette fields.
+func (tc *ThemeColors) fields() map[string]*color.Color {
+	return map[string]*color.Color{
+		"accent":               &tc.Accent,
+		"accent_secondary":     &tc.AccentSecondary,
+		"text":                 &tc.TextNormal,
+		"text_dim":             &tc.TextDim,
+		"text_bright":          &tc.TextBright,
+		"text_inverted":        &tc.TextInverted,
+		"border":               &tc.Border,
+		"border_focused":       &tc.BorderFocused,
+		"border_dim":           &tc.BorderDim,
+		"background":           &tc.Background,
+		"background_alt":       &tc.BackgroundAlt,
+		"selection":            &tc.Selection,
+		"status_bar":           &tc.StatusBar,
+		"status_bar_text":      &tc.StatusBarText,
+		"success":              &tc.SuccessColor,
+		"warning":              &tc.WarningColor,
+		"error":                &tc.ErrorColor,
+		"search_match":         &tc.SearchMatch,
+		"search_current":       &tc.SearchCurrent,
+		"log_trace":            &tc.LogTrace,
+		"log_debug":            &tc.LogDebug,
+		"log_info":             &tc.LogInfo,
+		"log_warn":             &tc.LogWarn,
+		"log_error":            &tc.LogError,
+		"log_fatal":            &tc.LogFatal,
+		"status_backlog":       &tc.StatusBacklog,
+		"status_blocked":       &tc.StatusBlocked,
+		"status_working":       &tc.StatusWorking,
+		"status_waiting":       &tc.StatusWaiting,
+		"status_warning":       &tc.StatusWarning,
+		"status_review":        &tc.StatusReview,
+		"status_done":          &tc.StatusDone,
+		"diff_add":             &tc.DiffAdd,
+		"diff_delete":          &tc.DiffDelete,
+		"diff_add_bg":          &tc.DiffAddBg,
+		"diff_delete_bg":       &tc.DiffDeleteBg,
+		"diff_add_emphasis":    &tc.DiffAddEmphasis,
+		"diff_delete_emphasis": &tc.DiffDeleteEmphasis,
+	}
+}
+
+// basePalette returns the built-in light or dark palette.
+func basePalette(isDark bool) ThemeColors {
 	if isDark {
 		return ThemeColors{
 			isDark: true,
@@ -170,6 +294,23 @@ func NewThemeColors(isDark bool) ThemeColors {
 			LogWarn:  lipgloss.Color("214"), // Orange/yellow
 			LogError: lipgloss.Color("196"), // Bright red
 			LogFatal: lipgloss.Color("201"), // Magenta (most critical)
+
+			// Kanban status colors
+			StatusBacklog: lipgloss.Color("111"), // Light blue
+			StatusBlocked: lipgloss.Color("209"), // Salmon
+			StatusWorking: lipgloss.Color("34"),  // Bright green
+			StatusWaiting: lipgloss.Color("178"), // Gold
+			StatusWarning: lipgloss.Color("203"), // Light red
+			StatusReview:  lipgloss.Color("141"), // Lavender
+			StatusDone:    lipgloss.Color("250"), // Light gray
+
+			// Diff colors
+			DiffAdd:            lipgloss.Color("#56d364"),
+			DiffDelete:         lipgloss.Color("#f85149"),
+			DiffAddBg:          lipgloss.Color("#1b3a27"),
+			DiffDeleteBg:       lipgloss.Color("#41212a"),
+			DiffAddEmphasis:    lipgloss.Color("#2e6b43"),
+			DiffDeleteEmphasis: lipgloss.Color("#7d2e3a"),
 		}
 	}
 
@@ -209,6 +350,23 @@ func NewThemeColors(isDark bool) ThemeColors {
 		LogWarn:  lipgloss.Color("166"), // Dark orange
 		LogError: lipgloss.Color("160"), // Dark red
 		LogFatal: lipgloss.Color("125"), // Dark magenta (most critical)
+
+		// Kanban status colors
+		StatusBacklog: lipgloss.Color("61"),  // Slate blue
+		StatusBlocked: lipgloss.Color("166"), // Dark orange
+		StatusWorking: lipgloss.Color("28"),  // Dark green
+		StatusWaiting: lipgloss.Color("130"), // Dark orange
+		StatusWarning: lipgloss.Color("160"), // Red
+		StatusReview:  lipgloss.Color("91"),  // Purple
+		StatusDone:    lipgloss.Color("240"), // Dark gray
+
+		// Diff colors
+		DiffAdd:            lipgloss.Color("#1a7f37"),
+		DiffDelete:         lipgloss.Color("#cf222e"),
+		DiffAddBg:          lipgloss.Color("#e6ffec"),
+		DiffDeleteBg:       lipgloss.Color("#ffebe9"),
+		DiffAddEmphasis:    lipgloss.Color("#abf2bc"),
+		DiffDeleteEmphasis: lipgloss.Color("#ffc1c0"),
 	}
 }
 
@@ -252,7 +410,8 @@ const (
 var cachedDarkMode atomic.Int32
 
 // DetectDarkMode returns whether the terminal is in dark mode.
-// It auto-detects based on terminal background color using lipgloss.
+// A theme with a fixed mode decides; otherwise it auto-detects based on
+// terminal background color using lipgloss.
 //
 // This function should be called BEFORE bubbletea starts, as
 // lipgloss.HasDarkBackground() reads from stdin.
@@ -260,6 +419,11 @@ func DetectDarkMode() bool {
 	if isDark, ok := cachedDarkModeValue(); ok {
 		return isDark
 	}
+	if mode := activeTheme().Mode; mode != "" {
+		isDark := mode == theme.ModeDark
+		setCachedDarkMode(isDark)
+		return isDark
+	}
 	// Auto-detect with improved reliability
 	return detectDarkModeWithRetry()
 }
@@ -2,6 +2,11 @@ package tui
 
 import (
 	"testing"
+
+	"github.com/charmbracelet/lipgloss/v2"
+
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/theme"
 )
 
 func TestDetectDarkMode_CachesBehavior(t *testing.T) {
@@ -183,6 +188,50 @@ func TestResetDarkModeCache(t *testing.T) {
 	cachedDarkMode.Store(darkModeUnknown)
 }
 
+func TestThemeColorsCoverThemeKeys(t *testing.T) {
+	tc := basePalette(true)
+	fields := tc.fields()
+	for _, key := range theme.TUIKeys {
+		field, ok := fields[key]
+		if !ok {
+			t.Errorf("theme key %q has no palette field", key)
+			continue
+		}
+		if *field == nil {
+			t.Errorf("palette field of %q has no default", key)
+		}
+	}
+	if len(fields) != len(theme.TUIKeys) {
+		t.Errorf("palette has %d fields, theme has %d keys", len(fields), len(theme.TUIKeys))
+	}
+}
+
+func TestThemeColorsFor(t *testing.T) {
+	th, err := theme.Parse("test", []byte("mode = \"light\"\n[tui]\naccent = \"#123456\"\nstatus_working = \"99\"\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetTheme(th)
+	t.Cleanup(func() { SetTheme(theme.Auto()) })
+
+	tc := NewThemeColors(true)
+	if tc.IsDark() {
+		t.Error("a light theme should use the light palette on a dark terminal")
+	}
+	if tc.Accent != lipgloss.Color("#123456") {
+		t.Errorf("Accent = %v", tc.Accent)
+	}
+	if tc.TextNormal != basePalette(false).TextNormal {
+		t.Error("colors left out should fall back to the light palette")
+	}
+	if kanbanStatusColor(service.DiscoveredWorking, true) != lipgloss.Color("99") {
+		t.Error("kanban status colors should follow the theme")
+	}
+	if themeColor("text", lipgloss.Color("1")) != lipgloss.Color("1") {
+		t.Error("themeColor should fall back for keys the theme leaves out")
+	}
+}
+
 func TestIsMatchLine(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,250 @@
+package tui
+
+import (
+	"image/color"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+
+	"github.com/dongho-jung/paw/internal/keymap"
+	"github.com/dongho-jung/paw/internal/logging"
+	"github.com/dongho-jung/paw/internal/theme"
+)
+
+// ThemePickerAction represents the selected action.
+type ThemePickerAction int
+
+// Theme picker action options.
+const (
+	ThemePickerCancel ThemePickerAction = iota
+	ThemePickerSelect
+)
+
+// ThemePicker lists the available themes and renders itself in the
+// highlighted theme, so moving the cursor previews it live.
+type ThemePicker struct {
+	names     []string
+	themes    map[string]*theme.Theme
+	errs      map[string]error
+	current   string
+	cursor    int
+	action    ThemePickerAction
+	onPreview func(*theme.Theme)
+
+	detectedDark bool // Terminal background, before any theme mode applies
+	colors       ThemeColors
+	width        int
+	height       int
+}
+
+// NewThemePicker creates a theme picker. onPreview (optional) is called with
+// the highlighted theme, and with the current theme again on cancel.
+func NewThemePicker(names []string, current string, onPreview func(*theme.Theme)) *ThemePicker {
+	logging.Debug("-> NewThemePicker(themes=%d, current=%s)", len(names), current)
+	defer logging.Debug("<- NewThemePicker")
+
+	m := &ThemePicker{
+		names:        names,
+		themes:       make(map[string]*theme.Theme),
+		errs:         make(map[string]error),
+		current:      current,
+		onPreview:    onPreview,
+		detectedDark: DetectDarkMode(),
+		width:        80,
+		height:       24,
+	}
+	for i, name := range names {
+		if name == current {
+			m.cursor = i
+		}
+	}
+	m.colors = themeColorsFor(m.highlighted(), m.detectedDark)
+	return m
+}
+
+// highlighted returns the theme under the cursor, or the auto theme if it
+// fails to load.
+func (m *ThemePicker) highlighted() *theme.Theme {
+	if len(m.names) == 0 {
+		return theme.Auto()
+	}
+	name := m.names[m.cursor]
+	if t, ok := m.themes[name]; ok {
+		return t
+	}
+	if _, failed := m.errs[name]; failed {
+		return theme.Auto()
+	}
+	t, err := theme.Lookup(name)
+	if err != nil {
+		logging.Warn("theme picker: %v", err)
+		m.errs[name] = err
+		return theme.Auto()
+	}
+	m.themes[name] = t
+	return t
+}
+
+// Init initializes the theme picker.
+func (m *ThemePicker) Init() tea.Cmd {
+	return tea.RequestBackgroundColor
+}
+
+// Update handles messages.
+func (m *ThemePicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case tea.BackgroundColorMsg:
+		m.detectedDark = msg.IsDark()
+		m.colors = themeColorsFor(m.highlighted(), m.detectedDark)
+		return m, nil
+
+	case tea.KeyMsg:
+		switch translateKey(msg, keymap.ScopeNav) {
+		case "ctrl+c", "esc", "q":
+			m.action = ThemePickerCancel
+			if m.onPreview != nil {
+				if t, err := theme.Lookup(m.current); err == nil {
+					m.onPreview(t)
+				}
+			}
+			return m, tea.Quit
+
+		case "enter":
+			if len(m.names) > 0 {
+				m.action = ThemePickerSelect
+			}
+			return m, tea.Quit
+
+		case "up", "k":
+			m.moveCursor(m.cursor - 1)
+			return m, nil
+
+		case "down", "j":
+			m.moveCursor(m.cursor + 1)
+			return m, nil
+		}
+	}
+
+	return m, nil
+}
+
+// moveCursor highlights another theme and previews it.
+func (m *ThemePicker) moveCursor(cursor int) {
+	if cursor < 0 || cursor >= len(m.names) || cursor == m.cursor {
+		return
+	}
+	m.cursor = cursor
+	t := m.highlighted()
+	m.colors = themeColorsFor(t, m.detectedDark)
+	if m.onPreview != nil {
+		m.onPreview(t)
+	}
+}
+
+// View renders the theme list next to a preview of the highlighted theme.
+func (m *ThemePicker) View() tea.View {
+	c := m.colors
+	title := lipgloss.NewStyle().Bold(true).Foreground(c.Accent)
+	dim := lipgloss.NewStyle().Foreground(c.TextDim)
+	item := lipgloss.NewStyle().Foreground(c.TextNormal)
+	selected := lipgloss.NewStyle().Foreground(c.Accent).Bold(true)
+
+	var list strings.Builder
+	for i, name := range m.names {
+		label := name
+		if name == m.current {
+			label += dim.Render(" (current)")
+		}
+		if i == m.cursor {
+			list.WriteString(selected.Render("> " + label))
+		} else {
+			list.WriteString(item.Render("  " + label))
+		}
+		list.WriteString("\n")
+	}
+
+	var sb strings.Builder
+	sb.WriteString(title.Render("Theme"))
+	sb.WriteString("\n")
+	sb.WriteString(dim.Render("Saved to the project config; the session restyles on select"))
+	sb.WriteString("\n\n")
+	sb.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, list.String(), "  ", m.renderPreview()))
+	sb.WriteString("\n")
+	sb.WriteString(dim.Render("↑/↓: Preview  Enter: Apply  Esc: Cancel"))
+
+	v := tea.NewView(sb.String())
+	v.AltScreen = true
+	return v
+}
+
+// renderPreview renders color samples of the highlighted theme.
+func (m *ThemePicker) renderPreview() string {
+	c := m.colors
+	fg := func(col color.Color, text string) string {
+		return lipgloss.NewStyle().Foreground(col).Render(text)
+	}
+
+	var lines []string
+	if len(m.names) > 0 {
+		if err := m.errs[m.names[m.cursor]]; err != nil {
+			lines = append(lines, fg(c.ErrorColor, "⚠ "+err.Error()), "")
+		}
+	}
+	lines = append(lines,
+		fg(c.TextBright, "Bright")+"  "+fg(c.TextNormal, "Normal")+"  "+fg(c.TextDim, "Dim")+"  "+fg(c.Accent, "Accent")+"  "+fg(c.AccentSecondary, "Secondary"),
+		lipgloss.NewStyle().Background(c.Selection).Foreground(c.TextBright).Render(" Selected row ")+" "+
+			lipgloss.NewStyle().Background(c.SearchMatch).Foreground(lipgloss.Color("0")).Render("match")+" "+
+			lipgloss.NewStyle().Background(c.SearchCurrent).Foreground(lipgloss.Color("0")).Render("current"),
+		"",
+		fg(c.StatusBacklog, "Backlog")+" "+fg(c.StatusBlocked, "Blocked")+" "+fg(c.StatusWorking, "Working")+" "+
+			fg(c.StatusWaiting, "Waiting")+" "+fg(c.StatusWarning, "Warning")+" "+fg(c.StatusReview, "Review")+" "+fg(c.StatusDone, "Done"),
+		fg(c.SuccessColor, "✓ success")+"  "+fg(c.WarningColor, "! warning")+"  "+fg(c.ErrorColor, "✗ error"),
+		fg(c.LogTrace, "TRACE")+" "+fg(c.LogDebug, "DEBUG")+" "+fg(c.LogInfo, "INFO")+" "+
+			fg(c.LogWarn, "WARN")+" "+fg(c.LogError, "ERROR")+" "+fg(c.LogFatal, "FATAL"),
+		"",
+		fg(c.DiffAdd, "+")+lipgloss.NewStyle().Background(c.DiffAddBg).Foreground(c.TextNormal).Render(" added ")+
+			lipgloss.NewStyle().Background(c.DiffAddEmphasis).Foreground(c.TextBright).Render("word"),
+		fg(c.DiffDelete, "-")+lipgloss.NewStyle().Background(c.DiffDeleteBg).Foreground(c.TextNormal).Render(" deleted ")+
+			lipgloss.NewStyle().Background(c.DiffDeleteEmphasis).Foreground(c.TextBright).Render("word"),
+		"",
+		lipgloss.NewStyle().Background(c.StatusBar).Foreground(c.StatusBarText).Render(" status bar "),
+	)
+
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(c.BorderFocused).
+		Padding(0, 1).
+		Render(strings.Join(lines, "\n"))
+}
+
+// Result returns the selected theme name and action.
+func (m *ThemePicker) Result() (ThemePickerAction, string) {
+	if m.action != ThemePickerSelect {
+		return m.action, ""
+	}
+	return m.action, m.names[m.cursor]
+}
+
+// RunThemePicker runs the theme picker and returns the selected theme name.
+func RunThemePicker(names []string, current string, onPreview func(*theme.Theme)) (ThemePickerAction, string, error) {
+	logging.Debug("-> RunThemePicker(themes=%d)", len(names))
+	defer logging.Debug("<- RunThemePicker")
+
+	m := NewThemePicker(names, current, onPreview)
+	p := tea.NewProgram(m)
+
+	finalModel, err := p.Run()
+	if err != nil {
+		logging.Debug("RunThemePicker: tea.Program.Run failed: %v", err)
+		return ThemePickerCancel, "", err
+	}
+
+	action, selected := finalModel.(*ThemePicker).Result()
+	logging.Debug("RunThemePicker: completed, action=%d, selected=%s", action, selected)
+	return action, selected, nil
+}
@@ -0,0 +1,42 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/dongho-jung/paw/internal/theme"
+)
+
+func TestThemePickerPreviewAndCancel(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	var previewed []string
+	m := NewThemePicker([]string{"auto", "dark", "solarized-light"}, "dark", func(th *theme.Theme) {
+		previewed = append(previewed, th.Name)
+	})
+	if m.cursor != 1 {
+		t.Fatalf("cursor = %d, want the current theme", m.cursor)
+	}
+
+	m.Update(keyPress("down"))
+	if m.colors.IsDark() {
+		t.Error("the picker should restyle itself in the highlighted light theme")
+	}
+	m.Update(keyPress("down")) // Already at the end
+	m.Update(keyPress("esc"))
+
+	if len(previewed) != 2 || previewed[0] != "solarized-light" || previewed[1] != "dark" {
+		t.Errorf("previewed = %v, want the highlighted theme then the current one", previewed)
+	}
+	if action, name := m.Result(); action != ThemePickerCancel || name != "" {
+		t.Errorf("Result() = %v, %q", action, name)
+	}
+}
+
+func TestThemePickerSelect(t *testing.T) {
+	m := NewThemePicker([]string{"auto", "dark"}, "auto", nil)
+	m.Update(keyPress("j"))
+	m.Update(keyPress("enter"))
+	if action, name := m.Result(); action != ThemePickerSelect || name != "dark" {
+		t.Errorf("Result() = %v, %q", action, name)
+	}
+}
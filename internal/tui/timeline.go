@@ -0,0 +1,439 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/dongho-jung/paw/internal/keymap"
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/task"
+)
+
+const (
+	// timelineRefreshInterval is how often the popup reloads the status history.
+	timelineRefreshInterval = 10 * time.Second
+	// timelineMaxNameWidth caps the task name column.
+	timelineMaxNameWidth = 28
+	// timelineMinBarWidth keeps bars readable on narrow screens.
+	timelineMinBarWidth = 10
+	// timelineTotalsWidth is the width of the "work 2h05m  wait 45m" column.
+	timelineTotalsWidth = 24
+)
+
+// Timeline spans.
+const (
+	TimelineDay  = 24 * time.Hour
+	TimelineWeek = 7 * 24 * time.Hour
+)
+
+// timelineGlyphs draw the bars, so statuses stay apart without colors.
+var timelineGlyphs = map[task.Status]string{
+	task.StatusPending:   "·",
+	task.StatusWorking:   "█",
+	task.StatusWaiting:   "▒",
+	task.StatusCorrupted: "▒",
+	task.StatusDone:      "▔",
+}
+
+// timelineLegend explains the bar glyphs.
+const timelineLegend = "█ working  ▒ waiting on you  ▔ done"
+
+// timelinePainter colors a run of bar glyphs of one status.
+type timelinePainter func(status task.Status, s string) string
+
+// timelineColumns returns the status that covers most of each bar column,
+// or "" where the task has no status.
+func timelineColumns(tl service.TaskTimeline, start, end time.Time, width int) []task.Status {
+	columns := make([]task.Status, width)
+	span := end.Sub(start)
+	if width <= 0 || span <= 0 {
+		return columns
+	}
+	for c := range columns {
+		colStart := start.Add(span * time.Duration(c) / time.Duration(width))
+		colEnd := start.Add(span * time.Duration(c+1) / time.Duration(width))
+		var best time.Duration
+		for _, seg := range tl.Segments {
+			overlap := minTime(seg.End, colEnd).Sub(maxTime(seg.Start, colStart))
+			if overlap > best {
+				best = overlap
+				columns[c] = seg.Status
+			}
+		}
+	}
+	return columns
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+// renderTimelineBar renders the bar of a task, painting runs of one status together.
+func renderTimelineBar(tl service.TaskTimeline, start, end time.Time, width int, paint timelinePainter) string {
+	columns := timelineColumns(tl, start, end, width)
+	var b strings.Builder
+	for i := 0; i < len(columns); {
+		j := i
+		for j < len(columns) && columns[j] == columns[i] {
+			j++
+		}
+		glyph, ok := timelineGlyphs[columns[i]]
+		if !ok {
+			glyph = " "
+		}
+		run := strings.Repeat(glyph, j-i)
+		if columns[i] != "" && paint != nil {
+			run = paint(columns[i], run)
+		}
+		b.WriteString(run)
+		i = j
+	}
+	return b.String()
+}
+
+// timelineAxis renders time labels under which the bars line up.
+func timelineAxis(start, end time.Time, width int) string {
+	span := end.Sub(start)
+	if width <= 0 || span <= 0 {
+		return ""
+	}
+
+	layout := "15:04"
+	if span > 2*TimelineDay {
+		layout = "Mon 02"
+	}
+	labelWidth := len(start.Format(layout)) + 2
+
+	// Pick the smallest step whose labels do not touch
+	steps := []time.Duration{time.Hour, 2 * time.Hour, 3 * time.Hour, 6 * time.Hour, 12 * time.Hour, TimelineDay, 2 * TimelineDay}
+	step := steps[len(steps)-1]
+	for _, s := range steps {
+		if int(int64(width)*int64(s)/int64(span)) >= labelWidth {
+			step = s
+			break
+		}
+	}
+
+	axis := []rune(strings.Repeat(" ", width))
+	for t := firstTimelineTick(start, step); t.Before(end); t = nextTimelineTick(t, step) {
+		col := int(int64(width) * int64(t.Sub(start)) / int64(span))
+		label := []rune(t.Format(layout))
+		if col+len(label) > width {
+			break
+		}
+		copy(axis[col:], label)
+	}
+	return string(axis)
+}
+
+// firstTimelineTick returns the first local time at or after start that is
+// a multiple of step (midnight for day steps).
+func firstTimelineTick(start time.Time, step time.Duration) time.Time {
+	local := start.Local()
+	if step >= TimelineDay {
+		t := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.Local)
+		for t.Before(start) {
+			t = t.AddDate(0, 0, 1)
+		}
+		return t
+	}
+	t := time.Date(local.Year(), local.Month(), local.Day(), local.Hour(), 0, 0, 0, time.Local)
+	hours := int(step / time.Hour)
+	for t.Before(start) || t.Hour()%hours != 0 {
+		t = t.Add(time.Hour)
+	}
+	return t
+}
+
+func nextTimelineTick(t time.Time, step time.Duration) time.Time {
+	if step >= TimelineDay {
+		return t.AddDate(0, 0, int(step/TimelineDay))
+	}
+	return t.Add(step)
+}
+
+// formatTimelineDuration renders a duration compactly: 45m, 2h05m, 3d04h.
+func formatTimelineDuration(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	default:
+		return fmt.Sprintf("%dd%02dh", int(d.Hours()/24), int(d.Hours())%24)
+	}
+}
+
+// timelineSummary sums the working and waiting time of all tasks.
+func timelineSummary(timelines []service.TaskTimeline) string {
+	var working, waiting time.Duration
+	for _, tl := range timelines {
+		working += tl.Total(task.StatusWorking)
+		waiting += tl.WaitTime()
+	}
+	return fmt.Sprintf("%d tasks · agents working %s · waiting on you %s",
+		len(timelines), formatTimelineDuration(working), formatTimelineDuration(waiting))
+}
+
+// timelineRows renders the axis line followed by one line per task: name,
+// bar and totals, fitted to width.
+func timelineRows(timelines []service.TaskTimeline, start, end time.Time, width int, paint timelinePainter) []string {
+	nameWidth := 0
+	for _, tl := range timelines {
+		nameWidth = max(nameWidth, ansi.StringWidth(tl.Task))
+	}
+	nameWidth = min(nameWidth, timelineMaxNameWidth)
+	barWidth := max(timelineMinBarWidth, width-nameWidth-timelineTotalsWidth-2)
+
+	rows := make([]string, 0, len(timelines)+1)
+	rows = append(rows, strings.Repeat(" ", nameWidth+1)+timelineAxis(start, end, barWidth))
+	for _, tl := range timelines {
+		name := ansi.Truncate(tl.Task, nameWidth, "…")
+		name += strings.Repeat(" ", nameWidth-ansi.StringWidth(name))
+		totals := fmt.Sprintf("work %6s  wait %6s",
+			formatTimelineDuration(tl.Total(task.StatusWorking)), formatTimelineDuration(tl.WaitTime()))
+		rows = append(rows, name+" "+renderTimelineBar(tl, start, end, barWidth, paint)+" "+totals)
+	}
+	return rows
+}
+
+// FormatTimeline renders the status timelines between start and end as text
+// lines of the given width: a summary, a time axis, one bar per task and a
+// legend. colored paints the bars with ANSI colors.
+func FormatTimeline(timelines []service.TaskTimeline, start, end time.Time, width int, colored bool) []string {
+	var paint timelinePainter
+	if colored {
+		styles := map[task.Status]lipgloss.Style{
+			task.StatusPending:   lipgloss.NewStyle().Foreground(lipgloss.Color("8")),
+			task.StatusWorking:   lipgloss.NewStyle().Foreground(lipgloss.Color("2")),
+			task.StatusWaiting:   lipgloss.NewStyle().Foreground(lipgloss.Color("3")),
+			task.StatusCorrupted: lipgloss.NewStyle().Foreground(lipgloss.Color("1")),
+			task.StatusDone:      lipgloss.NewStyle().Foreground(lipgloss.Color("4")),
+		}
+		paint = func(status task.Status, s string) string { return styles[status].Render(s) }
+	}
+
+	lines := []string{timelineSummary(timelines), ""}
+	lines = append(lines, timelineRows(timelines, start, end, width, paint)...)
+	return append(lines, "", timelineLegend)
+}
+
+type timelineTickMsg struct{}
+
+type timelineLoadedMsg struct {
+	timelines []service.TaskTimeline
+	start     time.Time
+	end       time.Time
+	err       error
+}
+
+// Timeline shows one bar per task across the last day or week, colored by
+// the time the task spent working, waiting on the user and done.
+type Timeline struct {
+	load func(since, now time.Time) ([]service.TaskTimeline, error)
+
+	span      time.Duration
+	timelines []service.TaskTimeline
+	start     time.Time
+	end       time.Time
+	err       error
+	loaded    bool
+	offset    int
+
+	isDark bool
+	colors ThemeColors
+	width  int
+	height int
+
+	// Style cache (reused across renders)
+	styleTitle   lipgloss.Style
+	styleHelp    lipgloss.Style
+	styleDim     lipgloss.Style
+	styleStatus  map[task.Status]lipgloss.Style
+	stylesCached bool
+}
+
+func newTimeline(load func(since, now time.Time) ([]service.TaskTimeline, error)) *Timeline {
+	isDark := DetectDarkMode()
+	return &Timeline{
+		load:   load,
+		span:   TimelineDay,
+		isDark: isDark,
+		colors: NewThemeColors(isDark),
+		width:  100,
+		height: 30,
+	}
+}
+
+// Init loads the status history.
+func (m *Timeline) Init() tea.Cmd {
+	if _, ok := cachedDarkModeValue(); ok {
+		return m.refresh()
+	}
+	return tea.Batch(tea.RequestBackgroundColor, m.refresh())
+}
+
+// refresh loads the timelines of the current span in the background.
+func (m *Timeline) refresh() tea.Cmd {
+	load, span := m.load, m.span
+	return func() tea.Msg {
+		end := time.Now()
+		start := end.Add(-span)
+		timelines, err := load(start, end)
+		return timelineLoadedMsg{timelines: timelines, start: start, end: end, err: err}
+	}
+}
+
+// Update handles messages.
+func (m *Timeline) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.clampOffset()
+		return m, nil
+	case tea.BackgroundColorMsg:
+		m.isDark = themeIsDark(msg.IsDark())
+		m.colors = NewThemeColors(m.isDark)
+		m.stylesCached = false
+		setCachedDarkMode(m.isDark)
+		return m, nil
+	case timelineLoadedMsg:
+		m.timelines, m.start, m.end, m.err = msg.timelines, msg.start, msg.end, msg.err
+		m.loaded = true
+		m.clampOffset()
+		return m, tea.Tick(timelineRefreshInterval, func(time.Time) tea.Msg { return timelineTickMsg{} })
+	case timelineTickMsg:
+		return m, m.refresh()
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+	return m, nil
+}
+
+func (m *Timeline) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if keyMatches(msg, keymap.ActionClose) {
+		return m, tea.Quit
+	}
+	// Span keys belong to the timeline, so they are matched before the keymap
+	switch msg.String() {
+	case "ctrl+c", "esc":
+		return m, tea.Quit
+	case "d":
+		return m.setSpan(TimelineDay)
+	case "w":
+		return m.setSpan(TimelineWeek)
+	case "tab":
+		if m.span == TimelineDay {
+			return m.setSpan(TimelineWeek)
+		}
+		return m.setSpan(TimelineDay)
+	}
+
+	switch translateViewerKey(msg) {
+	case "up":
+		m.offset--
+	case "down":
+		m.offset++
+	case "pgup":
+		m.offset -= m.pageSize()
+	case "pgdown":
+		m.offset += m.pageSize()
+	case "g":
+		m.offset = 0
+	case "G":
+		m.offset = len(m.timelines)
+	}
+	m.clampOffset()
+	return m, nil
+}
+
+func (m *Timeline) setSpan(span time.Duration) (tea.Model, tea.Cmd) {
+	if m.span == span {
+		return m, nil
+	}
+	m.span = span
+	m.offset = 0
+	return m, m.refresh()
+}
+
+// pageSize is the number of task rows that fit between the header and the help.
+func (m *Timeline) pageSize() int {
+	return max(1, m.height-5)
+}
+
+func (m *Timeline) clampOffset() {
+	m.offset = max(0, min(m.offset, len(m.timelines)-m.pageSize()))
+}
+
+// View renders the timeline.
+func (m *Timeline) View() tea.View {
+	c := m.colors
+	if !m.stylesCached {
+		m.styleTitle = lipgloss.NewStyle().Bold(true).Foreground(c.Accent)
+		m.styleHelp = lipgloss.NewStyle().Foreground(c.TextDim)
+		m.styleDim = lipgloss.NewStyle().Foreground(c.TextDim)
+		m.styleStatus = map[task.Status]lipgloss.Style{
+			task.StatusPending:   lipgloss.NewStyle().Foreground(c.TextDim),
+			task.StatusWorking:   lipgloss.NewStyle().Foreground(c.StatusWorking),
+			task.StatusWaiting:   lipgloss.NewStyle().Foreground(c.StatusWaiting),
+			task.StatusCorrupted: lipgloss.NewStyle().Foreground(c.StatusWarning),
+			task.StatusDone:      lipgloss.NewStyle().Foreground(c.StatusDone),
+		}
+		m.stylesCached = true
+	}
+
+	label := "last 24h"
+	if m.span == TimelineWeek {
+		label = "last 7 days"
+	}
+	header := m.styleTitle.Render("Timeline") + m.styleDim.Render("  "+label)
+
+	var body []string
+	switch {
+	case !m.loaded:
+		body = []string{m.styleDim.Render("  Loading status history...")}
+	case m.err != nil:
+		body = []string{m.styleDim.Render(fmt.Sprintf("  Failed to load status history: %v", m.err))}
+	case len(m.timelines) == 0:
+		body = []string{m.styleDim.Render("  No task status changes in this period (press w for the last 7 days)")}
+	default:
+		header += m.styleDim.Render(" · " + timelineSummary(m.timelines))
+		paint := func(status task.Status, s string) string { return m.styleStatus[status].Render(s) }
+		rows := timelineRows(m.timelines, m.start, m.end, m.width, paint)
+		body = append(body, m.styleDim.Render(rows[0]))
+		tasks := rows[1:]
+		end := min(len(tasks), m.offset+m.pageSize())
+		body = append(body, tasks[m.offset:end]...)
+	}
+
+	help := timelineLegend + "   d:day w:week ↑↓:scroll " + closeHint(keymap.ActionClose)
+	lines := append([]string{ansi.Truncate(header, m.width, "…")}, body...)
+	lines = append(lines, m.styleHelp.Render(ansi.Truncate(help, m.width, "…")))
+
+	v := tea.NewView(strings.Join(lines, "\n"))
+	v.AltScreen = true
+	return v
+}
+
+// RunTimeline runs the timeline viewer. load returns the task timelines
+// between since and now.
+func RunTimeline(load func(since, now time.Time) ([]service.TaskTimeline, error)) error {
+	_, err := tea.NewProgram(newTimeline(load)).Run()
+	return err
+}
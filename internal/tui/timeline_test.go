@@ -0,0 +1,144 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/dongho-jung/paw/internal/keymap"
+	"github.com/dongho-jung/paw/internal/service"
+	"github.com/dongho-jung/paw/internal/task"
+)
+
+func timelineTestData(end time.Time) []service.TaskTimeline {
+	start := end.Add(-TimelineDay)
+	at := func(hours int) time.Time { return start.Add(time.Duration(hours) * time.Hour) }
+	return []service.TaskTimeline{
+		{Task: "login-form", Segments: []service.StatusSegment{
+			{Status: task.StatusWorking, Start: at(0), End: at(6)},
+			{Status: task.StatusWaiting, Start: at(6), End: at(12)},
+			{Status: task.StatusDone, Start: at(12), End: at(24)},
+		}},
+		{Task: "fix-api", Segments: []service.StatusSegment{
+			{Status: task.StatusWorking, Start: at(18), End: at(24)},
+		}},
+	}
+}
+
+func TestTimelineColumns(t *testing.T) {
+	end := time.Date(2026, 1, 2, 12, 0, 0, 0, time.Local)
+	start := end.Add(-TimelineDay)
+	timelines := timelineTestData(end)
+
+	columns := timelineColumns(timelines[0], start, end, 4)
+	want := []task.Status{task.StatusWorking, task.StatusWaiting, task.StatusDone, task.StatusDone}
+	for i := range want {
+		if columns[i] != want[i] {
+			t.Errorf("columns = %v, want %v", columns, want)
+			break
+		}
+	}
+
+	if bar := renderTimelineBar(timelines[1], start, end, 8, nil); bar != "      ██" {
+		t.Errorf("bar = %q, want the last quarter working", bar)
+	}
+}
+
+func TestFormatTimeline(t *testing.T) {
+	end := time.Date(2026, 1, 2, 12, 0, 0, 0, time.Local)
+	lines := FormatTimeline(timelineTestData(end), end.Add(-TimelineDay), end, 80, false)
+
+	text := strings.Join(lines, "\n")
+	for _, want := range []string{
+		"2 tasks · agents working 12h00m · waiting on you 6h00m",
+		"login-form",
+		"work  6h00m  wait  6h00m",
+		"work  6h00m  wait     0m",
+		timelineLegend,
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("timeline missing %q:\n%s", want, text)
+		}
+	}
+	for _, line := range lines {
+		if w := ansi.StringWidth(line); w > 80 {
+			t.Errorf("line is %d columns wide, want <= 80: %q", w, line)
+		}
+	}
+}
+
+func TestTimelineAxis(t *testing.T) {
+	start := time.Date(2026, 1, 2, 0, 0, 0, 0, time.Local)
+
+	day := timelineAxis(start, start.Add(TimelineDay), 48)
+	if !strings.HasPrefix(day, "00:00") || !strings.Contains(day, "12:00") {
+		t.Errorf("day axis = %q, want hour labels", day)
+	}
+	week := timelineAxis(start, start.Add(TimelineWeek), 70)
+	if !strings.HasPrefix(week, start.Format("Mon 02")) {
+		t.Errorf("week axis = %q, want day labels", week)
+	}
+}
+
+func TestTimelineSpanKeys(t *testing.T) {
+	var spans []time.Duration
+	load := func(since, now time.Time) ([]service.TaskTimeline, error) {
+		spans = append(spans, now.Sub(since))
+		return timelineTestData(now), nil
+	}
+
+	m := newTimeline(load)
+	m.Update(tea.WindowSizeMsg{Width: 100, Height: 20})
+	m.Update(m.refresh()())
+
+	view := ansi.Strip(fmt.Sprint(m.View().Layer))
+	if !strings.Contains(view, "last 24h") || !strings.Contains(view, "fix-api") {
+		t.Errorf("view = %q, want the day timeline", view)
+	}
+
+	_, cmd := m.Update(keyPress("w"))
+	if cmd == nil {
+		t.Fatal("w should reload the timeline")
+	}
+	m.Update(cmd())
+	if !strings.Contains(ansi.Strip(fmt.Sprint(m.View().Layer)), "last 7 days") {
+		t.Error("w should switch to the week")
+	}
+	if len(spans) != 2 || spans[1] != TimelineWeek {
+		t.Errorf("loaded spans = %v, want a day then a week", spans)
+	}
+
+	if _, cmd := m.Update(keyPress("w")); cmd != nil {
+		t.Error("w on the week view should not reload")
+	}
+}
+
+func TestTimelineKeysFollowKeymap(t *testing.T) {
+	km, _ := keymap.New(map[string]string{"close": "Q", "down": "J"})
+	SetKeymap(km)
+	t.Cleanup(func() { SetKeymap(keymap.Default()) })
+
+	m := newTimeline(func(_, now time.Time) ([]service.TaskTimeline, error) {
+		return timelineTestData(now), nil
+	})
+	m.Update(tea.WindowSizeMsg{Width: 100, Height: 5})
+	m.Update(m.refresh()())
+
+	if view := ansi.Strip(fmt.Sprint(m.View().Layer)); !strings.Contains(view, "Q:close") {
+		t.Errorf("help should show the remapped close key:\n%s", view)
+	}
+	m.Update(keyPress("J"))
+	if m.offset != 1 {
+		t.Errorf("offset = %d, want J to scroll down", m.offset)
+	}
+	if _, cmd := m.Update(keyPress("q")); cmd != nil {
+		t.Error("q should no longer close the timeline")
+	}
+	if _, cmd := m.Update(keyPress("Q")); cmd == nil {
+		t.Error("Q should close the timeline")
+	}
+}
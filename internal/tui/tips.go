@@ -15,30 +15,31 @@ var ProjectName = ""
 // SessionName is the tmux session name (safe chars, no slashes like "repo-subdir").
 var SessionName = ""
 
-// tips contains usage tips shown to users.
+// tips contains usage tips shown to users. "{action}" is replaced with the
+// action's effective key; tips of disabled actions are not shown.
 var tips = []string{
 	// Keyboard shortcuts - Task commands
-	"Press ⌃N to create a new task",
-	"Press ⌃R to search task history (new task window)",
-	"Press ⌃T to open template picker (new task window)",
-	"Press ⌃F to finish task (action picker)",
+	"Press {new-task} to create a new task",
+	"Press {history-search} to search task history (new task window)",
+	"Press {template-picker} to open template picker (new task window)",
+	"Press {finish-task} to finish task (action picker)",
 	"Press Alt+Enter or F5 to submit task",
 	"Press Esc twice quickly to cancel input",
 
 	// Keyboard shortcuts - Navigation
-	"Press ⌥Tab to cycle panes/options",
-	"Press ⌥Shift+Tab to cycle panes backward",
-	"Use ⌥←/→ to move between windows",
-	"Press ⌃J to switch projects (session picker)",
+	"Press {cycle-pane} to cycle panes/options",
+	"Press {cycle-pane-back} to cycle panes backward",
+	"Use {prev-window}/{next-window} to move between windows",
+	"Press {project-picker} to switch projects (session picker)",
 
 	// Keyboard shortcuts - Toggle panels
-	"Press ⌃P to open command palette (type to filter)",
-	"Press ⌃G to toggle git viewer",
-	"Press ⌃O to toggle logs",
-	"Press ⌃B to toggle bottom shell",
-	"Press ⌃/ to open help",
-	"Press ⌃Y to edit prompts",
-	"Press ⌃Q to detach (session keeps running)",
+	"Press {command-palette} to open command palette (type to filter)",
+	"Press {toggle-git} to toggle git viewer",
+	"Press {toggle-logs} to toggle logs",
+	"Press {toggle-shell} to toggle bottom shell",
+	"Press {toggle-help} to open help",
+	"Press {prompt-picker} to edit prompts",
+	"Press {quit} to detach (session keeps running)",
 
 	// Mouse interactions
 	"Use mouse to select and copy text",
@@ -64,10 +65,16 @@ var tips = []string{
 // Pattern: -g[0-9a-f]+ or -g[0-9a-f]+-dirty at the end
 var versionHashRegex = regexp.MustCompile(`-g[0-9a-f]+(-dirty)?$`)
 
-// GetTip returns a random usage tip.
+// GetTip returns a random usage tip rendered with the effective keymap.
 // Each call returns a different random tip.
 func GetTip() string {
-	return tips[rand.IntN(len(tips))] //nolint:gosec // G404: weak random is fine for non-security UI tips
+	available := make([]string, 0, len(tips))
+	for _, tip := range tips {
+		if rendered, ok := activeKeymap().RenderTip(tip); ok {
+			available = append(available, rendered)
+		}
+	}
+	return available[rand.IntN(len(available))] //nolint:gosec // G404: weak random is fine for non-security UI tips
 }
 
 // SetVersion sets the PAW version string, stripping the git hash suffix.
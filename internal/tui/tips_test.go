@@ -1,6 +1,11 @@
 package tui
 
-import "testing"
+import (
+	"strings"
+	"testing"
+
+	"github.com/dongho-jung/paw/internal/keymap"
+)
 
 func TestSetVersion(t *testing.T) {
 	tests := []struct {
@@ -67,10 +72,10 @@ func TestGetTip(t *testing.T) {
 		t.Error("GetTip() returned empty string")
 	}
 
-	// Verify the tip is in the tips slice
+	// Verify the tip is a rendered entry of the tips slice
 	found := false
 	for _, validTip := range tips {
-		if tip == validTip {
+		if rendered, _ := activeKeymap().RenderTip(validTip); tip == rendered {
 			found = true
 			break
 		}
@@ -95,6 +100,25 @@ func TestGetTipRandomness(t *testing.T) {
 	}
 }
 
+func TestGetTipFollowsKeymap(t *testing.T) {
+	k, _ := keymap.New(map[string]string{"toggle-git": "C-x", "new-task": "none"})
+	SetKeymap(k)
+	t.Cleanup(func() { SetKeymap(keymap.Default()) })
+
+	seen := make(map[string]bool)
+	for i := 0; i < 500; i++ {
+		seen[GetTip()] = true
+	}
+	if !seen["Press ⌃X to toggle git viewer"] {
+		t.Error("tips should show the remapped key")
+	}
+	for tip := range seen {
+		if strings.Contains(tip, "{") || strings.Contains(tip, "create a new task") {
+			t.Errorf("unexpected tip %q", tip)
+		}
+	}
+}
+
 func TestSetProjectName(t *testing.T) {
 	tests := []struct {
 		name     string